@@ -15,6 +15,7 @@ import (
 
 	"github.com/autobrr/autobrr/internal/action"
 	"github.com/autobrr/autobrr/internal/api"
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/auth"
 	"github.com/autobrr/autobrr/internal/config"
 	"github.com/autobrr/autobrr/internal/database"
@@ -28,6 +29,7 @@ import (
 	"github.com/autobrr/autobrr/internal/irc"
 	"github.com/autobrr/autobrr/internal/list"
 	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/internal/manifest"
 	"github.com/autobrr/autobrr/internal/metrics"
 	"github.com/autobrr/autobrr/internal/notification"
 	"github.com/autobrr/autobrr/internal/proxy"
@@ -35,6 +37,7 @@ import (
 	"github.com/autobrr/autobrr/internal/releasedownload"
 	"github.com/autobrr/autobrr/internal/scheduler"
 	"github.com/autobrr/autobrr/internal/server"
+	"github.com/autobrr/autobrr/internal/tracking"
 	"github.com/autobrr/autobrr/internal/update"
 	"github.com/autobrr/autobrr/internal/user"
 	"github.com/autobrr/autobrr/pkg/sqlite3store"
@@ -137,6 +140,7 @@ func main() {
 	// setup repos
 	var (
 		apikeyRepo         = database.NewAPIRepo(log, db)
+		auditRepo          = database.NewAuditRepo(log, db)
 		downloadClientRepo = database.NewDownloadClientRepo(log, db)
 		actionRepo         = database.NewActionRepo(log, db, downloadClientRepo)
 		filterRepo         = database.NewFilterRepo(log, db)
@@ -147,6 +151,7 @@ func main() {
 		listRepo           = database.NewListRepo(log, db)
 		notificationRepo   = database.NewNotificationRepo(log, db)
 		releaseRepo        = database.NewReleaseRepo(log, db)
+		torrentStatsRepo   = database.NewTorrentStatsRepo(log, db)
 		userRepo           = database.NewUserRepo(log, db)
 		proxyRepo          = database.NewProxyRepo(log, db)
 	)
@@ -154,26 +159,40 @@ func main() {
 	// setup services
 	var (
 		apiService            = api.NewService(log, apikeyRepo)
+		auditService          = audit.NewService(log, cfg.Config, auditRepo)
 		updateService         = update.NewUpdate(log, cfg.Config)
-		notificationService   = notification.NewService(log, notificationRepo)
-		schedulingService     = scheduler.NewService(log, cfg.Config, notificationService, updateService)
+		notificationService   = notification.NewService(log, notificationRepo, auditService)
+		schedulingService     = scheduler.NewService(log, cfg.Config, notificationService, updateService, auditService)
 		userService           = user.NewService(userRepo)
 		authService           = auth.NewService(log, userService)
-		proxyService          = proxy.NewService(log, proxyRepo)
+		proxyService          = proxy.NewService(log, proxyRepo, auditService)
 		indexerAPIService     = indexer.NewAPIService(log, proxyService)
-		downloadService       = releasedownload.NewDownloadService(log, releaseRepo, indexerRepo, proxyService)
-		downloadClientService = download_client.NewService(log, downloadClientRepo)
-		actionService         = action.NewService(log, actionRepo, downloadClientService, downloadService, bus)
-		indexerService        = indexer.NewService(log, cfg.Config, bus, indexerRepo, releaseRepo, indexerAPIService, schedulingService)
-		filterService         = filter.NewService(log, filterRepo, actionService, releaseRepo, indexerAPIService, indexerService, downloadService, notificationService)
-		releaseService        = release.NewService(log, releaseRepo, actionService, filterService, indexerService, schedulingService, bus)
-		ircService            = irc.NewService(log, serverEvents, ircRepo, releaseService, indexerService, notificationService, proxyService)
-		feedService           = feed.NewService(log, feedRepo, feedCacheRepo, releaseService, proxyService, schedulingService)
-		listService           = list.NewService(log, listRepo, downloadClientService, filterService, schedulingService)
+		downloadService       = releasedownload.NewDownloadService(log, cfg.Config, releaseRepo, indexerRepo, proxyService)
+		downloadClientService = download_client.NewService(log, downloadClientRepo, auditService)
+		actionService         = action.NewService(log, actionRepo, downloadClientService, downloadService, bus, auditService)
+		indexerService        = indexer.NewService(log, cfg.Config, bus, indexerRepo, releaseRepo, indexerAPIService, schedulingService, auditService)
+		filterService         = filter.NewService(log, filterRepo, actionService, releaseRepo, indexerAPIService, indexerService, downloadService, notificationService, auditService)
+		releaseService        = release.NewService(log, cfg.Config, releaseRepo, actionService, filterService, indexerService, schedulingService, bus)
+		ircService            = irc.NewService(log, cfg.Config, serverEvents, ircRepo, releaseService, indexerService, notificationService, proxyService, auditService)
+		listService           = list.NewService(log, listRepo, downloadClientService, filterService, schedulingService, auditService)
+		feedService           = feed.NewService(log, cfg.Config, feedRepo, feedCacheRepo, releaseService, indexerService, filterService, listService, proxyService, schedulingService, auditService)
+		trackingService       = tracking.NewService(log, cfg.Config, torrentStatsRepo, downloadClientService, schedulingService)
+		manifestService       = manifest.NewService(log, manifest.Deps{
+			Proxies:           proxyService,
+			DownloadClients:   downloadClientService,
+			Indexers:          indexerService,
+			IrcNetworks:       ircService,
+			Feeds:             feedService,
+			Notifications:     notificationService,
+			DuplicateProfiles: releaseRepo,
+			Filters:           filterService,
+			Lists:             listService,
+			CleanupJobs:       releaseService,
+		})
 	)
 
 	// register event subscribers
-	events.NewSubscribers(log, bus, feedService, notificationService, releaseService)
+	events.NewSubscribers(log, bus, feedService, ircService, notificationService, releaseService, trackingService)
 
 	errorChannel := make(chan error)
 
@@ -189,6 +208,7 @@ func main() {
 			Date:                  date,
 			ActionService:         actionService,
 			ApiService:            apiService,
+			AuditService:          auditService,
 			AuthService:           authService,
 			DownloadClientService: downloadClientService,
 			FilterService:         filterService,
@@ -196,10 +216,12 @@ func main() {
 			IndexerService:        indexerService,
 			IrcService:            ircService,
 			ListService:           listService,
+			ManifestService:       manifestService,
 			NotificationService:   notificationService,
 			OIDCService:           oidcService,
 			ProxyService:          proxyService,
 			ReleaseService:        releaseService,
+			TrackingService:       trackingService,
 			UpdateService:         updateService,
 		},
 		)
@@ -225,7 +247,7 @@ func main() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
 
-	srv := server.NewServer(log, cfg.Config, ircService, indexerService, feedService, releaseService, listService, schedulingService, updateService)
+	srv := server.NewServer(log, cfg.Config, ircService, indexerService, feedService, releaseService, listService, trackingService, schedulingService, updateService)
 	if err := srv.Start(); err != nil {
 		log.Fatal().Stack().Err(err).Msg("could not start server")
 		return
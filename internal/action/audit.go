@@ -0,0 +1,60 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package action
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// actionAudit records actions without their download client, the client is referenced by id
+var actionAudit = audit.Resource[domain.Action]{
+	Type: domain.AuditResourceAction,
+	Key: func(a *domain.Action) (string, string) {
+		return strconv.Itoa(a.ID), a.Name
+	},
+	View: func(a *domain.Action) any {
+		view := *a
+		view.Client = nil
+
+		return &view
+	},
+}
+
+// findForAudit loads the action before a change, it returns nil if the action can not be found
+func (s *service) findForAudit(ctx context.Context, actionID int) *domain.Action {
+	action, err := s.repo.Get(ctx, &domain.GetActionRequest{Id: actionID})
+	if err != nil {
+		return nil
+	}
+
+	return action
+}
+
+// recordFilterActionsAudit records the actions of a filter that were created, updated or deleted
+func (s *service) recordFilterActionsAudit(ctx context.Context, before, after []*domain.Action) {
+	existing := make(map[int]*domain.Action, len(before))
+	for _, action := range before {
+		existing[action.ID] = action
+	}
+
+	for _, action := range after {
+		if old, ok := existing[action.ID]; ok {
+			actionAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, old, action)
+			delete(existing, action.ID)
+			continue
+		}
+
+		actionAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, action)
+	}
+
+	for _, action := range before {
+		if _, ok := existing[action.ID]; ok {
+			actionAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, action, nil)
+		}
+	}
+}
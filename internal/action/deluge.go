@@ -15,7 +15,7 @@ import (
 	"github.com/autobrr/go-deluge"
 )
 
-func (s *service) deluge(ctx context.Context, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) deluge(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
 	s.log.Debug().Msgf("action Deluge: %s", action.Name)
 
 	var err error
@@ -60,7 +60,7 @@ func (s *service) delugeCheckRulesCanDownload(ctx context.Context, del deluge.De
 			if len(activeDownloads) >= client.Settings.Rules.MaxActiveDownloads {
 				s.log.Debug().Msg("max active downloads reached, skipping")
 
-				rejections := []string{"max active downloads reached, skipping"}
+				rejections := []string{domain.ActionRejectionMaxActiveDownloads}
 				return rejections, nil
 
 				//	// TODO handle ignore slow torrents
@@ -88,19 +88,24 @@ func (s *service) delugeCheckRulesCanDownload(ctx context.Context, del deluge.De
 	return nil, nil
 }
 
-func (s *service) delugeV1(ctx context.Context, client *domain.DownloadClient, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) delugeV1(ctx context.Context, client *domain.DownloadClient, action *domain.Action, release *domain.Release) ([]string, error) {
+	password, err := domain.ResolveSecret(client.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not resolve password of client %s", client.Name)
+	}
+
 	//downloadClient := client.Client.(*deluge.Client)
 	downloadClient := deluge.NewV1(deluge.Settings{
 		Hostname:             client.Host,
 		Port:                 uint(client.Port),
 		Login:                client.Username,
-		Password:             client.Password,
+		Password:             password,
 		DebugServerResponses: true,
 		ReadWriteTimeout:     time.Second * 60,
 	})
 
 	// perform connection to Deluge server
-	err := downloadClient.Connect(ctx)
+	err = downloadClient.Connect(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not connect to client %s at %s", client.Name, client.Host)
 	}
@@ -148,7 +153,7 @@ func (s *service) delugeV1(ctx context.Context, client *domain.DownloadClient, a
 		return nil, nil
 	} else {
 		if release.TorrentTmpFile == "" {
-			if err := s.downloadSvc.DownloadRelease(ctx, &release); err != nil {
+			if err := s.downloadSvc.DownloadRelease(ctx, release); err != nil {
 				return nil, errors.Wrap(err, "could not download torrent file for release: %s", release.TorrentName)
 			}
 		}
@@ -218,19 +223,24 @@ func delugeSetOrCreateTorrentLabel(ctx context.Context, plugin *deluge.LabelPlug
 	return nil
 }
 
-func (s *service) delugeV2(ctx context.Context, client *domain.DownloadClient, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) delugeV2(ctx context.Context, client *domain.DownloadClient, action *domain.Action, release *domain.Release) ([]string, error) {
+	password, err := domain.ResolveSecret(client.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not resolve password of client %s", client.Name)
+	}
+
 	//downloadClient := client.Client.(*deluge.ClientV2)
 	downloadClient := deluge.NewV2(deluge.Settings{
 		Hostname:             client.Host,
 		Port:                 uint(client.Port),
 		Login:                client.Username,
-		Password:             client.Password,
+		Password:             password,
 		DebugServerResponses: true,
 		ReadWriteTimeout:     time.Second * 60,
 	})
 
 	// perform connection to Deluge server
-	err := downloadClient.Connect(ctx)
+	err = downloadClient.Connect(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not connect to client %s at %s", client.Name, client.Host)
 	}
@@ -277,7 +287,7 @@ func (s *service) delugeV2(ctx context.Context, client *domain.DownloadClient, a
 
 		return nil, nil
 	} else {
-		if err := s.downloadSvc.DownloadRelease(ctx, &release); err != nil {
+		if err := s.downloadSvc.DownloadRelease(ctx, release); err != nil {
 			return nil, errors.Wrap(err, "could not download torrent file for release: %s", release.TorrentName)
 		}
 
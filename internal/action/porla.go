@@ -15,7 +15,7 @@ import (
 	"github.com/autobrr/autobrr/pkg/porla"
 )
 
-func (s *service) porla(ctx context.Context, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) porla(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
 	s.log.Debug().Msgf("action Porla: %s", action.Name)
 
 	client, err := s.clientSvc.GetClient(ctx, action.ClientID)
@@ -75,7 +75,7 @@ func (s *service) porla(ctx context.Context, action *domain.Action, release doma
 
 		return nil, nil
 	} else {
-		if err := s.downloadSvc.DownloadRelease(ctx, &release); err != nil {
+		if err := s.downloadSvc.DownloadRelease(ctx, release); err != nil {
 			return nil, errors.Wrap(err, "could not download torrent file for release: %s", release.TorrentName)
 		}
 
@@ -120,7 +120,7 @@ func (s *service) porlaCheckRulesCanDownload(ctx context.Context, action *domain
 
 		if client.Settings.Rules.MaxActiveDownloads > 0 {
 			if len(torrents.Torrents) >= client.Settings.Rules.MaxActiveDownloads {
-				rejection := "max active downloads reached, skipping"
+				rejection := domain.ActionRejectionMaxActiveDownloads
 
 				s.log.Debug().Msg(rejection)
 
@@ -14,7 +14,7 @@ import (
 	"github.com/autobrr/go-qbittorrent"
 )
 
-func (s *service) qbittorrent(ctx context.Context, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) qbittorrent(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
 	s.log.Debug().Msgf("action qBittorrent: %s", action.Name)
 
 	client, err := s.clientSvc.GetClient(ctx, action.ClientID)
@@ -58,7 +58,7 @@ func (s *service) qbittorrent(ctx context.Context, action *domain.Action, releas
 		return nil, nil
 	}
 
-	if err := s.downloadSvc.DownloadRelease(ctx, &release); err != nil {
+	if err := s.downloadSvc.DownloadRelease(ctx, release); err != nil {
 		return nil, errors.Wrap(err, "could not download torrent file for release: %s", release.TorrentName)
 	}
 
@@ -210,7 +210,7 @@ func (s *service) qbittorrentCheckRulesCanDownload(ctx context.Context, action *
 		if len(activeDownloads) >= rules.MaxActiveDownloads {
 			// if we do not care about slow torrents then return early
 			if !rules.IgnoreSlowTorrents {
-				rejection := "max active downloads reached, skipping"
+				rejection := domain.ActionRejectionMaxActiveDownloads
 
 				s.log.Debug().Msg(rejection)
 
@@ -37,6 +37,55 @@ func (s *service) RunAction(ctx context.Context, action *domain.Action, release
 		return nil, err
 	}
 
+	if action.HasFailoverClients() {
+		rejections, err = s.runClientGroup(ctx, action, release)
+	} else {
+		rejections, err = s.runActionType(ctx, action, release)
+	}
+
+	payload := &domain.NotificationPayload{
+		Event:          domain.NotificationEventPushApproved,
+		ReleaseName:    release.TorrentName,
+		Filter:         release.FilterName,
+		FilterID:       release.FilterID,
+		Indexer:        release.Indexer.Name,
+		InfoHash:       release.TorrentHash,
+		Size:           release.Size,
+		Status:         domain.ReleasePushStatusApproved,
+		Action:         action.Name,
+		ActionType:     action.Type,
+		Rejections:     []string{},
+		Protocol:       release.Protocol,
+		Implementation: release.Implementation,
+		Timestamp:      time.Now(),
+		Release:        release,
+	}
+
+	if action.Client != nil {
+		payload.ActionClient = action.Client.Name
+	}
+
+	if err != nil {
+		s.log.Error().Err(err).Msgf("process action failed: %v for '%v'", action.Name, release.TorrentName)
+
+		payload.Event = domain.NotificationEventPushError
+		payload.Status = domain.ReleasePushStatusErr
+		payload.Rejections = []string{err.Error()}
+	}
+
+	if rejections != nil {
+		payload.Event = domain.NotificationEventPushRejected
+		payload.Status = domain.ReleasePushStatusRejected
+		payload.Rejections = rejections
+	}
+
+	// send separate event for notifications
+	s.bus.Publish(domain.EventNotificationSend, &payload.Event, payload)
+
+	return rejections, err
+}
+
+func (s *service) runActionType(ctx context.Context, action *domain.Action, release *domain.Release) (rejections []string, err error) {
 	switch action.Type {
 	case domain.ActionTypeTest:
 		s.test(action.Name)
@@ -51,19 +100,19 @@ func (s *service) RunAction(ctx context.Context, action *domain.Action, release
 		err = s.webhook(ctx, action, *release)
 
 	case domain.ActionTypeDelugeV1, domain.ActionTypeDelugeV2:
-		rejections, err = s.deluge(ctx, action, *release)
+		rejections, err = s.deluge(ctx, action, release)
 
 	case domain.ActionTypeQbittorrent:
-		rejections, err = s.qbittorrent(ctx, action, *release)
+		rejections, err = s.qbittorrent(ctx, action, release)
 
 	case domain.ActionTypeRTorrent:
 		rejections, err = s.rtorrent(ctx, action, *release)
 
 	case domain.ActionTypeTransmission:
-		rejections, err = s.transmission(ctx, action, *release)
+		rejections, err = s.transmission(ctx, action, release)
 
 	case domain.ActionTypePorla:
-		rejections, err = s.porla(ctx, action, *release)
+		rejections, err = s.porla(ctx, action, release)
 
 	case domain.ActionTypeRadarr:
 		rejections, err = s.radarr(ctx, action, *release)
@@ -90,46 +139,64 @@ func (s *service) RunAction(ctx context.Context, action *domain.Action, release
 		return nil, errors.New("unsupported action type: %s", action.Type)
 	}
 
-	payload := &domain.NotificationPayload{
-		Event:          domain.NotificationEventPushApproved,
-		ReleaseName:    release.TorrentName,
-		Filter:         release.FilterName,
-		FilterID:       release.FilterID,
-		Indexer:        release.Indexer.Name,
-		InfoHash:       release.TorrentHash,
-		Size:           release.Size,
-		Status:         domain.ReleasePushStatusApproved,
-		Action:         action.Name,
-		ActionType:     action.Type,
-		Rejections:     []string{},
-		Protocol:       release.Protocol,
-		Implementation: release.Implementation,
-		Timestamp:      time.Now(),
-		Release:        release,
-	}
+	return rejections, err
+}
 
-	if action.Client != nil {
-		payload.ActionClient = action.Client.Name
+// runClientGroup tries the download clients of the action in order until one accepts the release.
+// A client is skipped if it's unreachable or its download client rules reject the release.
+// action.Client is left set to the client that accepted the release, or the last one tried.
+func (s *service) runClientGroup(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
+	mainClientID := action.ClientID
+	defer func() {
+		action.ClientID = mainClientID
+	}()
+
+	group := action.ClientGroup()
+	if len(group) == 0 {
+		return nil, errors.New("no download clients set for action: %s", action.Name)
 	}
 
-	if err != nil {
-		s.log.Error().Err(err).Msgf("process action failed: %v for '%v'", action.Name, release.TorrentName)
+	var groupRejections []string
+	var lastErr error
+	unavailable := 0
 
-		payload.Event = domain.NotificationEventPushError
-		payload.Status = domain.ReleasePushStatusErr
-		payload.Rejections = []string{err.Error()}
-	}
+	for i, clientID := range group {
+		action.ClientID = clientID
+		action.Client = nil
 
-	if rejections != nil {
-		payload.Event = domain.NotificationEventPushRejected
-		payload.Status = domain.ReleasePushStatusRejected
-		payload.Rejections = rejections
+		rejections, err := s.runActionType(ctx, action, release)
+		if !domain.IsActionClientFailover(rejections, err) {
+			if i > 0 && err == nil && action.Client != nil {
+				s.log.Info().Msgf("action %s: release %s accepted by failover client %s", action.Name, release.TorrentName, action.Client.Name)
+			}
+			return rejections, err
+		}
+
+		clientName := fmt.Sprintf("%d", clientID)
+		if action.Client != nil {
+			clientName = action.Client.Name
+		}
+
+		if err != nil {
+			s.log.Warn().Err(err).Msgf("action %s: client %s unavailable, trying next client", action.Name, clientName)
+			groupRejections = append(groupRejections, fmt.Sprintf("client %s: %s", clientName, err.Error()))
+			lastErr = err
+			unavailable++
+			continue
+		}
+
+		s.log.Debug().Msgf("action %s: client %s rejected release %s: %s", action.Name, clientName, release.TorrentName, strings.Join(rejections, ", "))
+		for _, rejection := range rejections {
+			groupRejections = append(groupRejections, fmt.Sprintf("client %s: %s", clientName, rejection))
+		}
 	}
 
-	// send separate event for notifications
-	s.bus.Publish(domain.EventNotificationSend, &payload.Event, payload)
+	// all clients were unreachable, return the error so the push can be retried
+	if unavailable == len(group) {
+		return nil, errors.Wrap(lastErr, "all %d clients in group unavailable", len(group))
+	}
 
-	return rejections, err
+	return groupRejections, nil
 }
 
 func (s *service) CheckActionPreconditions(ctx context.Context, action *domain.Action, release *domain.Release) error {
@@ -158,8 +225,9 @@ func (s *service) test(name string) {
 }
 
 func (s *service) watchFolder(ctx context.Context, action *domain.Action, release domain.Release) error {
-	if release.HasMagnetUri() {
-		return fmt.Errorf("action watch folder does not support magnet links: %s", release.TorrentName)
+	// magnet links are resolved into a torrent file by the preconditions
+	if release.HasMagnetUri() && release.TorrentTmpFile == "" {
+		return fmt.Errorf("action watch folder could not resolve magnet link: %s", release.TorrentName)
 	}
 
 	s.log.Trace().Msgf("action WATCH_FOLDER: %v file: %v", action.WatchFolder, release.TorrentTmpFile)
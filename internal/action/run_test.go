@@ -0,0 +1,190 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package action
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/download_client"
+	"github.com/autobrr/autobrr/pkg/arr/radarr"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockClientService struct {
+	download_client.Service
+	clients map[int32]*domain.DownloadClient
+}
+
+func (m *mockClientService) GetClient(ctx context.Context, clientId int32) (*domain.DownloadClient, error) {
+	client, ok := m.clients[clientId]
+	if !ok {
+		return nil, domain.ErrRecordNotFound
+	}
+	return client, nil
+}
+
+func newRadarrClient(id int32, name string, host string) *domain.DownloadClient {
+	return &domain.DownloadClient{
+		ID:      id,
+		Name:    name,
+		Type:    domain.DownloadClientTypeRadarr,
+		Enabled: true,
+		Host:    host,
+		Client:  radarr.New(radarr.Config{Hostname: host, APIKey: "secret"}),
+	}
+}
+
+func newRadarrServer(t *testing.T, response string) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func Test_service_runClientGroup(t *testing.T) {
+	t.Parallel()
+
+	// closed server to get connection refused
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	approved := newRadarrServer(t, `[{"approved":true,"rejected":false,"rejections":[]}]`)
+	rejected := newRadarrServer(t, `[{"approved":false,"rejected":true,"rejections":["Not an upgrade"]}]`)
+	broken := newRadarrServer(t, `not json`)
+
+	clientSvc := &mockClientService{clients: map[int32]*domain.DownloadClient{
+		1: newRadarrClient(1, "radarr-down", down.URL),
+		2: newRadarrClient(2, "radarr-rejected", rejected),
+		3: newRadarrClient(3, "radarr-approved", approved),
+		4: newRadarrClient(4, "radarr-broken", broken),
+		5: newRadarrClient(5, "radarr-down-2", down.URL),
+	}}
+
+	s := &service{
+		log:       zerolog.Nop(),
+		clientSvc: clientSvc,
+	}
+
+	tests := []struct {
+		name           string
+		clientID       int32
+		failover       []int32
+		wantClient     string
+		wantRejections []string
+		wantErr        bool
+	}{
+		{
+			name:       "unreachable_then_approved",
+			clientID:   1,
+			failover:   []int32{3},
+			wantClient: "radarr-approved",
+		},
+		{
+			// the *arr rejects the release itself, another client would do the same
+			name:           "arr_rejected_stops",
+			clientID:       2,
+			failover:       []int32{3},
+			wantClient:     "radarr-rejected",
+			wantRejections: []string{"Not an upgrade"},
+		},
+		{
+			name:       "missing_client_then_approved",
+			clientID:   99,
+			failover:   []int32{3},
+			wantClient: "radarr-approved",
+		},
+		{
+			name:       "approved_first",
+			clientID:   3,
+			failover:   []int32{2},
+			wantClient: "radarr-approved",
+		},
+		{
+			name:           "unreachable_then_rejected",
+			clientID:       1,
+			failover:       []int32{2},
+			wantClient:     "radarr-rejected",
+			wantRejections: []string{"Not an upgrade"},
+		},
+		{
+			name:       "all_unreachable",
+			clientID:   1,
+			failover:   []int32{5},
+			wantClient: "radarr-down-2",
+			wantErr:    true,
+		},
+		{
+			name:       "other_error_stops",
+			clientID:   4,
+			failover:   []int32{3},
+			wantClient: "radarr-broken",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &domain.Action{
+				Name:              "radarr",
+				Type:              domain.ActionTypeRadarr,
+				ClientID:          tt.clientID,
+				FailoverClientIDs: tt.failover,
+			}
+
+			rejections, err := s.runClientGroup(context.Background(), action, &domain.Release{TorrentName: "That.Movie.2024.1080p.WEB-DL-GROUP"})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if len(tt.wantRejections) > 0 {
+				assert.Len(t, rejections, len(tt.wantRejections))
+				for i, want := range tt.wantRejections {
+					assert.Contains(t, rejections[i], want)
+				}
+			} else {
+				assert.Empty(t, rejections)
+			}
+
+			assert.NotNil(t, action.Client)
+			assert.Equal(t, tt.wantClient, action.Client.Name)
+
+			// the main client is kept on the action
+			assert.Equal(t, tt.clientID, action.ClientID)
+		})
+	}
+}
+
+func Test_service_watchFolder_magnet(t *testing.T) {
+	t.Parallel()
+
+	s := &service{log: zerolog.Nop()}
+
+	dir := t.TempDir()
+	action := &domain.Action{Name: "watch", Type: domain.ActionTypeWatchFolder, WatchFolder: dir}
+
+	release := domain.Release{
+		TorrentName: "Test.Release-GROUP",
+		MagnetURI:   "magnet:?xt=urn:btih:0123456789abcdef0123456789abcdef01234567",
+	}
+
+	// not resolved into a torrent file
+	assert.Error(t, s.watchFolder(context.Background(), action, release))
+
+	release.TorrentTmpFile = filepath.Join(t.TempDir(), "autobrr-resolved")
+	release.TorrentDataRawBytes = []byte("d4:infod4:name4:testee")
+
+	assert.NoError(t, s.watchFolder(context.Background(), action, release))
+	assert.FileExists(t, filepath.Join(dir, "autobrr-resolved.torrent"))
+}
@@ -9,10 +9,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/download_client"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/releasedownload"
+	"github.com/autobrr/autobrr/pkg/errors"
 	"github.com/autobrr/autobrr/pkg/sharedhttp"
 
 	"github.com/asaskevich/EventBus"
@@ -28,7 +30,7 @@ type Service interface {
 	FindByFilterID(ctx context.Context, filterID int, active *bool, withClient bool) ([]*domain.Action, error)
 	Delete(ctx context.Context, req *domain.DeleteActionRequest) error
 	DeleteByFilterID(ctx context.Context, filterID int) error
-	ToggleEnabled(actionID int) error
+	ToggleEnabled(ctx context.Context, actionID int) error
 
 	RunAction(ctx context.Context, action *domain.Action, release *domain.Release) (rejections []string, err error)
 }
@@ -40,17 +42,19 @@ type service struct {
 	clientSvc   download_client.Service
 	downloadSvc *releasedownload.DownloadService
 	bus         EventBus.Bus
+	auditSvc    audit.Service
 
 	httpClient *http.Client
 }
 
-func NewService(log logger.Logger, repo domain.ActionRepo, clientSvc download_client.Service, downloadSvc *releasedownload.DownloadService, bus EventBus.Bus) Service {
+func NewService(log logger.Logger, repo domain.ActionRepo, clientSvc download_client.Service, downloadSvc *releasedownload.DownloadService, bus EventBus.Bus, auditSvc audit.Service) Service {
 	s := &service{
 		log:         log.With().Str("module", "action").Logger(),
 		repo:        repo,
 		clientSvc:   clientSvc,
 		downloadSvc: downloadSvc,
 		bus:         bus,
+		auditSvc:    auditSvc,
 
 		httpClient: &http.Client{
 			Timeout:   time.Second * 120,
@@ -64,11 +68,72 @@ func NewService(log logger.Logger, repo domain.ActionRepo, clientSvc download_cl
 }
 
 func (s *service) Store(ctx context.Context, action *domain.Action) error {
-	return s.repo.Store(ctx, action)
+	if err := s.validate(ctx, action); err != nil {
+		return errors.Wrap(err, "invalid action: %s", action.Name)
+	}
+
+	if err := s.repo.Store(ctx, action); err != nil {
+		return err
+	}
+
+	actionAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, action)
+
+	return nil
 }
 
 func (s *service) StoreFilterActions(ctx context.Context, filterID int64, actions []*domain.Action) ([]*domain.Action, error) {
-	return s.repo.StoreFilterActions(ctx, filterID, actions)
+	for _, action := range actions {
+		if err := s.validate(ctx, action); err != nil {
+			return nil, errors.Wrap(err, "invalid action: %s", action.Name)
+		}
+	}
+
+	before, err := s.repo.FindByFilterID(ctx, int(filterID), nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.repo.StoreFilterActions(ctx, filterID, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordFilterActionsAudit(ctx, before, stored)
+
+	return stored, nil
+}
+
+func (s *service) validate(ctx context.Context, action *domain.Action) error {
+	if err := action.ValidateRetry(); err != nil {
+		return err
+	}
+
+	if err := action.ValidateFailover(); err != nil {
+		return err
+	}
+
+	if !action.HasFailoverClients() {
+		return nil
+	}
+
+	// failover clients must be of the same type as the main client
+	client, err := s.clientSvc.FindByID(ctx, action.ClientID)
+	if err != nil {
+		return errors.Wrap(err, "could not find client with id %d", action.ClientID)
+	}
+
+	for _, id := range action.FailoverClientIDs {
+		failover, err := s.clientSvc.FindByID(ctx, id)
+		if err != nil {
+			return errors.Wrap(err, "could not find failover client with id %d", id)
+		}
+
+		if failover.Type != client.Type {
+			return errors.New("failover client %s is of type %s, want %s", failover.Name, failover.Type, client.Type)
+		}
+	}
+
+	return nil
 }
 
 func (s *service) List(ctx context.Context) ([]domain.Action, error) {
@@ -99,13 +164,40 @@ func (s *service) FindByFilterID(ctx context.Context, filterID int, active *bool
 }
 
 func (s *service) Delete(ctx context.Context, req *domain.DeleteActionRequest) error {
-	return s.repo.Delete(ctx, req)
+	before := s.findForAudit(ctx, req.ActionId)
+
+	if err := s.repo.Delete(ctx, req); err != nil {
+		return err
+	}
+
+	actionAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, before, nil)
+
+	return nil
 }
 
 func (s *service) DeleteByFilterID(ctx context.Context, filterID int) error {
-	return s.repo.DeleteByFilterID(ctx, filterID)
+	before, err := s.repo.FindByFilterID(ctx, filterID, nil, false)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteByFilterID(ctx, filterID); err != nil {
+		return err
+	}
+
+	s.recordFilterActionsAudit(ctx, before, nil)
+
+	return nil
 }
 
-func (s *service) ToggleEnabled(actionID int) error {
-	return s.repo.ToggleEnabled(actionID)
+func (s *service) ToggleEnabled(ctx context.Context, actionID int) error {
+	before := s.findForAudit(ctx, actionID)
+
+	if err := s.repo.ToggleEnabled(actionID); err != nil {
+		return err
+	}
+
+	actionAudit.Record(ctx, s.auditSvc, domain.AuditActionToggle, before, s.findForAudit(ctx, actionID))
+
+	return nil
 }
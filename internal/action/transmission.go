@@ -22,7 +22,7 @@ const (
 var ErrReannounceTookTooLong = errors.New("ErrReannounceTookTooLong")
 var TrTrue = true
 
-func (s *service) transmission(ctx context.Context, action *domain.Action, release domain.Release) ([]string, error) {
+func (s *service) transmission(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
 	s.log.Debug().Msgf("action Transmission: %s", action.Name)
 
 	client, err := s.clientSvc.GetClient(ctx, action.ClientID)
@@ -115,7 +115,7 @@ func (s *service) transmission(ctx context.Context, action *domain.Action, relea
 		return nil, nil
 	}
 
-	if err := s.downloadSvc.DownloadRelease(ctx, &release); err != nil {
+	if err := s.downloadSvc.DownloadRelease(ctx, release); err != nil {
 		return nil, errors.Wrap(err, "could not download torrent file for release: %s", release.TorrentName)
 	}
 
@@ -290,7 +290,7 @@ func (s *service) transmissionCheckRulesCanDownload(ctx context.Context, action
 
 			// if max active downloads reached, check speed and if lower than threshold add anyway
 			if len(activeDownloads) >= client.Settings.Rules.MaxActiveDownloads {
-				rejection := "max active downloads reached, skipping"
+				rejection := domain.ActionRejectionMaxActiveDownloads
 
 				s.log.Debug().Msg(rejection)
 
@@ -4,7 +4,10 @@
 package announce
 
 import (
+	"context"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/indexer"
@@ -16,22 +19,78 @@ import (
 
 type Processor interface {
 	AddLineToQueue(channel string, line string) error
+	AddPlaybackLineToQueue(channel string, line string, timestamp time.Time) error
+	Stop()
+}
+
+// queuedLine is an announce line, timestamp is only set for lines played back by a bouncer
+type queuedLine struct {
+	line      string
+	timestamp time.Time
 }
 
 type announceProcessor struct {
 	log     zerolog.Logger
 	indexer *domain.IndexerDefinition
 
-	releaseSvc release.Service
+	onRelease      func(release *domain.Release)
+	onParseSuccess func()
+	onParseFailure func(failure domain.AnnounceParseFailure)
+	// seenRelease records the release and reports if it was seen before, played back duplicates are dropped
+	seenRelease func(release *domain.Release) bool
+	// storedRelease reports if a played back release was already stored before a restart
+	storedRelease func(release *domain.Release) bool
 
-	queues map[string]chan string
+	queues    map[string]chan queuedLine
+	consumers sync.WaitGroup
 }
 
-func NewAnnounceProcessor(log zerolog.Logger, releaseSvc release.Service, indexer *domain.IndexerDefinition) Processor {
+// NewAnnounceProcessor returns a processor that hands parsed releases to the release service.
+// Parse results are counted and lines that do not match the parse patterns are kept by the recorder if it is not nil.
+func NewAnnounceProcessor(log zerolog.Logger, releaseSvc release.Service, indexer *domain.IndexerDefinition, recorder *Recorder) Processor {
+	onParseSuccess := func() {}
+	onParseFailure := func(domain.AnnounceParseFailure) {}
+	if recorder != nil {
+		onParseSuccess = func() {
+			recorder.RecordParseSuccess(indexer.Identifier, indexer.Name)
+		}
+		onParseFailure = recorder.RecordParseFailure
+	}
+
+	ap := newAnnounceProcessor(log, indexer, releaseSvc.Enqueue, onParseSuccess, onParseFailure)
+	if recorder != nil {
+		ap.seenRelease = recorder.RecordRelease
+
+		// the recorder only knows the releases announced since the start
+		ap.storedRelease = func(rls *domain.Release) bool {
+			if rls.NormalizedHash == "" {
+				return false
+			}
+
+			since := time.Now().Add(-recorder.PlaybackMaxAge())
+
+			exists, err := releaseSvc.ExistsByNormalizedHash(context.Background(), rls.Indexer.Identifier, rls.NormalizedHash, since)
+			if err != nil {
+				ap.log.Error().Err(err).Msgf("announce: could not check if played back release was stored: %s", rls.TorrentName)
+				return false
+			}
+
+			return exists
+		}
+	}
+
+	return ap
+}
+
+func newAnnounceProcessor(log zerolog.Logger, indexer *domain.IndexerDefinition, onRelease func(*domain.Release), onParseSuccess func(), onParseFailure func(domain.AnnounceParseFailure)) *announceProcessor {
 	ap := &announceProcessor{
-		log:        log.With().Str("module", "announce_processor").Str("indexer", indexer.Name).Str("network", indexer.IRC.Network).Logger(),
-		releaseSvc: releaseSvc,
-		indexer:    indexer,
+		log:            log.With().Str("module", "announce_processor").Str("indexer", indexer.Name).Str("network", indexer.IRC.Network).Logger(),
+		indexer:        indexer,
+		onRelease:      onRelease,
+		onParseSuccess: onParseSuccess,
+		onParseFailure: onParseFailure,
+		seenRelease:    func(*domain.Release) bool { return false },
+		storedRelease:  func(*domain.Release) bool { return false },
 	}
 
 	// setup queues and consumers
@@ -42,11 +101,11 @@ func NewAnnounceProcessor(log zerolog.Logger, releaseSvc release.Service, indexe
 }
 
 func (a *announceProcessor) setupQueues() {
-	queues := make(map[string]chan string)
+	queues := make(map[string]chan queuedLine)
 	for _, channel := range a.indexer.IRC.Channels {
 		channel = strings.ToLower(channel)
 
-		queues[channel] = make(chan string, 128)
+		queues[channel] = make(chan queuedLine, 128)
 		a.log.Trace().Msgf("announce: setup queue: %v", channel)
 	}
 
@@ -55,27 +114,38 @@ func (a *announceProcessor) setupQueues() {
 
 func (a *announceProcessor) setupQueueConsumers() {
 	for queueName, queue := range a.queues {
-		go func(name string, q chan string) {
+		a.consumers.Add(1)
+		go func(name string, q chan queuedLine) {
+			defer a.consumers.Done()
+
 			a.log.Trace().Msgf("announce: setup queue consumer: %v", name)
-			a.processQueue(q)
+			a.processQueue(name, q)
 			a.log.Trace().Msgf("announce: queue consumer stopped: %v", name)
 		}(queueName, queue)
 	}
 }
 
-func (a *announceProcessor) processQueue(queue chan string) {
+func (a *announceProcessor) processQueue(channel string, queue chan queuedLine) {
 	for {
 		tmpVars := map[string]string{}
 		parseFailed := false
 		//patternParsed := false
 
+		// announces played back by a bouncer keep the time of their first line
+		var timestamp time.Time
+
 		for _, parseLine := range a.indexer.IRC.Parse.Lines {
-			line, err := a.getNextLine(queue)
+			next, err := a.getNextLine(queue)
 			if err != nil {
-				a.log.Error().Err(err).Msg("could not get line from queue")
+				a.log.Trace().Err(err).Msg("could not get line from queue")
 				return
 			}
 
+			line := next.line
+			if timestamp.IsZero() {
+				timestamp = next.timestamp
+			}
+
 			a.log.Trace().Msgf("announce: process line: %v", line)
 
 			if !a.indexer.Enabled {
@@ -87,6 +157,7 @@ func (a *announceProcessor) processQueue(queue chan string) {
 			match, err := indexer.ParseLine(&a.log, parseLine.Pattern, parseLine.Vars, tmpVars, line, parseLine.Ignore)
 			if err != nil {
 				a.log.Error().Err(err).Msgf("error parsing extract for line: %v", line)
+				a.parseFailure(channel, parseLine.Pattern, line)
 
 				parseFailed = true
 				break
@@ -94,6 +165,8 @@ func (a *announceProcessor) processQueue(queue chan string) {
 
 			if !match {
 				a.log.Debug().Msgf("line not matching expected regex pattern: %v", line)
+				a.parseFailure(channel, parseLine.Pattern, line)
+
 				parseFailed = true
 				break
 			}
@@ -103,32 +176,74 @@ func (a *announceProcessor) processQueue(queue chan string) {
 			continue
 		}
 
+		a.onParseSuccess()
+
 		rls := domain.NewRelease(domain.IndexerMinimal{ID: a.indexer.ID, Name: a.indexer.Name, Identifier: a.indexer.Identifier, IdentifierExternal: a.indexer.IdentifierExternal})
 		rls.Protocol = domain.ReleaseProtocol(a.indexer.Protocol)
 
+		playback := !timestamp.IsZero()
+		if playback {
+			rls.Timestamp = timestamp
+		}
+
 		// on lines matched
 		if err := a.indexer.IRC.Parse.Parse(a.indexer, tmpVars, rls); err != nil {
 			a.log.Error().Err(err).Msg("announce: could not parse announce for release")
 			continue
 		}
 
-		// process release in a new go routine
-		go a.releaseSvc.Process(rls)
+		// the bouncer can play back announces that were already processed before the disconnect or a restart
+		if seen := a.seenRelease(rls); playback && (seen || a.storedRelease(rls)) {
+			a.log.Debug().Msgf("announce: skip played back duplicate: %s", rls.TorrentName)
+			continue
+		}
+
+		// hand release over to the release processing queue
+		a.onRelease(rls)
 	}
 }
 
-func (a *announceProcessor) getNextLine(queue chan string) (string, error) {
+func (a *announceProcessor) parseFailure(channel string, pattern string, line string) {
+	a.onParseFailure(domain.AnnounceParseFailure{
+		Indexer:     a.indexer.Identifier,
+		IndexerName: a.indexer.Name,
+		Network:     a.indexer.IRC.Network,
+		Channel:     channel,
+		Line:        line,
+		Pattern:     pattern,
+		Timestamp:   time.Now(),
+	})
+}
+
+func (a *announceProcessor) getNextLine(queue chan queuedLine) (queuedLine, error) {
 	for {
 		line, ok := <-queue
 		if !ok {
-			return "", errors.New("could not queue line")
+			return queuedLine{}, errors.New("could not queue line")
 		}
 
 		return line, nil
 	}
 }
 
+// Stop closes the queues, lines that are already queued are still parsed.
+// No lines can be added afterwards.
+func (a *announceProcessor) Stop() {
+	for _, queue := range a.queues {
+		close(queue)
+	}
+}
+
 func (a *announceProcessor) AddLineToQueue(channel string, line string) error {
+	return a.addLine(channel, queuedLine{line: line})
+}
+
+// AddPlaybackLineToQueue queues a line played back by a bouncer, the release gets the original time of the announce
+func (a *announceProcessor) AddPlaybackLineToQueue(channel string, line string, timestamp time.Time) error {
+	return a.addLine(channel, queuedLine{line: line, timestamp: timestamp})
+}
+
+func (a *announceProcessor) addLine(channel string, line queuedLine) error {
 	channel = strings.ToLower(channel)
 	queue, ok := a.queues[channel]
 	if !ok {
@@ -137,7 +252,7 @@ func (a *announceProcessor) AddLineToQueue(channel string, line string) error {
 
 	queue <- line
 
-	a.log.Trace().Msgf("announce: queued line: %v", line)
+	a.log.Trace().Msgf("announce: queued line: %v", line.line)
 
 	return nil
 }
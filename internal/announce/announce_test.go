@@ -0,0 +1,204 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package announce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder(2, ParseAlert{}, 0)
+
+	for _, line := range []string{"one", "two", "three"} {
+		recorder.RecordLine(domain.AnnounceLine{Channel: "#Announce", Line: line})
+	}
+
+	lines := recorder.Lines("#announce")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "two", lines[0].Line)
+	assert.Equal(t, "three", lines[1].Line)
+
+	for i := 0; i < parseFailuresPerIndexer+10; i++ {
+		recorder.RecordParseFailure(domain.AnnounceParseFailure{Indexer: "mock"})
+	}
+	assert.Len(t, recorder.ParseFailures(), parseFailuresPerIndexer)
+
+	disabled := NewRecorder(0, ParseAlert{}, 0)
+	disabled.RecordLine(domain.AnnounceLine{Channel: "#announce", Line: "one"})
+	assert.Empty(t, disabled.Lines("#announce"))
+}
+
+func TestRecorder_ParseAlert(t *testing.T) {
+	t.Parallel()
+
+	var alerts []domain.AnnounceParserHealth
+	recorder := NewRecorder(0, ParseAlert{
+		Failures: 3,
+		Silence:  time.Hour,
+		OnFailing: func(health domain.AnnounceParserHealth) {
+			alerts = append(alerts, health)
+		},
+	}, 0)
+
+	start := time.Now()
+	failure := func(offset time.Duration) {
+		recorder.RecordParseFailure(domain.AnnounceParseFailure{Indexer: "mock", IndexerName: "Mock", Timestamp: start.Add(offset)})
+	}
+
+	recorder.RecordParseSuccess("mock", "Mock")
+	failure(time.Second)
+	failure(2 * time.Second)
+	assert.Empty(t, alerts)
+
+	failure(3 * time.Second)
+	failure(4 * time.Second)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "mock", alerts[0].Indexer)
+	assert.Equal(t, uint64(3), alerts[0].ConsecutiveFailures)
+	assert.True(t, alerts[0].Failing)
+
+	recorder.RecordParseSuccess("mock", "Mock")
+	health := recorder.ParserHealth()
+	require.Len(t, health, 1)
+	assert.Equal(t, uint64(2), health[0].Successes)
+	assert.Equal(t, uint64(4), health[0].Failures)
+	assert.Zero(t, health[0].ConsecutiveFailures)
+	assert.False(t, health[0].Failing)
+
+	// failures below the streak threshold still alert once nothing matched for an hour
+	recorder.RecordParseFailure(domain.AnnounceParseFailure{Indexer: "other", Timestamp: start})
+	recorder.RecordParseFailure(domain.AnnounceParseFailure{Indexer: "other", Timestamp: start.Add(time.Hour)})
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "other", alerts[1].Indexer)
+	assert.Zero(t, alerts[1].Successes)
+}
+
+func TestDryRunProcessor(t *testing.T) {
+	t.Parallel()
+
+	definition := &domain.IndexerDefinition{
+		Identifier: "mock",
+		Name:       "Mock",
+		Protocol:   "torrent",
+		URLS:       []string{"https://mock.local/"},
+		IRC: &domain.IndexerIRC{
+			Network:  "Mock",
+			Channels: []string{"#announce"},
+			Parse: &domain.IndexerIRCParse{
+				Type: "single",
+				Lines: []domain.IndexerIRCParseLine{
+					{
+						Pattern: `New: (.+) - (https?://.+/)(\d+)`,
+						Vars:    []string{"torrentName", "baseUrl", "torrentId"},
+					},
+				},
+				Match: domain.IndexerIRCParseMatch{
+					TorrentURL: "/download/{{ .torrentId }}",
+				},
+			},
+		},
+	}
+
+	processor := NewDryRunProcessor(zerolog.Nop(), definition)
+
+	require.NoError(t, processor.AddLineToQueue("#Announce", "New: That.Show.S01E01.1080p.WEB.H264-GROUP - https://mock.local/1"))
+	require.NoError(t, processor.AddLineToQueue("#announce", "Welcome to the announce channel"))
+	assert.Error(t, processor.AddLineToQueue("#other", "New: Other"))
+
+	releases, failures := processor.Close()
+
+	require.Len(t, releases, 1)
+	assert.Equal(t, "That.Show.S01E01.1080p.WEB.H264-GROUP", releases[0].TorrentName)
+	assert.Equal(t, "https://mock.local/download/1", releases[0].DownloadURL)
+
+	require.Len(t, failures, 1)
+	assert.Equal(t, "mock", failures[0].Indexer)
+	assert.Equal(t, "#announce", failures[0].Channel)
+	assert.Equal(t, "Welcome to the announce channel", failures[0].Line)
+	assert.WithinDuration(t, time.Now(), failures[0].Timestamp, time.Minute)
+}
+
+func TestAnnounceProcessor_Playback(t *testing.T) {
+	t.Parallel()
+
+	definition := &domain.IndexerDefinition{
+		Identifier: "mock",
+		Name:       "Mock",
+		Protocol:   "torrent",
+		URLS:       []string{"https://mock.local/"},
+		IRC: &domain.IndexerIRC{
+			Network:  "Mock",
+			Channels: []string{"#announce"},
+			Parse: &domain.IndexerIRCParse{
+				Type: "single",
+				Lines: []domain.IndexerIRCParseLine{
+					{
+						Pattern: `New: (.+) - (https?://.+/)(\d+)`,
+						Vars:    []string{"torrentName", "baseUrl", "torrentId"},
+					},
+				},
+				Match: domain.IndexerIRCParseMatch{
+					TorrentURL: "/download/{{ .torrentId }}",
+				},
+			},
+		},
+	}
+
+	recorder := NewRecorder(0, ParseAlert{}, time.Hour)
+
+	var releases []*domain.Release
+	processor := newAnnounceProcessor(zerolog.Nop(), definition, func(rls *domain.Release) {
+		releases = append(releases, rls)
+	}, func() {}, func(domain.AnnounceParseFailure) {})
+	processor.seenRelease = recorder.RecordRelease
+	// stored before a restart, the recorder does not know it
+	processor.storedRelease = func(rls *domain.Release) bool {
+		return rls.TorrentName == "That.Show.S01E03.1080p.WEB.H264-GROUP"
+	}
+
+	missed := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+
+	require.NoError(t, processor.AddLineToQueue("#announce", "New: That.Show.S01E01.1080p.WEB.H264-GROUP - https://mock.local/1"))
+	// played back by the bouncer after a reconnect
+	require.NoError(t, processor.AddPlaybackLineToQueue("#announce", "New: That.Show.S01E01.1080p.WEB.H264-GROUP - https://mock.local/1", missed))
+	require.NoError(t, processor.AddPlaybackLineToQueue("#announce", "New: That.Show.S01E02.1080p.WEB.H264-GROUP - https://mock.local/2", missed))
+	require.NoError(t, processor.AddPlaybackLineToQueue("#announce", "New: That.Show.S01E03.1080p.WEB.H264-GROUP - https://mock.local/3", missed))
+	// live duplicates are not dropped
+	require.NoError(t, processor.AddLineToQueue("#announce", "New: That.Show.S01E02.1080p.WEB.H264-GROUP - https://mock.local/2"))
+
+	processor.Stop()
+	processor.consumers.Wait()
+
+	require.Len(t, releases, 3)
+	assert.Equal(t, "That.Show.S01E01.1080p.WEB.H264-GROUP", releases[0].TorrentName)
+	assert.Equal(t, "That.Show.S01E02.1080p.WEB.H264-GROUP", releases[1].TorrentName)
+	assert.Equal(t, missed, releases[1].Timestamp)
+	assert.Equal(t, "That.Show.S01E02.1080p.WEB.H264-GROUP", releases[2].TorrentName)
+	assert.WithinDuration(t, time.Now(), releases[2].Timestamp, time.Minute)
+}
+
+func TestRecorder_PlaybackSince(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRecorder(0, ParseAlert{}, time.Hour)
+
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), recorder.PlaybackSince("#announce"), time.Minute)
+
+	last := time.Now().Add(-5 * time.Minute)
+	recorder.RecordLine(domain.AnnounceLine{Channel: "#Announce", Line: "one", Timestamp: last})
+	assert.Equal(t, last, recorder.PlaybackSince("#announce"))
+
+	// announces older than the max age are not played back
+	recorder.RecordLine(domain.AnnounceLine{Channel: "#old", Line: "one", Timestamp: time.Now().Add(-2 * time.Hour)})
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), recorder.PlaybackSince("#old"), time.Minute)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package announce
+
+import (
+	"sync"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/rs/zerolog"
+)
+
+// DryRunProcessor parses lines like the announce processor of the indexer but collects the releases
+// and parse failures instead of processing them, so no filters or actions are run.
+type DryRunProcessor struct {
+	*announceProcessor
+
+	mu       sync.Mutex
+	releases []*domain.Release
+	failures []domain.AnnounceParseFailure
+}
+
+func NewDryRunProcessor(log zerolog.Logger, indexer *domain.IndexerDefinition) *DryRunProcessor {
+	p := &DryRunProcessor{
+		releases: make([]*domain.Release, 0),
+		failures: make([]domain.AnnounceParseFailure, 0),
+	}
+
+	p.announceProcessor = newAnnounceProcessor(log.With().Bool("dry_run", true).Logger(), indexer, p.addRelease, func() {}, p.addParseFailure)
+
+	return p
+}
+
+func (p *DryRunProcessor) addRelease(rls *domain.Release) {
+	p.mu.Lock()
+	p.releases = append(p.releases, rls)
+	p.mu.Unlock()
+}
+
+func (p *DryRunProcessor) addParseFailure(failure domain.AnnounceParseFailure) {
+	p.mu.Lock()
+	p.failures = append(p.failures, failure)
+	p.mu.Unlock()
+}
+
+// Close stops the queue consumers once all queued lines are parsed and returns the results.
+// Lines of an announce that spans multiple lines are dropped if the announce is incomplete.
+func (p *DryRunProcessor) Close() ([]*domain.Release, []domain.AnnounceParseFailure) {
+	p.Stop()
+	p.consumers.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.releases, p.failures
+}
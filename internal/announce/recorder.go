@@ -0,0 +1,259 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package announce
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// parseFailuresPerIndexer is how many parse failures are kept per indexer
+const parseFailuresPerIndexer = 100
+
+// ParseAlert decides when the announces of an indexer are considered to be failing
+type ParseAlert struct {
+	// Failures is how many announces in a row have to fail, 0 disables the check
+	Failures int
+	// Silence is how long announces have to keep failing without a single one matching, 0 disables the check
+	Silence time.Duration
+	// OnFailing is called once when an indexer starts failing and again only after an announce matched
+	OnFailing func(health domain.AnnounceParserHealth)
+}
+
+// Recorder keeps the latest raw announce lines per channel, the latest parse failures per indexer
+// and the parse health per indexer of a network. With bouncer playback it also keeps the time of the
+// last announce per channel and the releases announced within the playback window.
+// Everything is kept in memory and lost on restart.
+type Recorder struct {
+	mu        sync.RWMutex
+	lineLimit int
+	lines     map[string][]domain.AnnounceLine
+	failures  map[string][]domain.AnnounceParseFailure
+	alert     ParseAlert
+	health    map[string]*parserHealth
+
+	playbackMaxAge time.Duration
+	lastAnnounce   map[string]time.Time
+	releases       map[string]time.Time
+	releasesPruned time.Time
+}
+
+type parserHealth struct {
+	domain.AnnounceParserHealth
+
+	// since is when the indexer was first seen, it stands in for the last success until there is one
+	since time.Time
+}
+
+// NewRecorder returns a recorder that keeps lineLimit lines per channel, 0 disables recording lines.
+// Announces played back by a bouncer are accepted up to playbackMaxAge old, 0 disables playback.
+func NewRecorder(lineLimit int, alert ParseAlert, playbackMaxAge time.Duration) *Recorder {
+	return &Recorder{
+		lineLimit:      lineLimit,
+		lines:          make(map[string][]domain.AnnounceLine),
+		failures:       make(map[string][]domain.AnnounceParseFailure),
+		alert:          alert,
+		health:         make(map[string]*parserHealth),
+		playbackMaxAge: playbackMaxAge,
+		lastAnnounce:   make(map[string]time.Time),
+		releases:       make(map[string]time.Time),
+	}
+}
+
+func (r *Recorder) RecordLine(line domain.AnnounceLine) {
+	if r.lineLimit <= 0 && r.playbackMaxAge <= 0 {
+		return
+	}
+
+	channel := strings.ToLower(line.Channel)
+
+	r.mu.Lock()
+	if r.lineLimit > 0 {
+		r.lines[channel] = appendRolling(r.lines[channel], line, r.lineLimit)
+	}
+	if r.playbackMaxAge > 0 && line.Timestamp.After(r.lastAnnounce[channel]) {
+		r.lastAnnounce[channel] = line.Timestamp
+	}
+	r.mu.Unlock()
+}
+
+// Lines returns a copy of the recorded lines of the channel, oldest first
+func (r *Recorder) Lines(channel string) []domain.AnnounceLine {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lines := r.lines[strings.ToLower(channel)]
+
+	return append(make([]domain.AnnounceLine, 0, len(lines)), lines...)
+}
+
+// RecordParseSuccess counts an announce that matched the parse patterns of the indexer and clears its alert
+func (r *Recorder) RecordParseSuccess(indexer string, indexerName string) {
+	now := time.Now()
+
+	r.mu.Lock()
+	h := r.parserHealth(indexer, indexerName, now)
+	h.Successes++
+	h.ConsecutiveFailures = 0
+	h.LastSuccess = now
+	h.Failing = false
+	r.mu.Unlock()
+}
+
+// RecordParseFailure keeps the failure, counts it and calls the alert once the indexer crosses a threshold
+func (r *Recorder) RecordParseFailure(failure domain.AnnounceParseFailure) {
+	r.mu.Lock()
+	r.failures[failure.Indexer] = appendRolling(r.failures[failure.Indexer], failure, parseFailuresPerIndexer)
+
+	h := r.parserHealth(failure.Indexer, failure.IndexerName, failure.Timestamp)
+	h.Failures++
+	h.ConsecutiveFailures++
+	h.LastFailure = failure.Timestamp
+
+	alert := !h.Failing && r.failing(h)
+	if alert {
+		h.Failing = true
+	}
+	health := h.AnnounceParserHealth
+	r.mu.Unlock()
+
+	if alert && r.alert.OnFailing != nil {
+		r.alert.OnFailing(health)
+	}
+}
+
+// parserHealth returns the health of the indexer, the caller must hold the lock
+func (r *Recorder) parserHealth(indexer string, indexerName string, now time.Time) *parserHealth {
+	h, ok := r.health[indexer]
+	if !ok {
+		h = &parserHealth{
+			AnnounceParserHealth: domain.AnnounceParserHealth{Indexer: indexer, IndexerName: indexerName},
+			since:                now,
+		}
+		r.health[indexer] = h
+	}
+
+	return h
+}
+
+func (r *Recorder) failing(h *parserHealth) bool {
+	if r.alert.Failures > 0 && h.ConsecutiveFailures >= uint64(r.alert.Failures) {
+		return true
+	}
+
+	if r.alert.Silence > 0 {
+		lastSuccess := h.since
+		if h.LastSuccess.After(lastSuccess) {
+			lastSuccess = h.LastSuccess
+		}
+
+		if h.LastFailure.Sub(lastSuccess) >= r.alert.Silence {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParserHealth returns the parse health of all indexers that announced on the network, sorted by indexer
+func (r *Recorder) ParserHealth() []domain.AnnounceParserHealth {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	health := make([]domain.AnnounceParserHealth, 0, len(r.health))
+	for _, h := range r.health {
+		health = append(health, h.AnnounceParserHealth)
+	}
+
+	slices.SortFunc(health, func(a, b domain.AnnounceParserHealth) int {
+		return strings.Compare(a.Indexer, b.Indexer)
+	})
+
+	return health
+}
+
+// ParseFailures returns a copy of the parse failures of all indexers, oldest first per indexer
+func (r *Recorder) ParseFailures() []domain.AnnounceParseFailure {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	failures := make([]domain.AnnounceParseFailure, 0)
+	for _, f := range r.failures {
+		failures = append(failures, f...)
+	}
+
+	return failures
+}
+
+// PlaybackMaxAge is how old announces played back by a bouncer can be, 0 means playback is disabled
+func (r *Recorder) PlaybackMaxAge() time.Duration {
+	return r.playbackMaxAge
+}
+
+// PlaybackSince returns when the last announce of the channel was seen, but no earlier than the playback max age
+func (r *Recorder) PlaybackSince(channel string) time.Time {
+	since := time.Now().Add(-r.playbackMaxAge)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if last := r.lastAnnounce[strings.ToLower(channel)]; last.After(since) {
+		return last
+	}
+
+	return since
+}
+
+// RecordRelease keeps the normalized hash of the release for the playback max age and reports if it was seen before
+func (r *Recorder) RecordRelease(release *domain.Release) bool {
+	if r.playbackMaxAge <= 0 {
+		return false
+	}
+
+	hash := release.NormalizedHash
+	if hash == "" {
+		hash = release.TorrentName
+	}
+
+	key := release.Indexer.Identifier + ":" + hash
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// drop the releases older than the playback max age once a minute
+	if now.Sub(r.releasesPruned) > time.Minute {
+		for k, seen := range r.releases {
+			if now.Sub(seen) > r.playbackMaxAge {
+				delete(r.releases, k)
+			}
+		}
+		r.releasesPruned = now
+	}
+
+	if _, ok := r.releases[key]; ok {
+		return true
+	}
+
+	r.releases[key] = release.Timestamp
+
+	return false
+}
+
+// appendRolling appends v and drops the oldest values above limit
+func appendRolling[T any](values []T, v T, limit int) []T {
+	if len(values) < limit {
+		return append(values, v)
+	}
+
+	copy(values, values[len(values)-limit+1:])
+	values = values[:limit]
+	values[limit-1] = v
+
+	return values
+}
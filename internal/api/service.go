@@ -19,7 +19,7 @@ type Service interface {
 	List(ctx context.Context) ([]domain.APIKey, error)
 	Store(ctx context.Context, key *domain.APIKey) error
 	Delete(ctx context.Context, key string) error
-	ValidateAPIKey(ctx context.Context, token string) bool
+	ValidateAPIKey(ctx context.Context, token string) (*domain.APIKey, bool)
 }
 
 type service struct {
@@ -52,6 +52,15 @@ func (s *service) List(ctx context.Context) ([]domain.APIKey, error) {
 }
 
 func (s *service) Store(ctx context.Context, apiKey *domain.APIKey) error {
+	// keys created without any scopes keep the previous behaviour of full access
+	if len(apiKey.Scopes) == 0 {
+		apiKey.Scopes = []string{domain.APIKeyScopeAdmin}
+	}
+
+	if err := apiKey.Validate(); err != nil {
+		return err
+	}
+
 	apiKey.Key = GenerateSecureToken(16)
 
 	if err := s.repo.Store(ctx, apiKey); err != nil {
@@ -83,23 +92,24 @@ func (s *service) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-func (s *service) ValidateAPIKey(ctx context.Context, key string) bool {
-	if _, ok := s.keyCache[key]; ok {
+// ValidateAPIKey returns the api key and true if the key exists.
+func (s *service) ValidateAPIKey(ctx context.Context, key string) (*domain.APIKey, bool) {
+	if apiKey, ok := s.keyCache[key]; ok {
 		s.log.Trace().Msgf("api service key cache hit: %s", key)
-		return true
+		return &apiKey, true
 	}
 
 	apiKey, err := s.repo.GetKey(ctx, key)
 	if err != nil {
 		s.log.Trace().Msgf("api service key cache invalid key: %s", key)
-		return false
+		return nil, false
 	}
 
 	s.log.Trace().Msgf("api service key cache miss: %s", key)
 
 	s.keyCache[key] = *apiKey
 
-	return true
+	return apiKey, true
 }
 
 func GenerateSecureToken(length int) string {
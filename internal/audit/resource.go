@@ -0,0 +1,48 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package audit
+
+import (
+	"context"
+
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// Resource describes how the changes of a resource type are recorded
+type Resource[T any] struct {
+	Type domain.AuditResource
+	// Key returns the id and name of the resource
+	Key func(v *T) (id string, name string)
+	// View returns what is recorded of the resource, like a copy without runtime state or nested resources.
+	// The resource is recorded as is if View is nil.
+	View func(v *T) any
+}
+
+// Record records a change of the resource, before is nil for created and after is nil for deleted resources
+func (r Resource[T]) Record(ctx context.Context, svc Service, action domain.AuditAction, before, after *T) {
+	current := after
+	if current == nil {
+		current = before
+	}
+
+	if current == nil {
+		return
+	}
+
+	id, name := r.Key(current)
+
+	svc.Record(ctx, action, r.Type, id, name, r.view(before), r.view(after))
+}
+
+func (r Resource[T]) view(v *T) any {
+	if v == nil {
+		return nil
+	}
+
+	if r.View == nil {
+		return v
+	}
+
+	return r.View(v)
+}
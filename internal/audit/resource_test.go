@@ -0,0 +1,52 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package audit
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResource_Record(t *testing.T) {
+	repo := &memoryRepo{}
+	svc := &service{log: zerolog.Nop(), config: &domain.Config{}, repo: repo}
+
+	resource := Resource[domain.Proxy]{
+		Type: domain.AuditResourceProxy,
+		Key: func(p *domain.Proxy) (string, string) {
+			return strconv.FormatInt(p.ID, 10), p.Name
+		},
+		View: func(p *domain.Proxy) any {
+			view := *p
+			view.Addr = ""
+			return &view
+		},
+	}
+
+	ctx := context.Background()
+	proxy := &domain.Proxy{ID: 1, Name: "proxy", Enabled: true, Addr: "socks5://localhost:1080"}
+
+	// nothing to record without a resource
+	resource.Record(ctx, svc, domain.AuditActionCreate, nil, nil)
+	assert.Empty(t, repo.entries)
+
+	resource.Record(ctx, svc, domain.AuditActionCreate, nil, proxy)
+	resource.Record(ctx, svc, domain.AuditActionDelete, proxy, nil)
+
+	require.Len(t, repo.entries, 2)
+	for _, entry := range repo.entries {
+		assert.Equal(t, domain.AuditResourceProxy, entry.ResourceType)
+		assert.Equal(t, "1", entry.ResourceID)
+		assert.Equal(t, "proxy", entry.ResourceName)
+		assert.NotContains(t, entry.Changes, domain.AuditChange{Field: "addr", Old: nil, New: proxy.Addr})
+		assert.NotContains(t, entry.Changes, domain.AuditChange{Field: "addr", Old: proxy.Addr, New: nil})
+	}
+}
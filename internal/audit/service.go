@@ -0,0 +1,85 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/rs/zerolog"
+)
+
+type Service interface {
+	Record(ctx context.Context, action domain.AuditAction, resource domain.AuditResource, resourceID string, resourceName string, old, new any)
+	Find(ctx context.Context, params domain.AuditQueryParams) (*domain.FindAuditResponse, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type service struct {
+	log    zerolog.Logger
+	config *domain.Config
+	repo   domain.AuditRepo
+}
+
+func NewService(log logger.Logger, config *domain.Config, repo domain.AuditRepo) Service {
+	return &service{
+		log:    log.With().Str("module", "audit").Logger(),
+		config: config,
+		repo:   repo,
+	}
+}
+
+// Record stores an entry with the fields that changed between old and new.
+// Updates without changes are skipped. Errors are only logged so the change itself never fails because of the audit log.
+func (s *service) Record(ctx context.Context, action domain.AuditAction, resource domain.AuditResource, resourceID string, resourceName string, old, new any) {
+	changes := domain.AuditDiff(old, new)
+	if action == domain.AuditActionUpdate && len(changes) == 0 {
+		return
+	}
+
+	actor, actorType := domain.AuditActorFromContext(ctx)
+
+	entry := &domain.AuditEntry{
+		Actor:        actor,
+		ActorType:    actorType,
+		Action:       action,
+		ResourceType: resource,
+		ResourceID:   resourceID,
+		ResourceName: resourceName,
+		Changes:      changes,
+	}
+
+	// the change is already done so it is recorded even if the request was cancelled
+	if err := s.repo.Store(context.WithoutCancel(ctx), entry); err != nil {
+		s.log.Error().Err(err).Msgf("could not store audit entry: %s %s %s", action, resource, resourceID)
+		return
+	}
+
+	s.log.Trace().Msgf("audit: %s %s %s by %s %s with %d changes", action, resource, resourceID, actorType, actor, len(changes))
+}
+
+func (s *service) Find(ctx context.Context, params domain.AuditQueryParams) (*domain.FindAuditResponse, error) {
+	return s.repo.Find(ctx, params)
+}
+
+// DeleteExpired removes entries older than the configured retention
+func (s *service) DeleteExpired(ctx context.Context) error {
+	if s.config.AuditRetentionDays <= 0 {
+		return nil
+	}
+
+	deleted, err := s.repo.DeleteBefore(ctx, time.Now().AddDate(0, 0, -s.config.AuditRetentionDays))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		s.log.Debug().Msgf("deleted %d audit entries older than %d days", deleted, s.config.AuditRetentionDays)
+	}
+
+	return nil
+}
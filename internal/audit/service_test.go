@@ -0,0 +1,81 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepo struct {
+	entries []domain.AuditEntry
+	before  time.Time
+}
+
+func (r *memoryRepo) Store(_ context.Context, entry *domain.AuditEntry) error {
+	entry.ID = int64(len(r.entries) + 1)
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *memoryRepo) Find(_ context.Context, _ domain.AuditQueryParams) (*domain.FindAuditResponse, error) {
+	return &domain.FindAuditResponse{Data: r.entries, TotalCount: uint64(len(r.entries))}, nil
+}
+
+func (r *memoryRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	r.before = before
+	return 0, nil
+}
+
+func TestService_Record(t *testing.T) {
+	repo := &memoryRepo{}
+	svc := &service{log: zerolog.Nop(), config: &domain.Config{}, repo: repo}
+
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{Username: "admin"})
+
+	proxy := &domain.Proxy{ID: 1, Name: "proxy", Enabled: true, Pass: "secret"}
+
+	// updates without changes are not recorded
+	svc.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceProxy, "1", proxy.Name, proxy, proxy)
+	assert.Empty(t, repo.entries)
+
+	disabled := *proxy
+	disabled.Enabled = false
+	svc.Record(ctx, domain.AuditActionToggle, domain.AuditResourceProxy, "1", proxy.Name, proxy, &disabled)
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, "admin", entry.Actor)
+	assert.Equal(t, domain.AuditActorUser, entry.ActorType)
+	assert.Equal(t, domain.AuditActionToggle, entry.Action)
+	assert.Equal(t, []domain.AuditChange{{Field: "enabled", Old: true, New: false}}, entry.Changes)
+
+	// deletes are recorded with api keys as actor
+	svc.Record(domain.ContextWithAPIKey(context.Background(), &domain.APIKey{Name: "script"}), domain.AuditActionDelete, domain.AuditResourceProxy, "1", proxy.Name, proxy, nil)
+
+	require.Len(t, repo.entries, 2)
+	assert.Equal(t, "script", repo.entries[1].Actor)
+	assert.Contains(t, repo.entries[1].Changes, domain.AuditChange{Field: "pass", Old: domain.RedactedStr, New: nil})
+}
+
+func TestService_DeleteExpired(t *testing.T) {
+	repo := &memoryRepo{}
+	cfg := &domain.Config{}
+	svc := &service{log: zerolog.Nop(), config: cfg, repo: repo}
+
+	// a retention of 0 keeps everything
+	require.NoError(t, svc.DeleteExpired(context.Background()))
+	assert.True(t, repo.before.IsZero())
+
+	cfg.AuditRetentionDays = 30
+	require.NoError(t, svc.DeleteExpired(context.Background()))
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), repo.before, time.Minute)
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -223,6 +224,64 @@ func (s *OIDCService) OauthAuthCodeURL(state string, opts ...oauth2.AuthCodeOpti
 	return s.oauthConfig.AuthCodeURL(state, opts...)
 }
 
+// RoleFromClaims maps the groups claim of the ID token and userinfo claims to the most privileged role.
+// Every user is an admin while no groups are mapped, once they are ok is false for users without a matching group.
+func (s *OIDCService) RoleFromClaims(claims ...map[string]any) (role domain.UserRole, ok bool) {
+	mapping := []struct {
+		role   domain.UserRole
+		groups string
+	}{
+		{domain.UserRoleAdmin, s.cfg.OIDCAdminGroups},
+		{domain.UserRoleEditor, s.cfg.OIDCEditorGroups},
+		{domain.UserRoleViewer, s.cfg.OIDCViewerGroups},
+	}
+
+	if s.cfg.OIDCAdminGroups == "" && s.cfg.OIDCEditorGroups == "" && s.cfg.OIDCViewerGroups == "" {
+		return domain.UserRoleAdmin, true
+	}
+
+	claimName := s.cfg.OIDCGroupsClaim
+	if claimName == "" {
+		claimName = "groups"
+	}
+
+	var userGroups []string
+	for _, c := range claims {
+		userGroups = append(userGroups, claimGroups(c[claimName])...)
+	}
+
+	for _, m := range mapping {
+		for _, group := range strings.Split(m.groups, ",") {
+			group = strings.TrimSpace(group)
+			if group != "" && slices.Contains(userGroups, group) {
+				return m.role, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// claimGroups reads a groups claim which is either a list or a single group
+func claimGroups(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, group := range v {
+			if g, ok := group.(string); ok {
+				groups = append(groups, g)
+			}
+		}
+		return groups
+	}
+
+	return nil
+}
+
 type Claims struct {
 	AuthURL        string   `json:"authorization_endpoint"`
 	TokenURL       string   `json:"token_endpoint"`
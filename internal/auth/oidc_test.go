@@ -0,0 +1,86 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCService_RoleFromClaims(t *testing.T) {
+	t.Parallel()
+
+	mapped := &domain.Config{
+		OIDCGroupsClaim:  "groups",
+		OIDCAdminGroups:  "autobrr-admins",
+		OIDCEditorGroups: "autobrr-editors, media",
+		OIDCViewerGroups: "family",
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *domain.Config
+		claims   []map[string]any
+		wantRole domain.UserRole
+		wantOk   bool
+	}{
+		{
+			name:     "no_mapping",
+			cfg:      &domain.Config{},
+			claims:   []map[string]any{{"groups": []any{"anything"}}},
+			wantRole: domain.UserRoleAdmin,
+			wantOk:   true,
+		},
+		{
+			name:     "viewer",
+			cfg:      mapped,
+			claims:   []map[string]any{{"groups": []any{"family"}}},
+			wantRole: domain.UserRoleViewer,
+			wantOk:   true,
+		},
+		{
+			name:     "most_privileged_role",
+			cfg:      mapped,
+			claims:   []map[string]any{{"groups": []any{"family", "media"}}},
+			wantRole: domain.UserRoleEditor,
+			wantOk:   true,
+		},
+		{
+			name:     "userinfo_groups",
+			cfg:      mapped,
+			claims:   []map[string]any{{"sub": "1"}, {"groups": "autobrr-admins"}},
+			wantRole: domain.UserRoleAdmin,
+			wantOk:   true,
+		},
+		{
+			name:     "custom_claim",
+			cfg:      &domain.Config{OIDCGroupsClaim: "roles", OIDCViewerGroups: "family"},
+			claims:   []map[string]any{{"groups": []any{"family"}, "roles": []any{"family"}}},
+			wantRole: domain.UserRoleViewer,
+			wantOk:   true,
+		},
+		{
+			name:   "no_matching_group",
+			cfg:    mapped,
+			claims: []map[string]any{{"groups": []any{"guests"}}},
+		},
+		{
+			name: "no_claims",
+			cfg:  mapped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &OIDCService{cfg: tt.cfg}
+
+			role, ok := s.RoleFromClaims(tt.claims...)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantRole, role)
+		})
+	}
+}
@@ -20,6 +20,11 @@ type Service interface {
 	Login(ctx context.Context, username, password string) (*domain.User, error)
 	CreateUser(ctx context.Context, req domain.CreateUserRequest) error
 	UpdateUser(ctx context.Context, req domain.UpdateUserRequest) error
+	GetUser(ctx context.Context, username string) (*domain.User, error)
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	AddUser(ctx context.Context, req domain.CreateUserRequest) error
+	EditUser(ctx context.Context, req domain.UpdateUserRequest) error
+	DeleteUser(ctx context.Context, username string) error
 	CreateHash(password string) (hash string, err error)
 	ComparePasswordAndHash(password string, hash string) (match bool, err error)
 }
@@ -83,7 +88,7 @@ func (s *service) CreateUser(ctx context.Context, req domain.CreateUserRequest)
 	}
 
 	if userCount > 0 {
-		return errors.New("only 1 user account is supported at the moment")
+		return errors.New("onboarding unavailable: user already registered")
 	}
 
 	hashed, err := s.CreateHash(req.Password)
@@ -93,6 +98,9 @@ func (s *service) CreateUser(ctx context.Context, req domain.CreateUserRequest)
 
 	req.Password = hashed
 
+	// the onboarded user manages everyone else
+	req.Role = domain.UserRoleAdmin
+
 	if err := s.userSvc.CreateUser(ctx, req); err != nil {
 		s.log.Error().Err(err).Msgf("could not create user: %s", req.Username)
 		return errors.New("failed to create new user")
@@ -102,6 +110,9 @@ func (s *service) CreateUser(ctx context.Context, req domain.CreateUserRequest)
 }
 
 func (s *service) UpdateUser(ctx context.Context, req domain.UpdateUserRequest) error {
+	// users can not change their own role
+	req.Role = ""
+
 	if req.PasswordCurrent == "" {
 		return errors.New("validation error: empty current password supplied")
 	}
@@ -151,6 +162,78 @@ func (s *service) UpdateUser(ctx context.Context, req domain.UpdateUserRequest)
 	return nil
 }
 
+func (s *service) GetUser(ctx context.Context, username string) (*domain.User, error) {
+	return s.userSvc.FindByUsername(ctx, username)
+}
+
+func (s *service) ListUsers(ctx context.Context) ([]domain.User, error) {
+	return s.userSvc.List(ctx)
+}
+
+// AddUser creates another user, unlike CreateUser it is not limited to onboarding
+func (s *service) AddUser(ctx context.Context, req domain.CreateUserRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	hashed, err := s.CreateHash(req.Password)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+
+	req.Password = hashed
+
+	if err := s.userSvc.CreateUser(ctx, req); err != nil {
+		s.log.Error().Err(err).Msgf("could not create user: %s", req.Username)
+		return err
+	}
+
+	s.log.Info().Str("role", string(req.Role)).Msgf("created user: %s", req.Username)
+
+	return nil
+}
+
+// EditUser changes the role or resets the password of a user without knowing the current password
+func (s *service) EditUser(ctx context.Context, req domain.UpdateUserRequest) error {
+	// renaming is left to the user
+	req.UsernameNew = ""
+
+	if req.Role == "" && req.PasswordNew == "" {
+		return errors.New("validation error: nothing to update")
+	}
+
+	if _, err := s.userSvc.FindByUsername(ctx, req.UsernameCurrent); err != nil {
+		return err
+	}
+
+	if req.PasswordNew != "" {
+		hashed, err := s.CreateHash(req.PasswordNew)
+		if err != nil {
+			return errors.New("failed to hash password")
+		}
+
+		req.PasswordNewHash = hashed
+	}
+
+	if err := s.userSvc.Update(ctx, req); err != nil {
+		s.log.Error().Err(err).Msgf("could not update user: %s", req.UsernameCurrent)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) DeleteUser(ctx context.Context, username string) error {
+	if err := s.userSvc.Delete(ctx, username); err != nil {
+		s.log.Error().Err(err).Msgf("could not delete user: %s", username)
+		return err
+	}
+
+	s.log.Info().Msgf("deleted user: %s", username)
+
+	return nil
+}
+
 func (s *service) ComparePasswordAndHash(password string, hash string) (match bool, err error) {
 	return argon2id.ComparePasswordAndHash(password, hash)
 }
@@ -96,6 +96,32 @@ checkForUpdates = true
 #
 sessionSecret = "{{ .sessionSecret }}"
 
+# Secret key
+#
+# Encrypts the passwords and api keys stored in the database.
+# Run "autobrrctl secrets:rotate" to encrypt existing rows or to change the key.
+# Keep a copy of the key, encrypted secrets can not be read without it.
+#
+# Generate one with: openssl rand -base64 32
+#
+#secretKey = ""
+
+# Secrets directory
+#
+# Directory that "file:" secret references are read from, like file:/run/secrets/qbit.
+# File references are disabled when it is not set.
+#
+#secretsDir = "/run/secrets"
+
+# Secrets environment prefix
+#
+# Only environment variables with this prefix can be used as "env:" secret references.
+# AUTOBRR__ variables can never be referenced.
+#
+# Default: "AUTOBRR_SECRET_"
+#
+#secretsEnvPrefix = "AUTOBRR_SECRET_"
+
 # Database Max Backups
 #
 # Default: 5
@@ -130,6 +156,17 @@ sessionSecret = "{{ .sessionSecret }}"
 #
 # Disable Built In Login Form (only works when using external auth)
 #oidcDisableBuiltInLogin = false
+#
+# Claim with the groups of the user, used to map groups to roles
+#
+# Default: "groups"
+#oidcGroupsClaim = "groups"
+#
+# Comma separated groups mapped to the admin, editor and viewer roles.
+# Without any groups set every OIDC user is an admin. Once set, users without a matching group can not log in.
+#oidcAdminGroups = ""
+#oidcEditorGroups = ""
+#oidcViewerGroups = ""
 
 # Metrics
 #
@@ -151,8 +188,99 @@ sessionSecret = "{{ .sessionSecret }}"
 #
 #metricsBasicAuthUsers = ""
 
+# Release workers
+#
+# Number of releases processed concurrently. Filter delays do not hold a worker.
+#
+# Default: 10
+#
+#releaseWorkers = 10
+
+# Magnet resolve timeout
+#
+# Seconds to wait for peers to send the torrent metadata of a magnet link.
+# Needed for magnet releases with watch folder actions, external filters or torrent file macros.
+#
+# Default: 60
+#
+#magnetResolveTimeout = 60
+
+# Magnet fetch helper
+#
+# Optional url to fetch the torrent file of a magnet link from before asking peers.
+# {infohash} is replaced with the info hash and {magnet} with the escaped magnet link.
+#
+#magnetFetchUrl = ""
+
+# Torrent tracking interval
+#
+# Minutes between polling download clients for upload, ratio and seed time of pushed torrents.
+# Set to 0 to disable tracking.
+#
+# Default: 15
+#
+#torrentTrackingInterval = 15
+
+# Backfill interval
+#
+# Hours between searching torznab and newznab feeds for the titles of filters with backfill enabled.
+# Set to 0 to disable the scheduled backfill.
+#
+# Default: 24
+#
+#backfillInterval = 24
+
+# Backfill search delay
+#
+# Minimum seconds between backfill searches on the same indexer.
+#
+# Default: 10
+#
+#backfillSearchDelay = 10
+
+# Audit log retention
+#
+# Days to keep the audit log of configuration changes. Set to 0 to keep it forever.
+#
+# Default: 90
+#
+#auditRetentionDays = 90
+
+# IRC announce recording
+#
+# Raw announce lines kept in memory per IRC channel so they can be replayed when debugging indexer definitions.
+# Set to 0 to disable recording.
+#
+# Default: 0
+#
+#ircAnnounceRecordLines = 0
+
+# Announce parse alerts
+#
+# Sends the ANNOUNCE_PARSE_FAILING notification when announces of an indexer stop matching its definition.
+# announceParseFailures is how many announces in a row have to fail.
+# announceParseSilence is how many minutes announces have to keep failing without a single one matching.
+# Set either to 0 to disable that check.
+#
+# Default: 10 and 60
+#
+#announceParseFailures = 10
+#announceParseSilence = 60
+
+# IRC bouncer playback
+#
+# Networks that use a bouncer ask it to play back the announces missed while disconnected, with IRCv3 chathistory (soju) or znc.in/playback (ZNC).
+# Played back announces older than this many minutes are skipped and announces that were already processed are dropped.
+# Set to 0 to disable playback.
+#
+# Default: 60
+#
+#ircBouncerPlaybackMaxAge = 60
+
 # Custom definitions
 #
+# Changed files are reloaded while running, or with autobrrctl definitions:reload
+#
 #customDefinitions = "test/definitions"
 `
 
@@ -264,43 +392,57 @@ func New(configPath string, version string) *AppConfig {
 	c.load(configPath)
 	c.loadFromEnv()
 
+	// references are resolved in every command, not only the server
+	domain.SetSecretReferenceOptions(c.Config.SecretsDir, c.Config.SecretsEnvPrefix)
+
 	return c
 }
 
 func (c *AppConfig) defaults() {
 	c.Config = &domain.Config{
-		Version:               "dev",
-		Host:                  "localhost",
-		Port:                  7474,
-		CorsAllowedOrigins:    "*",
-		LogLevel:              "TRACE",
-		LogPath:               "",
-		LogMaxSize:            50,
-		LogMaxBackups:         3,
-		BaseURL:               "/",
-		BaseURLModeLegacy:     true,
-		SessionSecret:         api.GenerateSecureToken(16),
-		CustomDefinitions:     "",
-		CheckForUpdates:       true,
-		DatabaseType:          "sqlite",
-		DatabaseAutoMigrate:   true,
-		DatabaseMaxBackups:    5,
-		DatabaseDSN:           "",
-		PostgresHost:          "",
-		PostgresPort:          0,
-		PostgresDatabase:      "",
-		PostgresUser:          "",
-		PostgresPass:          "",
-		PostgresSSLMode:       "disable",
-		PostgresExtraParams:   "",
-		PostgresSocket:        "",
-		ProfilingEnabled:      false,
-		ProfilingHost:         "127.0.0.1",
-		ProfilingPort:         6060,
-		MetricsEnabled:        false,
-		MetricsHost:           "127.0.0.1",
-		MetricsPort:           9074,
-		MetricsBasicAuthUsers: "",
+		Version:                  "dev",
+		Host:                     "localhost",
+		Port:                     7474,
+		CorsAllowedOrigins:       "*",
+		LogLevel:                 "TRACE",
+		LogPath:                  "",
+		LogMaxSize:               50,
+		LogMaxBackups:            3,
+		BaseURL:                  "/",
+		BaseURLModeLegacy:        true,
+		SessionSecret:            api.GenerateSecureToken(16),
+		SecretsEnvPrefix:         domain.DefaultSecretEnvPrefix,
+		CustomDefinitions:        "",
+		CheckForUpdates:          true,
+		DatabaseType:             "sqlite",
+		DatabaseAutoMigrate:      true,
+		DatabaseMaxBackups:       5,
+		DatabaseDSN:              "",
+		PostgresHost:             "",
+		PostgresPort:             0,
+		PostgresDatabase:         "",
+		PostgresUser:             "",
+		PostgresPass:             "",
+		PostgresSSLMode:          "disable",
+		PostgresExtraParams:      "",
+		PostgresSocket:           "",
+		ProfilingEnabled:         false,
+		ProfilingHost:            "127.0.0.1",
+		ProfilingPort:            6060,
+		MetricsEnabled:           false,
+		MetricsHost:              "127.0.0.1",
+		MetricsPort:              9074,
+		MetricsBasicAuthUsers:    "",
+		OIDCGroupsClaim:          "groups",
+		ReleaseWorkers:           10,
+		MagnetResolveTimeout:     60,
+		TorrentTrackingInterval:  15,
+		BackfillInterval:         24,
+		BackfillSearchDelay:      10,
+		AuditRetentionDays:       90,
+		AnnounceParseFailures:    10,
+		AnnounceParseSilence:     60,
+		IRCBouncerPlaybackMaxAge: 60,
 	}
 }
 
@@ -345,6 +487,18 @@ func (c *AppConfig) loadFromEnv() {
 		c.Config.SessionSecret = v
 	}
 
+	if v := GetEnvStr("SECRET_KEY"); v != "" {
+		c.Config.SecretKey = v
+	}
+
+	if v := GetEnvStr("SECRETS_DIR"); v != "" {
+		c.Config.SecretsDir = v
+	}
+
+	if v := GetEnvStr("SECRETS_ENV_PREFIX"); v != "" {
+		c.Config.SecretsEnvPrefix = v
+	}
+
 	if v := GetEnvStr("CUSTOM_DEFINITIONS"); v != "" {
 		c.Config.CustomDefinitions = v
 	}
@@ -444,6 +598,22 @@ func (c *AppConfig) loadFromEnv() {
 		c.Config.OIDCDisableBuiltInLogin = strings.EqualFold(strings.ToLower(v), "true")
 	}
 
+	if v := GetEnvStr("OIDC_GROUPS_CLAIM"); v != "" {
+		c.Config.OIDCGroupsClaim = v
+	}
+
+	if v := GetEnvStr("OIDC_ADMIN_GROUPS"); v != "" {
+		c.Config.OIDCAdminGroups = v
+	}
+
+	if v := GetEnvStr("OIDC_EDITOR_GROUPS"); v != "" {
+		c.Config.OIDCEditorGroups = v
+	}
+
+	if v := GetEnvStr("OIDC_VIEWER_GROUPS"); v != "" {
+		c.Config.OIDCViewerGroups = v
+	}
+
 	if v := GetEnvStr("METRICS_ENABLED"); v != "" {
 		c.Config.MetricsEnabled = strings.EqualFold(strings.ToLower(v), "true")
 	}
@@ -459,6 +629,67 @@ func (c *AppConfig) loadFromEnv() {
 	if v := GetEnvStr("METRICS_BASIC_AUTH_USERS"); v != "" {
 		c.Config.MetricsBasicAuthUsers = v
 	}
+
+	if v := GetEnvInt("RELEASE_WORKERS"); v > 0 {
+		c.Config.ReleaseWorkers = v
+	}
+
+	if v := GetEnvInt("MAGNET_RESOLVE_TIMEOUT"); v > 0 {
+		c.Config.MagnetResolveTimeout = v
+	}
+
+	if v := GetEnvStr("MAGNET_FETCH_URL"); v != "" {
+		c.Config.MagnetFetchURL = v
+	}
+
+	// 0 disables tracking so GetEnvInt can not be used
+	if v := GetEnvStr("TORRENT_TRACKING_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Config.TorrentTrackingInterval = interval
+		}
+	}
+
+	// 0 disables the scheduled backfill so GetEnvInt can not be used
+	if v := GetEnvStr("BACKFILL_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			c.Config.BackfillInterval = interval
+		}
+	}
+
+	if v := GetEnvInt("BACKFILL_SEARCH_DELAY"); v > 0 {
+		c.Config.BackfillSearchDelay = v
+	}
+
+	// 0 keeps the audit log forever so GetEnvInt can not be used
+	if v := GetEnvStr("AUDIT_RETENTION_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil {
+			c.Config.AuditRetentionDays = days
+		}
+	}
+
+	if v := GetEnvInt("IRC_ANNOUNCE_RECORD_LINES"); v > 0 {
+		c.Config.IRCAnnounceRecordLines = v
+	}
+
+	// 0 disables the check so GetEnvInt can not be used
+	if v := GetEnvStr("ANNOUNCE_PARSE_FAILURES"); v != "" {
+		if failures, err := strconv.Atoi(v); err == nil {
+			c.Config.AnnounceParseFailures = failures
+		}
+	}
+
+	if v := GetEnvStr("ANNOUNCE_PARSE_SILENCE"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			c.Config.AnnounceParseSilence = minutes
+		}
+	}
+
+	// 0 disables playback so GetEnvInt can not be used
+	if v := GetEnvStr("IRC_BOUNCER_PLAYBACK_MAX_AGE"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			c.Config.IRCBouncerPlaybackMaxAge = minutes
+		}
+	}
 }
 
 func GetEnvStr(key string) string {
@@ -13,6 +13,7 @@ import (
 	"github.com/autobrr/autobrr/pkg/errors"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 )
 
@@ -67,6 +68,10 @@ func (r *ActionRepo) findByFilterID(ctx context.Context, filterID int, active *b
 			"a.reannounce_delete",
 			"a.reannounce_interval",
 			"a.reannounce_max_attempts",
+			"a.retry_max_attempts",
+			"a.retry_backoff",
+			"a.retry_errors",
+			"a.failover_client_ids",
 			"a.webhook_host",
 			"a.webhook_type",
 			"a.webhook_method",
@@ -105,7 +110,7 @@ func (r *ActionRepo) findByFilterID(ctx context.Context, filterID int, active *b
 		var externalClientID, clientID sql.NullInt32
 		var paused, ignoreRules sql.NullBool
 
-		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
+		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &a.RetryMaxAttempts, &a.RetryBackoff, pq.Array(&a.RetryErrors), pq.Array(&a.FailoverClientIDs), &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
 			return nil, errors.Wrap(err, "error scanning row")
 		}
 
@@ -175,6 +180,10 @@ func (r *ActionRepo) findByFilterIDWithClient(ctx context.Context, filterID int,
 			"a.reannounce_delete",
 			"a.reannounce_interval",
 			"a.reannounce_max_attempts",
+			"a.retry_max_attempts",
+			"a.retry_backoff",
+			"a.retry_errors",
+			"a.failover_client_ids",
 			"a.webhook_host",
 			"a.webhook_type",
 			"a.webhook_method",
@@ -230,7 +239,7 @@ func (r *ActionRepo) findByFilterIDWithClient(ctx context.Context, filterID int,
 		var clientName, clientType, clientHost, clientUsername, clientPassword, clientSettings sql.Null[string]
 		var clientEnabled, clientTLS, clientTLSSkip sql.Null[bool]
 
-		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID, &clientClientId, &clientName, &clientType, &clientEnabled, &clientHost, &clientPort, &clientTLS, &clientTLSSkip, &clientUsername, &clientPassword, &clientSettings); err != nil {
+		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &a.RetryMaxAttempts, &a.RetryBackoff, pq.Array(&a.RetryErrors), pq.Array(&a.FailoverClientIDs), &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID, &clientClientId, &clientName, &clientType, &clientEnabled, &clientHost, &clientPort, &clientTLS, &clientTLSSkip, &clientUsername, &clientPassword, &clientSettings); err != nil {
 			return nil, errors.Wrap(err, "error scanning row")
 		}
 
@@ -280,6 +289,10 @@ func (r *ActionRepo) findByFilterIDWithClient(ctx context.Context, filterID int,
 				}
 			}
 
+			if err := r.db.decryptClient(&c); err != nil {
+				return nil, err
+			}
+
 			a.Client = &c
 		}
 
@@ -351,6 +364,10 @@ func (r *ActionRepo) findByFilterIDTx(ctx context.Context, tx *Tx, filterID int,
 			"reannounce_delete",
 			"reannounce_interval",
 			"reannounce_max_attempts",
+			"retry_max_attempts",
+			"retry_backoff",
+			"retry_errors",
+			"failover_client_ids",
 			"webhook_host",
 			"webhook_type",
 			"webhook_method",
@@ -389,7 +406,7 @@ func (r *ActionRepo) findByFilterIDTx(ctx context.Context, tx *Tx, filterID int,
 		var externalClientID, clientID sql.NullInt32
 		var paused, ignoreRules sql.NullBool
 
-		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
+		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &a.RetryMaxAttempts, &a.RetryBackoff, pq.Array(&a.RetryErrors), pq.Array(&a.FailoverClientIDs), &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
 			return nil, errors.Wrap(err, "error scanning row")
 		}
 
@@ -476,6 +493,10 @@ func (r *ActionRepo) attachDownloadClient(ctx context.Context, tx *Tx, clientID
 		}
 	}
 
+	if err := r.db.decryptClient(&client); err != nil {
+		return nil, err
+	}
+
 	return &client, nil
 }
 
@@ -508,6 +529,10 @@ func (r *ActionRepo) List(ctx context.Context) ([]domain.Action, error) {
 			"reannounce_delete",
 			"reannounce_interval",
 			"reannounce_max_attempts",
+			"retry_max_attempts",
+			"retry_backoff",
+			"retry_errors",
+			"failover_client_ids",
 			"webhook_host",
 			"webhook_type",
 			"webhook_method",
@@ -540,7 +565,7 @@ func (r *ActionRepo) List(ctx context.Context) ([]domain.Action, error) {
 		var externalClientID, clientID sql.NullInt32
 		var paused, ignoreRules sql.NullBool
 
-		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
+		if err := rows.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &a.RetryMaxAttempts, &a.RetryBackoff, pq.Array(&a.RetryErrors), pq.Array(&a.FailoverClientIDs), &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID); err != nil {
 			return nil, errors.Wrap(err, "error scanning row")
 		}
 
@@ -607,6 +632,10 @@ func (r *ActionRepo) Get(ctx context.Context, req *domain.GetActionRequest) (*do
 			"reannounce_delete",
 			"reannounce_interval",
 			"reannounce_max_attempts",
+			"retry_max_attempts",
+			"retry_backoff",
+			"retry_errors",
+			"failover_client_ids",
 			"webhook_host",
 			"webhook_type",
 			"webhook_method",
@@ -637,7 +666,7 @@ func (r *ActionRepo) Get(ctx context.Context, req *domain.GetActionRequest) (*do
 	var externalClientID, clientID, filterID sql.NullInt32
 	var paused, ignoreRules sql.NullBool
 
-	if err := row.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID, &filterID); err != nil {
+	if err := row.Scan(&a.ID, &a.Name, &a.Type, &a.Enabled, &execCmd, &execArgs, &watchFolder, &category, &tags, &label, &savePath, &downloadPath, &paused, &ignoreRules, &a.FirstLastPiecePrio, &a.SkipHashCheck, &contentLayout, &priorityLayout, &limitDl, &limitUl, &limitRatio, &limitSeedTime, &a.ReAnnounceSkip, &a.ReAnnounceDelete, &a.ReAnnounceInterval, &a.ReAnnounceMaxAttempts, &a.RetryMaxAttempts, &a.RetryBackoff, pq.Array(&a.RetryErrors), pq.Array(&a.FailoverClientIDs), &webhookHost, &webhookType, &webhookMethod, &webhookData, &externalClientID, &externalClient, &clientID, &filterID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrRecordNotFound
 		}
@@ -745,6 +774,10 @@ func (r *ActionRepo) Store(ctx context.Context, action *domain.Action) error {
 			"reannounce_delete",
 			"reannounce_interval",
 			"reannounce_max_attempts",
+			"retry_max_attempts",
+			"retry_backoff",
+			"retry_errors",
+			"failover_client_ids",
 			"webhook_host",
 			"webhook_type",
 			"webhook_method",
@@ -780,6 +813,10 @@ func (r *ActionRepo) Store(ctx context.Context, action *domain.Action) error {
 			action.ReAnnounceDelete,
 			action.ReAnnounceInterval,
 			action.ReAnnounceMaxAttempts,
+			action.RetryMaxAttempts,
+			action.RetryBackoff,
+			pq.Array(action.RetryErrors),
+			pq.Array(action.FailoverClientIDs),
 			toNullString(action.WebhookHost),
 			toNullString(action.WebhookType),
 			toNullString(action.WebhookMethod),
@@ -833,6 +870,10 @@ func (r *ActionRepo) Update(ctx context.Context, action domain.Action) (*domain.
 		Set("reannounce_delete", action.ReAnnounceDelete).
 		Set("reannounce_interval", action.ReAnnounceInterval).
 		Set("reannounce_max_attempts", action.ReAnnounceMaxAttempts).
+		Set("retry_max_attempts", action.RetryMaxAttempts).
+		Set("retry_backoff", action.RetryBackoff).
+		Set("retry_errors", pq.Array(action.RetryErrors)).
+		Set("failover_client_ids", pq.Array(action.FailoverClientIDs)).
 		Set("webhook_host", toNullString(action.WebhookHost)).
 		Set("webhook_type", toNullString(action.WebhookType)).
 		Set("webhook_method", toNullString(action.WebhookMethod)).
@@ -896,6 +937,10 @@ func (r *ActionRepo) StoreFilterActions(ctx context.Context, filterID int64, act
 				Set("reannounce_delete", action.ReAnnounceDelete).
 				Set("reannounce_interval", action.ReAnnounceInterval).
 				Set("reannounce_max_attempts", action.ReAnnounceMaxAttempts).
+				Set("retry_max_attempts", action.RetryMaxAttempts).
+				Set("retry_backoff", action.RetryBackoff).
+				Set("retry_errors", pq.Array(action.RetryErrors)).
+				Set("failover_client_ids", pq.Array(action.FailoverClientIDs)).
 				Set("webhook_host", toNullString(action.WebhookHost)).
 				Set("webhook_type", toNullString(action.WebhookType)).
 				Set("webhook_method", toNullString(action.WebhookMethod)).
@@ -946,6 +991,10 @@ func (r *ActionRepo) StoreFilterActions(ctx context.Context, filterID int64, act
 					"reannounce_delete",
 					"reannounce_interval",
 					"reannounce_max_attempts",
+					"retry_max_attempts",
+					"retry_backoff",
+					"retry_errors",
+					"failover_client_ids",
 					"webhook_host",
 					"webhook_type",
 					"webhook_method",
@@ -981,6 +1030,10 @@ func (r *ActionRepo) StoreFilterActions(ctx context.Context, filterID int64, act
 					action.ReAnnounceDelete,
 					action.ReAnnounceInterval,
 					action.ReAnnounceMaxAttempts,
+					action.RetryMaxAttempts,
+					action.RetryBackoff,
+					pq.Array(action.RetryErrors),
+					pq.Array(action.FailoverClientIDs),
 					toNullString(action.WebhookHost),
 					toNullString(action.WebhookType),
 					toNullString(action.WebhookMethod),
@@ -344,6 +344,7 @@ func TestActionRepo_Get(t *testing.T) {
 			assert.NotNil(t, createdFilters)
 
 			mockData.ClientID = mock.ID
+			mockData.FailoverClientIDs = []int32{mock.ID + 1, mock.ID + 2}
 			mockData.FilterID = createdFilters[0].ID
 			createdActions, err := repo.StoreFilterActions(context.Background(), int64(createdFilters[0].ID), []*domain.Action{mockData})
 			assert.NoError(t, err)
@@ -353,6 +354,7 @@ func TestActionRepo_Get(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, action)
 			assert.Equal(t, createdActions[0].ID, action.ID)
+			assert.Equal(t, []int32{mock.ID + 1, mock.ID + 2}, action.FailoverClientIDs)
 
 			// Cleanup
 			_ = repo.Delete(context.Background(), &domain.DeleteActionRequest{ActionId: createdActions[0].ID})
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/rs/zerolog"
+)
+
+func NewAuditRepo(log logger.Logger, db *DB) domain.AuditRepo {
+	return &AuditRepo{
+		log: log.With().Str("repo", "audit").Logger(),
+		db:  db,
+	}
+}
+
+type AuditRepo struct {
+	log zerolog.Logger
+	db  *DB
+}
+
+func (r *AuditRepo) Store(ctx context.Context, entry *domain.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if entry.Changes == nil {
+		entry.Changes = make([]domain.AuditChange, 0)
+	}
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal changes")
+	}
+
+	queryBuilder := r.db.squirrel.
+		Insert("audit_log").
+		Columns("actor", "actor_type", "action", "resource_type", "resource_id", "resource_name", "changes", "created_at").
+		Values(entry.Actor, entry.ActorType, entry.Action, entry.ResourceType, toNullString(entry.ResourceID), toNullString(entry.ResourceName), string(changes), entry.CreatedAt.UTC()).
+		Suffix("RETURNING id").RunWith(r.db.Handler)
+
+	if err := queryBuilder.QueryRowContext(ctx).Scan(&entry.ID); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
+
+// Find returns the entries matching the filters, newest first
+func (r *AuditRepo) Find(ctx context.Context, params domain.AuditQueryParams) (*domain.FindAuditResponse, error) {
+	where := sq.And{}
+	if params.Filters.Actor != "" {
+		where = append(where, sq.Eq{"actor": params.Filters.Actor})
+	}
+	if params.Filters.Action != "" {
+		where = append(where, sq.Eq{"action": params.Filters.Action})
+	}
+	if params.Filters.ResourceType != "" {
+		where = append(where, sq.Eq{"resource_type": params.Filters.ResourceType})
+	}
+	if params.Filters.ResourceID != "" {
+		where = append(where, sq.Eq{"resource_id": params.Filters.ResourceID})
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	queryBuilder := r.db.squirrel.
+		Select("id", "actor", "actor_type", "action", "resource_type", "resource_id", "resource_name", "changes", "created_at", "COUNT(*) OVER() AS total_count").
+		From("audit_log").
+		Where(where).
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset(params.Offset)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	resp := &domain.FindAuditResponse{
+		Data: make([]domain.AuditEntry, 0),
+	}
+
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var resourceID, resourceName sql.Null[string]
+		var changes string
+
+		if err := rows.Scan(&entry.ID, &entry.Actor, &entry.ActorType, &entry.Action, &entry.ResourceType, &resourceID, &resourceName, &changes, &entry.CreatedAt, &resp.TotalCount); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		entry.ResourceID = resourceID.V
+		entry.ResourceName = resourceName.V
+
+		if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+			r.log.Warn().Err(err).Msgf("could not unmarshal changes of audit entry %d", entry.ID)
+		}
+
+		resp.Data = append(resp.Data, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error rows find audit entries")
+	}
+
+	return resp, nil
+}
+
+// DeleteBefore removes entries older than before and returns how many were removed
+func (r *AuditRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	queryBuilder := r.db.squirrel.
+		Delete("audit_log").
+		Where(sq.Lt{"created_at": before.UTC()})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "error building query")
+	}
+
+	result, err := r.db.Handler.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "error executing query")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "error getting rows affected")
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build integration
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getMockAuditEntry() *domain.AuditEntry {
+	return &domain.AuditEntry{
+		Actor:        "admin",
+		ActorType:    domain.AuditActorUser,
+		Action:       domain.AuditActionUpdate,
+		ResourceType: domain.AuditResourceFilter,
+		ResourceID:   "1",
+		ResourceName: "TV",
+		Changes: []domain.AuditChange{
+			{Field: "enabled", Old: true, New: false},
+		},
+	}
+}
+
+func TestAuditRepo_Store(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+		repo := NewAuditRepo(log, db)
+
+		t.Run(fmt.Sprintf("Store_Succeeds [%s]", dbType), func(t *testing.T) {
+			entry := getMockAuditEntry()
+
+			err := repo.Store(context.Background(), entry)
+			require.NoError(t, err)
+			assert.NotZero(t, entry.ID)
+			assert.NotZero(t, entry.CreatedAt)
+
+			// Cleanup
+			_, _ = repo.DeleteBefore(context.Background(), time.Now().Add(time.Hour))
+		})
+	}
+}
+
+func TestAuditRepo_Find(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+		repo := NewAuditRepo(log, db)
+
+		t.Run(fmt.Sprintf("Find_Filters_And_Paginates [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := 0; i < 3; i++ {
+				require.NoError(t, repo.Store(ctx, getMockAuditEntry()))
+			}
+
+			other := getMockAuditEntry()
+			other.Actor = "sonarr"
+			other.ActorType = domain.AuditActorAPIKey
+			other.Action = domain.AuditActionDelete
+			other.ResourceType = domain.AuditResourceIndexer
+			other.Changes = nil
+			require.NoError(t, repo.Store(ctx, other))
+
+			resp, err := repo.Find(ctx, domain.AuditQueryParams{Limit: 2})
+			require.NoError(t, err)
+			assert.Equal(t, uint64(4), resp.TotalCount)
+			require.Len(t, resp.Data, 2)
+			assert.Equal(t, other.ID, resp.Data[0].ID)
+			assert.Empty(t, resp.Data[0].Changes)
+
+			resp, err = repo.Find(ctx, domain.AuditQueryParams{Limit: 2, Offset: 2})
+			require.NoError(t, err)
+			require.Len(t, resp.Data, 2)
+			assert.Equal(t, "TV", resp.Data[0].ResourceName)
+			assert.Equal(t, []domain.AuditChange{{Field: "enabled", Old: true, New: false}}, resp.Data[0].Changes)
+
+			params := domain.AuditQueryParams{}
+			params.Filters.ResourceType = domain.AuditResourceFilter
+			params.Filters.ResourceID = "1"
+			resp, err = repo.Find(ctx, params)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(3), resp.TotalCount)
+
+			params = domain.AuditQueryParams{}
+			params.Filters.Actor = "sonarr"
+			params.Filters.Action = domain.AuditActionDelete
+			resp, err = repo.Find(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, resp.Data, 1)
+			assert.Equal(t, domain.AuditActorAPIKey, resp.Data[0].ActorType)
+
+			// Cleanup
+			_, _ = repo.DeleteBefore(ctx, time.Now().Add(time.Hour))
+		})
+	}
+}
+
+func TestAuditRepo_DeleteBefore(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+		repo := NewAuditRepo(log, db)
+
+		t.Run(fmt.Sprintf("DeleteBefore_Removes_Old_Entries [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			old := getMockAuditEntry()
+			old.CreatedAt = time.Now().AddDate(0, 0, -100)
+			require.NoError(t, repo.Store(ctx, old))
+			require.NoError(t, repo.Store(ctx, getMockAuditEntry()))
+
+			deleted, err := repo.DeleteBefore(ctx, time.Now().AddDate(0, 0, -90))
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), deleted)
+
+			resp, err := repo.Find(ctx, domain.AuditQueryParams{})
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), resp.TotalCount)
+
+			// Cleanup
+			_, _ = repo.DeleteBefore(ctx, time.Now().Add(time.Hour))
+		})
+	}
+}
@@ -44,6 +44,7 @@ type DB struct {
 	lock    sync.RWMutex
 	ctx     context.Context
 	cfg     *domain.Config
+	secrets *secretCipher
 
 	cancel func()
 
@@ -62,6 +63,12 @@ func NewDB(cfg *domain.Config, log logger.Logger) (*DB, error) {
 	}
 	db.ctx, db.cancel = context.WithCancel(context.Background())
 
+	secrets, err := newSecretCipher(cfg.SecretKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secret key")
+	}
+	db.secrets = secrets
+
 	// Check for directly configured DSN in config
 	if cfg.DatabaseDSN != "" {
 		if strings.HasPrefix(cfg.DatabaseDSN, "postgres://") || strings.HasPrefix(cfg.DatabaseDSN, "postgresql://") {
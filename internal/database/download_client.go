@@ -78,6 +78,10 @@ func (r *DownloadClientRepo) List(ctx context.Context) ([]domain.DownloadClient,
 			}
 		}
 
+		if err := r.db.decryptClient(&f); err != nil {
+			return clients, err
+		}
+
 		clients = append(clients, f)
 	}
 	if err := rows.Err(); err != nil {
@@ -132,18 +136,22 @@ func (r *DownloadClientRepo) FindByID(ctx context.Context, id int32) (*domain.Do
 		}
 	}
 
+	if err := r.db.decryptClient(&client); err != nil {
+		return nil, err
+	}
+
 	return &client, nil
 }
 
 func (r *DownloadClientRepo) Store(ctx context.Context, client *domain.DownloadClient) error {
-	settings := domain.DownloadClientSettings{
+	settings := r.db.encryptClientSettings(domain.DownloadClientSettings{
 		APIKey:                   client.Settings.APIKey,
 		Basic:                    client.Settings.Basic,
 		Rules:                    client.Settings.Rules,
 		ExternalDownloadClientId: client.Settings.ExternalDownloadClientId,
 		ExternalDownloadClient:   client.Settings.ExternalDownloadClient,
 		Auth:                     client.Settings.Auth,
-	}
+	})
 
 	settingsJson, err := json.Marshal(&settings)
 	if err != nil {
@@ -153,7 +161,7 @@ func (r *DownloadClientRepo) Store(ctx context.Context, client *domain.DownloadC
 	queryBuilder := r.db.squirrel.
 		Insert("client").
 		Columns("name", "type", "enabled", "host", "port", "tls", "tls_skip_verify", "username", "password", "settings").
-		Values(client.Name, client.Type, client.Enabled, client.Host, client.Port, client.TLS, client.TLSSkipVerify, client.Username, client.Password, settingsJson).
+		Values(client.Name, client.Type, client.Enabled, client.Host, client.Port, client.TLS, client.TLSSkipVerify, client.Username, r.db.encryptSecret(client.Password), settingsJson).
 		Suffix("RETURNING id").RunWith(r.db.Handler)
 
 	// return values
@@ -172,14 +180,14 @@ func (r *DownloadClientRepo) Store(ctx context.Context, client *domain.DownloadC
 }
 
 func (r *DownloadClientRepo) Update(ctx context.Context, client *domain.DownloadClient) error {
-	settings := domain.DownloadClientSettings{
+	settings := r.db.encryptClientSettings(domain.DownloadClientSettings{
 		APIKey:                   client.Settings.APIKey,
 		Basic:                    client.Settings.Basic,
 		Rules:                    client.Settings.Rules,
 		ExternalDownloadClientId: client.Settings.ExternalDownloadClientId,
 		ExternalDownloadClient:   client.Settings.ExternalDownloadClient,
 		Auth:                     client.Settings.Auth,
-	}
+	})
 
 	settingsJson, err := json.Marshal(&settings)
 	if err != nil {
@@ -196,7 +204,7 @@ func (r *DownloadClientRepo) Update(ctx context.Context, client *domain.Download
 		Set("tls", client.TLS).
 		Set("tls_skip_verify", client.TLSSkipVerify).
 		Set("username", client.Username).
-		Set("password", client.Password).
+		Set("password", r.db.encryptSecret(client.Password)).
 		Set("settings", string(settingsJson)).
 		Where(sq.Eq{"id": client.ID})
 
@@ -117,6 +117,10 @@ func (r *FeedRepo) FindOne(ctx context.Context, params domain.FindOneParams) (*d
 	f.ApiKey = apiKey.String
 	f.Cookie = cookie.String
 
+	if err := r.db.decryptSecrets(&f.ApiKey, &f.Cookie); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt feed secrets")
+	}
+
 	if settings.Valid {
 		var settingsJson domain.FeedSettingsJSON
 		if err = json.Unmarshal([]byte(settings.String), &settingsJson); err != nil {
@@ -205,6 +209,10 @@ func (r *FeedRepo) FindByID(ctx context.Context, id int) (*domain.Feed, error) {
 	f.ApiKey = apiKey.String
 	f.Cookie = cookie.String
 
+	if err := r.db.decryptSecrets(&f.ApiKey, &f.Cookie); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt feed secrets")
+	}
+
 	if settings.Valid {
 		var settingsJson domain.FeedSettingsJSON
 		if err = json.Unmarshal([]byte(settings.String), &settingsJson); err != nil {
@@ -295,6 +303,10 @@ func (r *FeedRepo) Find(ctx context.Context) ([]domain.Feed, error) {
 		f.ApiKey = apiKey.String
 		f.Cookie = cookie.String
 
+		if err := r.db.decryptSecrets(&f.ApiKey, &f.Cookie); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt feed secrets")
+		}
+
 		f.Settings = &domain.FeedSettingsJSON{
 			DownloadType: domain.FeedDownloadTypeTorrent,
 		}
@@ -387,7 +399,7 @@ func (r *FeedRepo) Store(ctx context.Context, feed *domain.Feed) error {
 			feed.Timeout,
 			pq.Array(formatCategoryIDs(feed.Categories)),
 			capabilities,
-			feed.ApiKey,
+			r.db.encryptSecret(feed.ApiKey),
 			feed.TLSSkipVerify,
 			feed.IndexerID,
 			settings,
@@ -427,8 +439,8 @@ func (r *FeedRepo) Update(ctx context.Context, feed *domain.Feed) error {
 		Set("max_age", feed.MaxAge).
 		Set("categories", pq.Array(formatCategoryIDs(feed.Categories))).
 		Set("capabilities", capabilities).
-		Set("api_key", feed.ApiKey).
-		Set("cookie", feed.Cookie).
+		Set("api_key", r.db.encryptSecret(feed.ApiKey)).
+		Set("cookie", r.db.encryptSecret(feed.Cookie)).
 		Set("tls_skip_verify", feed.TLSSkipVerify).
 		Set("settings", settings).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
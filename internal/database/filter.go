@@ -6,6 +6,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -256,6 +257,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 			"f.freeleech",
 			"f.freeleech_percent",
 			"f.smart_episode",
+			"f.backfill",
 			"f.shows",
 			"f.seasons",
 			"f.episodes",
@@ -298,6 +300,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 			"f.max_seeders",
 			"f.min_leechers",
 			"f.max_leechers",
+			"f.expression",
 			"f.release_profile_duplicate_id",
 			"f.created_at",
 			"f.updated_at",
@@ -322,7 +325,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 	var f domain.Filter
 
 	// filter
-	var minSize, maxSize, maxDownloadsUnit, matchReleases, exceptReleases, matchReleaseGroups, exceptReleaseGroups, matchReleaseTags, exceptReleaseTags, matchDescription, exceptDescription, freeleechPercent, shows, seasons, episodes, years, months, days, artists, albums, matchCategories, exceptCategories, matchUploaders, exceptUploaders, matchRecordLabels, exceptRecordLabels, tags, exceptTags, tagsMatchLogic, exceptTagsMatchLogic sql.NullString
+	var minSize, maxSize, maxDownloadsUnit, matchReleases, exceptReleases, matchReleaseGroups, exceptReleaseGroups, matchReleaseTags, exceptReleaseTags, matchDescription, exceptDescription, freeleechPercent, shows, seasons, episodes, years, months, days, artists, albums, matchCategories, exceptCategories, matchUploaders, exceptUploaders, matchRecordLabels, exceptRecordLabels, tags, exceptTags, tagsMatchLogic, exceptTagsMatchLogic, expression sql.NullString
 	var useRegex, scene, freeleech, hasLog, hasCue, perfectFlac sql.NullBool
 	var delay, maxDownloads, logScore sql.NullInt32
 	var releaseProfileDuplicateId sql.NullInt64
@@ -353,6 +356,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 		&freeleech,
 		&freeleechPercent,
 		&f.SmartEpisode,
+		&f.Backfill,
 		&shows,
 		&seasons,
 		&episodes,
@@ -395,6 +399,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 		&f.MaxSeeders,
 		&f.MinLeechers,
 		&f.MaxLeechers,
+		&expression,
 		&releaseProfileDuplicateId,
 		&f.CreatedAt,
 		&f.UpdatedAt,
@@ -446,6 +451,7 @@ func (r *FilterRepo) FindByID(ctx context.Context, filterID int) (*domain.Filter
 	f.UseRegex = useRegex.Bool
 	f.Scene = scene.Bool
 	f.Freeleech = freeleech.Bool
+	f.Expression = expression.String
 	f.ReleaseProfileDuplicateID = releaseProfileDuplicateId.Int64
 
 	return &f, nil
@@ -484,6 +490,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 			"f.freeleech",
 			"f.freeleech_percent",
 			"f.smart_episode",
+			"f.backfill",
 			"f.shows",
 			"f.seasons",
 			"f.episodes",
@@ -526,6 +533,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 			"f.max_seeders",
 			"f.min_leechers",
 			"f.max_leechers",
+			"f.expression",
 			"f.created_at",
 			"f.updated_at",
 			"f.release_profile_duplicate_id",
@@ -579,7 +587,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 	for rows.Next() {
 		var f domain.Filter
 
-		var minSize, maxSize, maxDownloadsUnit, matchReleases, exceptReleases, matchReleaseGroups, exceptReleaseGroups, matchReleaseTags, exceptReleaseTags, matchDescription, exceptDescription, freeleechPercent, shows, seasons, episodes, years, months, days, artists, albums, matchCategories, exceptCategories, matchUploaders, exceptUploaders, matchRecordLabels, exceptRecordLabels, tags, exceptTags, tagsMatchLogic, exceptTagsMatchLogic sql.NullString
+		var minSize, maxSize, maxDownloadsUnit, matchReleases, exceptReleases, matchReleaseGroups, exceptReleaseGroups, matchReleaseTags, exceptReleaseTags, matchDescription, exceptDescription, freeleechPercent, shows, seasons, episodes, years, months, days, artists, albums, matchCategories, exceptCategories, matchUploaders, exceptUploaders, matchRecordLabels, exceptRecordLabels, tags, exceptTags, tagsMatchLogic, exceptTagsMatchLogic, expression sql.NullString
 		var useRegex, scene, freeleech, hasLog, hasCue, perfectFlac sql.NullBool
 		var delay, maxDownloads, logScore sql.NullInt32
 		var releaseProfileDuplicateID, rdpId sql.NullInt64
@@ -613,6 +621,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 			&freeleech,
 			&freeleechPercent,
 			&f.SmartEpisode,
+			&f.Backfill,
 			&shows,
 			&seasons,
 			&episodes,
@@ -655,6 +664,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 			&f.MaxSeeders,
 			&f.MinLeechers,
 			&f.MaxLeechers,
+			&expression,
 			&f.CreatedAt,
 			&f.UpdatedAt,
 			&releaseProfileDuplicateID,
@@ -725,6 +735,7 @@ func (r *FilterRepo) findByIndexerIdentifier(ctx context.Context, indexer string
 		f.UseRegex = useRegex.Bool
 		f.Scene = scene.Bool
 		f.Freeleech = freeleech.Bool
+		f.Expression = expression.String
 		f.ReleaseProfileDuplicateID = releaseProfileDuplicateID.Int64
 
 		f.Rejections = []string{}
@@ -882,6 +893,7 @@ func (r *FilterRepo) Store(ctx context.Context, filter *domain.Filter) error {
 			"freeleech",
 			"freeleech_percent",
 			"smart_episode",
+			"backfill",
 			"shows",
 			"seasons",
 			"episodes",
@@ -924,6 +936,7 @@ func (r *FilterRepo) Store(ctx context.Context, filter *domain.Filter) error {
 			"max_seeders",
 			"min_leechers",
 			"max_leechers",
+			"expression",
 			"release_profile_duplicate_id",
 		).
 		Values(
@@ -951,6 +964,7 @@ func (r *FilterRepo) Store(ctx context.Context, filter *domain.Filter) error {
 			filter.Freeleech,
 			filter.FreeleechPercent,
 			filter.SmartEpisode,
+			filter.Backfill,
 			filter.Shows,
 			filter.Seasons,
 			filter.Episodes,
@@ -993,6 +1007,7 @@ func (r *FilterRepo) Store(ctx context.Context, filter *domain.Filter) error {
 			filter.MaxSeeders,
 			filter.MinLeechers,
 			filter.MaxLeechers,
+			filter.Expression,
 			toNullInt64(filter.ReleaseProfileDuplicateID),
 		).
 		Suffix("RETURNING id").RunWith(r.db.Handler)
@@ -1038,6 +1053,7 @@ func (r *FilterRepo) Update(ctx context.Context, filter *domain.Filter) error {
 		Set("freeleech", filter.Freeleech).
 		Set("freeleech_percent", filter.FreeleechPercent).
 		Set("smart_episode", filter.SmartEpisode).
+		Set("backfill", filter.Backfill).
 		Set("shows", filter.Shows).
 		Set("seasons", filter.Seasons).
 		Set("episodes", filter.Episodes).
@@ -1080,6 +1096,7 @@ func (r *FilterRepo) Update(ctx context.Context, filter *domain.Filter) error {
 		Set("max_seeders", filter.MaxSeeders).
 		Set("min_leechers", filter.MinLeechers).
 		Set("max_leechers", filter.MaxLeechers).
+		Set("expression", filter.Expression).
 		Set("release_profile_duplicate_id", toNullInt64(filter.ReleaseProfileDuplicateID)).
 		Set("updated_at", time.Now().Format(time.RFC3339)).
 		Where(sq.Eq{"id": filter.ID})
@@ -1180,6 +1197,9 @@ func (r *FilterRepo) UpdatePartial(ctx context.Context, filter domain.FilterUpda
 	if filter.SmartEpisode != nil {
 		q = q.Set("smart_episode", filter.SmartEpisode)
 	}
+	if filter.Backfill != nil {
+		q = q.Set("backfill", filter.Backfill)
+	}
 	if filter.Shows != nil {
 		q = q.Set("shows", filter.Shows)
 	}
@@ -1306,6 +1326,9 @@ func (r *FilterRepo) UpdatePartial(ctx context.Context, filter domain.FilterUpda
 	if filter.MaxLeechers != nil {
 		q = q.Set("max_leechers", filter.MaxLeechers)
 	}
+	if filter.Expression != nil {
+		q = q.Set("expression", filter.Expression)
+	}
 	if filter.ReleaseProfileDuplicateID != nil {
 		q = q.Set("release_profile_duplicate_id", filter.ReleaseProfileDuplicateID)
 	}
@@ -1770,3 +1793,139 @@ func (r *FilterRepo) DeleteFilterNotifications(ctx context.Context, filterID int
 
 	return nil
 }
+
+// StoreRevision stores the revision as the next one of the filter
+func (r *FilterRepo) StoreRevision(ctx context.Context, revision *domain.FilterRevision) error {
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(revision.Filter)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal filter revision")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error begin transaction")
+	}
+
+	defer tx.Rollback()
+
+	latestQueryBuilder := r.db.squirrel.
+		Select("COALESCE(MAX(revision), 0)").
+		From("filter_revision").
+		Where(sq.Eq{"filter_id": revision.FilterID})
+
+	latestQuery, latestArgs, err := latestQueryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	var latest int
+	if err := tx.QueryRowContext(ctx, latestQuery, latestArgs...).Scan(&latest); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	revision.Revision = latest + 1
+
+	queryBuilder := r.db.squirrel.
+		Insert("filter_revision").
+		Columns("filter_id", "revision", "actor", "data", "created_at").
+		Values(revision.FilterID, revision.Revision, revision.Actor, string(data), revision.CreatedAt.UTC()).
+		Suffix("RETURNING id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&revision.ID); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "error storing filter revision for filter: %d", revision.FilterID)
+	}
+
+	r.log.Debug().Msgf("filter.StoreRevision: stored revision %d for filter: %d", revision.Revision, revision.FilterID)
+
+	return nil
+}
+
+// FindRevisions returns the revisions of the filter without their data, newest first
+func (r *FilterRepo) FindRevisions(ctx context.Context, filterID int) ([]domain.FilterRevision, error) {
+	queryBuilder := r.db.squirrel.
+		Select("id", "filter_id", "revision", "actor", "created_at").
+		From("filter_revision").
+		Where(sq.Eq{"filter_id": filterID}).
+		OrderBy("revision DESC")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	revisions := make([]domain.FilterRevision, 0)
+	for rows.Next() {
+		var revision domain.FilterRevision
+		if err := rows.Scan(&revision.ID, &revision.FilterID, &revision.Revision, &revision.Actor, &revision.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error iterating over filter revisions")
+	}
+
+	return revisions, nil
+}
+
+func (r *FilterRepo) FindRevision(ctx context.Context, filterID int, revision int) (*domain.FilterRevision, error) {
+	return r.findRevision(ctx, sq.Eq{"filter_id": filterID, "revision": revision})
+}
+
+func (r *FilterRepo) FindLatestRevision(ctx context.Context, filterID int) (*domain.FilterRevision, error) {
+	return r.findRevision(ctx, sq.Eq{"filter_id": filterID})
+}
+
+// findRevision returns the newest revision matching where with its data
+func (r *FilterRepo) findRevision(ctx context.Context, where sq.Eq) (*domain.FilterRevision, error) {
+	queryBuilder := r.db.squirrel.
+		Select("id", "filter_id", "revision", "actor", "data", "created_at").
+		From("filter_revision").
+		Where(where).
+		OrderBy("revision DESC").
+		Limit(1)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	var revision domain.FilterRevision
+	var data string
+
+	if err := r.db.Handler.QueryRowContext(ctx, query, args...).Scan(&revision.ID, &revision.FilterID, &revision.Revision, &revision.Actor, &data, &revision.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecordNotFound
+		}
+
+		return nil, errors.Wrap(err, "error scanning row")
+	}
+
+	if err := json.Unmarshal([]byte(data), &revision.Filter); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal filter revision %d", revision.ID)
+	}
+
+	return &revision, nil
+}
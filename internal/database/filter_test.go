@@ -83,6 +83,7 @@ func getMockFilter() *domain.Filter {
 		MatchDescription:     "Anime, x264",
 		ExceptDescription:    "Anime, x264",
 		UseRegexDescription:  true,
+		Expression:           `Resolution == "1080p" && Size < bytes("20GB")`,
 	}
 }
 
@@ -448,6 +449,7 @@ func TestFilterRepo_FindByID(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, filter)
 			assert.Equal(t, createdFilters[0].ID, filter.ID)
+			assert.Equal(t, mockData.Expression, filter.Expression)
 
 			// Cleanup
 			_ = repo.Delete(t.Context(), createdFilters[0].ID)
@@ -1010,3 +1012,60 @@ func TestFilterRepo_GetFilterDownloads(t *testing.T) {
 
 	}
 }
+
+func TestFilterRepo_StoreRevision(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+		repo := NewFilterRepo(log, db)
+
+		t.Run(fmt.Sprintf("StoreRevision_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			mockFilter := getMockFilter()
+			err := repo.Store(t.Context(), mockFilter)
+			assert.NoError(t, err)
+
+			first := &domain.FilterRevision{FilterID: mockFilter.ID, Actor: "admin", Filter: mockFilter}
+			err = repo.StoreRevision(t.Context(), first)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, first.Revision)
+
+			mockFilter.Shows = "Some Other Show"
+			second := &domain.FilterRevision{FilterID: mockFilter.ID, Actor: "sonarr", Filter: mockFilter}
+			err = repo.StoreRevision(t.Context(), second)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, second.Revision)
+
+			// Verify
+			revisions, err := repo.FindRevisions(t.Context(), mockFilter.ID)
+			assert.NoError(t, err)
+			assert.Len(t, revisions, 2)
+			assert.Equal(t, 2, revisions[0].Revision)
+			assert.Equal(t, "sonarr", revisions[0].Actor)
+			assert.Nil(t, revisions[0].Filter)
+
+			revision, err := repo.FindRevision(t.Context(), mockFilter.ID, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "admin", revision.Actor)
+			assert.Equal(t, mockFilter.Name, revision.Filter.Name)
+			assert.NotEqual(t, "Some Other Show", revision.Filter.Shows)
+
+			latest, err := repo.FindLatestRevision(t.Context(), mockFilter.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, latest.Revision)
+			assert.Equal(t, "Some Other Show", latest.Filter.Shows)
+
+			// Cleanup
+			_ = repo.Delete(t.Context(), mockFilter.ID)
+		})
+
+		t.Run(fmt.Sprintf("FindRevision_Fails_No_Record [%s]", dbType), func(t *testing.T) {
+			revision, err := repo.FindRevision(t.Context(), 9999, 1)
+			assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+			assert.Nil(t, revision)
+
+			revision, err = repo.FindLatestRevision(t.Context(), 9999)
+			assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+			assert.Nil(t, revision)
+		})
+	}
+}
@@ -30,7 +30,7 @@ func NewIndexerRepo(log logger.Logger, db *DB) domain.IndexerRepo {
 }
 
 func (r *IndexerRepo) Store(ctx context.Context, indexer domain.Indexer) (*domain.Indexer, error) {
-	settings, err := json.Marshal(indexer.Settings)
+	settings, err := json.Marshal(r.db.encryptIndexerSettings(indexer.Settings))
 	if err != nil {
 		return nil, errors.Wrap(err, "error marshaling json data")
 	}
@@ -50,7 +50,7 @@ func (r *IndexerRepo) Store(ctx context.Context, indexer domain.Indexer) (*domai
 }
 
 func (r *IndexerRepo) Update(ctx context.Context, indexer domain.Indexer) (*domain.Indexer, error) {
-	settings, err := json.Marshal(indexer.Settings)
+	settings, err := json.Marshal(r.db.encryptIndexerSettings(indexer.Settings))
 	if err != nil {
 		return nil, errors.Wrap(err, "error marshaling json data")
 	}
@@ -130,6 +130,10 @@ func (r *IndexerRepo) List(ctx context.Context) ([]domain.Indexer, error) {
 			return nil, errors.Wrap(err, "error unmarshal settings")
 		}
 
+		if err := r.db.decryptIndexerSettings(settingsMap); err != nil {
+			return nil, err
+		}
+
 		i.Settings = settingsMap
 
 		indexers = append(indexers, i)
@@ -180,6 +184,10 @@ func (r *IndexerRepo) FindByID(ctx context.Context, id int) (*domain.Indexer, er
 		return nil, errors.Wrap(err, "error unmarshal settings")
 	}
 
+	if err := r.db.decryptIndexerSettings(settingsMap); err != nil {
+		return nil, err
+	}
+
 	i.Settings = settingsMap
 
 	return &i, nil
@@ -231,6 +239,10 @@ func (r *IndexerRepo) GetBy(ctx context.Context, req domain.GetIndexerRequest) (
 		return nil, errors.Wrap(err, "error unmarshal settings")
 	}
 
+	if err := r.db.decryptIndexerSettings(settingsMap); err != nil {
+		return nil, err
+	}
+
 	i.Settings = settingsMap
 
 	return &i, nil
@@ -272,6 +284,10 @@ func (r *IndexerRepo) FindByFilterID(ctx context.Context, id int) ([]domain.Inde
 			return nil, errors.Wrap(err, "error unmarshal settings")
 		}
 
+		if err := r.db.decryptIndexerSettings(settingsMap); err != nil {
+			return nil, err
+		}
+
 		i.IdentifierExternal = identifierExternal.V
 		i.BaseURL = baseURL.V
 		i.ProxyID = proxyID.V
@@ -65,6 +65,10 @@ func (r *IrcRepo) GetNetworkByID(ctx context.Context, id int64) (*domain.IrcNetw
 	n.Auth.Password = password.V
 	n.ProxyId = proxyId.V
 
+	if err := r.db.decryptSecrets(&n.Pass, &n.Auth.Password); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt network secrets")
+	}
+
 	return &n, nil
 }
 
@@ -153,6 +157,10 @@ func (r *IrcRepo) FindActiveNetworks(ctx context.Context) ([]domain.IrcNetwork,
 
 		net.ProxyId = proxyId.V
 
+		if err := r.db.decryptSecrets(&net.Pass, &net.Auth.Password); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt network secrets")
+		}
+
 		networks = append(networks, net)
 	}
 	if err := rows.Err(); err != nil {
@@ -204,6 +212,10 @@ func (r *IrcRepo) ListNetworks(ctx context.Context) ([]domain.IrcNetwork, error)
 
 		net.ProxyId = proxyId.V
 
+		if err := r.db.decryptSecrets(&net.Pass, &net.Auth.Password); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt network secrets")
+		}
+
 		networks = append(networks, net)
 	}
 	if err := rows.Err(); err != nil {
@@ -241,6 +253,10 @@ func (r *IrcRepo) ListChannels(networkID int64) ([]domain.IrcChannel, error) {
 
 		ch.Password = pass.V
 
+		if err := r.db.decryptSecrets(&ch.Password); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt channel password")
+		}
+
 		channels = append(channels, ch)
 	}
 	if err := rows.Err(); err != nil {
@@ -296,6 +312,10 @@ func (r *IrcRepo) CheckExistingNetwork(ctx context.Context, network *domain.IrcN
 
 	net.ProxyId = proxyId.V
 
+	if err := r.db.decryptSecrets(&net.Pass, &net.Auth.Password); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt network secrets")
+	}
+
 	return &net, nil
 }
 
@@ -326,11 +346,11 @@ func (r *IrcRepo) StoreNetwork(ctx context.Context, network *domain.IrcNetwork)
 			network.Port,
 			network.TLS,
 			network.TLSSkipVerify,
-			toNullString(network.Pass),
+			toNullString(r.db.encryptSecret(network.Pass)),
 			toNullString(network.Nick),
 			network.Auth.Mechanism,
 			toNullString(network.Auth.Account),
-			toNullString(network.Auth.Password),
+			toNullString(r.db.encryptSecret(network.Auth.Password)),
 			toNullString(network.InviteCommand),
 			toNullString(network.BouncerAddr),
 			network.UseBouncer,
@@ -355,11 +375,11 @@ func (r *IrcRepo) UpdateNetwork(ctx context.Context, network *domain.IrcNetwork)
 		Set("port", network.Port).
 		Set("tls", network.TLS).
 		Set("tls_skip_verify", network.TLSSkipVerify).
-		Set("pass", toNullString(network.Pass)).
+		Set("pass", toNullString(r.db.encryptSecret(network.Pass))).
 		Set("nick", toNullString(network.Nick)).
 		Set("auth_mechanism", network.Auth.Mechanism).
 		Set("auth_account", toNullString(network.Auth.Account)).
-		Set("auth_password", toNullString(network.Auth.Password)).
+		Set("auth_password", toNullString(r.db.encryptSecret(network.Auth.Password))).
 		Set("invite_command", toNullString(network.InviteCommand)).
 		Set("bouncer_addr", toNullString(network.BouncerAddr)).
 		Set("use_bouncer", network.UseBouncer).
@@ -421,7 +441,7 @@ func (r *IrcRepo) StoreNetworkChannels(ctx context.Context, networkID int64, cha
 				channel.Enabled,
 				true,
 				channel.Name,
-				toNullString(channel.Password),
+				toNullString(r.db.encryptSecret(channel.Password)),
 				networkID,
 			).
 			Suffix("RETURNING id").
@@ -462,7 +482,7 @@ func (r *IrcRepo) StoreChannel(ctx context.Context, networkID int64, channel *do
 			Set("enabled", channel.Enabled).
 			Set("detached", channel.Detached).
 			Set("name", channel.Name).
-			Set("password", toNullString(channel.Password)).
+			Set("password", toNullString(r.db.encryptSecret(channel.Password))).
 			Where(sq.Eq{"id": channel.ID})
 
 		query, args, err := channelQueryBuilder.ToSql()
@@ -487,7 +507,7 @@ func (r *IrcRepo) StoreChannel(ctx context.Context, networkID int64, channel *do
 				channel.Enabled,
 				true,
 				channel.Name,
-				toNullString(channel.Password),
+				toNullString(r.db.encryptSecret(channel.Password)),
 				networkID,
 			).
 			Suffix("RETURNING id").
@@ -524,7 +544,7 @@ func (r *IrcRepo) UpdateChannel(channel *domain.IrcChannel) error {
 		Set("enabled", channel.Enabled).
 		Set("detached", channel.Detached).
 		Set("name", channel.Name).
-		Set("password", toNullString(channel.Password)).
+		Set("password", toNullString(r.db.encryptSecret(channel.Password))).
 		Where(sq.Eq{"id": channel.ID})
 
 	query, args, err := channelQueryBuilder.ToSql()
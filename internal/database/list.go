@@ -87,6 +87,10 @@ func (r *ListRepo) List(ctx context.Context) ([]*domain.List, error) {
 		list.LastRefreshStatus = domain.ListRefreshStatus(lastRefreshStatus.V)
 		list.Filters = make([]domain.ListFilter, 0)
 
+		if err := r.db.decryptSecrets(&list.APIKey); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt list api key")
+		}
+
 		lists = append(lists, &list)
 	}
 
@@ -147,6 +151,10 @@ func (r *ListRepo) FindByID(ctx context.Context, listID int64) (*domain.List, er
 	list.URL = url.V
 	list.APIKey = apiKey.V
 
+	if err := r.db.decryptSecrets(&list.APIKey); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt list api key")
+	}
+
 	return &list, nil
 }
 
@@ -182,7 +190,7 @@ func (r *ListRepo) Store(ctx context.Context, list *domain.List) error {
 			toNullInt32(int32(list.ClientID)),
 			list.URL,
 			pq.Array(list.Headers),
-			list.APIKey,
+			r.db.encryptSecret(list.APIKey),
 			list.MatchRelease,
 			pq.Array(list.TagsInclude),
 			pq.Array(list.TagsExclude),
@@ -227,7 +235,7 @@ func (r *ListRepo) Update(ctx context.Context, list *domain.List) error {
 		Set("client_id", toNullInt32(int32(list.ClientID))).
 		Set("url", list.URL).
 		Set("headers", pq.Array(list.Headers)).
-		Set("api_key", list.APIKey).
+		Set("api_key", r.db.encryptSecret(list.APIKey)).
 		Set("match_release", list.MatchRelease).
 		Set("tags_included", pq.Array(list.TagsInclude)).
 		Set("tags_excluded", pq.Array(list.TagsExclude)).
@@ -103,6 +103,18 @@ func PostgresMigrations(db *sql.DB, logger zerolog.Logger) *migrator.Migrator {
 	migrate.AddFileMigration("79_feeds_change_capabilities_to_json.sql")
 	migrate.AddFileMigration("80_feed_add_tls_skip_verify.sql")
 	migrate.AddFileMigration("81_irc_update_darkpeers_network.sql")
+	migrate.AddFileMigration("82_filter_add_expression.sql")
+	migrate.AddFileMigration("83_api_key_default_scopes.sql")
+	migrate.AddFileMigration("84_action_retry.sql")
+	migrate.AddFileMigration("85_action_failover_clients.sql")
+	migrate.AddFileMigration("86_release_torrent_stats.sql")
+	migrate.AddFileMigration("87_filter_add_backfill.sql")
+	migrate.AddFileMigration("88_add_notification_templates.sql")
+	migrate.AddFileMigration("89_add_notification_from_address.sql")
+	migrate.AddFileMigration("90_add_notification_digests.sql")
+	migrate.AddFileMigration("91_add_user_role.sql")
+	migrate.AddFileMigration("92_add_audit_log.sql")
+	migrate.AddFileMigration("93_add_filter_revision.sql")
 
 	return migrate
 }
@@ -0,0 +1,3 @@
+UPDATE api_key
+SET scopes = '{"admin"}'
+WHERE scopes = '{}';
@@ -0,0 +1,29 @@
+-- Retry policy for failed action pushes
+ALTER TABLE action
+    ADD COLUMN retry_max_attempts INTEGER DEFAULT 0;
+
+ALTER TABLE action
+    ADD COLUMN retry_backoff INTEGER DEFAULT 0;
+
+ALTER TABLE action
+    ADD COLUMN retry_errors TEXT[] DEFAULT '{}';
+
+CREATE TABLE release_action_retry
+(
+    id               SERIAL PRIMARY KEY,
+    release_id       INTEGER NOT NULL,
+    action_id        INTEGER NOT NULL,
+    action_status_id INTEGER,
+    attempts         INTEGER   DEFAULT 0,
+    max_attempts     INTEGER   DEFAULT 0,
+    next_attempt_at  TIMESTAMP NOT NULL,
+    last_error       TEXT,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (release_id) REFERENCES "release" (id) ON DELETE CASCADE,
+    FOREIGN KEY (action_id) REFERENCES "action" (id) ON DELETE CASCADE,
+    FOREIGN KEY (action_status_id) REFERENCES release_action_status (id) ON DELETE SET NULL
+);
+
+CREATE INDEX release_action_retry_next_attempt_at_index
+    ON release_action_retry (next_attempt_at);
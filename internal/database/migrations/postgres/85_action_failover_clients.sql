@@ -0,0 +1,3 @@
+-- Ordered download clients to fall back to when the action client is unavailable or rejects
+ALTER TABLE action
+    ADD COLUMN failover_client_ids INTEGER[] DEFAULT '{}';
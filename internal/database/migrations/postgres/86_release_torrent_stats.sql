@@ -0,0 +1,35 @@
+-- Upload, ratio and seed time of torrents pushed to download clients
+CREATE TABLE release_torrent_stats
+(
+    id              SERIAL PRIMARY KEY,
+    release_id      INTEGER,
+    filter_id       INTEGER,
+    indexer         TEXT,
+    client_id       INTEGER,
+    torrent_name    TEXT,
+    torrent_hash    TEXT    NOT NULL,
+    size            BIGINT  DEFAULT 0,
+    uploaded        BIGINT  DEFAULT 0,
+    downloaded      BIGINT  DEFAULT 0,
+    ratio           DOUBLE PRECISION DEFAULT 0,
+    seed_time       BIGINT  DEFAULT 0,
+    progress        DOUBLE PRECISION DEFAULT 0,
+    state           TEXT,
+    completed_at    TIMESTAMP,
+    removed         BOOLEAN DEFAULT FALSE,
+    last_checked_at TIMESTAMP,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (release_id) REFERENCES "release" (id) ON DELETE SET NULL,
+    FOREIGN KEY (filter_id) REFERENCES filter (id) ON DELETE SET NULL,
+    FOREIGN KEY (client_id) REFERENCES client (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX release_torrent_stats_client_id_torrent_hash_uindex
+    ON release_torrent_stats (client_id, torrent_hash);
+
+CREATE INDEX release_torrent_stats_filter_id_index
+    ON release_torrent_stats (filter_id);
+
+CREATE INDEX release_torrent_stats_indexer_index
+    ON release_torrent_stats (indexer);
@@ -0,0 +1,3 @@
+-- Add user-defined title and body templates to notifications
+ALTER TABLE notification ADD COLUMN title_template TEXT;
+ALTER TABLE notification ADD COLUMN body_template TEXT;
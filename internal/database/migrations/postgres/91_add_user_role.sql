@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN role TEXT DEFAULT 'admin' NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE filter_revision
+(
+    id         SERIAL PRIMARY KEY,
+    filter_id  INTEGER NOT NULL,
+    revision   INTEGER NOT NULL,
+    actor      TEXT    NOT NULL,
+    data       TEXT    NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (filter_id) REFERENCES filter (id) ON DELETE CASCADE,
+    UNIQUE (filter_id, revision)
+);
@@ -116,6 +116,18 @@ func SQLiteMigrations(db *sql.DB, logger zerolog.Logger) *migrator.Migrator {
 	migrate.AddFileMigration("89_feeds_change_capabilities_to_json.sql")
 	migrate.AddFileMigration("90_feed_add_tls_skip_verify.sql")
 	migrate.AddFileMigration("91_irc_update_darkpeers_network.sql")
+	migrate.AddFileMigration("92_filter_add_expression.sql")
+	migrate.AddFileMigration("93_api_key_default_scopes.sql")
+	migrate.AddFileMigration("94_action_retry.sql")
+	migrate.AddFileMigration("95_action_failover_clients.sql")
+	migrate.AddFileMigration("96_release_torrent_stats.sql")
+	migrate.AddFileMigration("97_filter_add_backfill.sql")
+	migrate.AddFileMigration("98_add_notification_templates.sql")
+	migrate.AddFileMigration("99_add_notification_from_address.sql")
+	migrate.AddFileMigration("100_add_notification_digests.sql")
+	migrate.AddFileMigration("101_add_user_role.sql")
+	migrate.AddFileMigration("102_add_audit_log.sql")
+	migrate.AddFileMigration("103_add_filter_revision.sql")
 	// Code above generated by go generate generate_migrations.go
 
 	return migrate
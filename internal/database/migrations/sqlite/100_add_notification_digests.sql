@@ -0,0 +1,37 @@
+-- Rate limits and digests of notifications
+ALTER TABLE notification
+    ADD COLUMN rate_limit INTEGER DEFAULT 0;
+
+ALTER TABLE notification
+    ADD COLUMN digest_interval INTEGER DEFAULT 0;
+
+ALTER TABLE notification
+    ADD COLUMN digest_events TEXT [] DEFAULT '{}';
+
+CREATE TABLE notification_digest_item
+(
+    id              INTEGER PRIMARY KEY,
+    notification_id INTEGER NOT NULL,
+    event           TEXT    NOT NULL,
+    filter          TEXT,
+    indexer         TEXT,
+    release_name    TEXT,
+    rejections      TEXT []   DEFAULT '{}',
+    rate_limited    BOOLEAN DEFAULT FALSE,
+    created_at      TIMESTAMP NOT NULL,
+    FOREIGN KEY (notification_id) REFERENCES notification (id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_item_notification_id_index
+    ON notification_digest_item (notification_id);
+
+CREATE TABLE notification_sent
+(
+    id              INTEGER PRIMARY KEY,
+    notification_id INTEGER   NOT NULL,
+    sent_at         TIMESTAMP NOT NULL,
+    FOREIGN KEY (notification_id) REFERENCES notification (id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_sent_notification_id_sent_at_index
+    ON notification_sent (notification_id, sent_at);
@@ -0,0 +1,18 @@
+CREATE TABLE audit_log
+(
+    id            INTEGER PRIMARY KEY,
+    actor         TEXT      NOT NULL,
+    actor_type    TEXT      NOT NULL,
+    action        TEXT      NOT NULL,
+    resource_type TEXT      NOT NULL,
+    resource_id   TEXT,
+    resource_name TEXT,
+    changes       TEXT      DEFAULT '[]' NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at_index
+    ON audit_log (created_at);
+
+CREATE INDEX audit_log_resource_type_resource_id_index
+    ON audit_log (resource_type, resource_id);
@@ -0,0 +1,3 @@
+-- Search indexer feeds for the filter on the scheduled backfill
+ALTER TABLE filter
+    ADD COLUMN backfill BOOLEAN DEFAULT FALSE;
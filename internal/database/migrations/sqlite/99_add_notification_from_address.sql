@@ -0,0 +1,2 @@
+-- Add the sender address of email notifications
+ALTER TABLE notification ADD COLUMN from_address TEXT;
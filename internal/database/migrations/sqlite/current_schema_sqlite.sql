@@ -3,6 +3,7 @@ CREATE TABLE users
     id         INTEGER PRIMARY KEY,
     username   TEXT NOT NULL,
     password   TEXT NOT NULL,
+    role       TEXT DEFAULT 'admin' NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     UNIQUE (username)
@@ -47,6 +48,12 @@ CREATE TABLE notification
     priority     INTEGER   DEFAULT 0,
     method       TEXT,
     headers      TEXT,
+    title_template TEXT,
+    body_template  TEXT,
+    from_address   TEXT,
+    rate_limit     INTEGER   DEFAULT 0,
+    digest_interval INTEGER  DEFAULT 0,
+    digest_events  TEXT [] DEFAULT '{}',
     created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -220,6 +227,8 @@ CREATE TABLE filter
     max_seeders                  INTEGER   DEFAULT 0,
     min_leechers                 INTEGER   DEFAULT 0,
     max_leechers                 INTEGER   DEFAULT 0,
+    expression                   TEXT      DEFAULT '',
+    backfill                     BOOLEAN   DEFAULT FALSE,
     release_profile_duplicate_id INTEGER,
     FOREIGN KEY (release_profile_duplicate_id) REFERENCES release_profile_duplicate (id) ON DELETE SET NULL
 );
@@ -321,6 +330,10 @@ CREATE TABLE action
     reannounce_delete       BOOLEAN DEFAULT false,
     reannounce_interval     INTEGER DEFAULT 7,
     reannounce_max_attempts INTEGER DEFAULT 50,
+    retry_max_attempts      INTEGER DEFAULT 0,
+    retry_backoff           INTEGER DEFAULT 0,
+    retry_errors            TEXT [] DEFAULT '{}',
+    failover_client_ids     INTEGER [] DEFAULT '{}',
     webhook_host            TEXT,
     webhook_method          TEXT,
     webhook_type            TEXT,
@@ -611,3 +624,117 @@ CREATE TABLE release_cleanup_job
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE TABLE release_action_retry
+(
+    id               INTEGER PRIMARY KEY,
+    release_id       INTEGER NOT NULL,
+    action_id        INTEGER NOT NULL,
+    action_status_id INTEGER,
+    attempts         INTEGER   DEFAULT 0,
+    max_attempts     INTEGER   DEFAULT 0,
+    next_attempt_at  TIMESTAMP NOT NULL,
+    last_error       TEXT,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (release_id) REFERENCES "release" (id) ON DELETE CASCADE,
+    FOREIGN KEY (action_id) REFERENCES action (id) ON DELETE CASCADE,
+    FOREIGN KEY (action_status_id) REFERENCES release_action_status (id) ON DELETE SET NULL
+);
+
+CREATE INDEX release_action_retry_next_attempt_at_index
+    ON release_action_retry (next_attempt_at);
+
+CREATE TABLE release_torrent_stats
+(
+    id              INTEGER PRIMARY KEY,
+    release_id      INTEGER,
+    filter_id       INTEGER,
+    indexer         TEXT,
+    client_id       INTEGER,
+    torrent_name    TEXT,
+    torrent_hash    TEXT    NOT NULL,
+    size            INTEGER DEFAULT 0,
+    uploaded        INTEGER DEFAULT 0,
+    downloaded      INTEGER DEFAULT 0,
+    ratio           REAL    DEFAULT 0,
+    seed_time       INTEGER DEFAULT 0,
+    progress        REAL    DEFAULT 0,
+    state           TEXT,
+    completed_at    TIMESTAMP,
+    removed         BOOLEAN DEFAULT FALSE,
+    last_checked_at TIMESTAMP,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (release_id) REFERENCES "release" (id) ON DELETE SET NULL,
+    FOREIGN KEY (filter_id) REFERENCES filter (id) ON DELETE SET NULL,
+    FOREIGN KEY (client_id) REFERENCES client (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX release_torrent_stats_client_id_torrent_hash_uindex
+    ON release_torrent_stats (client_id, torrent_hash);
+
+CREATE INDEX release_torrent_stats_filter_id_index
+    ON release_torrent_stats (filter_id);
+
+CREATE INDEX release_torrent_stats_indexer_index
+    ON release_torrent_stats (indexer);
+
+CREATE TABLE notification_digest_item
+(
+    id              INTEGER PRIMARY KEY,
+    notification_id INTEGER NOT NULL,
+    event           TEXT    NOT NULL,
+    filter          TEXT,
+    indexer         TEXT,
+    release_name    TEXT,
+    rejections      TEXT []   DEFAULT '{}',
+    rate_limited    BOOLEAN DEFAULT FALSE,
+    created_at      TIMESTAMP NOT NULL,
+    FOREIGN KEY (notification_id) REFERENCES notification (id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_item_notification_id_index
+    ON notification_digest_item (notification_id);
+
+CREATE TABLE notification_sent
+(
+    id              INTEGER PRIMARY KEY,
+    notification_id INTEGER   NOT NULL,
+    sent_at         TIMESTAMP NOT NULL,
+    FOREIGN KEY (notification_id) REFERENCES notification (id) ON DELETE CASCADE
+);
+
+CREATE INDEX notification_sent_notification_id_sent_at_index
+    ON notification_sent (notification_id, sent_at);
+
+CREATE TABLE audit_log
+(
+    id            INTEGER PRIMARY KEY,
+    actor         TEXT      NOT NULL,
+    actor_type    TEXT      NOT NULL,
+    action        TEXT      NOT NULL,
+    resource_type TEXT      NOT NULL,
+    resource_id   TEXT,
+    resource_name TEXT,
+    changes       TEXT      DEFAULT '[]' NOT NULL,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at_index
+    ON audit_log (created_at);
+
+CREATE INDEX audit_log_resource_type_resource_id_index
+    ON audit_log (resource_type, resource_id);
+
+CREATE TABLE filter_revision
+(
+    id         INTEGER PRIMARY KEY,
+    filter_id  INTEGER NOT NULL,
+    revision   INTEGER NOT NULL,
+    actor      TEXT    NOT NULL,
+    data       TEXT    NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (filter_id) REFERENCES filter (id) ON DELETE CASCADE,
+    UNIQUE (filter_id, revision)
+);
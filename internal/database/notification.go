@@ -31,7 +31,7 @@ func NewNotificationRepo(log logger.Logger, db *DB) domain.NotificationRepo {
 
 func (r *NotificationRepo) Find(ctx context.Context, _ domain.NotificationQueryParams) ([]domain.Notification, int, error) {
 	queryBuilder := r.db.squirrel.
-		Select("id", "name", "type", "enabled", "events", "webhook", "token", "api_key", "channel", "priority", "topic", "sound", "event_sounds", "host", "username", "password", "method", "headers", "created_at", "updated_at", "COUNT(*) OVER() AS total_count").
+		Select("id", "name", "type", "enabled", "events", "webhook", "token", "api_key", "channel", "priority", "topic", "sound", "event_sounds", "host", "username", "password", "method", "headers", "rooms", "targets", "from_address", "rate_limit", "digest_interval", "digest_events", "title_template", "body_template", "created_at", "updated_at", "COUNT(*) OVER() AS total_count").
 		From("notification").
 		OrderBy("name")
 
@@ -52,9 +52,9 @@ func (r *NotificationRepo) Find(ctx context.Context, _ domain.NotificationQueryP
 	for rows.Next() {
 		n := domain.NewNotification()
 
-		var webhook, token, apiKey, channel, host, topic, sound, eventSounds, username, password, method, headers sql.Null[string]
+		var webhook, token, apiKey, channel, host, topic, sound, eventSounds, username, password, method, headers, rooms, targets, fromAddress, titleTemplate, bodyTemplate sql.Null[string]
 
-		if err := rows.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &webhook, &token, &apiKey, &channel, &n.Priority, &topic, &sound, &eventSounds, &host, &username, &password, &method, &headers, &n.CreatedAt, &n.UpdatedAt, &totalCount); err != nil {
+		if err := rows.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &webhook, &token, &apiKey, &channel, &n.Priority, &topic, &sound, &eventSounds, &host, &username, &password, &method, &headers, &rooms, &targets, &fromAddress, &n.RateLimit, &n.DigestInterval, pq.Array(&n.DigestEvents), &titleTemplate, &bodyTemplate, &n.CreatedAt, &n.UpdatedAt, &totalCount); err != nil {
 			return nil, 0, errors.Wrap(err, "error scanning row")
 		}
 
@@ -69,6 +69,15 @@ func (r *NotificationRepo) Find(ctx context.Context, _ domain.NotificationQueryP
 		n.Password = password.V
 		n.Method = method.V
 		n.Headers = headers.V
+		n.Rooms = rooms.V
+		n.Targets = targets.V
+		n.FromAddress = fromAddress.V
+		n.TitleTemplate = titleTemplate.V
+		n.BodyTemplate = bodyTemplate.V
+
+		if err := r.db.decryptSecrets(&n.Token, &n.APIKey, &n.Password); err != nil {
+			return nil, 0, errors.Wrap(err, "could not decrypt notification secrets")
+		}
 
 		if eventSounds.Valid && eventSounds.V != "" {
 			if err := json.Unmarshal([]byte(eventSounds.V), &n.EventSounds); err != nil {
@@ -86,7 +95,7 @@ func (r *NotificationRepo) Find(ctx context.Context, _ domain.NotificationQueryP
 }
 
 func (r *NotificationRepo) List(ctx context.Context) ([]domain.Notification, error) {
-	rows, err := r.db.Handler.QueryContext(ctx, "SELECT id, name, type, enabled, events, token, api_key,  webhook, title, icon, host, username, password, channel, targets, devices, priority, topic, sound, event_sounds, method, headers, created_at, updated_at FROM notification ORDER BY name ASC")
+	rows, err := r.db.Handler.QueryContext(ctx, "SELECT id, name, type, enabled, events, token, api_key,  webhook, title, icon, host, username, password, channel, rooms, targets, devices, priority, topic, sound, event_sounds, method, headers, from_address, rate_limit, digest_interval, digest_events, title_template, body_template, created_at, updated_at FROM notification ORDER BY name ASC")
 	if err != nil {
 		return nil, errors.Wrap(err, "error executing query")
 	}
@@ -98,8 +107,8 @@ func (r *NotificationRepo) List(ctx context.Context) ([]domain.Notification, err
 		n := domain.NewNotification()
 		//var eventsSlice []string
 
-		var token, apiKey, webhook, title, icon, host, username, password, channel, targets, devices, topic, sound, eventSounds, method, headers sql.Null[string]
-		if err := rows.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &token, &apiKey, &webhook, &title, &icon, &host, &username, &password, &channel, &targets, &devices, &n.Priority, &topic, &sound, &eventSounds, &method, &headers, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		var token, apiKey, webhook, title, icon, host, username, password, channel, rooms, targets, devices, topic, sound, eventSounds, method, headers, fromAddress, titleTemplate, bodyTemplate sql.Null[string]
+		if err := rows.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &token, &apiKey, &webhook, &title, &icon, &host, &username, &password, &channel, &rooms, &targets, &devices, &n.Priority, &topic, &sound, &eventSounds, &method, &headers, &fromAddress, &n.RateLimit, &n.DigestInterval, pq.Array(&n.DigestEvents), &titleTemplate, &bodyTemplate, &n.CreatedAt, &n.UpdatedAt); err != nil {
 			return nil, errors.Wrap(err, "error scanning row")
 		}
 
@@ -113,13 +122,21 @@ func (r *NotificationRepo) List(ctx context.Context) ([]domain.Notification, err
 		n.Username = username.V
 		n.Password = password.V
 		n.Channel = channel.V
+		n.Rooms = rooms.V
 		n.Targets = targets.V
 		n.Devices = devices.V
 		n.Topic = topic.V
 		n.Method = method.V
 		n.Headers = headers.V
+		n.FromAddress = fromAddress.V
+		n.TitleTemplate = titleTemplate.V
+		n.BodyTemplate = bodyTemplate.V
 		n.Sound = sound.V
 
+		if err := r.db.decryptSecrets(&n.Token, &n.APIKey, &n.Password); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt notification secrets")
+		}
+
 		if eventSounds.Valid && eventSounds.V != "" {
 			if err := json.Unmarshal([]byte(eventSounds.V), &n.EventSounds); err != nil {
 				r.log.Warn().Err(err).Msg("error unmarshaling event_sounds")
@@ -152,6 +169,7 @@ func (r *NotificationRepo) FindByID(ctx context.Context, id int) (*domain.Notifi
 			"username",
 			"password",
 			"channel",
+			"rooms",
 			"targets",
 			"devices",
 			"priority",
@@ -160,6 +178,12 @@ func (r *NotificationRepo) FindByID(ctx context.Context, id int) (*domain.Notifi
 			"event_sounds",
 			"method",
 			"headers",
+			"from_address",
+			"rate_limit",
+			"digest_interval",
+			"digest_events",
+			"title_template",
+			"body_template",
 			"created_at",
 			"updated_at",
 		).
@@ -178,8 +202,8 @@ func (r *NotificationRepo) FindByID(ctx context.Context, id int) (*domain.Notifi
 
 	n := domain.NewNotification()
 
-	var token, apiKey, webhook, title, icon, host, username, password, channel, targets, devices, topic, sound, eventSounds, method, headers sql.Null[string]
-	if err := row.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &token, &apiKey, &webhook, &title, &icon, &host, &username, &password, &channel, &targets, &devices, &n.Priority, &topic, &sound, &eventSounds, &method, &headers, &n.CreatedAt, &n.UpdatedAt); err != nil {
+	var token, apiKey, webhook, title, icon, host, username, password, channel, rooms, targets, devices, topic, sound, eventSounds, method, headers, fromAddress, titleTemplate, bodyTemplate sql.Null[string]
+	if err := row.Scan(&n.ID, &n.Name, &n.Type, &n.Enabled, pq.Array(&n.Events), &token, &apiKey, &webhook, &title, &icon, &host, &username, &password, &channel, &rooms, &targets, &devices, &n.Priority, &topic, &sound, &eventSounds, &method, &headers, &fromAddress, &n.RateLimit, &n.DigestInterval, pq.Array(&n.DigestEvents), &titleTemplate, &bodyTemplate, &n.CreatedAt, &n.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrRecordNotFound
 		}
@@ -196,12 +220,20 @@ func (r *NotificationRepo) FindByID(ctx context.Context, id int) (*domain.Notifi
 	n.Username = username.V
 	n.Password = password.V
 	n.Channel = channel.V
+	n.Rooms = rooms.V
 	n.Targets = targets.V
 	n.Devices = devices.V
 	n.Topic = topic.V
 	n.Sound = sound.V
 	n.Method = method.V
 	n.Headers = headers.V
+	n.FromAddress = fromAddress.V
+	n.TitleTemplate = titleTemplate.V
+	n.BodyTemplate = bodyTemplate.V
+
+	if err := r.db.decryptSecrets(&n.Token, &n.APIKey, &n.Password); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt notification secrets")
+	}
 
 	if eventSounds.Valid && eventSounds.V != "" {
 		if err := json.Unmarshal([]byte(eventSounds.V), &n.EventSounds); err != nil {
@@ -238,6 +270,14 @@ func (r *NotificationRepo) Store(ctx context.Context, notification *domain.Notif
 			"password",
 			"method",
 			"headers",
+			"rooms",
+			"targets",
+			"from_address",
+			"rate_limit",
+			"digest_interval",
+			"digest_events",
+			"title_template",
+			"body_template",
 		).
 		Values(
 			notification.Name,
@@ -245,8 +285,8 @@ func (r *NotificationRepo) Store(ctx context.Context, notification *domain.Notif
 			notification.Enabled,
 			pq.Array(notification.Events),
 			toNullString(notification.Webhook),
-			toNullString(notification.Token),
-			toNullString(notification.APIKey),
+			toNullString(r.db.encryptSecret(notification.Token)),
+			toNullString(r.db.encryptSecret(notification.APIKey)),
 			toNullString(notification.Channel),
 			notification.Priority,
 			toNullString(notification.Topic),
@@ -254,9 +294,17 @@ func (r *NotificationRepo) Store(ctx context.Context, notification *domain.Notif
 			string(eventSoundsJSON),
 			toNullString(notification.Host),
 			toNullString(notification.Username),
-			toNullString(notification.Password),
+			toNullString(r.db.encryptSecret(notification.Password)),
 			toNullString(notification.Method),
 			toNullString(notification.Headers),
+			toNullString(notification.Rooms),
+			toNullString(notification.Targets),
+			toNullString(notification.FromAddress),
+			notification.RateLimit,
+			notification.DigestInterval,
+			pq.Array(notification.DigestEvents),
+			toNullString(notification.TitleTemplate),
+			toNullString(notification.BodyTemplate),
 		).
 		Suffix("RETURNING id").RunWith(r.db.Handler)
 
@@ -282,8 +330,8 @@ func (r *NotificationRepo) Update(ctx context.Context, notification *domain.Noti
 		Set("enabled", notification.Enabled).
 		Set("events", pq.Array(notification.Events)).
 		Set("webhook", toNullString(notification.Webhook)).
-		Set("token", toNullString(notification.Token)).
-		Set("api_key", toNullString(notification.APIKey)).
+		Set("token", toNullString(r.db.encryptSecret(notification.Token))).
+		Set("api_key", toNullString(r.db.encryptSecret(notification.APIKey))).
 		Set("channel", toNullString(notification.Channel)).
 		Set("priority", notification.Priority).
 		Set("topic", toNullString(notification.Topic)).
@@ -291,9 +339,17 @@ func (r *NotificationRepo) Update(ctx context.Context, notification *domain.Noti
 		Set("event_sounds", string(eventSoundsJSON)).
 		Set("host", toNullString(notification.Host)).
 		Set("username", toNullString(notification.Username)).
-		Set("password", toNullString(notification.Password)).
+		Set("password", toNullString(r.db.encryptSecret(notification.Password))).
 		Set("method", toNullString(notification.Method)).
 		Set("headers", toNullString(notification.Headers)).
+		Set("rooms", toNullString(notification.Rooms)).
+		Set("targets", toNullString(notification.Targets)).
+		Set("from_address", toNullString(notification.FromAddress)).
+		Set("rate_limit", notification.RateLimit).
+		Set("digest_interval", notification.DigestInterval).
+		Set("digest_events", pq.Array(notification.DigestEvents)).
+		Set("title_template", toNullString(notification.TitleTemplate)).
+		Set("body_template", toNullString(notification.BodyTemplate)).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
 		Where(sq.Eq{"id": notification.ID})
 
@@ -312,19 +368,41 @@ func (r *NotificationRepo) Update(ctx context.Context, notification *domain.Noti
 }
 
 func (r *NotificationRepo) Delete(ctx context.Context, notificationID int) error {
-	queryBuilder := r.db.squirrel.
-		Delete("notification").
-		Where(sq.Eq{"id": notificationID})
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error begin transaction")
+	}
 
-	query, args, err := queryBuilder.ToSql()
+	defer tx.Rollback()
+
+	// sqlite does not enforce the foreign keys outside of tests, delete the digest state explicitly
+	for _, table := range []string{"notification_digest_item", "notification_sent"} {
+		query, args, err := r.db.squirrel.Delete(table).Where(sq.Eq{"notification_id": notificationID}).ToSql()
+		if err != nil {
+			return errors.Wrap(err, "error building query")
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return errors.Wrap(err, "error executing query")
+		}
+	}
+
+	query, args, err := r.db.squirrel.
+		Delete("notification").
+		Where(sq.Eq{"id": notificationID}).
+		ToSql()
 	if err != nil {
 		return errors.Wrap(err, "error building query")
 	}
 
-	if _, err = r.db.Handler.ExecContext(ctx, query, args...); err != nil {
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
 		return errors.Wrap(err, "error executing query")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "error commit deleting notification")
+	}
+
 	r.log.Debug().Msgf("notification.delete: successfully deleted: %v", notificationID)
 
 	return nil
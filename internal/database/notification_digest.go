@@ -0,0 +1,149 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+func (r *NotificationRepo) StoreDigestItem(ctx context.Context, item *domain.NotificationDigestItem) error {
+	queryBuilder := r.db.squirrel.
+		Insert("notification_digest_item").
+		Columns("notification_id", "event", "filter", "indexer", "release_name", "rejections", "rate_limited", "created_at").
+		Values(item.NotificationID, item.Event, toNullString(item.Filter), toNullString(item.Indexer), toNullString(item.ReleaseName), pq.Array(item.Rejections), item.RateLimited, item.CreatedAt.UTC()).
+		Suffix("RETURNING id").RunWith(r.db.Handler)
+
+	if err := queryBuilder.QueryRowContext(ctx).Scan(&item.ID); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
+
+// FindDigestItems returns the pending digest items of all notifications ordered by notification and age
+func (r *NotificationRepo) FindDigestItems(ctx context.Context) ([]domain.NotificationDigestItem, error) {
+	queryBuilder := r.db.squirrel.
+		Select("id", "notification_id", "event", "filter", "indexer", "release_name", "rejections", "rate_limited", "created_at").
+		From("notification_digest_item").
+		OrderBy("notification_id", "id")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	items := make([]domain.NotificationDigestItem, 0)
+	for rows.Next() {
+		var item domain.NotificationDigestItem
+		var filter, indexer, releaseName sql.Null[string]
+
+		if err := rows.Scan(&item.ID, &item.NotificationID, &item.Event, &filter, &indexer, &releaseName, pq.Array(&item.Rejections), &item.RateLimited, &item.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		item.Filter = filter.V
+		item.Indexer = indexer.V
+		item.ReleaseName = releaseName.V
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error rows find digest items")
+	}
+
+	return items, nil
+}
+
+// DeleteDigestItems deletes the digest items of a notification up to and including maxID
+func (r *NotificationRepo) DeleteDigestItems(ctx context.Context, notificationID int, maxID int64) error {
+	queryBuilder := r.db.squirrel.
+		Delete("notification_digest_item").
+		Where(sq.Eq{"notification_id": notificationID}).
+		Where(sq.LtOrEq{"id": maxID})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	if _, err := r.db.Handler.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
+
+// StoreSent records a sent message for the rate limit of a notification
+func (r *NotificationRepo) StoreSent(ctx context.Context, notificationID int, sentAt time.Time) error {
+	queryBuilder := r.db.squirrel.
+		Insert("notification_sent").
+		Columns("notification_id", "sent_at").
+		Values(notificationID, sentAt.UTC())
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	if _, err := r.db.Handler.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
+
+// CountSent returns the number of messages sent by a notification since the given time
+func (r *NotificationRepo) CountSent(ctx context.Context, notificationID int, since time.Time) (int, error) {
+	queryBuilder := r.db.squirrel.
+		Select("COUNT(*)").
+		From("notification_sent").
+		Where(sq.Eq{"notification_id": notificationID}).
+		Where(sq.Gt{"sent_at": since.UTC()})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "error building query")
+	}
+
+	var count int
+	if err := r.db.Handler.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "error executing query")
+	}
+
+	return count, nil
+}
+
+// DeleteSentBefore prunes sent messages that no longer count towards a rate limit
+func (r *NotificationRepo) DeleteSentBefore(ctx context.Context, before time.Time) error {
+	queryBuilder := r.db.squirrel.
+		Delete("notification_sent").
+		Where(sq.Lt{"sent_at": before.UTC()})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	if _, err := r.db.Handler.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
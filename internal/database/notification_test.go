@@ -18,27 +18,33 @@ import (
 
 func getMockNotification() domain.Notification {
 	return domain.Notification{
-		ID:        1,
-		Name:      "MockNotification",
-		Type:      domain.NotificationTypeSlack,
-		Enabled:   true,
-		Events:    []string{"event1", "event2"},
-		Token:     "mock-token",
-		APIKey:    "mock-api-key",
-		Webhook:   "https://webhook.example.com",
-		Title:     "Mock Title",
-		Icon:      "https://icon.example.com",
-		Username:  "mock-username",
-		Host:      "https://host.example.com",
-		Password:  "mock-password",
-		Channel:   "#mock-channel",
-		Rooms:     "room1,room2",
-		Targets:   "target1,target2",
-		Devices:   "device1,device2",
-		Priority:  1,
-		Topic:     "mock-topic",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:             1,
+		Name:           "MockNotification",
+		Type:           domain.NotificationTypeSlack,
+		Enabled:        true,
+		Events:         []string{"event1", "event2"},
+		Token:          "mock-token",
+		APIKey:         "mock-api-key",
+		Webhook:        "https://webhook.example.com",
+		Title:          "Mock Title",
+		Icon:           "https://icon.example.com",
+		Username:       "mock-username",
+		Host:           "https://host.example.com",
+		Password:       "mock-password",
+		Channel:        "#mock-channel",
+		Rooms:          "room1,room2",
+		Targets:        "target1,target2",
+		Devices:        "device1,device2",
+		Priority:       1,
+		Topic:          "mock-topic",
+		TitleTemplate:  "{{ .Title }}",
+		BodyTemplate:   "{{ .ReleaseName }}",
+		FromAddress:    "autobrr@example.com",
+		RateLimit:      10,
+		DigestInterval: 15,
+		DigestEvents:   []string{"PUSH_REJECTED"},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 }
 
@@ -209,6 +215,14 @@ func TestNotificationRepo_FindByID(t *testing.T) {
 			assert.NotNil(t, notification)
 			assert.Equal(t, mockData.Name, notification.Name)
 			assert.Equal(t, mockData.Type, notification.Type)
+			assert.Equal(t, mockData.TitleTemplate, notification.TitleTemplate)
+			assert.Equal(t, mockData.BodyTemplate, notification.BodyTemplate)
+			assert.Equal(t, mockData.Rooms, notification.Rooms)
+			assert.Equal(t, mockData.Targets, notification.Targets)
+			assert.Equal(t, mockData.FromAddress, notification.FromAddress)
+			assert.Equal(t, mockData.RateLimit, notification.RateLimit)
+			assert.Equal(t, mockData.DigestInterval, notification.DigestInterval)
+			assert.Equal(t, mockData.DigestEvents, notification.DigestEvents)
 
 			// Cleanup
 			_ = repo.Delete(context.Background(), mockData.ID)
@@ -249,3 +263,76 @@ func TestNotificationRepo_List(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationRepo_Digest(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		repo := NewNotificationRepo(log, db)
+		mockData := getMockNotification()
+
+		t.Run(fmt.Sprintf("DigestItems [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			err := repo.Store(ctx, &mockData)
+			assert.NoError(t, err)
+
+			payload := domain.NotificationPayload{Filter: "TV", Indexer: "MockIndexer", ReleaseName: "Best.Show.S01E01", Rejections: []string{"size", "resolution"}}
+
+			first := domain.NewNotificationDigestItem(mockData.ID, domain.NotificationEventPushRejected, payload, false)
+			assert.NoError(t, repo.StoreDigestItem(ctx, first))
+
+			second := domain.NewNotificationDigestItem(mockData.ID, domain.NotificationEventReleaseNew, domain.NotificationPayload{}, true)
+			assert.NoError(t, repo.StoreDigestItem(ctx, second))
+
+			items, err := repo.FindDigestItems(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, items, 2)
+			assert.Equal(t, first.ID, items[0].ID)
+			assert.Equal(t, domain.NotificationEventPushRejected, items[0].Event)
+			assert.Equal(t, "TV", items[0].Filter)
+			assert.Equal(t, []string{"size", "resolution"}, items[0].Rejections)
+			assert.WithinDuration(t, first.CreatedAt, items[0].CreatedAt, time.Second)
+			assert.True(t, items[1].RateLimited)
+
+			assert.NoError(t, repo.DeleteDigestItems(ctx, mockData.ID, first.ID))
+
+			items, err = repo.FindDigestItems(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+			assert.Equal(t, second.ID, items[0].ID)
+
+			// items are deleted with the notification
+			_ = repo.Delete(ctx, mockData.ID)
+
+			items, err = repo.FindDigestItems(ctx)
+			assert.NoError(t, err)
+			assert.Empty(t, items)
+		})
+
+		t.Run(fmt.Sprintf("Sent [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			err := repo.Store(ctx, &mockData)
+			assert.NoError(t, err)
+
+			now := time.Now()
+			assert.NoError(t, repo.StoreSent(ctx, mockData.ID, now.Add(-2*time.Hour)))
+			assert.NoError(t, repo.StoreSent(ctx, mockData.ID, now.Add(-30*time.Minute)))
+			assert.NoError(t, repo.StoreSent(ctx, mockData.ID, now))
+
+			count, err := repo.CountSent(ctx, mockData.ID, now.Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			assert.NoError(t, repo.DeleteSentBefore(ctx, now.Add(-10*time.Minute)))
+
+			count, err = repo.CountSent(ctx, mockData.ID, now.Add(-24*time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			// Cleanup
+			_ = repo.Delete(ctx, mockData.ID)
+		})
+	}
+}
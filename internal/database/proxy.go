@@ -46,7 +46,7 @@ func (r *ProxyRepo) Store(ctx context.Context, p *domain.Proxy) error {
 			p.Type,
 			toNullString(p.Addr),
 			toNullString(p.User),
-			toNullString(p.Pass),
+			toNullString(r.db.encryptSecret(p.Pass)),
 			p.Timeout,
 		).
 		Suffix("RETURNING id").
@@ -71,7 +71,7 @@ func (r *ProxyRepo) Update(ctx context.Context, p *domain.Proxy) error {
 		Set("type", p.Type).
 		Set("addr", p.Addr).
 		Set("auth_user", toNullString(p.User)).
-		Set("auth_pass", toNullString(p.Pass)).
+		Set("auth_pass", toNullString(r.db.encryptSecret(p.Pass))).
 		Set("timeout", p.Timeout).
 		Set("updated_at", time.Now().Format(time.RFC3339)).
 		Where(sq.Eq{"id": p.ID})
@@ -139,6 +139,10 @@ func (r *ProxyRepo) List(ctx context.Context) ([]domain.Proxy, error) {
 		proxy.User = user.String
 		proxy.Pass = pass.String
 
+		if err := r.db.decryptSecrets(&proxy.Pass); err != nil {
+			return nil, errors.Wrap(err, "could not decrypt proxy secrets")
+		}
+
 		proxies = append(proxies, proxy)
 	}
 
@@ -231,6 +235,10 @@ func (r *ProxyRepo) FindByID(ctx context.Context, id int64) (*domain.Proxy, erro
 	proxy.User = user.String
 	proxy.Pass = pass.String
 
+	if err := r.db.decryptSecrets(&proxy.Pass); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt proxy secrets")
+	}
+
 	return &proxy, nil
 }
 
@@ -8,6 +8,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -218,3 +219,56 @@ func TestProxyRepo_FindByID(t *testing.T) {
 
 	}
 }
+
+func TestProxyRepo_EncryptedSecrets(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+		repo := NewProxyRepo(log, db)
+		mockData := getMockProxy()
+		mockData.User = "user"
+		mockData.Pass = "hunter2"
+
+		t.Run(fmt.Sprintf("Rotate_Secret_Key [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			// Setup
+			err := repo.Store(ctx, mockData)
+			assert.NoError(t, err)
+
+			// Execute
+			rows, err := db.RotateSecretKey(ctx, "0123456789abcdef0123456789abcdef")
+			assert.NoError(t, err)
+			assert.Equal(t, 1, rows)
+
+			var stored string
+			err = db.Handler.QueryRowContext(ctx, "SELECT auth_pass FROM proxy WHERE id = "+fmt.Sprint(mockData.ID)).Scan(&stored)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(stored, encryptedSecretPrefix))
+
+			proxy, err := repo.FindByID(ctx, mockData.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter2", proxy.Pass)
+
+			// updates are encrypted with the current key
+			proxy.Pass = "hunter3"
+			err = repo.Update(ctx, proxy)
+			assert.NoError(t, err)
+
+			proxies, err := repo.List(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter3", proxies[0].Pass)
+
+			// decrypt again
+			rows, err = db.RotateSecretKey(ctx, "")
+			assert.NoError(t, err)
+			assert.Equal(t, 1, rows)
+
+			err = db.Handler.QueryRowContext(ctx, "SELECT auth_pass FROM proxy WHERE id = "+fmt.Sprint(mockData.ID)).Scan(&stored)
+			assert.NoError(t, err)
+			assert.Equal(t, "hunter3", stored)
+
+			// Cleanup
+			_ = repo.Delete(ctx, mockData.ID)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -89,6 +90,7 @@ func (repo *ReleaseRepo) StoreReleaseActionStatus(ctx context.Context, status *d
 		queryBuilder := repo.db.squirrel.
 			Update("release_action_status").
 			Set("status", status.Status).
+			Set("client", status.Client).
 			Set("rejections", pq.Array(status.Rejections)).
 			Set("timestamp", status.Timestamp.Format(time.RFC3339)).
 			Where(sq.Eq{"id": status.ID}).
@@ -502,6 +504,95 @@ func (repo *ReleaseRepo) findReleases(ctx context.Context, tx *Tx, params domain
 	return resp, nil
 }
 
+// FindForFilterTest returns stored releases for a filter dry run, newest first.
+// Releases are parsed from their name again since not every parsed field is stored.
+func (repo *ReleaseRepo) FindForFilterTest(ctx context.Context, req *domain.FilterTestRequest) ([]*domain.Release, error) {
+	queryBuilder := repo.db.squirrel.
+		Select("r.id", "r.indexer", "r.protocol", "r.implementation", "r.timestamp", "r.announce_type", "r.info_url", "r.download_url", "r.group_id", "r.torrent_id", "r.torrent_name", "r.size", "r.category", "r.origin", "r.tags", "r.uploader", "r.pre_time").
+		From("release r").
+		OrderBy("r.id DESC").
+		Limit(req.Limit)
+
+	if !req.From.IsZero() {
+		if repo.db.Driver == "sqlite" {
+			queryBuilder = queryBuilder.Where("datetime(r.timestamp) >= datetime(?)", req.From.UTC().Format(time.DateTime))
+		} else {
+			queryBuilder = queryBuilder.Where(sq.GtOrEq{"r.timestamp": req.From})
+		}
+	}
+
+	if !req.To.IsZero() {
+		if repo.db.Driver == "sqlite" {
+			queryBuilder = queryBuilder.Where("datetime(r.timestamp) <= datetime(?)", req.To.UTC().Format(time.DateTime))
+		} else {
+			queryBuilder = queryBuilder.Where(sq.LtOrEq{"r.timestamp": req.To})
+		}
+	}
+
+	if len(req.Indexers) > 0 {
+		queryBuilder = queryBuilder.Where(sq.Eq{"r.indexer": req.Indexers})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	repo.log.Trace().Str("database", "release.findForFilterTest").Msgf("query: '%s', args: '%v'", query, args)
+
+	rows, err := repo.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	releases := make([]*domain.Release, 0)
+
+	for rows.Next() {
+		var indexer, protocol, implementation, announceType, infoURL, downloadURL, groupID, torrentID, category, origin, uploader, preTime sql.NullString
+		var size sql.NullInt64
+		var id int64
+		var timestamp time.Time
+		var name string
+		var tags []string
+
+		if err := rows.Scan(&id, &indexer, &protocol, &implementation, &timestamp, &announceType, &infoURL, &downloadURL, &groupID, &torrentID, &name, &size, &category, &origin, pq.Array(&tags), &uploader, &preTime); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		rls := domain.NewRelease(domain.IndexerMinimal{Identifier: indexer.String})
+		rls.ID = id
+		rls.Timestamp = timestamp
+		rls.Protocol = domain.ReleaseProtocol(protocol.String)
+		rls.Implementation = domain.ReleaseImplementation(implementation.String)
+		rls.AnnounceType = domain.AnnounceType(announceType.String)
+		rls.InfoURL = infoURL.String
+		rls.DownloadURL = downloadURL.String
+		rls.GroupID = groupID.String
+		rls.TorrentID = torrentID.String
+		rls.Size = uint64(size.Int64)
+		rls.Category = category.String
+		rls.Origin = origin.String
+		rls.Uploader = uploader.String
+		rls.PreTime = preTime.String
+
+		if len(tags) > 0 {
+			rls.Tags = tags
+		}
+
+		rls.ParseString(name)
+
+		releases = append(releases, rls)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row error")
+	}
+
+	return releases, nil
+}
+
 func (repo *ReleaseRepo) FindDuplicateReleaseProfiles(ctx context.Context) ([]*domain.DuplicateReleaseProfile, error) {
 	queryBuilder := repo.db.squirrel.
 		Select(
@@ -800,6 +891,279 @@ CROSS JOIN (
 	return &rls, nil
 }
 
+// statsBucketExpr returns the expression to truncate a timestamp column to the start of a bucket as a RFC3339 string
+func (repo *ReleaseRepo) statsBucketExpr(column string, bucket domain.ReleaseStatsBucket, withTimeZone bool) string {
+	if repo.db.Driver == "sqlite" {
+		switch bucket {
+		case domain.ReleaseStatsBucketHour:
+			return fmt.Sprintf("strftime('%%Y-%%m-%%dT%%H:00:00Z', %s)", column)
+		case domain.ReleaseStatsBucketWeek:
+			// weeks start on monday like date_trunc in postgres
+			return fmt.Sprintf("strftime('%%Y-%%m-%%dT00:00:00Z', %s, 'weekday 0', '-6 days')", column)
+		default:
+			return fmt.Sprintf("strftime('%%Y-%%m-%%dT00:00:00Z', %s)", column)
+		}
+	}
+
+	if withTimeZone {
+		column = column + " AT TIME ZONE 'UTC'"
+	}
+
+	return fmt.Sprintf(`to_char(date_trunc('%s', %s), 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`, bucket, column)
+}
+
+func (repo *ReleaseRepo) statsTimeRange(qb sq.SelectBuilder, column string, from, to time.Time) sq.SelectBuilder {
+	if repo.db.Driver == "sqlite" {
+		return qb.
+			Where(fmt.Sprintf("datetime(%s) >= datetime(?)", column), from.UTC().Format(time.DateTime)).
+			Where(fmt.Sprintf("datetime(%s) < datetime(?)", column), to.UTC().Format(time.DateTime))
+	}
+
+	return qb.Where(sq.GtOrEq{column: from}).Where(sq.Lt{column: to})
+}
+
+// StatsTimeseries returns release and push status counts per time bucket, optionally grouped by indexer, filter, action or client
+func (repo *ReleaseRepo) StatsTimeseries(ctx context.Context, params *domain.ReleaseStatsTimeseriesParams) (*domain.ReleaseStatsTimeseries, error) {
+	type pointKey struct {
+		bucket string
+		group  string
+	}
+
+	points := make(map[pointKey]*domain.ReleaseStatsTimeseriesPoint)
+	keys := make([]pointKey, 0)
+
+	point := func(bucket, group string) (*domain.ReleaseStatsTimeseriesPoint, error) {
+		key := pointKey{bucket: bucket, group: group}
+		if p, ok := points[key]; ok {
+			return p, nil
+		}
+
+		t, err := time.Parse(time.RFC3339, bucket)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse bucket: %s", bucket)
+		}
+
+		p := &domain.ReleaseStatsTimeseriesPoint{Time: t, Group: group}
+		points[key] = p
+		keys = append(keys, key)
+
+		return p, nil
+	}
+
+	if params.GroupBy.HasReleaseCounts() {
+		groupColumn := "''"
+		switch params.GroupBy {
+		case domain.ReleaseStatsGroupByIndexer:
+			groupColumn = "r.indexer"
+		case domain.ReleaseStatsGroupByFilter:
+			groupColumn = "r.filter"
+		}
+
+		bucketExpr := repo.statsBucketExpr("r.timestamp", params.Bucket, true)
+
+		qb := repo.db.squirrel.
+			Select(
+				bucketExpr+" AS bucket",
+				"COALESCE("+groupColumn+", '') AS grp",
+				"COUNT(*)",
+				"COUNT(CASE WHEN r.filter_status = 'FILTER_APPROVED' THEN 0 END)",
+				"COUNT(CASE WHEN r.filter_status = 'FILTER_REJECTED' THEN 0 END)",
+			).
+			From("release r").
+			GroupBy("bucket", "grp")
+
+		qb = repo.statsTimeRange(qb, "r.timestamp", params.From, params.To)
+
+		query, args, err := qb.ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "error building query")
+		}
+
+		rows, err := repo.db.Handler.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error executing query")
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var bucket, group string
+			var total, filtered, rejected int64
+
+			if err := rows.Scan(&bucket, &group, &total, &filtered, &rejected); err != nil {
+				return nil, errors.Wrap(err, "error scanning row")
+			}
+
+			p, err := point(bucket, group)
+			if err != nil {
+				return nil, err
+			}
+
+			p.TotalCount = total
+			p.FilteredCount = filtered
+			p.FilterRejectedCount = rejected
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "rows error")
+		}
+	}
+
+	groupColumn := "''"
+	switch params.GroupBy {
+	case domain.ReleaseStatsGroupByIndexer:
+		groupColumn = "r.indexer"
+	case domain.ReleaseStatsGroupByFilter:
+		groupColumn = "ras.filter"
+	case domain.ReleaseStatsGroupByAction:
+		groupColumn = "ras.action"
+	case domain.ReleaseStatsGroupByClient:
+		groupColumn = "ras.client"
+	}
+
+	bucketExpr := repo.statsBucketExpr("ras.timestamp", params.Bucket, false)
+
+	qb := repo.db.squirrel.
+		Select(
+			bucketExpr+" AS bucket",
+			"COALESCE("+groupColumn+", '') AS grp",
+			"COUNT(CASE WHEN ras.status = 'PUSH_APPROVED' THEN 0 END)",
+			"COUNT(CASE WHEN ras.status = 'PUSH_REJECTED' THEN 0 END)",
+			"COUNT(CASE WHEN ras.status = 'PUSH_ERROR' THEN 0 END)",
+		).
+		From("release_action_status ras").
+		Join("release r ON r.id = ras.release_id").
+		GroupBy("bucket", "grp")
+
+	qb = repo.statsTimeRange(qb, "ras.timestamp", params.From, params.To)
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := repo.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var bucket, group string
+		var approved, rejected, errored int64
+
+		if err := rows.Scan(&bucket, &group, &approved, &rejected, &errored); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		p, err := point(bucket, group)
+		if err != nil {
+			return nil, err
+		}
+
+		p.PushApprovedCount = approved
+		p.PushRejectedCount = rejected
+		p.PushErrorCount = errored
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+
+	slices.SortFunc(keys, func(a, b pointKey) int {
+		if c := strings.Compare(a.bucket, b.bucket); c != 0 {
+			return c
+		}
+		return strings.Compare(a.group, b.group)
+	})
+
+	res := &domain.ReleaseStatsTimeseries{
+		From:    params.From,
+		To:      params.To,
+		Bucket:  params.Bucket,
+		GroupBy: params.GroupBy,
+		Points:  make([]*domain.ReleaseStatsTimeseriesPoint, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		res.Points = append(res.Points, points[key])
+	}
+
+	return res, nil
+}
+
+// StatsBreakdown returns the stored release counts per indexer and filter and push counts per indexer, filter, action and client
+func (repo *ReleaseRepo) StatsBreakdown(ctx context.Context) (*domain.ReleaseStatsBreakdown, error) {
+	res := &domain.ReleaseStatsBreakdown{
+		Releases: make([]domain.ReleaseStatsReleaseCount, 0),
+		Pushes:   make([]domain.ReleaseStatsPushCount, 0),
+	}
+
+	query, args, err := repo.db.squirrel.
+		Select("COALESCE(r.indexer, '') AS indexer", "COALESCE(r.filter, '') AS filter", "COALESCE(r.filter_status, '') AS filter_status", "COUNT(*)").
+		From("release r").
+		// group by the same expressions as selected, rows with NULL and empty values are one series
+		GroupBy("COALESCE(r.indexer, '')", "COALESCE(r.filter, '')", "COALESCE(r.filter_status, '')").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := repo.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.ReleaseStatsReleaseCount
+		if err := rows.Scan(&c.Indexer, &c.Filter, &c.FilterStatus, &c.Count); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		res.Releases = append(res.Releases, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+
+	query, args, err = repo.db.squirrel.
+		Select("COALESCE(r.indexer, '') AS indexer", "COALESCE(ras.filter, '') AS filter", "COALESCE(ras.action, '') AS action", "COALESCE(ras.client, '') AS client", "COALESCE(ras.status, '') AS status", "COUNT(*)").
+		From("release_action_status ras").
+		Join("release r ON r.id = ras.release_id").
+		// client and filter were added later and are NULL on older rows
+		GroupBy("COALESCE(r.indexer, '')", "COALESCE(ras.filter, '')", "COALESCE(ras.action, '')", "COALESCE(ras.client, '')", "COALESCE(ras.status, '')").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	pushRows, err := repo.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer pushRows.Close()
+
+	for pushRows.Next() {
+		var c domain.ReleaseStatsPushCount
+		if err := pushRows.Scan(&c.Indexer, &c.Filter, &c.Action, &c.Client, &c.Status, &c.Count); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		res.Pushes = append(res.Pushes, c)
+	}
+
+	if err := pushRows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+
+	return res, nil
+}
+
 func (repo *ReleaseRepo) Delete(ctx context.Context, req *domain.DeleteReleaseRequest) error {
 	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -1261,6 +1625,40 @@ func (repo *ReleaseRepo) CheckIsDuplicateRelease(ctx context.Context, profile *d
 	return true, nil
 }
 
+// ExistsByNormalizedHash reports if a release of the indexer with the normalized hash was stored since the given time
+func (repo *ReleaseRepo) ExistsByNormalizedHash(ctx context.Context, indexer string, hash string, since time.Time) (bool, error) {
+	queryBuilder := repo.db.squirrel.
+		Select("1").
+		From("release r").
+		Where(sq.Eq{"r.indexer": indexer}).
+		Where(sq.Eq{"r.normalized_hash": hash}).
+		Limit(1)
+
+	if repo.db.Driver == "sqlite" {
+		queryBuilder = queryBuilder.Where("datetime(r.timestamp) >= datetime(?)", since.UTC().Format(time.DateTime))
+	} else {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{"r.timestamp": since})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "error building query")
+	}
+
+	repo.log.Trace().Str("database", "release.existsByNormalizedHash").Msgf("query: %q, args: %q", query, args)
+
+	var exists int
+	if err := repo.db.Handler.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "error executing query")
+	}
+
+	return true, nil
+}
+
 func (r *ReleaseRepo) ListCleanupJobs(ctx context.Context) ([]*domain.ReleaseCleanupJob, error) {
 	queryBuilder := r.db.squirrel.
 		Select(
@@ -1560,3 +1958,151 @@ func (r *ReleaseRepo) DeleteCleanupJob(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *ReleaseRepo) StoreActionRetry(ctx context.Context, retry *domain.ReleaseActionRetry) error {
+	if retry.ID != 0 {
+		queryBuilder := r.db.squirrel.
+			Update("release_action_retry").
+			Set("attempts", retry.Attempts).
+			Set("next_attempt_at", retry.NextAttemptAt.UTC().Format(time.RFC3339)).
+			Set("last_error", toNullString(retry.LastError)).
+			Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+			Where(sq.Eq{"id": retry.ID})
+
+		query, args, err := queryBuilder.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "error building query")
+		}
+
+		result, err := r.db.Handler.ExecContext(ctx, query, args...)
+		if err != nil {
+			return errors.Wrap(err, "error executing query")
+		}
+
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return errors.Wrap(err, "error getting rows affected")
+		} else if rowsAffected == 0 {
+			return domain.ErrRecordNotFound
+		}
+
+		return nil
+	}
+
+	queryBuilder := r.db.squirrel.
+		Insert("release_action_retry").
+		Columns(
+			"release_id",
+			"action_id",
+			"action_status_id",
+			"attempts",
+			"max_attempts",
+			"next_attempt_at",
+			"last_error",
+		).
+		Values(
+			retry.ReleaseID,
+			retry.ActionID,
+			toNullInt64(retry.ActionStatusID),
+			retry.Attempts,
+			retry.MaxAttempts,
+			retry.NextAttemptAt.UTC().Format(time.RFC3339),
+			toNullString(retry.LastError),
+		).
+		Suffix("RETURNING id").RunWith(r.db.Handler)
+
+	if err := queryBuilder.QueryRowContext(ctx).Scan(&retry.ID); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	return nil
+}
+
+func (r *ReleaseRepo) FindDueActionRetries(ctx context.Context, now time.Time, limit uint64) ([]*domain.ReleaseActionRetry, error) {
+	queryBuilder := r.db.squirrel.
+		Select(
+			"id",
+			"release_id",
+			"action_id",
+			"action_status_id",
+			"attempts",
+			"max_attempts",
+			"next_attempt_at",
+			"last_error",
+			"created_at",
+			"updated_at",
+		).
+		From("release_action_retry").
+		OrderBy("next_attempt_at ASC").
+		Limit(limit)
+
+	if r.db.Driver == "sqlite" {
+		queryBuilder = queryBuilder.Where("datetime(next_attempt_at) <= datetime(?)", now.UTC().Format(time.DateTime))
+	} else {
+		queryBuilder = queryBuilder.Where(sq.LtOrEq{"next_attempt_at": now.UTC()})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	retries := make([]*domain.ReleaseActionRetry, 0)
+	for rows.Next() {
+		var retry domain.ReleaseActionRetry
+
+		var actionStatusID sql.NullInt64
+		var lastError sql.NullString
+
+		if err := rows.Scan(
+			&retry.ID,
+			&retry.ReleaseID,
+			&retry.ActionID,
+			&actionStatusID,
+			&retry.Attempts,
+			&retry.MaxAttempts,
+			&retry.NextAttemptAt,
+			&lastError,
+			&retry.CreatedAt,
+			&retry.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		retry.ActionStatusID = actionStatusID.Int64
+		retry.LastError = lastError.String
+
+		retries = append(retries, &retry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row error")
+	}
+
+	return retries, nil
+}
+
+func (r *ReleaseRepo) DeleteActionRetry(ctx context.Context, id int64) error {
+	queryBuilder := r.db.squirrel.
+		Delete("release_action_retry").
+		Where(sq.Eq{"id": id})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	if _, err := r.db.Handler.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	r.log.Debug().Msgf("release_action_retry.delete: successfully deleted: %v", id)
+
+	return nil
+}
@@ -248,6 +248,115 @@ func TestReleaseRepo_Find(t *testing.T) {
 	}
 }
 
+func TestReleaseRepo_FindForFilterTest(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		filterRepo := NewFilterRepo(log, db)
+		repo := NewReleaseRepo(log, db)
+
+		t.Run(fmt.Sprintf("FindForFilterTest_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			err := filterRepo.Store(context.Background(), getMockFilter())
+			assert.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(context.Background())
+			assert.NoError(t, err)
+			assert.NotNil(t, createdFilters)
+
+			oldRelease := getMockRelease()
+			oldRelease.FilterID = createdFilters[0].ID
+			oldRelease.TorrentName = "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP"
+			oldRelease.Timestamp = time.Now().Add(-48 * time.Hour)
+
+			newRelease := getMockRelease()
+			newRelease.FilterID = createdFilters[0].ID
+			newRelease.TorrentName = "That.Show.S01E02.2160p.WEB-DL.DDP5.1.H.265-GROUP"
+
+			otherIndexerRelease := getMockRelease()
+			otherIndexerRelease.FilterID = createdFilters[0].ID
+			otherIndexerRelease.Indexer.Identifier = "ptp"
+
+			for _, rls := range []*domain.Release{oldRelease, newRelease, otherIndexerRelease} {
+				err = repo.Store(context.Background(), rls)
+				assert.NoError(t, err)
+			}
+
+			// Execute
+			releases, err := repo.FindForFilterTest(context.Background(), &domain.FilterTestRequest{
+				From:     time.Now().Add(-72 * time.Hour),
+				To:       time.Now().Add(-24 * time.Hour),
+				Indexers: []string{"btn"},
+				Limit:    10,
+			})
+
+			// Verify
+			assert.NoError(t, err)
+			assert.Len(t, releases, 1)
+			assert.Equal(t, oldRelease.ID, releases[0].ID)
+			assert.Equal(t, "btn", releases[0].Indexer.Identifier)
+			assert.Equal(t, "1080p", releases[0].Resolution)
+			assert.Equal(t, 1, releases[0].Episode)
+			assert.Equal(t, oldRelease.Size, releases[0].Size)
+
+			releases, err = repo.FindForFilterTest(context.Background(), &domain.FilterTestRequest{Limit: 10})
+			assert.NoError(t, err)
+			assert.Len(t, releases, 3)
+			// newest first
+			assert.Equal(t, otherIndexerRelease.ID, releases[0].ID)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = filterRepo.Delete(context.Background(), createdFilters[0].ID)
+		})
+	}
+}
+
+func TestReleaseRepo_ExistsByNormalizedHash(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		filterRepo := NewFilterRepo(log, db)
+		repo := NewReleaseRepo(log, db)
+
+		t.Run(fmt.Sprintf("ExistsByNormalizedHash_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			err := filterRepo.Store(context.Background(), getMockFilter())
+			assert.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(context.Background())
+			assert.NoError(t, err)
+			assert.NotNil(t, createdFilters)
+
+			mockData := getMockRelease()
+			mockData.FilterID = createdFilters[0].ID
+			mockData.NormalizedHash = "e0e5b3aab3e3ea9f2ee0c5ec8ac3bff5"
+			mockData.Timestamp = time.Now().Add(-10 * time.Minute)
+
+			err = repo.Store(context.Background(), mockData)
+			assert.NoError(t, err)
+
+			// Execute
+			exists, err := repo.ExistsByNormalizedHash(context.Background(), "btn", mockData.NormalizedHash, time.Now().Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.True(t, exists)
+
+			exists, err = repo.ExistsByNormalizedHash(context.Background(), "ptp", mockData.NormalizedHash, time.Now().Add(-time.Hour))
+			assert.NoError(t, err)
+			assert.False(t, exists)
+
+			// stored before the playback window
+			exists, err = repo.ExistsByNormalizedHash(context.Background(), "btn", mockData.NormalizedHash, time.Now().Add(-5*time.Minute))
+			assert.NoError(t, err)
+			assert.False(t, exists)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = filterRepo.Delete(context.Background(), createdFilters[0].ID)
+		})
+	}
+}
+
 func TestReleaseRepo_FindRecent(t *testing.T) {
 	for dbType, db := range testDBs {
 		log := setupLoggerForTest()
@@ -536,6 +645,177 @@ func TestReleaseRepo_Stats(t *testing.T) {
 	}
 }
 
+func TestReleaseRepo_StatsTimeseries(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		downloadClientRepo := NewDownloadClientRepo(log, db)
+		filterRepo := NewFilterRepo(log, db)
+		actionRepo := NewActionRepo(log, db, downloadClientRepo)
+		repo := NewReleaseRepo(log, db)
+
+		mockData := getMockRelease()
+		releaseActionMockData := getMockReleaseActionStatus()
+		actionMockData := getMockAction()
+
+		t.Run(fmt.Sprintf("StatsTimeseries_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			mock := getMockDownloadClient()
+			err := downloadClientRepo.Store(context.Background(), &mock)
+			assert.NoError(t, err)
+
+			err = filterRepo.Store(context.Background(), getMockFilter())
+			assert.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(context.Background())
+			assert.NoError(t, err)
+			assert.NotNil(t, createdFilters)
+
+			actionMockData.FilterID = createdFilters[0].ID
+			actionMockData.ClientID = mock.ID
+			mockData.FilterID = createdFilters[0].ID
+
+			timestamp := time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC)
+			mockData.Timestamp = timestamp
+
+			err = repo.Store(context.Background(), mockData)
+			assert.NoError(t, err)
+			err = actionRepo.Store(context.Background(), actionMockData)
+			assert.NoError(t, err)
+
+			releaseActionMockData.ReleaseID = mockData.ID
+			releaseActionMockData.ActionID = int64(actionMockData.ID)
+			releaseActionMockData.FilterID = int64(createdFilters[0].ID)
+			releaseActionMockData.Timestamp = timestamp
+
+			err = repo.StoreReleaseActionStatus(context.Background(), releaseActionMockData)
+			assert.NoError(t, err)
+
+			// Execute
+			stats, err := repo.StatsTimeseries(context.Background(), &domain.ReleaseStatsTimeseriesParams{
+				From:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				To:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				Bucket:  domain.ReleaseStatsBucketDay,
+				GroupBy: domain.ReleaseStatsGroupByIndexer,
+			})
+
+			// Verify
+			assert.NoError(t, err)
+			assert.Len(t, stats.Points, 1)
+			assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), stats.Points[0].Time)
+			assert.Equal(t, "btn", stats.Points[0].Group)
+			assert.Equal(t, int64(1), stats.Points[0].TotalCount)
+			assert.Equal(t, int64(1), stats.Points[0].FilteredCount)
+			assert.Equal(t, int64(1), stats.Points[0].PushApprovedCount)
+
+			// weeks start on monday
+			stats, err = repo.StatsTimeseries(context.Background(), &domain.ReleaseStatsTimeseriesParams{
+				From:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				To:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				Bucket:  domain.ReleaseStatsBucketWeek,
+				GroupBy: domain.ReleaseStatsGroupByClient,
+			})
+			assert.NoError(t, err)
+			assert.Len(t, stats.Points, 1)
+			assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), stats.Points[0].Time)
+			assert.Equal(t, releaseActionMockData.Client, stats.Points[0].Group)
+			assert.Equal(t, int64(0), stats.Points[0].TotalCount)
+			assert.Equal(t, int64(1), stats.Points[0].PushApprovedCount)
+
+			// outside of the time range
+			stats, err = repo.StatsTimeseries(context.Background(), &domain.ReleaseStatsTimeseriesParams{
+				From:   time.Date(2024, 5, 15, 14, 0, 0, 0, time.UTC),
+				To:     time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC),
+				Bucket: domain.ReleaseStatsBucketHour,
+			})
+			assert.NoError(t, err)
+			assert.Len(t, stats.Points, 0)
+
+			breakdown, err := repo.StatsBreakdown(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.ReleaseStatsReleaseCount{{Indexer: "btn", Filter: mockData.FilterName, FilterStatus: string(domain.ReleaseStatusFilterApproved), Count: 1}}, breakdown.Releases)
+			assert.Equal(t, []domain.ReleaseStatsPushCount{{Indexer: "btn", Filter: releaseActionMockData.Filter, Action: releaseActionMockData.Action, Client: releaseActionMockData.Client, Status: string(domain.ReleasePushStatusApproved), Count: 1}}, breakdown.Pushes)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = actionRepo.Delete(context.Background(), &domain.DeleteActionRequest{ActionId: actionMockData.ID})
+			_ = filterRepo.Delete(context.Background(), createdFilters[0].ID)
+			_ = downloadClientRepo.Delete(context.Background(), mock.ID)
+		})
+	}
+}
+
+func TestReleaseRepo_StatsBreakdown_NullValues(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		downloadClientRepo := NewDownloadClientRepo(log, db)
+		filterRepo := NewFilterRepo(log, db)
+		actionRepo := NewActionRepo(log, db, downloadClientRepo)
+		repo := NewReleaseRepo(log, db)
+
+		t.Run(fmt.Sprintf("StatsBreakdown_NullValues [%s]", dbType), func(t *testing.T) {
+			// Setup
+			mock := getMockDownloadClient()
+			err := downloadClientRepo.Store(context.Background(), &mock)
+			assert.NoError(t, err)
+
+			err = filterRepo.Store(context.Background(), getMockFilter())
+			assert.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(context.Background())
+			assert.NoError(t, err)
+			assert.NotNil(t, createdFilters)
+
+			actionMockData := getMockAction()
+			actionMockData.FilterID = createdFilters[0].ID
+			actionMockData.ClientID = mock.ID
+			err = actionRepo.Store(context.Background(), actionMockData)
+			assert.NoError(t, err)
+
+			var releaseIDs, statusIDs []int64
+			for i := 0; i < 2; i++ {
+				rls := getMockRelease()
+				rls.FilterID = createdFilters[0].ID
+				err = repo.Store(context.Background(), rls)
+				assert.NoError(t, err)
+
+				status := getMockReleaseActionStatus()
+				status.ReleaseID = rls.ID
+				status.ActionID = int64(actionMockData.ID)
+				status.FilterID = int64(createdFilters[0].ID)
+				err = repo.StoreReleaseActionStatus(context.Background(), status)
+				assert.NoError(t, err)
+
+				releaseIDs = append(releaseIDs, rls.ID)
+				statusIDs = append(statusIDs, status.ID)
+			}
+
+			// rows stored before the columns were added are NULL, newer rows are empty
+			for i, value := range []string{"NULL", "''"} {
+				_, err = db.Handler.Exec(fmt.Sprintf("UPDATE release SET filter = %s WHERE id = %d", value, releaseIDs[i]))
+				assert.NoError(t, err)
+				_, err = db.Handler.Exec(fmt.Sprintf("UPDATE release_action_status SET filter = %s, client = %s WHERE id = %d", value, value, statusIDs[i]))
+				assert.NoError(t, err)
+			}
+
+			// Execute
+			breakdown, err := repo.StatsBreakdown(context.Background())
+
+			// Verify
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.ReleaseStatsReleaseCount{{Indexer: "btn", Filter: "", FilterStatus: string(domain.ReleaseStatusFilterApproved), Count: 2}}, breakdown.Releases)
+			assert.Equal(t, []domain.ReleaseStatsPushCount{{Indexer: "btn", Filter: "", Action: "okay", Client: "", Status: string(domain.ReleasePushStatusApproved), Count: 2}}, breakdown.Pushes)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = actionRepo.Delete(context.Background(), &domain.DeleteActionRequest{ActionId: actionMockData.ID})
+			_ = filterRepo.Delete(context.Background(), createdFilters[0].ID)
+			_ = downloadClientRepo.Delete(context.Background(), mock.ID)
+		})
+	}
+}
+
 func TestReleaseRepo_Delete(t *testing.T) {
 	for dbType, db := range testDBs {
 		log := setupLoggerForTest()
@@ -1818,3 +2098,87 @@ func TestReleaseCleanupJobRepo_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseActionRetryRepo(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		downloadClientRepo := NewDownloadClientRepo(log, db)
+		filterRepo := NewFilterRepo(log, db)
+		actionRepo := NewActionRepo(log, db, downloadClientRepo)
+		repo := NewReleaseRepo(log, db)
+
+		mockData := getMockRelease()
+		actionMockData := getMockAction()
+
+		t.Run(fmt.Sprintf("StoreFindDelete_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			mock := getMockDownloadClient()
+			err := downloadClientRepo.Store(context.Background(), &mock)
+			assert.NoError(t, err)
+
+			err = filterRepo.Store(context.Background(), getMockFilter())
+			assert.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(context.Background())
+			assert.NoError(t, err)
+			assert.NotNil(t, createdFilters)
+
+			actionMockData.FilterID = createdFilters[0].ID
+			actionMockData.ClientID = mock.ID
+			actionMockData.RetryMaxAttempts = 3
+			actionMockData.RetryBackoff = 30
+			actionMockData.RetryErrors = []string{string(domain.ActionRetryErrorTimeout)}
+			mockData.FilterID = createdFilters[0].ID
+
+			err = repo.Store(context.Background(), mockData)
+			assert.NoError(t, err)
+			err = actionRepo.Store(context.Background(), actionMockData)
+			assert.NoError(t, err)
+
+			storedAction, err := actionRepo.Get(context.Background(), &domain.GetActionRequest{Id: actionMockData.ID})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, storedAction.RetryMaxAttempts)
+			assert.Equal(t, 30, storedAction.RetryBackoff)
+			assert.Equal(t, []string{"TIMEOUT"}, storedAction.RetryErrors)
+
+			retry := &domain.ReleaseActionRetry{
+				ReleaseID:     mockData.ID,
+				ActionID:      actionMockData.ID,
+				MaxAttempts:   3,
+				NextAttemptAt: time.Now().Add(-1 * time.Minute),
+				LastError:     "connection refused",
+			}
+
+			// Execute
+			err = repo.StoreActionRetry(context.Background(), retry)
+			assert.NoError(t, err)
+			assert.NotEqual(t, int64(0), retry.ID)
+
+			// Verify
+			due, err := repo.FindDueActionRetries(context.Background(), time.Now(), 10)
+			assert.NoError(t, err)
+			assert.Len(t, due, 1)
+			assert.Equal(t, retry.ID, due[0].ID)
+			assert.Equal(t, "connection refused", due[0].LastError)
+
+			retry.Attempts = 1
+			retry.NextAttemptAt = time.Now().Add(1 * time.Hour)
+			err = repo.StoreActionRetry(context.Background(), retry)
+			assert.NoError(t, err)
+
+			due, err = repo.FindDueActionRetries(context.Background(), time.Now(), 10)
+			assert.NoError(t, err)
+			assert.Len(t, due, 0)
+
+			err = repo.DeleteActionRetry(context.Background(), retry.ID)
+			assert.NoError(t, err)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = actionRepo.Delete(context.Background(), &domain.DeleteActionRequest{ActionId: actionMockData.ID})
+			_ = filterRepo.Delete(context.Background(), createdFilters[0].ID)
+			_ = downloadClientRepo.Delete(context.Background(), mock.ID)
+		})
+	}
+}
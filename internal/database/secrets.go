@@ -0,0 +1,353 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package database
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// encryptedSecretPrefix marks values encrypted with the configured secret key
+const encryptedSecretPrefix = "enc:v1:"
+
+// secretKeyMinLength keeps short passwords from being used as key
+const secretKeyMinLength = 16
+
+// secretCipher encrypts secret columns with AES-GCM. A nil cipher stores
+// secrets in plain text.
+type secretCipher struct {
+	aead cipher.AEAD
+}
+
+func newSecretCipher(key string) (*secretCipher, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	if len(key) < secretKeyMinLength {
+		return nil, errors.New("secret key must be at least %d characters", secretKeyMinLength)
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create secret cipher")
+	}
+
+	aead, err := cipher.NewGCMWithRandomNonce(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create secret cipher")
+	}
+
+	return &secretCipher{aead: aead}, nil
+}
+
+// encrypt returns the encrypted value. Empty values and env: or file:
+// references hold no secret and are stored as is.
+func (c *secretCipher) encrypt(value string) string {
+	if c == nil || value == "" || domain.IsSecretReference(value) || strings.HasPrefix(value, encryptedSecretPrefix) {
+		return value
+	}
+
+	sealed := c.aead.Seal(nil, nil, []byte(value), nil)
+
+	return encryptedSecretPrefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+// decrypt returns the plain text of an encrypted value. Values stored before
+// encryption was enabled are returned unchanged.
+func (c *secretCipher) decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedSecretPrefix) {
+		return value, nil
+	}
+
+	if c == nil {
+		return "", errors.New("secret is encrypted but no secret key is configured")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedSecretPrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "could not decode secret")
+	}
+
+	plain, err := c.aead.Open(nil, nil, sealed, nil)
+	if err != nil {
+		return "", errors.New("could not decrypt secret, the secret key does not match")
+	}
+
+	return string(plain), nil
+}
+
+func (db *DB) encryptSecret(value string) string {
+	return db.secrets.encrypt(value)
+}
+
+// decryptSecrets decrypts the values in place
+func (db *DB) decryptSecrets(values ...*string) error {
+	for _, v := range values {
+		plain, err := db.secrets.decrypt(*v)
+		if err != nil {
+			return err
+		}
+
+		*v = plain
+	}
+
+	return nil
+}
+
+// encryptClientSettings returns a copy of the settings with the secrets encrypted
+func (db *DB) encryptClientSettings(settings domain.DownloadClientSettings) domain.DownloadClientSettings {
+	settings.APIKey = db.encryptSecret(settings.APIKey)
+	settings.Auth.Password = db.encryptSecret(settings.Auth.Password)
+	settings.Basic.Password = db.encryptSecret(settings.Basic.Password)
+
+	return settings
+}
+
+// decryptClient decrypts the password and settings secrets of a download client in place
+func (db *DB) decryptClient(client *domain.DownloadClient) error {
+	if err := db.decryptSecrets(&client.Password, &client.Settings.APIKey, &client.Settings.Auth.Password, &client.Settings.Basic.Password); err != nil {
+		return errors.Wrap(err, "could not decrypt secrets of download client: %s", client.Name)
+	}
+
+	return nil
+}
+
+// encryptIndexerSettings returns a copy of the settings with the secret settings encrypted
+func (db *DB) encryptIndexerSettings(settings map[string]string) map[string]string {
+	if settings == nil {
+		return nil
+	}
+
+	encrypted := make(map[string]string, len(settings))
+	for key, value := range settings {
+		if domain.IsIndexerSecretSetting(key) {
+			value = db.encryptSecret(value)
+		}
+		encrypted[key] = value
+	}
+
+	return encrypted
+}
+
+func (db *DB) decryptIndexerSettings(settings map[string]string) error {
+	for key, value := range settings {
+		plain, err := db.secrets.decrypt(value)
+		if err != nil {
+			return errors.Wrap(err, "could not decrypt indexer setting: %s", key)
+		}
+		settings[key] = plain
+	}
+
+	return nil
+}
+
+// secretColumn is a column holding secrets. Document columns store json
+// with the secrets inside and are rewritten through convert.
+type secretColumn struct {
+	table   string
+	column  string
+	convert func(value string, fn func(string) (string, error)) (string, error)
+}
+
+var secretColumns = []secretColumn{
+	{table: "client", column: "password"},
+	{table: "client", column: "settings", convert: convertClientSettings},
+	{table: "feed", column: "api_key"},
+	{table: "feed", column: "cookie"},
+	{table: "indexer", column: "settings", convert: convertIndexerSettings},
+	{table: "irc_network", column: "pass"},
+	{table: "irc_network", column: "auth_password"},
+	{table: "irc_channel", column: "password"},
+	{table: "list", column: "api_key"},
+	{table: "notification", column: "token"},
+	{table: "notification", column: "api_key"},
+	{table: "notification", column: "password"},
+	{table: "proxy", column: "auth_pass"},
+}
+
+func convertClientSettings(value string, fn func(string) (string, error)) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	var settings domain.DownloadClientSettings
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal download client settings")
+	}
+
+	for _, v := range []*string{&settings.APIKey, &settings.Auth.Password, &settings.Basic.Password} {
+		converted, err := fn(*v)
+		if err != nil {
+			return "", err
+		}
+		*v = converted
+	}
+
+	data, err := json.Marshal(&settings)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal download client settings")
+	}
+
+	return string(data), nil
+}
+
+func convertIndexerSettings(value string, fn func(string) (string, error)) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	settings := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal indexer settings")
+	}
+
+	for key, v := range settings {
+		if !domain.IsIndexerSecretSetting(key) {
+			continue
+		}
+
+		converted, err := fn(v)
+		if err != nil {
+			return "", errors.Wrap(err, "setting %s", key)
+		}
+		settings[key] = converted
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal indexer settings")
+	}
+
+	return string(data), nil
+}
+
+// RotateSecretKey re-encrypts all secret columns with newKey. Rows stored in
+// plain text are encrypted too, an empty newKey decrypts everything.
+// It returns the number of rows that were rewritten.
+func (db *DB) RotateSecretKey(ctx context.Context, newKey string) (int, error) {
+	next, err := newSecretCipher(newKey)
+	if err != nil {
+		return 0, err
+	}
+
+	rotate := func(value string) (string, error) {
+		plain, err := db.secrets.decrypt(value)
+		if err != nil {
+			return "", err
+		}
+
+		return next.encrypt(plain), nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "error begin transaction")
+	}
+
+	defer tx.Rollback()
+
+	updated := 0
+
+	for _, col := range secretColumns {
+		values, err := db.secretColumnValues(ctx, tx, col)
+		if err != nil {
+			return 0, err
+		}
+
+		for id, value := range values {
+			var rotated string
+			if col.convert != nil {
+				rotated, err = col.convert(value, rotate)
+			} else {
+				rotated, err = rotate(value)
+			}
+			if err != nil {
+				return 0, errors.Wrap(err, "could not rotate %s.%s of row %d", col.table, col.column, id)
+			}
+
+			if rotated == value {
+				continue
+			}
+
+			query, args, err := db.squirrel.
+				Update(col.table).
+				Set(col.column, rotated).
+				Where(sq.Eq{"id": id}).
+				ToSql()
+			if err != nil {
+				return 0, errors.Wrap(err, "error building query")
+			}
+
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return 0, errors.Wrap(err, "error executing query")
+			}
+
+			updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "error commit rotating secret key")
+	}
+
+	db.secrets = next
+
+	return updated, nil
+}
+
+// secretColumnValues reads the non-empty values of a secret column by row id
+func (db *DB) secretColumnValues(ctx context.Context, tx *Tx, col secretColumn) (map[int64]string, error) {
+	queryBuilder := db.squirrel.
+		Select("id", col.column).
+		From(col.table).
+		Where(sq.NotEq{col.column: nil})
+
+	// json columns can not be compared to text on postgres
+	if col.convert == nil {
+		queryBuilder = queryBuilder.Where(sq.NotEq{col.column: ""})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	values := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var value string
+
+		if err := rows.Scan(&id, &value); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		values[id] = value
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error rows")
+	}
+
+	return values, nil
+}
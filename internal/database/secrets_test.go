@@ -0,0 +1,63 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretCipher(t *testing.T) {
+	t.Parallel()
+
+	c, err := newSecretCipher("0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	encrypted := c.encrypt("hunter2")
+	assert.True(t, strings.HasPrefix(encrypted, encryptedSecretPrefix))
+	assert.NotContains(t, encrypted, "hunter2")
+
+	// already encrypted values are not encrypted twice
+	assert.Equal(t, encrypted, c.encrypt(encrypted))
+
+	plain, err := c.decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", plain)
+
+	// empty values and references are stored as is
+	assert.Equal(t, "", c.encrypt(""))
+	assert.Equal(t, "env:QBIT_PASS", c.encrypt("env:QBIT_PASS"))
+	assert.Equal(t, "file:/run/secrets/qbit", c.encrypt("file:/run/secrets/qbit"))
+
+	// values stored before encryption was enabled
+	plain, err = c.decrypt("hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", plain)
+
+	other, err := newSecretCipher("fedcba9876543210fedcba9876543210")
+	require.NoError(t, err)
+
+	_, err = other.decrypt(encrypted)
+	assert.Error(t, err)
+
+	var disabled *secretCipher
+	assert.Equal(t, "hunter2", disabled.encrypt("hunter2"))
+
+	_, err = disabled.decrypt(encrypted)
+	assert.Error(t, err)
+}
+
+func TestNewSecretCipher(t *testing.T) {
+	t.Parallel()
+
+	c, err := newSecretCipher("")
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	_, err = newSecretCipher("short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/rs/zerolog"
+)
+
+type TorrentStatsRepo struct {
+	log zerolog.Logger
+	db  *DB
+}
+
+func NewTorrentStatsRepo(log logger.Logger, db *DB) domain.TorrentStatsRepo {
+	return &TorrentStatsRepo{
+		log: log.With().Str("repo", "torrent_stats").Logger(),
+		db:  db,
+	}
+}
+
+// Store adds a torrent to track. If the torrent is already tracked in the client it is tracked again with the new release.
+func (r *TorrentStatsRepo) Store(ctx context.Context, stats *domain.ReleaseTorrentStats) error {
+	queryBuilder := r.db.squirrel.
+		Insert("release_torrent_stats").
+		Columns(
+			"release_id",
+			"filter_id",
+			"indexer",
+			"client_id",
+			"torrent_name",
+			"torrent_hash",
+			"size",
+		).
+		Values(
+			toNullInt64(stats.ReleaseID),
+			toNullInt32(int32(stats.FilterID)),
+			stats.Indexer,
+			stats.ClientID,
+			stats.TorrentName,
+			stats.TorrentHash,
+			stats.Size,
+		).
+		Suffix("ON CONFLICT (client_id, torrent_hash) DO UPDATE SET release_id = excluded.release_id, filter_id = excluded.filter_id, removed = FALSE, updated_at = CURRENT_TIMESTAMP RETURNING id").
+		RunWith(r.db.Handler)
+
+	if err := queryBuilder.QueryRowContext(ctx).Scan(&stats.ID); err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	r.log.Debug().Msgf("torrent_stats.store: %s in client %d", stats.TorrentHash, stats.ClientID)
+
+	return nil
+}
+
+func (r *TorrentStatsRepo) Update(ctx context.Context, stats *domain.ReleaseTorrentStats) error {
+	queryBuilder := r.db.squirrel.
+		Update("release_torrent_stats").
+		Set("size", stats.Size).
+		Set("uploaded", stats.Uploaded).
+		Set("downloaded", stats.Downloaded).
+		Set("ratio", stats.Ratio).
+		Set("seed_time", stats.SeedTime).
+		Set("progress", stats.Progress).
+		Set("state", toNullString(stats.State)).
+		Set("completed_at", toNullTimeString(stats.CompletedAt)).
+		Set("removed", stats.Removed).
+		Set("last_checked_at", toNullTimeString(stats.LastCheckedAt)).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": stats.ID})
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query")
+	}
+
+	result, err := r.db.Handler.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "error executing query")
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return errors.Wrap(err, "error getting rows affected")
+	} else if rowsAffected == 0 {
+		return domain.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// FindActive returns the torrents that are still in a client
+func (r *TorrentStatsRepo) FindActive(ctx context.Context) ([]*domain.ReleaseTorrentStats, error) {
+	queryBuilder := r.selectStats().
+		Where(sq.Eq{"s.removed": false}).
+		Where(sq.NotEq{"s.client_id": nil}).
+		OrderBy("s.client_id", "s.id")
+
+	return r.findStats(ctx, queryBuilder)
+}
+
+func (r *TorrentStatsRepo) FindByReleaseID(ctx context.Context, releaseID int64) ([]*domain.ReleaseTorrentStats, error) {
+	queryBuilder := r.selectStats().
+		Where(sq.Eq{"s.release_id": releaseID}).
+		OrderBy("s.id")
+
+	return r.findStats(ctx, queryBuilder)
+}
+
+func (r *TorrentStatsRepo) selectStats() sq.SelectBuilder {
+	return r.db.squirrel.
+		Select(
+			"s.id",
+			"s.release_id",
+			"s.filter_id",
+			"s.indexer",
+			"s.client_id",
+			"c.type",
+			"s.torrent_name",
+			"s.torrent_hash",
+			"s.size",
+			"s.uploaded",
+			"s.downloaded",
+			"s.ratio",
+			"s.seed_time",
+			"s.progress",
+			"s.state",
+			"s.completed_at",
+			"s.removed",
+			"s.last_checked_at",
+			"s.created_at",
+			"s.updated_at",
+		).
+		From("release_torrent_stats s").
+		LeftJoin("client c ON c.id = s.client_id")
+}
+
+func (r *TorrentStatsRepo) findStats(ctx context.Context, queryBuilder sq.SelectBuilder) ([]*domain.ReleaseTorrentStats, error) {
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	items := make([]*domain.ReleaseTorrentStats, 0)
+	for rows.Next() {
+		var stats domain.ReleaseTorrentStats
+
+		var releaseID, filterID, clientID sql.NullInt64
+		var indexer, clientType, torrentName, state sql.NullString
+		var completedAt, lastCheckedAt sql.NullTime
+
+		if err := rows.Scan(
+			&stats.ID,
+			&releaseID,
+			&filterID,
+			&indexer,
+			&clientID,
+			&clientType,
+			&torrentName,
+			&stats.TorrentHash,
+			&stats.Size,
+			&stats.Uploaded,
+			&stats.Downloaded,
+			&stats.Ratio,
+			&stats.SeedTime,
+			&stats.Progress,
+			&state,
+			&completedAt,
+			&stats.Removed,
+			&lastCheckedAt,
+			&stats.CreatedAt,
+			&stats.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		stats.ReleaseID = releaseID.Int64
+		stats.FilterID = int(filterID.Int64)
+		stats.Indexer = indexer.String
+		stats.ClientID = int32(clientID.Int64)
+		stats.ClientType = domain.DownloadClientType(clientType.String)
+		stats.TorrentName = torrentName.String
+		stats.State = state.String
+
+		if completedAt.Valid {
+			stats.CompletedAt = &completedAt.Time
+		}
+
+		if lastCheckedAt.Valid {
+			stats.LastCheckedAt = &lastCheckedAt.Time
+		}
+
+		items = append(items, &stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row error")
+	}
+
+	return items, nil
+}
+
+func (r *TorrentStatsRepo) StatsByFilter(ctx context.Context) ([]*domain.TorrentStatsSummary, error) {
+	queryBuilder := r.selectSummary("s.filter_id", "f.name", "''").
+		Join("filter f ON f.id = s.filter_id").
+		GroupBy("s.filter_id", "f.name").
+		OrderBy("f.name")
+
+	return r.findSummary(ctx, queryBuilder)
+}
+
+func (r *TorrentStatsRepo) StatsByIndexer(ctx context.Context) ([]*domain.TorrentStatsSummary, error) {
+	queryBuilder := r.selectSummary("COALESCE(i.id, 0)", "COALESCE(i.name, s.indexer)", "s.indexer").
+		LeftJoin("indexer i ON i.identifier = s.indexer").
+		Where(sq.NotEq{"s.indexer": nil}).
+		GroupBy("s.indexer", "i.id", "i.name").
+		OrderBy("s.indexer")
+
+	return r.findSummary(ctx, queryBuilder)
+}
+
+func (r *TorrentStatsRepo) selectSummary(id, name, identifier string) sq.SelectBuilder {
+	return r.db.squirrel.
+		Select(
+			id,
+			name,
+			identifier,
+			"COUNT(*)",
+			"COALESCE(SUM(CASE WHEN s.completed_at IS NOT NULL THEN 1 ELSE 0 END), 0)",
+			"COALESCE(SUM(CASE WHEN s.removed THEN 1 ELSE 0 END), 0)",
+			"COALESCE(SUM(s.size), 0)",
+			"COALESCE(SUM(s.uploaded), 0)",
+			"COALESCE(SUM(s.downloaded), 0)",
+			"COALESCE(SUM(s.seed_time), 0)",
+		).
+		From("release_torrent_stats s")
+}
+
+func (r *TorrentStatsRepo) findSummary(ctx context.Context, queryBuilder sq.SelectBuilder) ([]*domain.TorrentStatsSummary, error) {
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	items := make([]*domain.TorrentStatsSummary, 0)
+	for rows.Next() {
+		var summary domain.TorrentStatsSummary
+
+		if err := rows.Scan(
+			&summary.ID,
+			&summary.Name,
+			&summary.Identifier,
+			&summary.Torrents,
+			&summary.Completed,
+			&summary.Removed,
+			&summary.Size,
+			&summary.Uploaded,
+			&summary.Downloaded,
+			&summary.SeedTime,
+		); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		summary.Calculate()
+
+		items = append(items, &summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row error")
+	}
+
+	return items, nil
+}
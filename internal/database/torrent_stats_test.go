@@ -0,0 +1,136 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build integration
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTorrentStatsRepo(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		downloadClientRepo := NewDownloadClientRepo(log, db)
+		filterRepo := NewFilterRepo(log, db)
+		releaseRepo := NewReleaseRepo(log, db)
+		repo := NewTorrentStatsRepo(log, db)
+
+		t.Run(fmt.Sprintf("StoreUpdateSummary_Succeeds [%s]", dbType), func(t *testing.T) {
+			ctx := context.Background()
+
+			// Setup
+			client := getMockDownloadClient()
+			err := downloadClientRepo.Store(ctx, &client)
+			require.NoError(t, err)
+
+			err = filterRepo.Store(ctx, getMockFilter())
+			require.NoError(t, err)
+
+			createdFilters, err := filterRepo.ListFilters(ctx)
+			require.NoError(t, err)
+			require.NotEmpty(t, createdFilters)
+
+			release := getMockRelease()
+			release.FilterID = createdFilters[0].ID
+			err = releaseRepo.Store(ctx, release)
+			require.NoError(t, err)
+
+			first := &domain.ReleaseTorrentStats{
+				ReleaseID:   release.ID,
+				FilterID:    release.FilterID,
+				Indexer:     release.Indexer.Identifier,
+				ClientID:    client.ID,
+				TorrentName: release.TorrentName,
+				TorrentHash: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Size:        1000,
+			}
+			second := &domain.ReleaseTorrentStats{
+				ReleaseID:   release.ID,
+				FilterID:    release.FilterID,
+				Indexer:     release.Indexer.Identifier,
+				ClientID:    client.ID,
+				TorrentName: release.TorrentName,
+				TorrentHash: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+				Size:        3000,
+			}
+
+			// Execute
+			require.NoError(t, repo.Store(ctx, first))
+			require.NoError(t, repo.Store(ctx, second))
+			assert.NotEqual(t, int64(0), first.ID)
+
+			active, err := repo.FindActive(ctx)
+			require.NoError(t, err)
+			require.Len(t, active, 2)
+			assert.Equal(t, domain.DownloadClientTypeQbittorrent, active[0].ClientType)
+			assert.Nil(t, active[0].CompletedAt)
+
+			now := time.Now()
+			completed := now.Add(-2 * time.Hour)
+
+			first.Apply(&domain.TorrentClientStats{Uploaded: 2500, Downloaded: 1000, Ratio: 2.5, SeedTime: 7200, Progress: 1, State: "uploading", CompletedAt: &completed}, now)
+			require.NoError(t, repo.Update(ctx, first))
+
+			second.Apply(nil, now)
+			require.NoError(t, repo.Update(ctx, second))
+
+			// Verify
+			active, err = repo.FindActive(ctx)
+			require.NoError(t, err)
+			require.Len(t, active, 1)
+			assert.Equal(t, first.ID, active[0].ID)
+			assert.Equal(t, int64(2500), active[0].Uploaded)
+			assert.Equal(t, int64(7200), active[0].SeedTime)
+			assert.Equal(t, "uploading", active[0].State)
+			require.NotNil(t, active[0].CompletedAt)
+			assert.WithinDuration(t, completed, *active[0].CompletedAt, time.Second)
+			assert.NotNil(t, active[0].LastCheckedAt)
+
+			byRelease, err := repo.FindByReleaseID(ctx, release.ID)
+			require.NoError(t, err)
+			assert.Len(t, byRelease, 2)
+
+			byFilter, err := repo.StatsByFilter(ctx)
+			require.NoError(t, err)
+			require.Len(t, byFilter, 1)
+			assert.Equal(t, int64(createdFilters[0].ID), byFilter[0].ID)
+			assert.Equal(t, createdFilters[0].Name, byFilter[0].Name)
+			assert.Equal(t, 2, byFilter[0].Torrents)
+			assert.Equal(t, 1, byFilter[0].Completed)
+			assert.Equal(t, 1, byFilter[0].Removed)
+			assert.Equal(t, int64(2500), byFilter[0].Uploaded)
+			assert.Equal(t, int64(3600), byFilter[0].AvgSeedTime)
+
+			byIndexer, err := repo.StatsByIndexer(ctx)
+			require.NoError(t, err)
+			require.Len(t, byIndexer, 1)
+			assert.Equal(t, "btn", byIndexer[0].Identifier)
+			assert.Equal(t, 2, byIndexer[0].Torrents)
+
+			// pushing the same torrent again tracks it again
+			second.Removed = false
+			require.NoError(t, repo.Store(ctx, second))
+
+			active, err = repo.FindActive(ctx)
+			require.NoError(t, err)
+			assert.Len(t, active, 2)
+
+			// Cleanup
+			_ = releaseRepo.Delete(ctx, &domain.DeleteReleaseRequest{OlderThan: 0})
+			_ = filterRepo.Delete(ctx, createdFilters[0].ID)
+			_ = downloadClientRepo.Delete(ctx, client.ID)
+			_, _ = db.Handler.ExecContext(ctx, "DELETE FROM release_torrent_stats")
+		})
+	}
+}
@@ -48,9 +48,45 @@ func (r *UserRepo) GetUserCount(ctx context.Context) (int, error) {
 	return result, nil
 }
 
+func (r *UserRepo) List(ctx context.Context) ([]domain.User, error) {
+	queryBuilder := r.db.squirrel.
+		Select("id", "username", "role", "created_at").
+		From("users").
+		OrderBy("username")
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query")
+	}
+
+	rows, err := r.db.Handler.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing query")
+	}
+
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		var user domain.User
+
+		if err := rows.Scan(&user.ID, &user.Username, &user.Role, &user.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "error scanning row")
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error rows list users")
+	}
+
+	return users, nil
+}
+
 func (r *UserRepo) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
 	queryBuilder := r.db.squirrel.
-		Select("id", "username", "password").
+		Select("id", "username", "password", "role", "created_at").
 		From("users").
 		Where(sq.Eq{"username": username})
 
@@ -66,7 +102,7 @@ func (r *UserRepo) FindByUsername(ctx context.Context, username string) (*domain
 
 	var user domain.User
 
-	if err := row.Scan(&user.ID, &user.Username, &user.Password); err != nil {
+	if err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Role, &user.CreatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrRecordNotFound
 		}
@@ -80,8 +116,8 @@ func (r *UserRepo) FindByUsername(ctx context.Context, username string) (*domain
 func (r *UserRepo) Store(ctx context.Context, req domain.CreateUserRequest) error {
 	queryBuilder := r.db.squirrel.
 		Insert("users").
-		Columns("username", "password").
-		Values(req.Username, req.Password)
+		Columns("username", "password", "role").
+		Values(req.Username, req.Password, req.Role)
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -107,6 +143,10 @@ func (r *UserRepo) Update(ctx context.Context, user domain.UpdateUserRequest) er
 		queryBuilder = queryBuilder.Set("password", user.PasswordNewHash)
 	}
 
+	if user.Role != "" {
+		queryBuilder = queryBuilder.Set("role", user.Role)
+	}
+
 	queryBuilder = queryBuilder.Where(sq.Eq{"username": user.UsernameCurrent})
 
 	query, args, err := queryBuilder.ToSql()
@@ -20,6 +20,7 @@ func getMockUser() domain.User {
 		ID:       0,
 		Username: "AkenoHimejima",
 		Password: "password",
+		Role:     domain.UserRoleEditor,
 	}
 }
 
@@ -128,6 +129,7 @@ func TestUserRepo_FindByUsername(t *testing.T) {
 			err := repo.Store(context.Background(), domain.CreateUserRequest{
 				Username: userMockData.Username,
 				Password: userMockData.Password,
+				Role:     userMockData.Role,
 			})
 			assert.NoError(t, err)
 
@@ -136,6 +138,7 @@ func TestUserRepo_FindByUsername(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, user)
 			assert.Equal(t, userMockData.Username, user.Username)
+			assert.Equal(t, userMockData.Role, user.Role)
 
 			// Cleanup
 			_ = repo.Delete(context.Background(), userMockData.Username)
@@ -143,6 +146,50 @@ func TestUserRepo_FindByUsername(t *testing.T) {
 	}
 }
 
+func TestUserRepo_List(t *testing.T) {
+	for dbType, db := range testDBs {
+		log := setupLoggerForTest()
+
+		repo := NewUserRepo(log, db)
+
+		t.Run(fmt.Sprintf("List_Succeeds [%s]", dbType), func(t *testing.T) {
+			// Setup
+			for _, user := range []domain.CreateUserRequest{
+				{Username: "viewer", Password: "password", Role: domain.UserRoleViewer},
+				{Username: "admin", Password: "password", Role: domain.UserRoleAdmin},
+			} {
+				err := repo.Store(context.Background(), user)
+				assert.NoError(t, err)
+			}
+
+			// Execute
+			users, err := repo.List(context.Background())
+			assert.NoError(t, err)
+
+			// Verify
+			assert.Len(t, users, 2)
+			assert.Equal(t, "admin", users[0].Username)
+			assert.Equal(t, domain.UserRoleAdmin, users[0].Role)
+			assert.Equal(t, "viewer", users[1].Username)
+			assert.Equal(t, domain.UserRoleViewer, users[1].Role)
+			assert.Empty(t, users[1].Password)
+
+			// Update role
+			err = repo.Update(context.Background(), domain.UpdateUserRequest{UsernameCurrent: "viewer", Role: domain.UserRoleEditor})
+			assert.NoError(t, err)
+
+			user, err := repo.FindByUsername(context.Background(), "viewer")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.UserRoleEditor, user.Role)
+			assert.Equal(t, "password", user.Password)
+
+			// Cleanup
+			_ = repo.Delete(context.Background(), "viewer")
+			_ = repo.Delete(context.Background(), "admin")
+		})
+	}
+}
+
 func TestUserRepo_Delete(t *testing.T) {
 	for dbType, db := range testDBs {
 		log := setupLoggerForTest()
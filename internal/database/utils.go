@@ -6,6 +6,7 @@ package database
 import (
 	"database/sql"
 	"path"
+	"time"
 )
 
 func dataSourceName(configPath string, name string) string {
@@ -43,3 +44,15 @@ func toNullFloat64(s float64) sql.NullFloat64 {
 		Valid:   s != 0,
 	}
 }
+
+// toNullTimeString formats times as RFC3339 in UTC so sqlite can compare them with datetime()
+func toNullTimeString(t *time.Time) sql.Null[string] {
+	if t == nil || t.IsZero() {
+		return sql.Null[string]{}
+	}
+
+	return sql.Null[string]{
+		V:     t.UTC().Format(time.RFC3339),
+		Valid: true,
+	}
+}
@@ -48,6 +48,9 @@ type Action struct {
 	ReAnnounceDelete         bool                `json:"reannounce_delete,omitempty"`
 	ReAnnounceInterval       int64               `json:"reannounce_interval,omitempty"`
 	ReAnnounceMaxAttempts    int64               `json:"reannounce_max_attempts,omitempty"`
+	RetryMaxAttempts         int                 `json:"retry_max_attempts,omitempty"`
+	RetryBackoff             int                 `json:"retry_backoff,omitempty"`
+	RetryErrors              []string            `json:"retry_errors,omitempty"`
 	WebhookHost              string              `json:"webhook_host,omitempty"`
 	WebhookType              string              `json:"webhook_type,omitempty"`
 	WebhookMethod            string              `json:"webhook_method,omitempty"`
@@ -57,6 +60,7 @@ type Action struct {
 	ExternalDownloadClient   string              `json:"external_download_client,omitempty"`
 	FilterID                 int                 `json:"filter_id,omitempty"`
 	ClientID                 int32               `json:"client_id,omitempty"`
+	FailoverClientIDs        []int32             `json:"failover_client_ids,omitempty"`
 	Client                   *DownloadClient     `json:"client,omitempty"`
 }
 
@@ -159,6 +163,16 @@ const (
 	ActionTypeNzbget       ActionType = "NZBGET"
 )
 
+// SupportsTorrentStats reports if the client of the action can be polled for upload, ratio and seed time
+func (a ActionType) SupportsTorrentStats() bool {
+	switch a {
+	case ActionTypeQbittorrent, ActionTypeDelugeV1, ActionTypeDelugeV2, ActionTypeTransmission, ActionTypePorla:
+		return true
+	default:
+		return false
+	}
+}
+
 type ActionContentLayout string
 
 const (
@@ -0,0 +1,101 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// HasFailoverClients returns true if the action has download clients to fall back to
+func (a *Action) HasFailoverClients() bool {
+	return len(a.FailoverClientIDs) > 0
+}
+
+// ClientGroup returns the download clients of the action in the order they should be tried.
+// The main client is always first, followed by the failover clients.
+func (a *Action) ClientGroup() []int32 {
+	group := make([]int32, 0, len(a.FailoverClientIDs)+1)
+
+	if a.ClientID > 0 {
+		group = append(group, a.ClientID)
+	}
+
+	for _, id := range a.FailoverClientIDs {
+		if id <= 0 || slices.Contains(group, id) {
+			continue
+		}
+		group = append(group, id)
+	}
+
+	return group
+}
+
+// ValidateFailover checks the failover clients of the action
+func (a *Action) ValidateFailover() error {
+	if !a.HasFailoverClients() {
+		return nil
+	}
+
+	switch a.Type {
+	case ActionTypeTest, ActionTypeExec, ActionTypeWatchFolder, ActionTypeWebhook:
+		return errors.New("failover clients not supported for action type: %s", a.Type)
+	}
+
+	if a.ClientID == 0 {
+		return errors.New("failover clients require a download client")
+	}
+
+	seen := map[int32]struct{}{a.ClientID: {}}
+	for _, id := range a.FailoverClientIDs {
+		if id <= 0 {
+			return errors.New("invalid failover client id: %d", id)
+		}
+
+		if _, ok := seen[id]; ok {
+			return errors.New("duplicate failover client id: %d", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// ActionRejectionMaxActiveDownloads is the rejection of the download client rules when the client has too many active downloads
+const ActionRejectionMaxActiveDownloads = "max active downloads reached, skipping"
+
+// clientRuleRejections are the starts of the rejections of the download client rules.
+// Other rejections, like an *arr not wanting the release, are the same for every client.
+var clientRuleRejections = []string{
+	ActionRejectionMaxActiveDownloads,
+	"total download speed",
+	"total upload speed",
+}
+
+// IsClientRuleRejection returns true if the rejection is from the rules of the download client
+func IsClientRuleRejection(rejection string) bool {
+	for _, prefix := range clientRuleRejections {
+		if strings.HasPrefix(rejection, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsActionClientFailover checks if the next client in the group should be tried.
+// Rejections by the client rules and unreachable clients fall through, other rejections and errors do not.
+func IsActionClientFailover(rejections []string, err error) bool {
+	if err == nil {
+		return slices.ContainsFunc(rejections, IsClientRuleRejection)
+	}
+
+	if errors.Is(err, ErrRecordNotFound) {
+		return true
+	}
+
+	return ClassifyActionError(err) != ""
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAction_ClientGroup(t *testing.T) {
+	t.Parallel()
+
+	a := &Action{ClientID: 1, FailoverClientIDs: []int32{3, 1, 0, 2, 3}}
+	assert.Equal(t, []int32{1, 3, 2}, a.ClientGroup())
+
+	a = &Action{ClientID: 1}
+	assert.Equal(t, []int32{1}, a.ClientGroup())
+}
+
+func TestAction_ValidateFailover(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		action  Action
+		wantErr bool
+	}{
+		{name: "no_failover", action: Action{Type: ActionTypeExec}},
+		{name: "valid", action: Action{Type: ActionTypeQbittorrent, ClientID: 1, FailoverClientIDs: []int32{2, 3}}},
+		{name: "unsupported_type", action: Action{Type: ActionTypeWebhook, ClientID: 1, FailoverClientIDs: []int32{2}}, wantErr: true},
+		{name: "no_main_client", action: Action{Type: ActionTypeQbittorrent, FailoverClientIDs: []int32{2}}, wantErr: true},
+		{name: "main_client_in_failover", action: Action{Type: ActionTypeQbittorrent, ClientID: 1, FailoverClientIDs: []int32{1}}, wantErr: true},
+		{name: "duplicate", action: Action{Type: ActionTypeQbittorrent, ClientID: 1, FailoverClientIDs: []int32{2, 2}}, wantErr: true},
+		{name: "invalid_id", action: Action{Type: ActionTypeQbittorrent, ClientID: 1, FailoverClientIDs: []int32{-1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.action.ValidateFailover()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIsActionClientFailover(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsActionClientFailover(nil, nil))
+	assert.True(t, IsActionClientFailover([]string{ActionRejectionMaxActiveDownloads}, nil))
+	assert.True(t, IsActionClientFailover([]string{"total download speed (12000) above threshold: (10000), skipping"}, nil))
+	// *arr rejections are the same on every client
+	assert.False(t, IsActionClientFailover([]string{"Not an upgrade for existing movie file(s)"}, nil))
+	assert.False(t, IsActionClientFailover([]string{"Unknown Series"}, nil))
+	assert.True(t, IsActionClientFailover(nil, errors.New("dial tcp 127.0.0.1:8080: connect: connection refused")))
+	assert.True(t, IsActionClientFailover(nil, errors.Wrap(ErrRecordNotFound, "could not get client")))
+	assert.False(t, IsActionClientFailover(nil, errors.New("could not unmarshal data")))
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"context"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+const (
+	// ActionRetryDefaultBackoff is the wait in seconds before the first retry if the action has none set
+	ActionRetryDefaultBackoff = 60
+	ActionRetryMaxBackoff     = 6 * time.Hour
+)
+
+// ActionRetryError is the kind of error an action can be retried on
+type ActionRetryError string
+
+const (
+	ActionRetryErrorTimeout    ActionRetryError = "TIMEOUT"
+	ActionRetryErrorConnection ActionRetryError = "CONNECTION"
+	ActionRetryErrorAny        ActionRetryError = "ANY"
+)
+
+// ClassifyActionError returns the retry error kind of err or an empty string if it's neither a timeout nor connection error.
+// Some clients don't wrap the underlying error so the message is checked as a fallback.
+func ClassifyActionError(err error) ActionRetryError {
+	if err == nil {
+		return ""
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ActionRetryErrorTimeout
+	}
+
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return ActionRetryErrorConnection
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, s := range []string{"timeout", "timed out", "deadline exceeded"} {
+		if strings.Contains(msg, s) {
+			return ActionRetryErrorTimeout
+		}
+	}
+
+	for _, s := range []string{"connection refused", "connection reset", "no such host", "network is unreachable", "unexpected eof", "broken pipe"} {
+		if strings.Contains(msg, s) {
+			return ActionRetryErrorConnection
+		}
+	}
+
+	return ""
+}
+
+// RetryEnabled returns true if failed pushes of the action should be retried
+func (a *Action) RetryEnabled() bool {
+	return a.RetryMaxAttempts > 0
+}
+
+// IsRetryableError checks err against the retry errors of the action.
+// Timeouts and connection errors are retried if no retry errors are set.
+func (a *Action) IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	retryErrors := a.RetryErrors
+	if len(retryErrors) == 0 {
+		retryErrors = []string{string(ActionRetryErrorTimeout), string(ActionRetryErrorConnection)}
+	}
+
+	if slices.Contains(retryErrors, string(ActionRetryErrorAny)) {
+		return true
+	}
+
+	kind := ClassifyActionError(err)
+	if kind == "" {
+		return false
+	}
+
+	return slices.Contains(retryErrors, string(kind))
+}
+
+// RetryBackoffDuration returns the wait before the next attempt.
+// The backoff doubles for each attempt made and is capped at ActionRetryMaxBackoff.
+func (a *Action) RetryBackoffDuration(attempts int) time.Duration {
+	backoff := a.RetryBackoff
+	if backoff <= 0 {
+		backoff = ActionRetryDefaultBackoff
+	}
+
+	wait := time.Duration(backoff) * time.Second
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= ActionRetryMaxBackoff {
+			return ActionRetryMaxBackoff
+		}
+	}
+
+	return wait
+}
+
+// ValidateRetry checks the retry policy of the action
+func (a *Action) ValidateRetry() error {
+	if a.RetryMaxAttempts < 0 {
+		return errors.New("retry_max_attempts must be 0 or more")
+	}
+
+	if a.RetryBackoff < 0 {
+		return errors.New("retry_backoff must be 0 or more")
+	}
+
+	for _, e := range a.RetryErrors {
+		switch ActionRetryError(e) {
+		case ActionRetryErrorTimeout, ActionRetryErrorConnection, ActionRetryErrorAny:
+		default:
+			return errors.New("invalid retry error: %s", e)
+		}
+	}
+
+	return nil
+}
+
+// ReleaseActionRetry is a pending retry of a failed action push
+type ReleaseActionRetry struct {
+	ID             int64     `json:"id"`
+	ReleaseID      int64     `json:"release_id"`
+	ActionID       int       `json:"action_id"`
+	ActionStatusID int64     `json:"action_status_id"`
+	Attempts       int       `json:"attempts"`
+	MaxAttempts    int       `json:"max_attempts"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	LastError      string    `json:"last_error"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ReleaseActionRetryRepo interface {
+	StoreActionRetry(ctx context.Context, retry *ReleaseActionRetry) error
+	FindDueActionRetries(ctx context.Context, now time.Time, limit uint64) ([]*ReleaseActionRetry, error)
+	DeleteActionRetry(ctx context.Context, id int64) error
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyActionError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want ActionRetryError
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "deadline", err: errors.Wrap(context.DeadlineExceeded, "could not add torrent"), want: ActionRetryErrorTimeout},
+		{name: "net_op", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, want: ActionRetryErrorConnection},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "qbit.local"}, want: ActionRetryErrorConnection},
+		{name: "message_timeout", err: errors.New("login error: Client.Timeout exceeded while awaiting headers"), want: ActionRetryErrorTimeout},
+		{name: "message_connection", err: errors.New("dial tcp 127.0.0.1:8080: connect: connection refused"), want: ActionRetryErrorConnection},
+		{name: "other", err: errors.New("unsupported action type"), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyActionError(tt.err))
+		})
+	}
+}
+
+func TestAction_IsRetryableError(t *testing.T) {
+	t.Parallel()
+
+	timeoutErr := errors.Wrap(context.DeadlineExceeded, "timeout")
+	otherErr := errors.New("bad category")
+
+	defaults := &Action{RetryMaxAttempts: 3}
+	assert.True(t, defaults.IsRetryableError(timeoutErr))
+	assert.False(t, defaults.IsRetryableError(otherErr))
+	assert.False(t, defaults.IsRetryableError(nil))
+
+	connectionOnly := &Action{RetryMaxAttempts: 3, RetryErrors: []string{string(ActionRetryErrorConnection)}}
+	assert.False(t, connectionOnly.IsRetryableError(timeoutErr))
+
+	anyErr := &Action{RetryMaxAttempts: 3, RetryErrors: []string{string(ActionRetryErrorAny)}}
+	assert.True(t, anyErr.IsRetryableError(otherErr))
+}
+
+func TestAction_RetryBackoffDuration(t *testing.T) {
+	t.Parallel()
+
+	a := &Action{RetryBackoff: 30}
+	assert.Equal(t, 30*time.Second, a.RetryBackoffDuration(1))
+	assert.Equal(t, 60*time.Second, a.RetryBackoffDuration(2))
+	assert.Equal(t, 120*time.Second, a.RetryBackoffDuration(3))
+	assert.Equal(t, ActionRetryMaxBackoff, a.RetryBackoffDuration(20))
+
+	defaults := &Action{}
+	assert.Equal(t, ActionRetryDefaultBackoff*time.Second, defaults.RetryBackoffDuration(1))
+}
+
+func TestAction_ValidateRetry(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&Action{RetryMaxAttempts: 3, RetryErrors: []string{"TIMEOUT", "CONNECTION"}}).ValidateRetry())
+	assert.Error(t, (&Action{RetryMaxAttempts: -1}).ValidateRetry())
+	assert.Error(t, (&Action{RetryBackoff: -1}).ValidateRetry())
+	assert.Error(t, (&Action{RetryErrors: []string{"SOMETIMES"}}).ValidateRetry())
+}
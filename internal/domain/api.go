@@ -5,7 +5,11 @@ package domain
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
 )
 
 type APIRepo interface {
@@ -22,6 +26,97 @@ type APIKey struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// API key scopes. Each group of routes requires a read scope for safe methods
+// and a write scope for everything else. A write scope implies its read scope.
+const (
+	APIKeyScopeAdmin         = "admin"
+	APIKeyScopeFiltersRead   = "filters:read"
+	APIKeyScopeFiltersWrite  = "filters:write"
+	APIKeyScopeReleasesRead  = "releases:read"
+	APIKeyScopeReleasesWrite = "releases:write"
+	APIKeyScopeReleasesPush  = "releases:push"
+	APIKeyScopeIRCRead       = "irc:read"
+	APIKeyScopeIRCAdmin      = "irc:admin"
+	APIKeyScopeConfigRead    = "config:read"
+	APIKeyScopeConfigWrite   = "config:write"
+	APIKeyScopeListsRefresh  = "lists:refresh"
+)
+
+// APIKeyScopes is the list of all valid scopes.
+var APIKeyScopes = []string{
+	APIKeyScopeAdmin,
+	APIKeyScopeFiltersRead,
+	APIKeyScopeFiltersWrite,
+	APIKeyScopeReleasesRead,
+	APIKeyScopeReleasesWrite,
+	APIKeyScopeReleasesPush,
+	APIKeyScopeIRCRead,
+	APIKeyScopeIRCAdmin,
+	APIKeyScopeConfigRead,
+	APIKeyScopeConfigWrite,
+	APIKeyScopeListsRefresh,
+}
+
+// apiKeyScopeImplies maps a scope to the scopes it grants in addition to itself.
+var apiKeyScopeImplies = map[string][]string{
+	APIKeyScopeFiltersWrite:  {APIKeyScopeFiltersRead},
+	APIKeyScopeReleasesWrite: {APIKeyScopeReleasesRead, APIKeyScopeReleasesPush},
+	APIKeyScopeIRCAdmin:      {APIKeyScopeIRCRead},
+	APIKeyScopeConfigWrite:   {APIKeyScopeConfigRead, APIKeyScopeListsRefresh},
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	return slices.Contains(APIKeyScopes, scope)
+}
+
+// HasScope reports whether the key grants the scope, either directly, through
+// a scope that implies it, or through admin.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == APIKeyScopeAdmin || s == scope {
+			return true
+		}
+
+		if slices.Contains(apiKeyScopeImplies[s], scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type apiKeyContextKey struct{}
+
+// ContextWithAPIKey stores the API key that authenticated a request
+func ContextWithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext returns the API key that authenticated the request, or nil for session requests
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey)
+	return key
+}
+
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return errors.New("validation error: name is required")
+	}
+
+	var invalid []string
+	for _, scope := range k.Scopes {
+		if !IsValidAPIKeyScope(scope) {
+			invalid = append(invalid, scope)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return errors.New("validation error: invalid scopes: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
 const RedactedStr = "<redacted>"
 
 func RedactString(s string) string {
@@ -29,6 +124,11 @@ func RedactString(s string) string {
 		return ""
 	}
 
+	// references hold no secret and show where the value comes from
+	if IsSecretReference(s) {
+		return s
+	}
+
 	return RedactedStr
 }
 
@@ -0,0 +1,61 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey_HasScope(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{name: "no_scopes", scopes: []string{}, scope: APIKeyScopeFiltersRead, want: false},
+		{name: "admin", scopes: []string{APIKeyScopeAdmin}, scope: APIKeyScopeConfigWrite, want: true},
+		{name: "exact", scopes: []string{APIKeyScopeFiltersRead}, scope: APIKeyScopeFiltersRead, want: true},
+		{name: "read_does_not_grant_write", scopes: []string{APIKeyScopeFiltersRead}, scope: APIKeyScopeFiltersWrite, want: false},
+		{name: "write_grants_read", scopes: []string{APIKeyScopeFiltersWrite}, scope: APIKeyScopeFiltersRead, want: true},
+		{name: "irc_admin_grants_read", scopes: []string{APIKeyScopeIRCAdmin}, scope: APIKeyScopeIRCRead, want: true},
+		{name: "releases_write_grants_push", scopes: []string{APIKeyScopeReleasesWrite}, scope: APIKeyScopeReleasesPush, want: true},
+		{name: "push_does_not_grant_read", scopes: []string{APIKeyScopeReleasesPush}, scope: APIKeyScopeReleasesRead, want: false},
+		{name: "other_resource", scopes: []string{APIKeyScopeFiltersWrite}, scope: APIKeyScopeConfigRead, want: false},
+		{name: "multiple", scopes: []string{APIKeyScopeFiltersRead, APIKeyScopeReleasesPush}, scope: APIKeyScopeReleasesPush, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &APIKey{Name: "test", Scopes: tt.scopes}
+			assert.Equal(t, tt.want, k.HasScope(tt.scope))
+		})
+	}
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		key     APIKey
+		wantErr bool
+	}{
+		{name: "valid", key: APIKey{Name: "sonarr", Scopes: []string{APIKeyScopeReleasesPush}}, wantErr: false},
+		{name: "valid_no_scopes", key: APIKey{Name: "sonarr", Scopes: []string{}}, wantErr: false},
+		{name: "missing_name", key: APIKey{Scopes: []string{APIKeyScopeAdmin}}, wantErr: true},
+		{name: "invalid_scope", key: APIKey{Name: "sonarr", Scopes: []string{"filters:delete"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AuditRepo interface {
+	Store(ctx context.Context, entry *AuditEntry) error
+	Find(ctx context.Context, params AuditQueryParams) (*FindAuditResponse, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "CREATE"
+	AuditActionUpdate AuditAction = "UPDATE"
+	AuditActionDelete AuditAction = "DELETE"
+	AuditActionToggle AuditAction = "TOGGLE"
+)
+
+type AuditResource string
+
+const (
+	AuditResourceFilter         AuditResource = "FILTER"
+	AuditResourceAction         AuditResource = "ACTION"
+	AuditResourceDownloadClient AuditResource = "DOWNLOAD_CLIENT"
+	AuditResourceIndexer        AuditResource = "INDEXER"
+	AuditResourceIrcNetwork     AuditResource = "IRC_NETWORK"
+	AuditResourceFeed           AuditResource = "FEED"
+	AuditResourceNotification   AuditResource = "NOTIFICATION"
+	AuditResourceProxy          AuditResource = "PROXY"
+	AuditResourceList           AuditResource = "LIST"
+)
+
+type AuditActorType string
+
+const (
+	AuditActorUser   AuditActorType = "USER"
+	AuditActorAPIKey AuditActorType = "API_KEY"
+	AuditActorSystem AuditActorType = "SYSTEM"
+)
+
+type AuditEntry struct {
+	ID           int64          `json:"id"`
+	Actor        string         `json:"actor"`
+	ActorType    AuditActorType `json:"actor_type"`
+	Action       AuditAction    `json:"action"`
+	ResourceType AuditResource  `json:"resource_type"`
+	ResourceID   string         `json:"resource_id"`
+	ResourceName string         `json:"resource_name"`
+	Changes      []AuditChange  `json:"changes"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// AuditChange is a single changed field. Field is the dotted json path of the value.
+type AuditChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type AuditQueryParams struct {
+	Limit   uint64
+	Offset  uint64
+	Filters struct {
+		Actor        string
+		Action       AuditAction
+		ResourceType AuditResource
+		ResourceID   string
+	}
+}
+
+type FindAuditResponse struct {
+	Data       []AuditEntry `json:"data"`
+	TotalCount uint64       `json:"count"`
+}
+
+// AuditActorFromContext returns who makes the change: the session user, the API key or the system itself
+func AuditActorFromContext(ctx context.Context) (string, AuditActorType) {
+	if user := UserFromContext(ctx); user != nil {
+		return user.Username, AuditActorUser
+	}
+
+	if key := APIKeyFromContext(ctx); key != nil {
+		return key.Name, AuditActorAPIKey
+	}
+
+	return "system", AuditActorSystem
+}
+
+// auditIgnoredFields change on every write and are left out of the diff
+var auditIgnoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// IsAuditSecretField reports if the value at the dotted json path holds a secret
+func IsAuditSecretField(field string) bool {
+	return IsSecretField(field[strings.LastIndex(field, ".")+1:])
+}
+
+// AuditDiff returns the fields that differ between two versions of a resource.
+// Either side may be nil for created and deleted resources, empty values are left out then.
+// Structs and maps are walked by their json names, slices are compared as a whole.
+// Secrets are redacted, also inside slices, but a changed secret is still listed.
+func AuditDiff(old, new any) []AuditChange {
+	before := map[string]any{}
+	after := map[string]any{}
+
+	created := flattenAuditValue(before, "", reflect.ValueOf(old))
+	deleted := flattenAuditValue(after, "", reflect.ValueOf(new))
+
+	fields := make([]string, 0, len(before)+len(after))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	changes := make([]AuditChange, 0)
+	for _, field := range fields {
+		o, n := before[field], after[field]
+		if reflect.DeepEqual(o, n) || auditIgnoredFields[field[strings.LastIndex(field, ".")+1:]] {
+			continue
+		}
+
+		if (created && isEmptyAuditValue(n)) || (deleted && isEmptyAuditValue(o)) {
+			continue
+		}
+
+		if IsAuditSecretField(field) {
+			o, n = redactAuditValue(o), redactAuditValue(n)
+		} else {
+			o, n = redactAuditSecrets(o), redactAuditSecrets(n)
+		}
+
+		changes = append(changes, AuditChange{Field: field, Old: o, New: n})
+	}
+
+	return changes
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// flattenAuditValue adds the leaves of v to dst and reports if v is nil
+func flattenAuditValue(dst map[string]any, path string, v reflect.Value) bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case !v.IsValid():
+		return true
+
+	case v.Kind() == reflect.Struct && v.Type() != timeType:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+
+			if field.Anonymous && name == "" {
+				flattenAuditValue(dst, path, v.Field(i))
+				continue
+			}
+
+			if name == "" {
+				name = field.Name
+			}
+
+			flattenAuditValue(dst, joinAuditPath(path, name), v.Field(i))
+		}
+
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		iter := v.MapRange()
+		for iter.Next() {
+			flattenAuditValue(dst, joinAuditPath(path, iter.Key().String()), iter.Value())
+		}
+
+	default:
+		dst[path] = auditLeafValue(v)
+	}
+
+	return false
+}
+
+// auditLeafValue converts a value to its json form so entries look like the api responses
+func auditLeafValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+	case reflect.Struct:
+		if v.IsZero() {
+			return nil
+		}
+	case reflect.String:
+		if !v.Type().Implements(marshalerType) {
+			return v.String()
+		}
+	case reflect.Bool:
+		return v.Bool()
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil
+	}
+
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+
+	return out
+}
+
+func isEmptyAuditValue(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case bool:
+		return !val
+	case float64:
+		return val == 0
+	}
+	return false
+}
+
+func redactAuditValue(v any) any {
+	if s, ok := v.(string); ok {
+		return RedactString(s)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return RedactedStr
+}
+
+// redactAuditSecrets redacts the secrets nested in slice values like the external filters of a filter
+func redactAuditSecrets(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			if IsAuditSecretField(k) {
+				out[k] = redactAuditValue(item)
+				continue
+			}
+			out[k] = redactAuditSecrets(item)
+		}
+		return out
+
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = redactAuditSecrets(item)
+		}
+		return out
+	}
+
+	return v
+}
+
+func joinAuditPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff(t *testing.T) {
+	t.Parallel()
+
+	client := DownloadClient{
+		ID:       1,
+		Name:     "qbit",
+		Type:     DownloadClientTypeQbittorrent,
+		Enabled:  true,
+		Host:     "http://localhost",
+		Port:     8080,
+		Username: "admin",
+		Password: "hunter2",
+	}
+
+	tests := []struct {
+		name string
+		old  any
+		new  any
+		want []AuditChange
+	}{
+		{
+			name: "unchanged",
+			old:  client,
+			new:  client,
+			want: []AuditChange{},
+		},
+		{
+			name: "update",
+			old:  client,
+			new: func() DownloadClient {
+				c := client
+				c.Enabled = false
+				c.Port = 8081
+				c.Settings.Rules.MaxActiveDownloads = 2
+				return c
+			}(),
+			want: []AuditChange{
+				{Field: "enabled", Old: true, New: false},
+				{Field: "port", Old: float64(8080), New: float64(8081)},
+				{Field: "settings.rules.max_active_downloads", Old: float64(0), New: float64(2)},
+			},
+		},
+		{
+			name: "secret_changed",
+			old:  client,
+			new: func() DownloadClient {
+				c := client
+				c.Password = "correct horse"
+				return c
+			}(),
+			want: []AuditChange{
+				{Field: "password", Old: RedactedStr, New: RedactedStr},
+			},
+		},
+		{
+			name: "secret_reference",
+			old:  client,
+			new: func() DownloadClient {
+				c := client
+				c.Password = "env:QBIT_PASSWORD"
+				return c
+			}(),
+			want: []AuditChange{
+				{Field: "password", Old: RedactedStr, New: "env:QBIT_PASSWORD"},
+			},
+		},
+		{
+			name: "create",
+			old:  nil,
+			new:  &Proxy{ID: 2, Name: "proxy", Enabled: true, Type: ProxyTypeSocks5, Addr: "socks5://localhost:1080", Pass: "secret"},
+			want: []AuditChange{
+				{Field: "addr", Old: nil, New: "socks5://localhost:1080"},
+				{Field: "enabled", Old: nil, New: true},
+				{Field: "id", Old: nil, New: float64(2)},
+				{Field: "name", Old: nil, New: "proxy"},
+				{Field: "pass", Old: nil, New: RedactedStr},
+				{Field: "type", Old: nil, New: "SOCKS5"},
+			},
+		},
+		{
+			name: "delete",
+			old:  &Indexer{ID: 3, Name: "mock", Settings: map[string]string{"passkey": "abc", "uid": ""}},
+			new:  (*Indexer)(nil),
+			want: []AuditChange{
+				{Field: "id", Old: float64(3), New: nil},
+				{Field: "name", Old: "mock", New: nil},
+				{Field: "settings.passkey", Old: RedactedStr, New: nil},
+			},
+		},
+		{
+			name: "slices_and_maps",
+			old:  &Filter{Name: "tv", Shows: "show", Tags: "", Resolutions: []string{"1080p"}},
+			new:  &Filter{Name: "tv", Shows: "show,other", Tags: "", Resolutions: []string{"1080p", "2160p"}},
+			want: []AuditChange{
+				{Field: "resolutions", Old: []any{"1080p"}, New: []any{"1080p", "2160p"}},
+				{Field: "shows", Old: "show", New: "show,other"},
+			},
+		},
+		{
+			name: "webhook_secrets",
+			old:  &Notification{ID: 4, Name: "discord", Webhook: "https://discord.com/api/webhooks/1/token"},
+			new:  &Notification{ID: 4, Name: "discord", Webhook: "https://discord.com/api/webhooks/2/token", Headers: "Authorization=Bearer abc"},
+			want: []AuditChange{
+				{Field: "headers", Old: "", New: RedactedStr},
+				{Field: "webhook", Old: RedactedStr, New: RedactedStr},
+			},
+		},
+		{
+			name: "exec_args",
+			old:  &Action{ID: 5, Name: "exec", Type: ActionTypeExec, ExecArgs: "--api-key abc"},
+			new:  &Action{ID: 5, Name: "exec", Type: ActionTypeExec, ExecArgs: "--api-key def"},
+			want: []AuditChange{
+				{Field: "exec_args", Old: RedactedStr, New: RedactedStr},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AuditDiff(tt.old, tt.new))
+		})
+	}
+}
+
+func TestAuditDiff_NestedSecrets(t *testing.T) {
+	t.Parallel()
+
+	old := &Filter{Name: "tv", External: []FilterExternal{{Name: "check", WebhookHost: "http://localhost", WebhookHeaders: "X-Api-Key=abc", ExecArgs: "--api-key abc"}}}
+	new := &Filter{Name: "tv", External: []FilterExternal{{Name: "check", WebhookHost: "http://localhost:8080", WebhookHeaders: "X-Api-Key=abc", ExecArgs: "--api-key abc"}}}
+
+	changes := AuditDiff(old, new)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "external", changes[0].Field)
+
+	for _, value := range []any{changes[0].Old, changes[0].New} {
+		external := value.([]any)[0].(map[string]any)
+		assert.Equal(t, RedactedStr, external["webhook_headers"])
+		assert.Equal(t, RedactedStr, external["exec_args"])
+		assert.Equal(t, "check", external["name"])
+	}
+}
+
+func TestAuditActorFromContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	actor, actorType := AuditActorFromContext(ctx)
+	assert.Equal(t, "system", actor)
+	assert.Equal(t, AuditActorSystem, actorType)
+
+	actor, actorType = AuditActorFromContext(ContextWithAPIKey(ctx, &APIKey{Name: "sonarr"}))
+	assert.Equal(t, "sonarr", actor)
+	assert.Equal(t, AuditActorAPIKey, actorType)
+
+	actor, actorType = AuditActorFromContext(ContextWithUser(ctx, &User{Username: "admin"}))
+	assert.Equal(t, "admin", actor)
+	assert.Equal(t, AuditActorUser, actorType)
+}
@@ -69,6 +69,18 @@ func (c DownloadClient) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ResolveSecrets returns a copy of the client with env: and file: references
+// replaced by their values. The cached client implementation is not copied.
+func (c DownloadClient) ResolveSecrets() (DownloadClient, error) {
+	c.Client = nil
+
+	if err := resolveSecrets(&c.Password, &c.Settings.APIKey, &c.Settings.Auth.Password, &c.Settings.Basic.Password); err != nil {
+		return c, errors.Wrap(err, "could not resolve secrets of download client: %s", c.Name)
+	}
+
+	return c, nil
+}
+
 type DownloadClientSettings struct {
 	APIKey                   string              `json:"apikey,omitempty"`
 	Basic                    BasicAuth           `json:"basic,omitempty"` // Deprecated: Use Auth instead
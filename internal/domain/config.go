@@ -4,46 +4,64 @@
 package domain
 
 type Config struct {
-	Version                 string
-	ConfigPath              string
-	Host                    string `toml:"host"`
-	Port                    int    `toml:"port"`
-	CorsAllowedOrigins      string `toml:"corsAllowedOrigins"`
-	LogLevel                string `toml:"logLevel"`
-	LogPath                 string `toml:"logPath"`
-	LogMaxSize              int    `toml:"logMaxSize"`
-	LogMaxBackups           int    `toml:"logMaxBackups"`
-	BaseURL                 string `toml:"baseUrl"`
-	BaseURLModeLegacy       bool   `toml:"baseUrlModeLegacy"`
-	SessionSecret           string `toml:"sessionSecret"`
-	CustomDefinitions       string `toml:"customDefinitions"`
-	CheckForUpdates         bool   `toml:"checkForUpdates"`
-	DatabaseType            string `toml:"databaseType"`
-	DatabaseDSN             string `toml:"databaseDSN"`
-	DatabaseMaxBackups      int    `toml:"databaseMaxBackups"`
-	DatabaseAutoMigrate     bool   `toml:"databaseAutoMigrate"`
-	PostgresHost            string `toml:"postgresHost"`
-	PostgresPort            int    `toml:"postgresPort"`
-	PostgresDatabase        string `toml:"postgresDatabase"`
-	PostgresUser            string `toml:"postgresUser"`
-	PostgresPass            string `toml:"postgresPass"`
-	PostgresSSLMode         string `toml:"postgresSSLMode"`
-	PostgresSocket          string `toml:"postgresSocket"`
-	PostgresExtraParams     string `toml:"postgresExtraParams"`
-	ProfilingEnabled        bool   `toml:"profilingEnabled"`
-	ProfilingHost           string `toml:"profilingHost"`
-	ProfilingPort           int    `toml:"profilingPort"`
-	OIDCEnabled             bool   `toml:"oidcEnabled"`
-	OIDCIssuer              string `toml:"oidcIssuer"`
-	OIDCClientID            string `toml:"oidcClientId"`
-	OIDCClientSecret        string `toml:"oidcClientSecret"`
-	OIDCRedirectURL         string `toml:"oidcRedirectUrl"`
-	OIDCScopes              string `toml:"oidcScopes"`
-	OIDCDisableBuiltInLogin bool   `toml:"oidcDisableBuiltInLogin"`
-	MetricsEnabled          bool   `toml:"metricsEnabled"`
-	MetricsHost             string `toml:"metricsHost"`
-	MetricsPort             int    `toml:"metricsPort"`
-	MetricsBasicAuthUsers   string `toml:"metricsBasicAuthUsers"`
+	Version                  string
+	ConfigPath               string
+	Host                     string `toml:"host"`
+	Port                     int    `toml:"port"`
+	CorsAllowedOrigins       string `toml:"corsAllowedOrigins"`
+	LogLevel                 string `toml:"logLevel"`
+	LogPath                  string `toml:"logPath"`
+	LogMaxSize               int    `toml:"logMaxSize"`
+	LogMaxBackups            int    `toml:"logMaxBackups"`
+	BaseURL                  string `toml:"baseUrl"`
+	BaseURLModeLegacy        bool   `toml:"baseUrlModeLegacy"`
+	SessionSecret            string `toml:"sessionSecret"`
+	SecretKey                string `toml:"secretKey"`
+	SecretsDir               string `toml:"secretsDir"`
+	SecretsEnvPrefix         string `toml:"secretsEnvPrefix"`
+	CustomDefinitions        string `toml:"customDefinitions"`
+	CheckForUpdates          bool   `toml:"checkForUpdates"`
+	DatabaseType             string `toml:"databaseType"`
+	DatabaseDSN              string `toml:"databaseDSN"`
+	DatabaseMaxBackups       int    `toml:"databaseMaxBackups"`
+	DatabaseAutoMigrate      bool   `toml:"databaseAutoMigrate"`
+	PostgresHost             string `toml:"postgresHost"`
+	PostgresPort             int    `toml:"postgresPort"`
+	PostgresDatabase         string `toml:"postgresDatabase"`
+	PostgresUser             string `toml:"postgresUser"`
+	PostgresPass             string `toml:"postgresPass"`
+	PostgresSSLMode          string `toml:"postgresSSLMode"`
+	PostgresSocket           string `toml:"postgresSocket"`
+	PostgresExtraParams      string `toml:"postgresExtraParams"`
+	ProfilingEnabled         bool   `toml:"profilingEnabled"`
+	ProfilingHost            string `toml:"profilingHost"`
+	ProfilingPort            int    `toml:"profilingPort"`
+	OIDCEnabled              bool   `toml:"oidcEnabled"`
+	OIDCIssuer               string `toml:"oidcIssuer"`
+	OIDCClientID             string `toml:"oidcClientId"`
+	OIDCClientSecret         string `toml:"oidcClientSecret"`
+	OIDCRedirectURL          string `toml:"oidcRedirectUrl"`
+	OIDCScopes               string `toml:"oidcScopes"`
+	OIDCDisableBuiltInLogin  bool   `toml:"oidcDisableBuiltInLogin"`
+	OIDCGroupsClaim          string `toml:"oidcGroupsClaim"`
+	OIDCAdminGroups          string `toml:"oidcAdminGroups"`
+	OIDCEditorGroups         string `toml:"oidcEditorGroups"`
+	OIDCViewerGroups         string `toml:"oidcViewerGroups"`
+	MetricsEnabled           bool   `toml:"metricsEnabled"`
+	MetricsHost              string `toml:"metricsHost"`
+	MetricsPort              int    `toml:"metricsPort"`
+	MetricsBasicAuthUsers    string `toml:"metricsBasicAuthUsers"`
+	ReleaseWorkers           int    `toml:"releaseWorkers"`
+	MagnetResolveTimeout     int    `toml:"magnetResolveTimeout"`
+	MagnetFetchURL           string `toml:"magnetFetchUrl"`
+	TorrentTrackingInterval  int    `toml:"torrentTrackingInterval"`
+	BackfillInterval         int    `toml:"backfillInterval"`
+	BackfillSearchDelay      int    `toml:"backfillSearchDelay"`
+	AuditRetentionDays       int    `toml:"auditRetentionDays"`
+	IRCAnnounceRecordLines   int    `toml:"ircAnnounceRecordLines"`
+	AnnounceParseFailures    int    `toml:"announceParseFailures"`
+	AnnounceParseSilence     int    `toml:"announceParseSilence"`
+	IRCBouncerPlaybackMaxAge int    `toml:"ircBouncerPlaybackMaxAge"`
 }
 
 type ConfigUpdate struct {
@@ -10,6 +10,8 @@ const (
 	EventReleasePushStatus        = "release:push"
 	EventNotificationSend         = "events:notification"
 	EventIndexerDelete            = "indexer:delete"
+	EventReleaseTrackTorrent      = "release:track-torrent"
+	EventIndexerDefinitionReload  = "indexer:definition-reload"
 )
 
 type EventsReleasePushed struct {
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/autobrr/autobrr/pkg/errors"
 	"github.com/autobrr/autobrr/pkg/newznab"
 	"github.com/autobrr/autobrr/pkg/torznab"
 )
@@ -70,6 +71,15 @@ type Feed struct {
 	Proxy    *Proxy `json:"-"`
 }
 
+// ResolveSecrets returns a copy of the feed with env: and file: references replaced by their values
+func (f Feed) ResolveSecrets() (Feed, error) {
+	if err := resolveSecrets(&f.ApiKey, &f.Cookie); err != nil {
+		return f, errors.Wrap(err, "could not resolve secrets of feed: %s", f.Name)
+	}
+
+	return f, nil
+}
+
 func (f Feed) MarshalJSON() ([]byte, error) {
 	type Alias Feed
 	return json.Marshal(&struct {
@@ -99,6 +109,7 @@ const (
 	FeedTypeTorznab FeedType = "TORZNAB"
 	FeedTypeNewznab FeedType = "NEWZNAB"
 	FeedTypeRSS     FeedType = "RSS"
+	FeedTypeAPI     FeedType = "API"
 )
 
 type FeedDownloadType string
@@ -167,6 +178,11 @@ type FeedCapabilities struct {
 	Searching  FeedCapabilitiesSearching  `json:"searching"`
 }
 
+// SupportsSearch reports if the feed accepts free text queries
+func (c *FeedCapabilities) SupportsSearch() bool {
+	return c != nil && c.Searching.Search.Available == "yes"
+}
+
 func NewFeedCapabilitiesFromTorznab(caps *torznab.Caps) *FeedCapabilities {
 	c := &FeedCapabilities{
 		Server: FeedCapsServer{
@@ -282,3 +298,41 @@ func parseFeedCapLimitValue(value any) int {
 		return 0
 	}
 }
+
+var ErrFeedBackfillRunning = errors.New("backfill already running")
+
+// FeedBackfillRequest selects the filters to search torznab and newznab feeds for.
+// Either a filter or a list is required, the list searches every filter it syncs titles into.
+type FeedBackfillRequest struct {
+	FilterID int   `json:"filter_id,omitempty"`
+	ListID   int64 `json:"list_id,omitempty"`
+	FeedIDs  []int `json:"feed_ids,omitempty"`
+}
+
+func (r *FeedBackfillRequest) Validate() error {
+	if r.FilterID == 0 && r.ListID == 0 {
+		return errors.New("filter_id or list_id is required")
+	}
+
+	return nil
+}
+
+// FeedBackfillPlan holds the queries built from a filter and the feeds to search them on
+type FeedBackfillPlan struct {
+	FilterID   int                `json:"filter_id"`
+	FilterName string             `json:"filter_name"`
+	Queries    []string           `json:"queries"`
+	Feeds      []FeedBackfillFeed `json:"feeds"`
+}
+
+type FeedBackfillFeed struct {
+	ID      int            `json:"id"`
+	Name    string         `json:"name"`
+	Indexer IndexerMinimal `json:"indexer"`
+}
+
+type FeedBackfillResult struct {
+	Searches int `json:"searches"`
+	Releases int `json:"releases"`
+	Errors   int `json:"errors"`
+}
@@ -40,6 +40,10 @@ type FilterRepo interface {
 	GetFilterNotifications(ctx context.Context, filterID int) ([]FilterNotification, error)
 	StoreFilterNotifications(ctx context.Context, filterID int, notifications []FilterNotification) error
 	DeleteFilterNotifications(ctx context.Context, filterID int) error
+	StoreRevision(ctx context.Context, revision *FilterRevision) error
+	FindRevisions(ctx context.Context, filterID int) ([]FilterRevision, error)
+	FindRevision(ctx context.Context, filterID int, revision int) (*FilterRevision, error)
+	FindLatestRevision(ctx context.Context, filterID int) (*FilterRevision, error)
 }
 
 type FilterDownloads struct {
@@ -131,6 +135,7 @@ type Filter struct {
 	Freeleech                 bool                     `json:"freeleech,omitempty"`
 	FreeleechPercent          string                   `json:"freeleech_percent,omitempty"`
 	SmartEpisode              bool                     `json:"smart_episode"`
+	Backfill                  bool                     `json:"backfill"`
 	Shows                     string                   `json:"shows,omitempty"`
 	Seasons                   string                   `json:"seasons,omitempty"`
 	Episodes                  string                   `json:"episodes,omitempty"`
@@ -180,6 +185,7 @@ type Filter struct {
 	MaxSeeders                int                      `json:"max_seeders,omitempty"`
 	MinLeechers               int                      `json:"min_leechers,omitempty"`
 	MaxLeechers               int                      `json:"max_leechers,omitempty"`
+	Expression                string                   `json:"expression,omitempty"`
 	ActionsCount              int                      `json:"actions_count"`
 	ActionsEnabledCount       int                      `json:"actions_enabled_count"`
 	IsAutoUpdated             bool                     `json:"is_auto_updated"`
@@ -290,6 +296,7 @@ type FilterUpdate struct {
 	Freeleech                 *bool                   `json:"freeleech,omitempty"`
 	FreeleechPercent          *string                 `json:"freeleech_percent,omitempty"`
 	SmartEpisode              *bool                   `json:"smart_episode,omitempty"`
+	Backfill                  *bool                   `json:"backfill,omitempty"`
 	Shows                     *string                 `json:"shows,omitempty"`
 	Seasons                   *string                 `json:"seasons,omitempty"`
 	Episodes                  *string                 `json:"episodes,omitempty"`
@@ -333,6 +340,7 @@ type FilterUpdate struct {
 	MaxSeeders                *int                    `json:"max_seeders,omitempty"`
 	MinLeechers               *int                    `json:"min_leechers,omitempty"`
 	MaxLeechers               *int                    `json:"max_leechers,omitempty"`
+	Expression                *string                 `json:"expression,omitempty"`
 	ReleaseProfileDuplicateID *int64                  `json:"release_profile_duplicate_id,omitempty"`
 	Actions                   []*Action               `json:"actions,omitempty"`
 	External                  []FilterExternal        `json:"external,omitempty"`
@@ -349,6 +357,12 @@ func (f *Filter) Validate() error {
 		return fmt.Errorf("error validating filter size limits: %w", err)
 	}
 
+	if f.Expression != "" {
+		if _, err := CompileExpression(f.Expression); err != nil {
+			return errors.Wrap(err, "validation: invalid expression")
+		}
+	}
+
 	for _, external := range f.External {
 		if external.Type == ExternalFilterTypeExec {
 			if external.ExecCmd != "" && external.Enabled {
@@ -708,6 +722,15 @@ func (f *Filter) CheckFilter(r *Release) (*RejectionReasons, bool) {
 		}
 	}
 
+	if f.Expression != "" {
+		// wait for the additional size check if the size is not announced
+		if r.Size == 0 && f.ExpressionRequiresSize() {
+			r.AdditionalSizeCheckRequired = true
+		} else {
+			f.CheckReleaseExpression(r)
+		}
+	}
+
 	if f.RejectReasons.Len() > 0 {
 		return f.RejectReasons, false
 	}
@@ -0,0 +1,114 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+const (
+	FilterTestDefaultLimit = 1000
+	FilterTestMaxLimit     = 10000
+)
+
+// FilterTestRequest selects the releases a filter is tested against.
+// If ReleaseNames is set the names are parsed and tested instead of stored releases.
+type FilterTestRequest struct {
+	FilterID     int       `json:"-"`
+	From         time.Time `json:"from,omitempty"`
+	To           time.Time `json:"to,omitempty"`
+	Indexers     []string  `json:"indexers,omitempty"`
+	ReleaseNames []string  `json:"release_names,omitempty"`
+	Limit        uint64    `json:"limit,omitempty"`
+}
+
+func (r *FilterTestRequest) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return errors.New("validation error: to must be after from")
+	}
+
+	if r.Limit > FilterTestMaxLimit {
+		return errors.New("validation error: limit must be at most %d", FilterTestMaxLimit)
+	}
+
+	if r.Limit == 0 {
+		r.Limit = FilterTestDefaultLimit
+	}
+
+	return nil
+}
+
+type FilterTestResult struct {
+	FilterID     int                       `json:"filter_id"`
+	FilterName   string                    `json:"filter_name"`
+	Total        int                       `json:"total"`
+	Matched      int                       `json:"matched"`
+	Rejected     int                       `json:"rejected"`
+	Undetermined int                       `json:"undetermined"`
+	Releases     []FilterTestReleaseResult `json:"releases"`
+}
+
+type FilterTestReleaseResult struct {
+	ReleaseID int64     `json:"release_id,omitempty"`
+	Name      string    `json:"name"`
+	Indexer   string    `json:"indexer,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Match     bool      `json:"match"`
+	// SizeUnknown releases passed the other checks but the size they need was not announced
+	SizeUnknown bool     `json:"size_unknown,omitempty"`
+	Rejections  []string `json:"rejections,omitempty"`
+}
+
+// DryRun checks releases against a copy of the filter without any side effects.
+// Only the checks in CheckFilter are run. Max downloads are ignored since the counters
+// reflect the current state and not the time of the release. Releases without a size
+// are undetermined when the filter needs it, a live run would download the torrent to check.
+func (f *Filter) DryRun(releases []*Release) *FilterTestResult {
+	filter := *f
+	filter.MaxDownloads = 0
+	filter.MaxDownloadsUnit = ""
+
+	result := &FilterTestResult{
+		FilterID:   f.ID,
+		FilterName: f.Name,
+		Total:      len(releases),
+		Releases:   make([]FilterTestReleaseResult, 0, len(releases)),
+	}
+
+	for _, release := range releases {
+		// the same releases are checked against several filters
+		release.AdditionalSizeCheckRequired = false
+
+		rejections, match := filter.CheckFilter(release)
+		match = match && rejections.Len() == 0
+
+		sizeUnknown := match && release.AdditionalSizeCheckRequired
+		if sizeUnknown {
+			match = false
+		}
+
+		switch {
+		case sizeUnknown:
+			result.Undetermined++
+		case match:
+			result.Matched++
+		default:
+			result.Rejected++
+		}
+
+		result.Releases = append(result.Releases, FilterTestReleaseResult{
+			ReleaseID:   release.ID,
+			Name:        release.TorrentName,
+			Indexer:     release.Indexer.Identifier,
+			Timestamp:   release.Timestamp,
+			Match:       match,
+			SizeUnknown: sizeUnknown,
+			Rejections:  rejections.Strings(),
+		})
+	}
+
+	return result
+}
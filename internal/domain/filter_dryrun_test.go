@@ -0,0 +1,100 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_DryRun(t *testing.T) {
+	t.Parallel()
+
+	filter := &Filter{
+		ID:               1,
+		Name:             "test",
+		Resolutions:      []string{"1080p"},
+		MaxDownloads:     1,
+		MaxDownloadsUnit: FilterMaxDownloadsHour,
+		Downloads:        &FilterDownloads{HourCount: 5},
+	}
+
+	var releases []*Release
+	for _, name := range []string{
+		"That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP",
+		"That.Show.S01E02.2160p.WEB-DL.DDP5.1.H.265-GROUP",
+		"That.Show.S01E03.1080p.WEB-DL.DDP5.1.H.264-GROUP",
+	} {
+		rls := NewRelease(IndexerMinimal{Identifier: "mock"})
+		rls.ParseString(name)
+		releases = append(releases, rls)
+	}
+
+	result := filter.DryRun(releases)
+
+	assert.Equal(t, 1, result.FilterID)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, 1, result.Rejected)
+	assert.Len(t, result.Releases, 3)
+
+	assert.True(t, result.Releases[0].Match)
+	assert.Empty(t, result.Releases[0].Rejections)
+	assert.Equal(t, "mock", result.Releases[0].Indexer)
+
+	assert.False(t, result.Releases[1].Match)
+	assert.Equal(t, []string{"[resolution] not matching: got 2160p want: [1080p]"}, result.Releases[1].Rejections)
+
+	// the filter itself is left untouched
+	assert.Equal(t, 1, filter.MaxDownloads)
+	assert.Equal(t, FilterMaxDownloadsHour, filter.MaxDownloadsUnit)
+}
+
+func TestFilter_DryRun_SizeUnknown(t *testing.T) {
+	t.Parallel()
+
+	sized := &Filter{ID: 1, Name: "sized", MaxSize: "10GB"}
+	expression := &Filter{ID: 2, Name: "expression", Expression: "Size < 10000000000"}
+	plain := &Filter{ID: 3, Name: "plain"}
+
+	unknown := NewRelease(IndexerMinimal{Identifier: "mock"})
+	unknown.ParseString("That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP")
+
+	known := NewRelease(IndexerMinimal{Identifier: "mock"})
+	known.ParseString("That.Show.S01E02.1080p.WEB-DL.DDP5.1.H.264-GROUP")
+	known.Size = 5_000_000_000
+
+	releases := []*Release{unknown, known}
+
+	for _, filter := range []*Filter{sized, expression} {
+		result := filter.DryRun(releases)
+
+		assert.Equal(t, 1, result.Matched, filter.Name)
+		assert.Equal(t, 0, result.Rejected, filter.Name)
+		assert.Equal(t, 1, result.Undetermined, filter.Name)
+
+		assert.False(t, result.Releases[0].Match, filter.Name)
+		assert.True(t, result.Releases[0].SizeUnknown, filter.Name)
+		assert.True(t, result.Releases[1].Match, filter.Name)
+		assert.False(t, result.Releases[1].SizeUnknown, filter.Name)
+	}
+
+	// the size is not needed without limits, also after the releases were checked by other filters
+	result := plain.DryRun(releases)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, 0, result.Undetermined)
+	assert.False(t, result.Releases[0].SizeUnknown)
+}
+
+func TestFilterTestRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	req := &FilterTestRequest{}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, uint64(FilterTestDefaultLimit), req.Limit)
+
+	req = &FilterTestRequest{Limit: FilterTestMaxLimit + 1}
+	assert.Error(t, req.Validate())
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/ttlcache"
+
+	"github.com/dustin/go-humanize"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+var expressionCache = ttlcache.New(
+	ttlcache.Options[string, *vm.Program]{}.
+		SetTimerResolution(5 * time.Minute).
+		SetDefaultTTL(15 * time.Minute),
+)
+
+// expressionOptions returns the options used to compile filter expressions.
+// The environment is the Macro struct so the same variables available in macros
+// like TorrentName, Size, Resolution or Freeleech can be used in expressions.
+func expressionOptions() []expr.Option {
+	return []expr.Option{
+		expr.Env(Macro{}),
+		expr.AsBool(),
+		expr.Function(
+			"bytes",
+			func(params ...any) (any, error) {
+				size, err := humanize.ParseBytes(params[0].(string))
+				if err != nil {
+					return nil, errors.Wrap(err, "could not parse bytes")
+				}
+				return size, nil
+			},
+			new(func(string) uint64),
+		),
+	}
+}
+
+// CompileExpression compiles a filter expression and returns an error if it is invalid.
+//
+// Expressions are evaluated against the release macro variables and must return a bool:
+//
+//	Resolution == "1080p" && Source == "WEB-DL" && (Size < bytes("8GB") || Freeleech)
+//	TorrentName matches "(?i)\\bREPACK\\b" && Group in ["GROUP1", "GROUP2"]
+func CompileExpression(expression string) (*vm.Program, error) {
+	if program, ok := expressionCache.Get(expression); ok {
+		return program, nil
+	}
+
+	program, err := expr.Compile(expression, expressionOptions()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compile expression")
+	}
+
+	expressionCache.Set(expression, program, ttlcache.DefaultTTL)
+
+	return program, nil
+}
+
+// sizeVisitor finds the size variables in an expression
+type sizeVisitor struct {
+	found bool
+}
+
+func (v *sizeVisitor) Visit(node *ast.Node) {
+	if n, ok := (*node).(*ast.IdentifierNode); ok && (n.Value == "Size" || n.Value == "SizeString") {
+		v.found = true
+	}
+}
+
+// ExpressionRequiresSize reports if the filter expression uses the release size.
+// It is evaluated after the additional size check if the size is not announced, like the size limits.
+func (f *Filter) ExpressionRequiresSize() bool {
+	if f.Expression == "" {
+		return false
+	}
+
+	program, err := CompileExpression(f.Expression)
+	if err != nil {
+		return false
+	}
+
+	node := program.Node()
+	visitor := &sizeVisitor{}
+	ast.Walk(&node, visitor)
+
+	return visitor.found
+}
+
+// CheckReleaseExpression evaluates the filter expression against the release and adds the rejection if it does not match
+func (f *Filter) CheckReleaseExpression(r *Release) bool {
+	match, err := f.checkExpression(r)
+	if err != nil {
+		f.RejectReasons.Add("expression", fmt.Sprintf("error evaluating expression: %v", err), f.Expression)
+		return false
+	}
+
+	if !match {
+		f.RejectReasons.Add("expression", match, f.Expression)
+		return false
+	}
+
+	return true
+}
+
+// checkExpression evaluates the filter expression against the release.
+func (f *Filter) checkExpression(r *Release) (bool, error) {
+	program, err := CompileExpression(f.Expression)
+	if err != nil {
+		return false, err
+	}
+
+	output, err := expr.Run(program, NewMacro(*r))
+	if err != nil {
+		return false, errors.Wrap(err, "could not run expression")
+	}
+
+	match, ok := output.(bool)
+	if !ok {
+		return false, errors.New("expression did not return a bool: %v", output)
+	}
+
+	return match, nil
+}
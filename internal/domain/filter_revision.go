@@ -0,0 +1,157 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FilterRevision is an immutable snapshot of a filter with its actions, external filters,
+// indexers and notifications, taken every time the filter is saved.
+type FilterRevision struct {
+	ID        int64     `json:"id"`
+	FilterID  int       `json:"filter_id"`
+	Revision  int       `json:"revision"`
+	Actor     string    `json:"actor"`
+	Filter    *Filter   `json:"filter,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FilterRevisionDiff struct {
+	FilterID int           `json:"filter_id"`
+	From     int           `json:"from"`
+	To       int           `json:"to"`
+	Changes  []AuditChange `json:"changes"`
+}
+
+// NewFilterRevisionSnapshot returns a copy of the filter as it is stored in a revision.
+// Loaded relations like download clients and duplicate profiles are left out,
+// indexers are reduced to what is needed to connect them again.
+func NewFilterRevisionSnapshot(f *Filter) *Filter {
+	snapshot := *f
+	snapshot.Downloads = nil
+	snapshot.DuplicateHandling = nil
+	snapshot.ActionsCount = 0
+	snapshot.ActionsEnabledCount = 0
+
+	snapshot.Actions = make([]*Action, 0, len(f.Actions))
+	for _, a := range f.Actions {
+		action := *a
+		action.Client = nil
+		snapshot.Actions = append(snapshot.Actions, &action)
+	}
+
+	snapshot.Indexers = make([]Indexer, 0, len(f.Indexers))
+	for _, indexer := range f.Indexers {
+		snapshot.Indexers = append(snapshot.Indexers, Indexer{ID: indexer.ID, Name: indexer.Name, Identifier: indexer.Identifier})
+	}
+
+	return &snapshot
+}
+
+// filterRevisionView is the shape the filter fields of two revisions are compared in
+type filterRevisionView struct {
+	Filter
+	Indexers []string `json:"indexers"`
+}
+
+func newFilterRevisionView(f *Filter) *filterRevisionView {
+	view := &filterRevisionView{Filter: *f}
+	view.Filter.Actions = nil
+	view.Filter.External = nil
+	view.Filter.Indexers = nil
+
+	for _, indexer := range f.Indexers {
+		view.Indexers = append(view.Indexers, indexer.Identifier)
+	}
+
+	return view
+}
+
+// DiffFilterRevisions returns the fields that differ between two revisions of a filter.
+// Actions and external filters are matched by name so a change shows up on the field that changed.
+func DiffFilterRevisions(from, to *FilterRevision) *FilterRevisionDiff {
+	changes := AuditDiff(newFilterRevisionView(from.Filter), newFilterRevisionView(to.Filter))
+	changes = append(changes, diffFilterRevisionItems("actions", filterRevisionActions(from.Filter), filterRevisionActions(to.Filter))...)
+	changes = append(changes, diffFilterRevisionItems("external", filterRevisionExternal(from.Filter), filterRevisionExternal(to.Filter))...)
+
+	slices.SortFunc(changes, func(a, b AuditChange) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+
+	return &FilterRevisionDiff{
+		FilterID: to.FilterID,
+		From:     from.Revision,
+		To:       to.Revision,
+		Changes:  changes,
+	}
+}
+
+func filterRevisionActions(f *Filter) map[string]Action {
+	actions := make(map[string]Action, len(f.Actions))
+	for _, a := range f.Actions {
+		action := *a
+		action.ID = 0
+		action.FilterID = 0
+		actions[uniqueRevisionKey(actions, a.Name)] = action
+	}
+
+	return actions
+}
+
+func filterRevisionExternal(f *Filter) map[string]FilterExternal {
+	external := make(map[string]FilterExternal, len(f.External))
+	for _, e := range f.External {
+		e.ID = 0
+		e.FilterId = 0
+		external[uniqueRevisionKey(external, e.Name)] = e
+	}
+
+	return external
+}
+
+// diffFilterRevisionItems diffs the items with the same key, items only in one of them are diffed against nothing
+func diffFilterRevisionItems[V any](prefix string, before, after map[string]V) []AuditChange {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	changes := make([]AuditChange, 0)
+	for _, key := range keys {
+		var old, new any
+		if v, ok := before[key]; ok {
+			old = v
+		}
+		if v, ok := after[key]; ok {
+			new = v
+		}
+
+		for _, change := range AuditDiff(old, new) {
+			change.Field = prefix + "." + key + "." + change.Field
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// uniqueRevisionKey returns name, or name with a counter if it is already used
+func uniqueRevisionKey[V any](m map[string]V, name string) string {
+	key := name
+	for i := 2; ; i++ {
+		if _, ok := m[key]; !ok {
+			return key
+		}
+		key = fmt.Sprintf("%s (%d)", name, i)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFilterRevisions(t *testing.T) {
+	t.Parallel()
+
+	filter := &Filter{
+		ID:      1,
+		Name:    "TV",
+		Enabled: true,
+		Shows:   "Show",
+		Actions: []*Action{
+			{ID: 1, Name: "qbit", Type: ActionTypeQbittorrent, Enabled: true, ClientID: 1, Client: &DownloadClient{ID: 1, Password: "hunter2"}},
+			{ID: 2, Name: "test", Type: ActionTypeTest},
+		},
+		External: []FilterExternal{
+			{ID: 1, Name: "hook", Type: ExternalFilterTypeWebhook, WebhookHost: "http://localhost"},
+		},
+		Indexers: []Indexer{
+			{ID: 1, Name: "Mock", Identifier: "mock", Settings: map[string]string{"passkey": "secret"}},
+		},
+	}
+
+	snapshot := NewFilterRevisionSnapshot(filter)
+	assert.Nil(t, snapshot.Actions[0].Client)
+	assert.Nil(t, snapshot.Indexers[0].Settings)
+	assert.NotNil(t, filter.Actions[0].Client, "the filter itself is not changed")
+
+	// a stored revision is the same as the filter it was taken from
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+
+	var stored *Filter
+	require.NoError(t, json.Unmarshal(data, &stored))
+
+	from := &FilterRevision{FilterID: 1, Revision: 1, Filter: stored}
+	assert.Empty(t, DiffFilterRevisions(from, &FilterRevision{FilterID: 1, Revision: 2, Filter: snapshot}).Changes)
+
+	changed := NewFilterRevisionSnapshot(filter)
+	changed.Shows = "Other Show"
+	changed.Actions[0].SavePath = "/data"
+	changed.Actions = changed.Actions[:1]
+	changed.External[0].Enabled = true
+	changed.Indexers = nil
+
+	diff := DiffFilterRevisions(from, &FilterRevision{FilterID: 1, Revision: 3, Filter: changed})
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 3, diff.To)
+	assert.Equal(t, []AuditChange{
+		{Field: "actions.qbit.save_path", Old: "", New: "/data"},
+		{Field: "actions.test.name", Old: "test", New: nil},
+		{Field: "actions.test.type", Old: "TEST", New: nil},
+		{Field: "external.hook.enabled", Old: false, New: true},
+		{Field: "indexers", Old: []any{"mock"}, New: nil},
+		{Field: "shows", Old: "Show", New: "Other Show"},
+	}, diff.Changes)
+}
@@ -2375,6 +2375,10 @@ func Test_validation(t *testing.T) {
 		{name: "valid size limit", filter: Filter{Name: "test", MaxSize: "12MB"}, valid: true},
 		{name: "gibberish max size limit", filter: Filter{Name: "test", MaxSize: "asdf"}, valid: false},
 		{name: "gibberish min size limit", filter: Filter{Name: "test", MinSize: "qwerty"}, valid: false},
+		{name: "valid expression", filter: Filter{Name: "test", Expression: `Resolution == "1080p" && Size < bytes("8GB")`}, valid: true},
+		{name: "invalid expression syntax", filter: Filter{Name: "test", Expression: `Resolution ==`}, valid: false},
+		{name: "unknown expression variable", filter: Filter{Name: "test", Expression: `NotAField == "1080p"`}, valid: false},
+		{name: "non bool expression", filter: Filter{Name: "test", Expression: `Resolution`}, valid: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2383,6 +2387,96 @@ func Test_validation(t *testing.T) {
 	}
 }
 
+func TestFilter_CheckFilter_Expression(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		expression string
+		release    *Release
+		want       bool
+	}{
+		{
+			name:       "web-dl under size",
+			expression: `Resolution == "1080p" && Source == "WEB-DL" && (Size < bytes("8GB") || Freeleech)`,
+			release:    &Release{TorrentName: "That Show S01E01 1080p WEB-DL DD5.1 H.264-GROUP", Size: 4000000000},
+			want:       true,
+		},
+		{
+			name:       "web-dl over size",
+			expression: `Resolution == "1080p" && Source == "WEB-DL" && (Size < bytes("8GB") || Freeleech)`,
+			release:    &Release{TorrentName: "That Show S01E01 1080p WEB-DL DD5.1 H.264-GROUP", Size: 9000000000},
+			want:       false,
+		},
+		{
+			name:       "web-dl over size freeleech",
+			expression: `Resolution == "1080p" && Source == "WEB-DL" && (Size < bytes("8GB") || Freeleech)`,
+			release:    &Release{TorrentName: "That Show S01E01 1080p WEB-DL DD5.1 H.264-GROUP", Size: 9000000000, Freeleech: true},
+			want:       true,
+		},
+		{
+			name:       "regex and list membership",
+			expression: `TorrentName matches "(?i)s01e0[1-5]" && Group in ["GROUP", "OTHER"]`,
+			release:    &Release{TorrentName: "That Show S01E03 1080p WEB-DL DD5.1 H.264-GROUP"},
+			want:       true,
+		},
+		{
+			name:       "list membership no match",
+			expression: `Group not in ["GROUP", "OTHER"]`,
+			release:    &Release{TorrentName: "That Show S01E03 1080p WEB-DL DD5.1 H.264-GROUP"},
+			want:       false,
+		},
+		{
+			name:       "indexer identifier",
+			expression: `IndexerIdentifier == "mock" && Season >= 1`,
+			release:    &Release{TorrentName: "That Show S01E03 1080p WEB-DL DD5.1 H.264-GROUP", Indexer: IndexerMinimal{Identifier: "mock"}},
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.release.ParseString(tt.release.TorrentName)
+
+			f := Filter{Name: "expression", Enabled: true, Expression: tt.expression}
+			assert.NoError(t, f.Validate())
+
+			rejections, got := f.CheckFilter(tt.release)
+			assert.Equal(t, tt.want, got)
+
+			if !tt.want {
+				assert.Contains(t, rejections.String(), "[expression] not matching")
+			}
+		})
+	}
+}
+
+func TestFilter_CheckFilter_ExpressionSize(t *testing.T) {
+	t.Parallel()
+
+	f := Filter{Name: "expression", Enabled: true, Expression: `Source == "WEB-DL" && Size < bytes("8GB")`}
+	assert.True(t, f.ExpressionRequiresSize())
+	assert.False(t, (&Filter{Expression: `Source == "WEB-DL"`}).ExpressionRequiresSize())
+
+	// the expression waits for the additional size check if the size is not announced
+	release := &Release{TorrentName: "That Show S01E01 1080p WEB-DL DD5.1 H.264-GROUP"}
+	release.ParseString(release.TorrentName)
+
+	rejections, got := f.CheckFilter(release)
+	assert.True(t, got)
+	assert.Equal(t, 0, rejections.Len())
+	assert.True(t, release.AdditionalSizeCheckRequired)
+
+	release.Size = 9000000000
+	assert.False(t, f.CheckReleaseExpression(release))
+	assert.Contains(t, f.RejectReasons.String(), "[expression] not matching")
+
+	// errors are rejected with the same key
+	f = Filter{Name: "expression", Enabled: true, Expression: `TorrentName matches Group`}
+	release.Group = "("
+	rejections, got = f.CheckFilter(release)
+	assert.False(t, got)
+	assert.Contains(t, rejections.String(), "[expression] not matching: got error evaluating expression")
+}
+
 func Test_checkSizeFilter(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
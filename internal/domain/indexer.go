@@ -42,27 +42,32 @@ type Indexer struct {
 	Settings           map[string]string `json:"settings,omitempty"`
 }
 
-func (i Indexer) MarshalJSON() ([]byte, error) {
-	// Define secret keys that should be redacted
-	secretKeys := map[string]bool{
-		"rsskey":       true,
-		"rss_key":      true,
-		"passkey":      true,
-		"authkey":      true,
-		"torrentpass":  true,
-		"torrent_pass": true,
-		"api_key":      true,
-		"apikey":       true,
-		"uid":          true,
-		"key":          true,
-		"token":        true,
-		"cookie":       true,
-	}
+// indexerSecretSettings are the setting keys that should be redacted
+var indexerSecretSettings = map[string]bool{
+	"rsskey":       true,
+	"rss_key":      true,
+	"passkey":      true,
+	"authkey":      true,
+	"torrentpass":  true,
+	"torrent_pass": true,
+	"api_key":      true,
+	"apikey":       true,
+	"uid":          true,
+	"key":          true,
+	"token":        true,
+	"cookie":       true,
+}
+
+// IsIndexerSecretSetting reports if the indexer setting holds a secret
+func IsIndexerSecretSetting(key string) bool {
+	return indexerSecretSettings[strings.ToLower(key)]
+}
 
+func (i Indexer) MarshalJSON() ([]byte, error) {
 	// Create a copy of the settings map with redacted secrets
 	redactedSettings := make(map[string]string)
 	for key, value := range i.Settings {
-		if secretKeys[strings.ToLower(key)] {
+		if IsIndexerSecretSetting(key) {
 			redactedSettings[key] = RedactString(value)
 		} else {
 			redactedSettings[key] = value
@@ -81,7 +86,7 @@ func (i Indexer) MarshalJSON() ([]byte, error) {
 }
 
 func (i Indexer) ImplementationIsFeed() bool {
-	return i.Implementation == "rss" || i.Implementation == "torznab" || i.Implementation == "newznab"
+	return i.Implementation == "rss" || i.Implementation == "torznab" || i.Implementation == "newznab" || i.Implementation == "api"
 }
 
 type IndexerMinimal struct {
@@ -121,6 +126,7 @@ type IndexerDefinition struct {
 	Torznab            *Torznab          `json:"torznab,omitempty"`
 	Newznab            *Newznab          `json:"newznab,omitempty"`
 	RSS                *FeedSettings     `json:"rss,omitempty"`
+	API                *IndexerAPI       `json:"api,omitempty"`
 }
 
 type IndexerImplementation string
@@ -130,6 +136,7 @@ const (
 	IndexerImplementationTorznab IndexerImplementation = "torznab"
 	IndexerImplementationNewznab IndexerImplementation = "newznab"
 	IndexerImplementationRSS     IndexerImplementation = "rss"
+	IndexerImplementationAPI     IndexerImplementation = "api"
 	IndexerImplementationLegacy  IndexerImplementation = ""
 )
 
@@ -143,6 +150,8 @@ func (i IndexerImplementation) String() string {
 		return "newznab"
 	case IndexerImplementationRSS:
 		return "rss"
+	case IndexerImplementationAPI:
+		return "api"
 	case IndexerImplementationLegacy:
 		return ""
 	}
@@ -178,6 +187,7 @@ type IndexerDefinitionCustom struct {
 	Torznab        *Torznab          `json:"torznab,omitempty"`
 	Newznab        *Newznab          `json:"newznab,omitempty"`
 	RSS            *FeedSettings     `json:"rss,omitempty"`
+	API            *IndexerAPI       `json:"api,omitempty"`
 	Parse          *IndexerIRCParse  `json:"parse,omitempty"`
 }
 
@@ -201,6 +211,7 @@ func (i *IndexerDefinitionCustom) ToIndexerDefinition() *IndexerDefinition {
 		Torznab:        i.Torznab,
 		Newznab:        i.Newznab,
 		RSS:            i.RSS,
+		API:            i.API,
 	}
 
 	if i.IRC != nil && i.Parse != nil {
@@ -421,7 +432,14 @@ func (p *IndexerIRCParseMatch) ParseTorrentName(vars map[string]string, rls *Rel
 }
 
 func (p *IndexerIRCParse) MapCustomVariables(vars map[string]string) error {
-	for varsKey, varsKeyMap := range p.Mappings {
+	mapCustomVariables(p.Mappings, vars)
+
+	return nil
+}
+
+// mapCustomVariables adds the vars of mappings matching the value of a var
+func mapCustomVariables(mappings map[string]map[string]map[string]string, vars map[string]string) {
+	for varsKey, varsKeyMap := range mappings {
 		varsValue, ok := vars[varsKey]
 		if !ok {
 			continue
@@ -436,8 +454,6 @@ func (p *IndexerIRCParse) MapCustomVariables(vars map[string]string) error {
 			vars[k] = v
 		}
 	}
-
-	return nil
 }
 
 func (p *IndexerIRCParse) Parse(def *IndexerDefinition, vars map[string]string, rls *Release) error {
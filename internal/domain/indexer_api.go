@@ -0,0 +1,286 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+type IndexerAPIResponseType string
+
+const (
+	IndexerAPIResponseTypeJSON IndexerAPIResponseType = "json"
+	IndexerAPIResponseTypeHTML IndexerAPIResponseType = "html"
+)
+
+// IndexerAPI describes how to poll the browse api or page of an indexer like a feed
+type IndexerAPI struct {
+	MinInterval int                                     `json:"minInterval"`
+	Request     IndexerAPIRequest                       `json:"request"`
+	Pagination  *IndexerAPIPagination                   `json:"pagination,omitempty"`
+	Response    IndexerAPIResponse                      `json:"response"`
+	Match       IndexerIRCParseMatch                    `json:"match"`
+	Mappings    map[string]map[string]map[string]string `json:"mappings,omitempty"`
+}
+
+type IndexerAPIRequest struct {
+	// URL is a template that is joined with the indexer base url. Settings like apikey can be used as {{ .apikey }}
+	URL    string `json:"url"`
+	Method string `json:"method,omitempty"`
+	// Headers values are templates just like URL and can be used for auth like Authorization: Bearer {{ .apikey }}
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type IndexerAPIPagination struct {
+	// Param is the query param holding the page number or offset
+	Param string `json:"param"`
+	Start int    `json:"start"`
+	// Step is 1 for page numbers or the page size for offsets
+	Step     int `json:"step"`
+	MaxPages int `json:"maxPages"`
+}
+
+type IndexerAPIResponse struct {
+	Type IndexerAPIResponseType `json:"type"`
+	// Items is the dotted JSON path to the list of items or the CSS selector of each item row
+	Items  string                     `json:"items"`
+	Fields map[string]IndexerAPIField `json:"fields"`
+}
+
+type IndexerAPIField struct {
+	// Path is the dotted JSON path relative to the item or the CSS selector within the item row
+	Path string `json:"path"`
+	// Attribute to read for html, defaults to the element text
+	Attribute string `json:"attribute,omitempty"`
+	// Pattern is an optional regex and the first capture group is used as value
+	Pattern string `json:"pattern,omitempty"`
+}
+
+func (a *IndexerAPI) Validate() error {
+	if a.Request.URL == "" {
+		return errors.New("api: request url required")
+	}
+
+	switch a.Request.Method {
+	case "", http.MethodGet, http.MethodPost:
+	default:
+		return errors.New("api: unsupported request method: %s", a.Request.Method)
+	}
+
+	switch a.Response.Type {
+	case IndexerAPIResponseTypeJSON:
+	case IndexerAPIResponseTypeHTML:
+		if a.Response.Items == "" {
+			return errors.New("api: html response requires items selector")
+		}
+	default:
+		return errors.New("api: unsupported response type: %s", a.Response.Type)
+	}
+
+	if _, ok := a.Response.Fields["torrentName"]; !ok && a.Match.TorrentName == "" {
+		return errors.New("api: torrentName field or match.torrentname required")
+	}
+
+	for name, field := range a.Response.Fields {
+		if field.Path == "" {
+			return errors.New("api: field %s requires path", name)
+		}
+
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return errors.Wrap(err, "api: field %s has invalid pattern", name)
+			}
+		}
+	}
+
+	if a.Pagination != nil && a.Pagination.Param == "" {
+		return errors.New("api: pagination requires param")
+	}
+
+	return nil
+}
+
+// Pages returns the values of the pagination param for each page to fetch.
+// Without pagination a single empty value is returned.
+func (a *IndexerAPI) Pages() []string {
+	if a.Pagination == nil {
+		return []string{""}
+	}
+
+	maxPages := max(a.Pagination.MaxPages, 1)
+	step := max(a.Pagination.Step, 1)
+
+	pages := make([]string, 0, maxPages)
+	for i := 0; i < maxPages; i++ {
+		pages = append(pages, strconv.Itoa(a.Pagination.Start+i*step))
+	}
+
+	return pages
+}
+
+// NewRequest builds the request for a page from the definition with the indexer settings as template vars
+func (a *IndexerAPI) NewRequest(baseURL string, vars map[string]string, page string) (*http.Request, error) {
+	requestURL, err := parseTemplateURL(baseURL, a.Request.URL, vars, "apiurl")
+	if err != nil {
+		return nil, err
+	}
+
+	if page != "" && a.Pagination != nil {
+		query := requestURL.Query()
+		query.Set(a.Pagination.Param, page)
+		requestURL.RawQuery = query.Encode()
+	}
+
+	method := a.Request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequest(method, requestURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build request")
+	}
+
+	for key, value := range a.Request.Headers {
+		tmpl, err := template.New(key).Funcs(sprig.TxtFuncMap()).Parse(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create header %s template", key)
+		}
+
+		var header bytes.Buffer
+		if err := tmpl.Execute(&header, &vars); err != nil {
+			return nil, errors.Wrap(err, "could not write header %s template output", key)
+		}
+
+		req.Header.Set(key, header.String())
+	}
+
+	if cookie, ok := vars["cookie"]; ok && cookie != "" && req.Header.Get("Cookie") == "" {
+		req.Header.Set("Cookie", cookie)
+	}
+
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", "autobrr")
+	}
+
+	return req, nil
+}
+
+// FieldValue applies the optional pattern of a field to the extracted value
+func (f IndexerAPIField) FieldValue(value string) string {
+	value = strings.TrimSpace(value)
+
+	if f.Pattern == "" || value == "" {
+		return value
+	}
+
+	rxp, err := regexp.Compile(f.Pattern)
+	if err != nil {
+		return ""
+	}
+
+	matches := rxp.FindStringSubmatch(value)
+	switch len(matches) {
+	case 0:
+		return ""
+	case 1:
+		return matches[0]
+	default:
+		return strings.TrimSpace(matches[1])
+	}
+}
+
+// Parse maps the vars extracted from an api response item onto the release
+func (a *IndexerAPI) Parse(def *IndexerDefinition, vars map[string]string, rls *Release) error {
+	mapCustomVariables(a.Mappings, vars)
+
+	// merge vars from the item and vars from settings
+	mergedVars := mergeVars(vars, def.SettingsMap)
+
+	// torrent name can be built from other fields
+	if err := a.Match.ParseTorrentName(mergedVars, rls); err != nil {
+		return errors.Wrap(err, "could not parse release name")
+	}
+
+	if rls.TorrentName != "" {
+		vars["torrentName"] = rls.TorrentName
+	}
+
+	if err := rls.MapVars(def, vars); err != nil {
+		return errors.Wrap(err, "could not map variables for release")
+	}
+
+	baseUrl := def.BaseURL
+	if baseUrl == "" {
+		if len(def.URLS) == 0 {
+			return errors.New("could not find a valid indexer baseUrl")
+		}
+
+		baseUrl = def.URLS[0]
+	}
+
+	// parse urls
+	if err := a.Match.ParseURLs(baseUrl, mergedVars, rls); err != nil {
+		return errors.Wrap(err, "could not parse urls for release")
+	}
+
+	if rls.DownloadURL == "" {
+		if downloadURL, ok := vars["downloadUrl"]; ok && downloadURL != "" {
+			rls.DownloadURL = resolveAPIURL(baseUrl, downloadURL)
+		}
+	}
+
+	if rls.InfoURL == "" {
+		if infoURL, ok := vars["infoUrl"]; ok && infoURL != "" {
+			rls.InfoURL = resolveAPIURL(baseUrl, infoURL)
+		}
+	}
+
+	if seeders, err := strconv.Atoi(vars["seeders"]); err == nil {
+		rls.Seeders = seeders
+	}
+
+	if leechers, err := strconv.Atoi(vars["leechers"]); err == nil {
+		rls.Leechers = leechers
+	}
+
+	if magnetURI, ok := vars["magnetUri"]; ok && strings.HasPrefix(magnetURI, MagnetURIPrefix) {
+		rls.MagnetURI = magnetURI
+	}
+
+	if err := (IRCParserDefault{}).Parse(rls, vars); err != nil {
+		return errors.Wrap(err, "could not parse release")
+	}
+
+	if v, ok := def.SettingsMap["cookie"]; ok {
+		rls.RawCookie = v
+	}
+
+	return nil
+}
+
+// resolveAPIURL makes relative urls from api responses absolute
+func resolveAPIURL(baseURL, ref string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ref
+	}
+
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	return u.String()
+}
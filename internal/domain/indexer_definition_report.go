@@ -0,0 +1,43 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import "fmt"
+
+const (
+	IndexerDefinitionSourceBuiltin = "builtin"
+	IndexerDefinitionSourceCustom  = "custom"
+)
+
+// IndexerDefinitionReport is the result of validating an indexer definition file and running its embedded line tests
+type IndexerDefinitionReport struct {
+	File        string   `json:"file"`
+	Source      string   `json:"source"`
+	Identifier  string   `json:"identifier"`
+	Name        string   `json:"name"`
+	Valid       bool     `json:"valid"`
+	Tests       int      `json:"tests"`
+	TestsPassed int      `json:"tests_passed"`
+	Errors      []string `json:"errors"`
+	Warnings    []string `json:"warnings"`
+}
+
+func (r *IndexerDefinitionReport) AddError(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+	r.Valid = false
+}
+
+func (r *IndexerDefinitionReport) AddWarning(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// IndexerDefinitionReload is the result of reloading the custom definitions
+type IndexerDefinitionReload struct {
+	// Definitions are the identifiers of the definitions that were added, changed or removed
+	Definitions []string `json:"definitions"`
+	// Indexers are the identifiers of the configured indexers that use the new definitions
+	Indexers []string `json:"indexers"`
+	// Failed are the reports of changed files that did not validate, the previous definitions are kept
+	Failed []IndexerDefinitionReport `json:"failed"`
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
 )
 
 type IrcRepo interface {
@@ -103,31 +105,59 @@ func (in IrcNetwork) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ResolveSecrets returns a copy of the network with env: and file: references
+// replaced by their values, including the proxy and channel passwords.
+func (in IrcNetwork) ResolveSecrets() (IrcNetwork, error) {
+	if err := resolveSecrets(&in.Pass, &in.Auth.Password); err != nil {
+		return in, errors.Wrap(err, "could not resolve secrets of network: %s", in.Name)
+	}
+
+	if in.Proxy != nil {
+		proxy, err := in.Proxy.ResolveSecrets()
+		if err != nil {
+			return in, err
+		}
+		in.Proxy = &proxy
+	}
+
+	channels := make([]IrcChannel, len(in.Channels))
+	for i, channel := range in.Channels {
+		if err := resolveSecrets(&channel.Password); err != nil {
+			return in, errors.Wrap(err, "could not resolve password of channel: %s", channel.Name)
+		}
+		channels[i] = channel
+	}
+	in.Channels = channels
+
+	return in, nil
+}
+
 type IrcNetworkWithHealth struct {
-	ID               int64               `json:"id"`
-	Name             string              `json:"name"`
-	Enabled          bool                `json:"enabled"`
-	Server           string              `json:"server"`
-	Port             int                 `json:"port"`
-	TLS              bool                `json:"tls"`
-	TLSSkipVerify    bool                `json:"tls_skip_verify"`
-	Pass             string              `json:"pass"`
-	Nick             string              `json:"nick"`
-	Auth             IRCAuth             `json:"auth,omitempty"`
-	InviteCommand    string              `json:"invite_command"`
-	UseBouncer       bool                `json:"use_bouncer"`
-	BouncerAddr      string              `json:"bouncer_addr"`
-	BotMode          bool                `json:"bot_mode"`
-	CurrentNick      string              `json:"current_nick"`
-	PreferredNick    string              `json:"preferred_nick"`
-	UseProxy         bool                `json:"use_proxy"`
-	ProxyId          int64               `json:"proxy_id"`
-	Proxy            *Proxy              `json:"proxy"`
-	Channels         []ChannelWithHealth `json:"channels"`
-	Connected        bool                `json:"connected"`
-	ConnectedSince   time.Time           `json:"connected_since"`
-	ConnectionErrors []string            `json:"connection_errors"`
-	Healthy          bool                `json:"healthy"`
+	ID               int64                  `json:"id"`
+	Name             string                 `json:"name"`
+	Enabled          bool                   `json:"enabled"`
+	Server           string                 `json:"server"`
+	Port             int                    `json:"port"`
+	TLS              bool                   `json:"tls"`
+	TLSSkipVerify    bool                   `json:"tls_skip_verify"`
+	Pass             string                 `json:"pass"`
+	Nick             string                 `json:"nick"`
+	Auth             IRCAuth                `json:"auth,omitempty"`
+	InviteCommand    string                 `json:"invite_command"`
+	UseBouncer       bool                   `json:"use_bouncer"`
+	BouncerAddr      string                 `json:"bouncer_addr"`
+	BotMode          bool                   `json:"bot_mode"`
+	CurrentNick      string                 `json:"current_nick"`
+	PreferredNick    string                 `json:"preferred_nick"`
+	UseProxy         bool                   `json:"use_proxy"`
+	ProxyId          int64                  `json:"proxy_id"`
+	Proxy            *Proxy                 `json:"proxy"`
+	Channels         []ChannelWithHealth    `json:"channels"`
+	Connected        bool                   `json:"connected"`
+	ConnectedSince   time.Time              `json:"connected_since"`
+	ConnectionErrors []string               `json:"connection_errors"`
+	Healthy          bool                   `json:"healthy"`
+	Parsers          []AnnounceParserHealth `json:"parsers"`
 }
 
 func (in IrcNetworkWithHealth) MarshalJSON() ([]byte, error) {
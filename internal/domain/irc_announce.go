@@ -0,0 +1,83 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// AnnounceLine is a raw announce line recorded from an IRC channel
+type AnnounceLine struct {
+	Channel   string    `json:"channel"`
+	Nick      string    `json:"nick"`
+	Line      string    `json:"line"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AnnounceParseFailure is an announce line that did not match the parse pattern of the indexer
+type AnnounceParseFailure struct {
+	Indexer     string    `json:"indexer"`
+	IndexerName string    `json:"indexer_name"`
+	Network     string    `json:"network"`
+	Channel     string    `json:"channel"`
+	Line        string    `json:"line"`
+	Pattern     string    `json:"pattern"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// AnnounceReplayRequest selects the recorded lines of a channel that are replayed.
+// A zero From or To leaves that side of the window open.
+type AnnounceReplayRequest struct {
+	NetworkID int64     `json:"-"`
+	Channel   string    `json:"-"`
+	From      time.Time `json:"from,omitempty"`
+	To        time.Time `json:"to,omitempty"`
+}
+
+func (r *AnnounceReplayRequest) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return errors.New("validation error: to must be after from")
+	}
+
+	return nil
+}
+
+// Contains reports if the line was recorded within the window
+func (r *AnnounceReplayRequest) Contains(line AnnounceLine) bool {
+	if !r.From.IsZero() && line.Timestamp.Before(r.From) {
+		return false
+	}
+
+	if !r.To.IsZero() && line.Timestamp.After(r.To) {
+		return false
+	}
+
+	return true
+}
+
+// AnnounceReplayResult is what the replayed lines were parsed into and how the filters of the indexer
+// matched them. Only the filter checks are run, no external filters or actions.
+type AnnounceReplayResult struct {
+	Indexer  string                 `json:"indexer"`
+	Channel  string                 `json:"channel"`
+	Lines    int                    `json:"lines"`
+	Releases []*Release             `json:"releases"`
+	Failures []AnnounceParseFailure `json:"failures"`
+	Filters  []*FilterTestResult    `json:"filters"`
+}
+
+// AnnounceParserHealth counts how many announces of an indexer matched the parse patterns of its definition.
+// Failing is set once the alert thresholds are crossed and cleared by the next matching announce.
+type AnnounceParserHealth struct {
+	Indexer             string    `json:"indexer"`
+	IndexerName         string    `json:"indexer_name"`
+	Successes           uint64    `json:"successes"`
+	Failures            uint64    `json:"failures"`
+	ConsecutiveFailures uint64    `json:"consecutive_failures"`
+	LastSuccess         time.Time `json:"last_success"`
+	LastFailure         time.Time `json:"last_failure"`
+	Failing             bool      `json:"failing"`
+}
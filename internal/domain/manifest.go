@@ -0,0 +1,181 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// ManifestVersion is the version of the manifest document format
+const ManifestVersion = 1
+
+// Manifest is a declarative document of the full configuration.
+// Resources reference each other by name instead of database id so a manifest
+// can be applied to any instance.
+type Manifest struct {
+	Version           int                `json:"version"`
+	Proxies           []ManifestResource `json:"proxies"`
+	DownloadClients   []ManifestResource `json:"download_clients"`
+	Indexers          []ManifestResource `json:"indexers"`
+	IrcNetworks       []ManifestResource `json:"irc_networks"`
+	Feeds             []ManifestResource `json:"feeds"`
+	Notifications     []ManifestResource `json:"notifications"`
+	DuplicateProfiles []ManifestResource `json:"duplicate_profiles"`
+	Filters           []ManifestResource `json:"filters"`
+	Lists             []ManifestResource `json:"lists"`
+	CleanupJobs       []ManifestResource `json:"cleanup_jobs"`
+}
+
+// ManifestResource holds the fields of a single resource in its json format
+type ManifestResource map[string]any
+
+func (m *Manifest) Validate() error {
+	if m.Version == 0 {
+		return errors.New("manifest version is required")
+	}
+
+	if m.Version > ManifestVersion {
+		return errors.New("unsupported manifest version %d, max supported version is %d", m.Version, ManifestVersion)
+	}
+
+	return nil
+}
+
+type ManifestKind string
+
+const (
+	ManifestKindProxy            ManifestKind = "proxy"
+	ManifestKindDownloadClient   ManifestKind = "download_client"
+	ManifestKindIndexer          ManifestKind = "indexer"
+	ManifestKindIrcNetwork       ManifestKind = "irc_network"
+	ManifestKindFeed             ManifestKind = "feed"
+	ManifestKindNotification     ManifestKind = "notification"
+	ManifestKindDuplicateProfile ManifestKind = "duplicate_profile"
+	ManifestKindFilter           ManifestKind = "filter"
+	ManifestKindList             ManifestKind = "list"
+	ManifestKindCleanupJob       ManifestKind = "cleanup_job"
+)
+
+// ManifestKinds are ordered so that every kind only references kinds before it
+var ManifestKinds = []ManifestKind{
+	ManifestKindProxy,
+	ManifestKindDownloadClient,
+	ManifestKindIndexer,
+	ManifestKindIrcNetwork,
+	ManifestKindFeed,
+	ManifestKindNotification,
+	ManifestKindDuplicateProfile,
+	ManifestKindFilter,
+	ManifestKindList,
+	ManifestKindCleanupJob,
+}
+
+// Section returns the resources of a kind, nil if the section is missing from the document
+func (m *Manifest) Section(kind ManifestKind) []ManifestResource {
+	return *m.section(kind)
+}
+
+func (m *Manifest) SetSection(kind ManifestKind, resources []ManifestResource) {
+	*m.section(kind) = resources
+}
+
+func (m *Manifest) section(kind ManifestKind) *[]ManifestResource {
+	switch kind {
+	case ManifestKindProxy:
+		return &m.Proxies
+	case ManifestKindDownloadClient:
+		return &m.DownloadClients
+	case ManifestKindIndexer:
+		return &m.Indexers
+	case ManifestKindIrcNetwork:
+		return &m.IrcNetworks
+	case ManifestKindFeed:
+		return &m.Feeds
+	case ManifestKindNotification:
+		return &m.Notifications
+	case ManifestKindDuplicateProfile:
+		return &m.DuplicateProfiles
+	case ManifestKindFilter:
+		return &m.Filters
+	case ManifestKindList:
+		return &m.Lists
+	default:
+		return &m.CleanupJobs
+	}
+}
+
+type ManifestFormat string
+
+const (
+	ManifestFormatYAML ManifestFormat = "yaml"
+	ManifestFormatJSON ManifestFormat = "json"
+)
+
+// ManifestSecrets controls how secrets like passwords and api keys are exported
+type ManifestSecrets string
+
+const (
+	// ManifestSecretsExclude replaces secrets with the redacted placeholder.
+	// Redacted secrets keep their current value on import.
+	ManifestSecretsExclude ManifestSecrets = "exclude"
+
+	// ManifestSecretsInclude exports secrets as plain text
+	ManifestSecretsInclude ManifestSecrets = "include"
+
+	// ManifestSecretsReference replaces secrets with env:AUTOBRR_SECRET_* references
+	// which are resolved on import like stored references.
+	ManifestSecretsReference ManifestSecrets = "reference"
+)
+
+type ManifestExportOptions struct {
+	Secrets ManifestSecrets
+}
+
+func (o *ManifestExportOptions) Validate() error {
+	switch o.Secrets {
+	case "":
+		o.Secrets = ManifestSecretsExclude
+	case ManifestSecretsExclude, ManifestSecretsInclude, ManifestSecretsReference:
+	default:
+		return errors.New("invalid secrets option: %s", o.Secrets)
+	}
+
+	return nil
+}
+
+type ManifestPlanOptions struct {
+	// Prune deletes resources that are missing from a section of the manifest.
+	// Sections that are left out of the document entirely are never pruned.
+	Prune bool
+}
+
+type ManifestChangeType string
+
+const (
+	ManifestChangeCreate ManifestChangeType = "create"
+	ManifestChangeUpdate ManifestChangeType = "update"
+	ManifestChangeDelete ManifestChangeType = "delete"
+)
+
+type ManifestChange struct {
+	Kind   ManifestKind       `json:"kind"`
+	Name   string             `json:"name"`
+	Type   ManifestChangeType `json:"type"`
+	Fields []string           `json:"fields,omitempty"`
+}
+
+type ManifestPlan struct {
+	Changes   []ManifestChange `json:"changes"`
+	Unchanged int              `json:"unchanged"`
+	Errors    []string         `json:"errors,omitempty"`
+	Applied   bool             `json:"applied"`
+}
+
+func (p *ManifestPlan) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+func (p *ManifestPlan) Valid() bool {
+	return len(p.Errors) == 0
+}
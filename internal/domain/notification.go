@@ -21,6 +21,13 @@ type NotificationRepo interface {
 	GetFilterNotifications(ctx context.Context, filterID int) ([]FilterNotification, error)
 	StoreFilterNotifications(ctx context.Context, filterID int, notifications []FilterNotification) error
 	DeleteFilterNotifications(ctx context.Context, filterID int) error
+
+	StoreDigestItem(ctx context.Context, item *NotificationDigestItem) error
+	FindDigestItems(ctx context.Context) ([]NotificationDigestItem, error)
+	DeleteDigestItems(ctx context.Context, notificationID int, maxID int64) error
+	StoreSent(ctx context.Context, notificationID int, sentAt time.Time) error
+	CountSent(ctx context.Context, notificationID int, since time.Time) (int, error)
+	DeleteSentBefore(ctx context.Context, before time.Time) error
 }
 
 type NotificationSender interface {
@@ -33,32 +40,38 @@ type NotificationSender interface {
 }
 
 type Notification struct {
-	ID            int                  `json:"id"`
-	Name          string               `json:"name"`
-	Type          NotificationType     `json:"type"`
-	Enabled       bool                 `json:"enabled"`
-	Events        []string             `json:"events"`
-	Token         string               `json:"token"`
-	APIKey        string               `json:"api_key"`
-	Webhook       string               `json:"webhook"`
-	Title         string               `json:"title"`
-	Icon          string               `json:"icon"`
-	Username      string               `json:"username"`
-	Host          string               `json:"host"`
-	Password      string               `json:"password"`
-	Channel       string               `json:"channel"`
-	Rooms         string               `json:"rooms"`
-	Targets       string               `json:"targets"`
-	Devices       string               `json:"devices"`
-	Priority      int32                `json:"priority"`
-	Topic         string               `json:"topic"`
-	Sound         string               `json:"sound"`
-	EventSounds   map[string]string    `json:"event_sounds,omitempty"` // event -> sound mapping
-	UsedByFilters []FilterNotification `json:"used_by_filters,omitempty"`
-	Method        string               `json:"method,omitempty"`
-	Headers       string               `json:"headers,omitempty"`
-	CreatedAt     time.Time            `json:"created_at"`
-	UpdatedAt     time.Time            `json:"updated_at"`
+	ID             int                  `json:"id"`
+	Name           string               `json:"name"`
+	Type           NotificationType     `json:"type"`
+	Enabled        bool                 `json:"enabled"`
+	Events         []string             `json:"events"`
+	Token          string               `json:"token"`
+	APIKey         string               `json:"api_key"`
+	Webhook        string               `json:"webhook"`
+	Title          string               `json:"title"`
+	Icon           string               `json:"icon"`
+	Username       string               `json:"username"`
+	Host           string               `json:"host"`
+	Password       string               `json:"password"`
+	Channel        string               `json:"channel"`
+	Rooms          string               `json:"rooms"`
+	Targets        string               `json:"targets"`
+	Devices        string               `json:"devices"`
+	Priority       int32                `json:"priority"`
+	Topic          string               `json:"topic"`
+	Sound          string               `json:"sound"`
+	EventSounds    map[string]string    `json:"event_sounds,omitempty"` // event -> sound mapping
+	UsedByFilters  []FilterNotification `json:"used_by_filters,omitempty"`
+	Method         string               `json:"method,omitempty"`
+	Headers        string               `json:"headers,omitempty"`
+	FromAddress    string               `json:"from_address,omitempty"`
+	RateLimit      int                  `json:"rate_limit"`      // max messages per hour, 0 is unlimited
+	DigestInterval int                  `json:"digest_interval"` // minutes, 0 disables digests
+	DigestEvents   []string             `json:"digest_events"`
+	TitleTemplate  string               `json:"title_template,omitempty"`
+	BodyTemplate   string               `json:"body_template,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
 
 	filters map[int]NotificationEvents
 }
@@ -111,6 +124,18 @@ func (n *Notification) IsEnabled() bool {
 		if n.Webhook != "" {
 			return true
 		}
+	case NotificationTypeMatrix:
+		if n.Host != "" && n.Token != "" && n.Rooms != "" {
+			return true
+		}
+	case NotificationTypeSlack:
+		if n.Webhook != "" {
+			return true
+		}
+	case NotificationTypeEmail:
+		if n.Host != "" && n.FromAddress != "" && n.Targets != "" {
+			return true
+		}
 	}
 	return false
 }
@@ -207,6 +232,7 @@ type NotificationPayload struct {
 	Sender              string
 	FilterNotifications []FilterNotification // per-filter notifications
 	Release             *Release             // full release data for webhook
+	Digest              *NotificationDigest  // summary of a digest event
 }
 
 type NotificationType string
@@ -228,19 +254,22 @@ const (
 	NotificationTypeLunaSea    NotificationType = "LUNASEA"
 	NotificationTypeShoutrrr   NotificationType = "SHOUTRRR"
 	NotificationTypeWebhook    NotificationType = "WEBHOOK"
+	NotificationTypeEmail      NotificationType = "EMAIL"
 )
 
 type NotificationEvent string
 
 const (
-	NotificationEventAppUpdateAvailable NotificationEvent = "APP_UPDATE_AVAILABLE"
-	NotificationEventPushApproved       NotificationEvent = "PUSH_APPROVED"
-	NotificationEventPushRejected       NotificationEvent = "PUSH_REJECTED"
-	NotificationEventPushError          NotificationEvent = "PUSH_ERROR"
-	NotificationEventIRCDisconnected    NotificationEvent = "IRC_DISCONNECTED"
-	NotificationEventIRCReconnected     NotificationEvent = "IRC_RECONNECTED"
-	NotificationEventReleaseNew         NotificationEvent = "RELEASE_NEW"
-	NotificationEventTest               NotificationEvent = "TEST"
+	NotificationEventAppUpdateAvailable   NotificationEvent = "APP_UPDATE_AVAILABLE"
+	NotificationEventPushApproved         NotificationEvent = "PUSH_APPROVED"
+	NotificationEventPushRejected         NotificationEvent = "PUSH_REJECTED"
+	NotificationEventPushError            NotificationEvent = "PUSH_ERROR"
+	NotificationEventIRCDisconnected      NotificationEvent = "IRC_DISCONNECTED"
+	NotificationEventIRCReconnected       NotificationEvent = "IRC_RECONNECTED"
+	NotificationEventAnnounceParseFailing NotificationEvent = "ANNOUNCE_PARSE_FAILING"
+	NotificationEventReleaseNew           NotificationEvent = "RELEASE_NEW"
+	NotificationEventDigest               NotificationEvent = "DIGEST"
+	NotificationEventTest                 NotificationEvent = "TEST"
 )
 
 func (e NotificationEvent) String() string {
@@ -287,14 +316,16 @@ type NotificationQueryParams struct {
 type WebhookEventType string
 
 const (
-	WebhookEventReleaseNew      WebhookEventType = "release.new"
-	WebhookEventActionApproved  WebhookEventType = "action.approved"
-	WebhookEventActionRejected  WebhookEventType = "action.rejected"
-	WebhookEventActionError     WebhookEventType = "action.error"
-	WebhookEventIRCDisconnected WebhookEventType = "irc.disconnected"
-	WebhookEventIRCReconnected  WebhookEventType = "irc.reconnected"
-	WebhookEventAppUpdate       WebhookEventType = "app.update_available"
-	WebhookEventTest            WebhookEventType = "test"
+	WebhookEventReleaseNew           WebhookEventType = "release.new"
+	WebhookEventActionApproved       WebhookEventType = "action.approved"
+	WebhookEventActionRejected       WebhookEventType = "action.rejected"
+	WebhookEventActionError          WebhookEventType = "action.error"
+	WebhookEventIRCDisconnected      WebhookEventType = "irc.disconnected"
+	WebhookEventIRCReconnected       WebhookEventType = "irc.reconnected"
+	WebhookEventAnnounceParseFailing WebhookEventType = "irc.announce_parse_failing"
+	WebhookEventAppUpdate            WebhookEventType = "app.update_available"
+	WebhookEventDigest               WebhookEventType = "notification.digest"
+	WebhookEventTest                 WebhookEventType = "test"
 )
 
 // WebhookEvent is the top-level webhook payload structure
@@ -308,11 +339,12 @@ type WebhookEvent struct {
 
 // WebhookData contains all nested event data
 type WebhookData struct {
-	Release *WebhookRelease `json:"release,omitempty"`
-	Indexer *WebhookIndexer `json:"indexer,omitempty"`
-	Filter  *WebhookFilter  `json:"filter,omitempty"`
-	Action  *WebhookAction  `json:"action,omitempty"`
-	Result  *WebhookResult  `json:"result,omitempty"`
+	Release *WebhookRelease     `json:"release,omitempty"`
+	Indexer *WebhookIndexer     `json:"indexer,omitempty"`
+	Filter  *WebhookFilter      `json:"filter,omitempty"`
+	Action  *WebhookAction      `json:"action,omitempty"`
+	Result  *WebhookResult      `json:"result,omitempty"`
+	Digest  *NotificationDigest `json:"digest,omitempty"`
 }
 
 // WebhookRelease contains release-specific data
@@ -415,8 +447,12 @@ func mapNotificationEventToWebhookEvent(event NotificationEvent) WebhookEventTyp
 		return WebhookEventIRCDisconnected
 	case NotificationEventIRCReconnected:
 		return WebhookEventIRCReconnected
+	case NotificationEventAnnounceParseFailing:
+		return WebhookEventAnnounceParseFailing
 	case NotificationEventAppUpdateAvailable:
 		return WebhookEventAppUpdate
+	case NotificationEventDigest:
+		return WebhookEventDigest
 	case NotificationEventTest:
 		return WebhookEventTest
 	default:
@@ -538,6 +574,8 @@ func NewWebhookEvent(event NotificationEvent, payload NotificationPayload, id st
 		}
 	}
 
+	data.Digest = payload.Digest
+
 	eventPayload.Data = data
 
 	return eventPayload
@@ -0,0 +1,207 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+const (
+	// NotificationRateLimitWindow is the window the rate limit of a notification counts sent messages in
+	NotificationRateLimitWindow = time.Hour
+
+	// notificationDigestMaxInterval is the longest digest interval in minutes
+	notificationDigestMaxInterval = 24 * 60
+
+	// notificationDigestTopCount is the number of entries listed per section of a digest
+	notificationDigestTopCount = 5
+)
+
+// NotificationDigestItem is an event collected for the next digest of a notification
+type NotificationDigestItem struct {
+	ID             int64             `json:"id"`
+	NotificationID int               `json:"notification_id"`
+	Event          NotificationEvent `json:"event"`
+	Filter         string            `json:"filter"`
+	Indexer        string            `json:"indexer"`
+	ReleaseName    string            `json:"release_name"`
+	Rejections     []string          `json:"rejections"`
+	RateLimited    bool              `json:"rate_limited"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+func NewNotificationDigestItem(notificationID int, event NotificationEvent, payload NotificationPayload, rateLimited bool) *NotificationDigestItem {
+	return &NotificationDigestItem{
+		NotificationID: notificationID,
+		Event:          event,
+		Filter:         payload.Filter,
+		Indexer:        payload.Indexer,
+		ReleaseName:    payload.ReleaseName,
+		Rejections:     payload.Rejections,
+		RateLimited:    rateLimited,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// NotificationDigestCount is the number of events of a filter, indexer or rejection reason
+type NotificationDigestCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NotificationDigest summarizes the events collected over a digest window
+type NotificationDigest struct {
+	Total       int                       `json:"total"`
+	RateLimited int                       `json:"rate_limited"`
+	From        time.Time                 `json:"from"`
+	To          time.Time                 `json:"to"`
+	Events      []NotificationDigestCount `json:"events"`
+	Filters     []NotificationDigestCount `json:"filters"`
+	Indexers    []NotificationDigestCount `json:"indexers"`
+	Rejections  []NotificationDigestCount `json:"rejections"`
+}
+
+// NewNotificationDigest counts the items per event, filter, indexer and rejection reason.
+// The rejection reasons are limited to the most common ones.
+func NewNotificationDigest(items []NotificationDigestItem) *NotificationDigest {
+	d := &NotificationDigest{Total: len(items)}
+
+	events := map[string]int{}
+	filters := map[string]int{}
+	indexers := map[string]int{}
+	rejections := map[string]int{}
+
+	for i, item := range items {
+		if i == 0 || item.CreatedAt.Before(d.From) {
+			d.From = item.CreatedAt
+		}
+		if item.CreatedAt.After(d.To) {
+			d.To = item.CreatedAt
+		}
+
+		if item.RateLimited {
+			d.RateLimited++
+		}
+
+		events[string(item.Event)]++
+
+		if item.Filter != "" {
+			filters[item.Filter]++
+		}
+		if item.Indexer != "" {
+			indexers[item.Indexer]++
+		}
+		for _, reason := range item.Rejections {
+			rejections[reason]++
+		}
+	}
+
+	d.Events = digestCounts(events, 0)
+	d.Filters = digestCounts(filters, 0)
+	d.Indexers = digestCounts(indexers, 0)
+	d.Rejections = digestCounts(rejections, notificationDigestTopCount)
+
+	return d
+}
+
+// digestCounts sorts the counts with the most common first, limit 0 keeps all
+func digestCounts(counts map[string]int, limit int) []NotificationDigestCount {
+	result := make([]NotificationDigestCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, NotificationDigestCount{Name: name, Count: count})
+	}
+
+	slices.SortFunc(result, func(a, b NotificationDigestCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// String returns the digest as plain text message
+func (d *NotificationDigest) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d events between %s and %s UTC", d.Total, d.From.UTC().Format("2006-01-02 15:04"), d.To.UTC().Format("15:04"))
+	if d.RateLimited > 0 {
+		fmt.Fprintf(&b, ", %d held back by the rate limit", d.RateLimited)
+	}
+	b.WriteString("\n")
+
+	sections := []struct {
+		title  string
+		counts []NotificationDigestCount
+	}{
+		{"Events", d.Events},
+		{"Filters", d.Filters},
+		{"Indexers", d.Indexers},
+		{"Top rejection reasons", d.Rejections},
+	}
+
+	for _, section := range sections {
+		if len(section.counts) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s:\n", section.title)
+
+		for i, c := range section.counts {
+			if i == notificationDigestTopCount {
+				fmt.Fprintf(&b, "- %d more\n", len(section.counts)-i)
+				break
+			}
+			fmt.Fprintf(&b, "- %s: %d\n", c.Name, c.Count)
+		}
+	}
+
+	return b.String()
+}
+
+// DigestEnabled reports whether the event is collected into digests instead of sent right away
+func (n *Notification) DigestEnabled(event NotificationEvent) bool {
+	if n.DigestInterval <= 0 {
+		return false
+	}
+
+	return slices.Contains(n.DigestEvents, string(event))
+}
+
+// DigestWindow returns how long events are collected before a digest is sent.
+// Events held back by the rate limit without a digest are sent after the rate limit window.
+func (n *Notification) DigestWindow() time.Duration {
+	if n.DigestInterval > 0 {
+		return time.Duration(n.DigestInterval) * time.Minute
+	}
+
+	return NotificationRateLimitWindow
+}
+
+func (n *Notification) validateLimits() error {
+	if n.RateLimit < 0 {
+		return errors.New("rate limit can not be negative")
+	}
+
+	if n.DigestInterval < 0 || n.DigestInterval > notificationDigestMaxInterval {
+		return errors.New("digest interval must be between 0 and %d minutes", notificationDigestMaxInterval)
+	}
+
+	if n.DigestInterval > 0 && len(n.DigestEvents) == 0 {
+		return errors.New("digest interval is set but no digest events are selected")
+	}
+
+	return nil
+}
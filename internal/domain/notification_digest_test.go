@@ -0,0 +1,109 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationDigest(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)
+
+	items := []NotificationDigestItem{
+		{Event: NotificationEventPushRejected, Filter: "TV", Indexer: "A", Rejections: []string{"size", "resolution"}, CreatedAt: start},
+		{Event: NotificationEventPushRejected, Filter: "TV", Indexer: "B", Rejections: []string{"size"}, CreatedAt: start.Add(5 * time.Minute)},
+		{Event: NotificationEventReleaseNew, Filter: "Movies", Indexer: "A", RateLimited: true, CreatedAt: start.Add(14 * time.Minute)},
+	}
+
+	d := NewNotificationDigest(items)
+
+	assert.Equal(t, 3, d.Total)
+	assert.Equal(t, 1, d.RateLimited)
+	assert.Equal(t, start, d.From)
+	assert.Equal(t, start.Add(14*time.Minute), d.To)
+	assert.Equal(t, []NotificationDigestCount{{Name: "PUSH_REJECTED", Count: 2}, {Name: "RELEASE_NEW", Count: 1}}, d.Events)
+	assert.Equal(t, []NotificationDigestCount{{Name: "TV", Count: 2}, {Name: "Movies", Count: 1}}, d.Filters)
+	assert.Equal(t, []NotificationDigestCount{{Name: "A", Count: 2}, {Name: "B", Count: 1}}, d.Indexers)
+	assert.Equal(t, []NotificationDigestCount{{Name: "size", Count: 2}, {Name: "resolution", Count: 1}}, d.Rejections)
+
+	assert.Equal(t, `3 events between 2026-10-17 14:00 and 14:14 UTC, 1 held back by the rate limit
+
+Events:
+- PUSH_REJECTED: 2
+- RELEASE_NEW: 1
+
+Filters:
+- TV: 2
+- Movies: 1
+
+Indexers:
+- A: 2
+- B: 1
+
+Top rejection reasons:
+- size: 2
+- resolution: 1
+`, d.String())
+}
+
+func TestNewNotificationDigest_TopRejections(t *testing.T) {
+	t.Parallel()
+
+	var items []NotificationDigestItem
+	for _, reason := range []string{"a", "b", "c", "d", "e", "f", "f"} {
+		items = append(items, NotificationDigestItem{Event: NotificationEventPushRejected, Rejections: []string{reason}})
+	}
+
+	d := NewNotificationDigest(items)
+
+	assert.Len(t, d.Rejections, 5)
+	assert.Equal(t, NotificationDigestCount{Name: "f", Count: 2}, d.Rejections[0])
+}
+
+func TestNotification_DigestEnabled(t *testing.T) {
+	t.Parallel()
+
+	n := Notification{DigestInterval: 15, DigestEvents: []string{string(NotificationEventPushRejected)}}
+
+	assert.True(t, n.DigestEnabled(NotificationEventPushRejected))
+	assert.False(t, n.DigestEnabled(NotificationEventPushApproved))
+	assert.Equal(t, 15*time.Minute, n.DigestWindow())
+
+	n.DigestInterval = 0
+	assert.False(t, n.DigestEnabled(NotificationEventPushRejected))
+	assert.Equal(t, NotificationRateLimitWindow, n.DigestWindow())
+}
+
+func TestNotification_Validate_Limits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notification Notification
+		wantErr      bool
+	}{
+		{name: "none", notification: Notification{}},
+		{name: "rate_limit", notification: Notification{RateLimit: 10}},
+		{name: "digest", notification: Notification{DigestInterval: 15, DigestEvents: []string{"PUSH_REJECTED"}}},
+		{name: "negative_rate_limit", notification: Notification{RateLimit: -1}, wantErr: true},
+		{name: "digest_without_events", notification: Notification{DigestInterval: 15}, wantErr: true},
+		{name: "digest_too_long", notification: Notification{DigestInterval: 24*60 + 1, DigestEvents: []string{"PUSH_REJECTED"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notification.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"bytes"
+	"text/template"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/ttlcache"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// NotificationTemplateData is the data available in notification title and body templates.
+// Payload fields are used directly like {{ .ReleaseName }}, the release macros
+// through {{ .Macro.Resolution }} and the full release through {{ .Release }}.
+type NotificationTemplateData struct {
+	NotificationPayload
+
+	// Title is the rendered title template or the default title of the event
+	Title string
+
+	// EventType is the namespaced event type like release.new
+	EventType WebhookEventType
+
+	Macro Macro
+}
+
+func NewNotificationTemplateData(event NotificationEvent, payload NotificationPayload, title string) NotificationTemplateData {
+	release := Release{}
+	if payload.Release != nil {
+		release = *payload.Release
+	}
+
+	if payload.Event == "" {
+		payload.Event = event
+	}
+
+	return NotificationTemplateData{
+		NotificationPayload: payload,
+		Title:               title,
+		EventType:           mapNotificationEventToWebhookEvent(event),
+		Macro:               NewMacro(release),
+	}
+}
+
+// RenderNotificationTemplate executes a title or body template with the sprig functions
+func RenderNotificationTemplate(text string, data NotificationTemplateData) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, ok := templateCache.Get(text)
+	if !ok {
+		var err error
+		tmpl, err = template.New("notification").Funcs(sprig.TxtFuncMap()).Parse(text)
+		if err != nil {
+			return "", errors.Wrap(err, "could not parse notification template")
+		}
+		templateCache.Set(text, tmpl, ttlcache.DefaultTTL)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "could not execute notification template")
+	}
+
+	return buf.String(), nil
+}
+
+// Validate checks the rate limit and digest settings and renders the title and
+// body templates with a sample release to catch syntax errors and unknown fields
+// before they are saved.
+func (n *Notification) Validate() error {
+	if err := n.validateLimits(); err != nil {
+		return err
+	}
+
+	if n.TitleTemplate == "" && n.BodyTemplate == "" {
+		return nil
+	}
+
+	data := NewNotificationTemplateData(NotificationEventTest, NotificationPayload{
+		Subject:   "Test Notification",
+		Message:   "autobrr goes brr!!",
+		Event:     NotificationEventTest,
+		Timestamp: time.Now(),
+		Release:   &Release{},
+	}, "Test")
+
+	if _, err := RenderNotificationTemplate(n.TitleTemplate, data); err != nil {
+		return errors.Wrap(err, "invalid title template")
+	}
+
+	if _, err := RenderNotificationTemplate(n.BodyTemplate, data); err != nil {
+		return errors.Wrap(err, "invalid body template")
+	}
+
+	return nil
+}
+
+// NotificationPreview is a rendered notification of a test event
+type NotificationPreview struct {
+	Event NotificationEvent `json:"event"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+}
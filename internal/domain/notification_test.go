@@ -111,3 +111,30 @@ func TestNewWebhookEvent_NilRelease(t *testing.T) {
 	assert.Equal(t, id, result.ID)
 	assert.Nil(t, result.Data.Release)
 }
+
+func TestNotification_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notification Notification
+		wantErr      bool
+	}{
+		{name: "no_templates", notification: Notification{}},
+		{name: "valid", notification: Notification{TitleTemplate: "{{ .Title }}", BodyTemplate: `{"text": {{ .ReleaseName | toJson }}, "size": "{{ .Macro.SizeString }}"}`}},
+		{name: "release_fields", notification: Notification{BodyTemplate: "{{ .Release.TorrentName }} {{ .Release.Indexer.Name }}"}},
+		{name: "syntax_error", notification: Notification{TitleTemplate: "{{ .Title "}, wantErr: true},
+		{name: "unknown_field", notification: Notification{BodyTemplate: "{{ .Macro.Unknown }}"}, wantErr: true},
+		{name: "unknown_function", notification: Notification{BodyTemplate: "{{ .Title | shout }}"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notification.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -42,6 +42,15 @@ func (p Proxy) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ResolveSecrets returns a copy of the proxy with env: and file: references replaced by their values
+func (p Proxy) ResolveSecrets() (Proxy, error) {
+	if err := resolveSecrets(&p.Pass); err != nil {
+		return p, errors.Wrap(err, "could not resolve secrets of proxy: %s", p.Name)
+	}
+
+	return p, nil
+}
+
 type ProxyType string
 
 const (
@@ -57,6 +57,24 @@ func (r *RejectionReasons) String() string {
 	return builder.String()
 }
 
+// Strings returns each rejection formatted as a separate string
+func (r *RejectionReasons) Strings() []string {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	reasons := make([]string, 0, len(r.data))
+	for _, rejection := range r.data {
+		if rejection.format != "" {
+			reasons = append(reasons, fmt.Sprintf(rejection.format, rejection.key, rejection.got, rejection.want))
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("[%s] not matching: got %v want: %v", rejection.key, rejection.got, rejection.want))
+	}
+
+	return reasons
+}
+
 func (r *RejectionReasons) StringTruncated() string {
 	r.m.RLock()
 	defer r.m.RUnlock()
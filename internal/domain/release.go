@@ -40,9 +40,12 @@ type ReleaseRepo interface {
 	Store(ctx context.Context, release *Release) error
 	Update(ctx context.Context, r *Release) error
 	Find(ctx context.Context, params ReleaseQueryParams) (*FindReleasesResponse, error)
+	FindForFilterTest(ctx context.Context, req *FilterTestRequest) ([]*Release, error)
 	Get(ctx context.Context, req *GetReleaseRequest) (*Release, error)
 	GetIndexerOptions(ctx context.Context) ([]string, error)
 	Stats(ctx context.Context) (*ReleaseStats, error)
+	StatsTimeseries(ctx context.Context, params *ReleaseStatsTimeseriesParams) (*ReleaseStatsTimeseries, error)
+	StatsBreakdown(ctx context.Context) (*ReleaseStatsBreakdown, error)
 	Delete(ctx context.Context, req *DeleteReleaseRequest) error
 	CheckSmartEpisodeCanDownload(ctx context.Context, p *SmartEpisodeParams) (bool, error)
 	UpdateBaseURL(ctx context.Context, indexer string, oldBaseURL, newBaseURL string) error
@@ -54,8 +57,10 @@ type ReleaseRepo interface {
 	FindDuplicateReleaseProfiles(ctx context.Context) ([]*DuplicateReleaseProfile, error)
 	DeleteReleaseProfileDuplicate(ctx context.Context, id int64) error
 	CheckIsDuplicateRelease(ctx context.Context, profile *DuplicateReleaseProfile, release *Release) (bool, error)
+	ExistsByNormalizedHash(ctx context.Context, indexer string, hash string, since time.Time) (bool, error)
 
 	ReleaseCleanupJobRepo
+	ReleaseActionRetryRepo
 }
 
 type Release struct {
@@ -570,6 +575,8 @@ const (
 	ReleaseImplementationTorznab ReleaseImplementation = "TORZNAB"
 	ReleaseImplementationNewznab ReleaseImplementation = "NEWZNAB"
 	ReleaseImplementationRSS     ReleaseImplementation = "RSS"
+	ReleaseImplementationAPI     ReleaseImplementation = "API"
+	ReleaseImplementationAPIFeed ReleaseImplementation = "API_FEED"
 )
 
 func (r ReleaseImplementation) String() string {
@@ -582,6 +589,10 @@ func (r ReleaseImplementation) String() string {
 		return "NEWZNAB"
 	case ReleaseImplementationRSS:
 		return "RSS"
+	case ReleaseImplementationAPI:
+		return "API"
+	case ReleaseImplementationAPIFeed:
+		return "API_FEED"
 	default:
 		return "IRC"
 	}
@@ -871,6 +882,11 @@ func (r *Release) DownloadTorrentFileCtx(ctx context.Context) error {
 }
 
 func (r *Release) downloadTorrentFile(ctx context.Context) error {
+	if r.TorrentTmpFile != "" {
+		// already downloaded, or resolved from the magnet link
+		return nil
+	}
+
 	if r.HasMagnetUri() {
 		return errors.New("downloading magnet links is not supported: %s", r.MagnetURI)
 	} else if r.Protocol != ReleaseProtocolTorrent {
@@ -879,9 +895,6 @@ func (r *Release) downloadTorrentFile(ctx context.Context) error {
 
 	if r.DownloadURL == "" {
 		return errors.New("download_file: url can't be empty")
-	} else if r.TorrentTmpFile != "" {
-		// already downloaded
-		return nil
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.DownloadURL, nil)
@@ -0,0 +1,144 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// ReleasePushRequest is a structured release sent by an external announce source.
+type ReleasePushRequest struct {
+	Name              string   `json:"name"`
+	IndexerIdentifier string   `json:"indexer_identifier"`
+	Protocol          string   `json:"protocol,omitempty"`
+	DownloadURL       string   `json:"download_url,omitempty"`
+	MagnetURI         string   `json:"magnet_uri,omitempty"`
+	InfoURL           string   `json:"info_url,omitempty"`
+	TorrentID         string   `json:"torrent_id,omitempty"`
+	Size              uint64   `json:"size,omitempty"`
+	Category          string   `json:"category,omitempty"`
+	Categories        []string `json:"categories,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+	ReleaseTags       string   `json:"release_tags,omitempty"`
+	Uploader          string   `json:"uploader,omitempty"`
+	Origin            string   `json:"origin,omitempty"`
+	Freeleech         bool     `json:"freeleech,omitempty"`
+	FreeleechPercent  int      `json:"freeleech_percent,omitempty"`
+	AnnounceType      string   `json:"announce_type,omitempty"`
+}
+
+func (r *ReleasePushRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("validation error: name is required")
+	}
+
+	if r.IndexerIdentifier == "" {
+		return errors.New("validation error: indexer_identifier is required")
+	}
+
+	if r.DownloadURL == "" && r.MagnetURI == "" {
+		return errors.New("validation error: download_url or magnet_uri is required")
+	}
+
+	if r.MagnetURI != "" && !strings.HasPrefix(r.MagnetURI, MagnetURIPrefix) {
+		return errors.New("validation error: invalid magnet_uri")
+	}
+
+	switch ReleaseProtocol(r.Protocol) {
+	case "", ReleaseProtocolTorrent, ReleaseProtocolNzb:
+	default:
+		return errors.New("validation error: invalid protocol %q", r.Protocol)
+	}
+
+	if r.FreeleechPercent < 0 || r.FreeleechPercent > 100 {
+		return errors.New("validation error: freeleech_percent must be between 0 and 100")
+	}
+
+	if r.AnnounceType != "" {
+		if _, err := ParseAnnounceType(r.AnnounceType); err != nil {
+			return errors.Wrap(err, "validation error: invalid announce_type")
+		}
+	}
+
+	return nil
+}
+
+// ToRelease builds a release for the indexer the same way an announce would.
+func (r *ReleasePushRequest) ToRelease(indexer IndexerMinimal) *Release {
+	rls := NewRelease(indexer)
+	rls.Implementation = ReleaseImplementationAPI
+
+	if r.Protocol != "" {
+		rls.Protocol = ReleaseProtocol(r.Protocol)
+	}
+
+	rls.DownloadURL = r.DownloadURL
+	rls.MagnetURI = r.MagnetURI
+	rls.InfoURL = r.InfoURL
+	rls.TorrentID = r.TorrentID
+	rls.Size = r.Size
+	rls.Category = r.Category
+	rls.Categories = r.Categories
+	rls.Uploader = r.Uploader
+	rls.Origin = r.Origin
+	rls.ReleaseTags = r.ReleaseTags
+
+	if len(r.Tags) > 0 {
+		rls.Tags = r.Tags
+	}
+
+	if r.AnnounceType != "" {
+		if announceType, err := ParseAnnounceType(r.AnnounceType); err == nil {
+			rls.AnnounceType = announceType
+		}
+	}
+
+	if r.Freeleech || r.FreeleechPercent > 0 {
+		rls.Freeleech = true
+		rls.FreeleechPercent = r.FreeleechPercent
+		if rls.FreeleechPercent == 0 {
+			rls.FreeleechPercent = 100
+		}
+
+		rls.Bonus = append(rls.Bonus, "Freeleech")
+
+		switch rls.FreeleechPercent {
+		case 25:
+			rls.Bonus = append(rls.Bonus, "Freeleech25")
+		case 50:
+			rls.Bonus = append(rls.Bonus, "Freeleech50")
+		case 75:
+			rls.Bonus = append(rls.Bonus, "Freeleech75")
+		case 100:
+			rls.Bonus = append(rls.Bonus, "Freeleech100")
+		}
+	}
+
+	rls.ParseString(r.Name)
+
+	return rls
+}
+
+// ReleasePushResult is the outcome of a pushed release.
+type ReleasePushResult struct {
+	Name         string                `json:"name"`
+	Indexer      string                `json:"indexer"`
+	ReleaseID    int64                 `json:"release_id,omitempty"`
+	FilterStatus ReleaseFilterStatus   `json:"filter_status"`
+	Filters      []ReleaseFilterResult `json:"filters"`
+	Actions      []ReleaseActionStatus `json:"actions"`
+	DelayedUntil *time.Time            `json:"delayed_until,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
+
+// ReleaseFilterResult is the outcome of checking a release against a single filter.
+type ReleaseFilterResult struct {
+	FilterID   int      `json:"filter_id"`
+	FilterName string   `json:"filter_name"`
+	Match      bool     `json:"match"`
+	Rejections []string `json:"rejections,omitempty"`
+}
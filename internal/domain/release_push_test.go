@@ -0,0 +1,74 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleasePushRequest_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		req     ReleasePushRequest
+		wantErr bool
+	}{
+		{name: "valid_download_url", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1"}, wantErr: false},
+		{name: "valid_magnet", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", MagnetURI: "magnet:?xt=urn:btih:abc"}, wantErr: false},
+		{name: "missing_name", req: ReleasePushRequest{IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1"}, wantErr: true},
+		{name: "missing_indexer", req: ReleasePushRequest{Name: "Test", DownloadURL: "https://example.com/dl/1"}, wantErr: true},
+		{name: "missing_download", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock"}, wantErr: true},
+		{name: "invalid_magnet", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", MagnetURI: "https://example.com"}, wantErr: true},
+		{name: "invalid_protocol", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1", Protocol: "ftp"}, wantErr: true},
+		{name: "invalid_freeleech_percent", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1", FreeleechPercent: 150}, wantErr: true},
+		{name: "invalid_announce_type", req: ReleasePushRequest{Name: "Test", IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1", AnnounceType: "OLD"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReleasePushRequest_ToRelease(t *testing.T) {
+	t.Parallel()
+
+	req := ReleasePushRequest{
+		Name:              "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP",
+		IndexerIdentifier: "mock",
+		DownloadURL:       "https://example.com/dl/1",
+		Size:              1073741824,
+		Category:          "TV",
+		Uploader:          "Uploader1",
+		FreeleechPercent:  50,
+		AnnounceType:      "PROMO",
+	}
+
+	rls := req.ToRelease(IndexerMinimal{ID: 1, Name: "Mock", Identifier: "mock"})
+
+	assert.Equal(t, ReleaseImplementationAPI, rls.Implementation)
+	assert.Equal(t, ReleaseProtocolTorrent, rls.Protocol)
+	assert.Equal(t, "mock", rls.Indexer.Identifier)
+	assert.Equal(t, "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP", rls.TorrentName)
+	assert.Equal(t, "That Show", rls.Title)
+	assert.Equal(t, 1, rls.Season)
+	assert.Equal(t, 1, rls.Episode)
+	assert.Equal(t, "1080p", rls.Resolution)
+	assert.Equal(t, "GROUP", rls.Group)
+	assert.Equal(t, "https://example.com/dl/1", rls.DownloadURL)
+	assert.Equal(t, uint64(1073741824), rls.Size)
+	assert.Equal(t, "TV", rls.Category)
+	assert.Equal(t, "Uploader1", rls.Uploader)
+	assert.True(t, rls.Freeleech)
+	assert.Equal(t, 50, rls.FreeleechPercent)
+	assert.Equal(t, []string{"Freeleech", "Freeleech50"}, rls.Bonus)
+	assert.Equal(t, AnnounceTypePromo, rls.AnnounceType)
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import "time"
+
+// ReleaseQueueStats is a snapshot of the release processing pipeline.
+type ReleaseQueueStats struct {
+	Workers       int    `json:"workers"`
+	QueueDepth    int    `json:"queue_depth"`
+	QueueCapacity int    `json:"queue_capacity"`
+	InFlight      int64  `json:"in_flight"`
+	Delayed       int    `json:"delayed"`
+	Processed     uint64 `json:"processed"`
+}
+
+// ReleaseDelayedJob is a matched release waiting for the filter delay before running actions.
+type ReleaseDelayedJob struct {
+	ID          string    `json:"id"`
+	ReleaseID   int64     `json:"release_id"`
+	ReleaseName string    `json:"release_name"`
+	Indexer     string    `json:"indexer"`
+	FilterID    int       `json:"filter_id"`
+	FilterName  string    `json:"filter_name"`
+	Delay       int       `json:"delay"`
+	QueuedAt    time.Time `json:"queued_at"`
+	RunAt       time.Time `json:"run_at"`
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// ReleaseStatsMaxBuckets caps the number of buckets returned for a single time range
+const ReleaseStatsMaxBuckets = 1000
+
+type ReleaseStatsBucket string
+
+const (
+	ReleaseStatsBucketHour ReleaseStatsBucket = "hour"
+	ReleaseStatsBucketDay  ReleaseStatsBucket = "day"
+	ReleaseStatsBucketWeek ReleaseStatsBucket = "week"
+)
+
+func (b ReleaseStatsBucket) Duration() time.Duration {
+	switch b {
+	case ReleaseStatsBucketHour:
+		return time.Hour
+	case ReleaseStatsBucketWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+type ReleaseStatsGroupBy string
+
+const (
+	ReleaseStatsGroupByNone    ReleaseStatsGroupBy = ""
+	ReleaseStatsGroupByIndexer ReleaseStatsGroupBy = "indexer"
+	ReleaseStatsGroupByFilter  ReleaseStatsGroupBy = "filter"
+	ReleaseStatsGroupByAction  ReleaseStatsGroupBy = "action"
+	ReleaseStatsGroupByClient  ReleaseStatsGroupBy = "client"
+)
+
+// HasReleaseCounts reports if releases can be counted for the grouping.
+// Actions and clients only exist on the push status of a release.
+func (g ReleaseStatsGroupBy) HasReleaseCounts() bool {
+	return g == ReleaseStatsGroupByNone || g == ReleaseStatsGroupByIndexer || g == ReleaseStatsGroupByFilter
+}
+
+type ReleaseStatsTimeseriesParams struct {
+	From    time.Time
+	To      time.Time
+	Bucket  ReleaseStatsBucket
+	GroupBy ReleaseStatsGroupBy
+}
+
+// Validate sets defaults for the bucket and time range and checks they can be queried
+func (p *ReleaseStatsTimeseriesParams) Validate() error {
+	switch p.Bucket {
+	case "":
+		p.Bucket = ReleaseStatsBucketDay
+	case ReleaseStatsBucketHour, ReleaseStatsBucketDay, ReleaseStatsBucketWeek:
+	default:
+		return errors.New("invalid bucket: %s", p.Bucket)
+	}
+
+	switch p.GroupBy {
+	case ReleaseStatsGroupByNone, ReleaseStatsGroupByIndexer, ReleaseStatsGroupByFilter, ReleaseStatsGroupByAction, ReleaseStatsGroupByClient:
+	default:
+		return errors.New("invalid group_by: %s", p.GroupBy)
+	}
+
+	if p.To.IsZero() {
+		p.To = time.Now()
+	}
+
+	if p.From.IsZero() {
+		p.From = p.To.Add(-30 * p.Bucket.Duration())
+	}
+
+	if !p.From.Before(p.To) {
+		return errors.New("from must be before to")
+	}
+
+	if p.To.Sub(p.From)/p.Bucket.Duration() > ReleaseStatsMaxBuckets {
+		return errors.New("time range exceeds %d %s buckets", ReleaseStatsMaxBuckets, p.Bucket)
+	}
+
+	return nil
+}
+
+type ReleaseStatsTimeseries struct {
+	From    time.Time                      `json:"from"`
+	To      time.Time                      `json:"to"`
+	Bucket  ReleaseStatsBucket             `json:"bucket"`
+	GroupBy ReleaseStatsGroupBy            `json:"group_by"`
+	Points  []*ReleaseStatsTimeseriesPoint `json:"points"`
+}
+
+// ReleaseStatsTimeseriesPoint holds the counts of a single bucket and group.
+// Release counts are zero when grouped by action or client.
+type ReleaseStatsTimeseriesPoint struct {
+	Time                time.Time `json:"time"`
+	Group               string    `json:"group,omitempty"`
+	TotalCount          int64     `json:"total_count"`
+	FilteredCount       int64     `json:"filtered_count"`
+	FilterRejectedCount int64     `json:"filter_rejected_count"`
+	PushApprovedCount   int64     `json:"push_approved_count"`
+	PushRejectedCount   int64     `json:"push_rejected_count"`
+	PushErrorCount      int64     `json:"push_error_count"`
+}
+
+// ReleaseStatsBreakdown holds the counts of the stored releases per dimension for the metrics collectors
+type ReleaseStatsBreakdown struct {
+	Releases []ReleaseStatsReleaseCount `json:"releases"`
+	Pushes   []ReleaseStatsPushCount    `json:"pushes"`
+}
+
+type ReleaseStatsReleaseCount struct {
+	Indexer      string `json:"indexer"`
+	Filter       string `json:"filter"`
+	FilterStatus string `json:"filter_status"`
+	Count        int64  `json:"count"`
+}
+
+type ReleaseStatsPushCount struct {
+	Indexer string `json:"indexer"`
+	Filter  string `json:"filter"`
+	Action  string `json:"action"`
+	Client  string `json:"client"`
+	Status  string `json:"status"`
+	Count   int64  `json:"count"`
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseStatsTimeseriesParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		params  ReleaseStatsTimeseriesParams
+		want    ReleaseStatsTimeseriesParams
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			params: ReleaseStatsTimeseriesParams{To: to},
+			want:   ReleaseStatsTimeseriesParams{From: to.AddDate(0, 0, -30), To: to, Bucket: ReleaseStatsBucketDay},
+		},
+		{
+			name:   "hour",
+			params: ReleaseStatsTimeseriesParams{To: to, Bucket: ReleaseStatsBucketHour, GroupBy: ReleaseStatsGroupByIndexer},
+			want:   ReleaseStatsTimeseriesParams{From: to.Add(-30 * time.Hour), To: to, Bucket: ReleaseStatsBucketHour, GroupBy: ReleaseStatsGroupByIndexer},
+		},
+		{
+			name:    "invalid_bucket",
+			params:  ReleaseStatsTimeseriesParams{Bucket: "month"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_group_by",
+			params:  ReleaseStatsTimeseriesParams{GroupBy: "uploader"},
+			wantErr: true,
+		},
+		{
+			name:    "from_after_to",
+			params:  ReleaseStatsTimeseriesParams{From: to.Add(time.Hour), To: to},
+			wantErr: true,
+		},
+		{
+			name:    "too_many_buckets",
+			params:  ReleaseStatsTimeseriesParams{From: to.AddDate(-1, 0, 0), To: to, Bucket: ReleaseStatsBucketHour},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.params)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package domain
 
 import (
+	"context"
 	"testing"
 
 	"github.com/moistari/rls"
@@ -1149,3 +1150,18 @@ func TestRelease_Hash(t *testing.T) {
 		})
 	}
 }
+
+func TestRelease_DownloadTorrentFile_ResolvedMagnet(t *testing.T) {
+	r := &Release{
+		TorrentName:    "Test.Release-GROUP",
+		MagnetURI:      "magnet:?xt=urn:btih:0123456789abcdef0123456789abcdef01234567",
+		Protocol:       ReleaseProtocolTorrent,
+		TorrentTmpFile: "/tmp/autobrr-resolved",
+	}
+
+	assert.NoError(t, r.DownloadTorrentFileCtx(context.Background()))
+	assert.Equal(t, "/tmp/autobrr-resolved", r.TorrentTmpFile)
+
+	r.TorrentTmpFile = ""
+	assert.Error(t, r.DownloadTorrentFileCtx(context.Background()))
+}
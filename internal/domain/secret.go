@@ -0,0 +1,177 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// Secret fields accept a reference instead of the value itself. References
+// are stored as is and resolved when the secret is used.
+const (
+	// SecretRefEnv reads the secret from an environment variable, env:AUTOBRR_SECRET_QBIT_PASS
+	SecretRefEnv = "env:"
+
+	// SecretRefFile reads the secret from a file in the secrets directory, file:/run/secrets/qbit
+	SecretRefFile = "file:"
+
+	// DefaultSecretEnvPrefix is the prefix environment variables need to be referenced
+	DefaultSecretEnvPrefix = "AUTOBRR_SECRET_"
+
+	// configEnvPrefix is the prefix of the config variables like AUTOBRR__SECRET_KEY, they can never be referenced
+	configEnvPrefix = "AUTOBRR__"
+)
+
+// secretFieldNames are json field names that hold secrets in addition to the indexer secret settings.
+// The audit log and the manifest export both redact them.
+var secretFieldNames = map[string]bool{
+	"password":        true,
+	"pass":            true,
+	"secret":          true,
+	"headers":         true,
+	"webhook_headers": true,
+	// notification webhook urls like discord hold a token
+	"webhook": true,
+	// exec args of actions and external filters often carry api keys
+	"exec_args":          true,
+	"external_exec_args": true,
+}
+
+// IsSecretField reports if the json field holds a secret
+func IsSecretField(name string) bool {
+	name = strings.ToLower(name)
+
+	return secretFieldNames[name] || IsIndexerSecretSetting(name)
+}
+
+// secretReferenceOptions limit what references can read, anyone who can edit a
+// secret field could otherwise read any variable or file of the process
+type secretReferenceOptions struct {
+	dir       string
+	envPrefix string
+}
+
+var secretReferences atomic.Pointer[secretReferenceOptions]
+
+// SetSecretReferenceOptions sets the directory file references are read from and the prefix
+// of referenced environment variables. File references are refused without a directory.
+func SetSecretReferenceOptions(dir string, envPrefix string) {
+	if envPrefix == "" {
+		envPrefix = DefaultSecretEnvPrefix
+	}
+
+	secretReferences.Store(&secretReferenceOptions{dir: dir, envPrefix: envPrefix})
+}
+
+// SecretEnvPrefix returns the prefix environment variables need to be referenced
+func SecretEnvPrefix() string {
+	return getSecretReferenceOptions().envPrefix
+}
+
+func getSecretReferenceOptions() *secretReferenceOptions {
+	if opts := secretReferences.Load(); opts != nil {
+		return opts
+	}
+
+	return &secretReferenceOptions{envPrefix: DefaultSecretEnvPrefix}
+}
+
+// IsSecretReference reports whether the value points to an environment variable or file
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretRefEnv) || strings.HasPrefix(value, SecretRefFile)
+}
+
+// ResolveSecret returns the value of a secret reference. Other values are returned unchanged.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretRefEnv):
+		name := strings.TrimPrefix(value, SecretRefEnv)
+		if name == "" {
+			return "", errors.New("secret reference %q is missing the variable name", value)
+		}
+
+		opts := getSecretReferenceOptions()
+		if !strings.HasPrefix(name, opts.envPrefix) || strings.HasPrefix(strings.ToUpper(name), configEnvPrefix) {
+			return "", errors.New("environment variable %s can not be referenced, only variables starting with %s", name, opts.envPrefix)
+		}
+
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", errors.New("environment variable %s is not set", name)
+		}
+
+		return v, nil
+
+	case strings.HasPrefix(value, SecretRefFile):
+		path := strings.TrimPrefix(value, SecretRefFile)
+		if path == "" {
+			return "", errors.New("secret reference %q is missing the file path", value)
+		}
+
+		path, err := secretFilePath(path)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read secret file")
+		}
+
+		// secret files usually end with a newline
+		return strings.TrimRight(string(data), "\r\n"), nil
+
+	default:
+		return value, nil
+	}
+}
+
+// secretFilePath returns the path of a secret file if it is inside the secrets directory.
+// Relative paths are read from the secrets directory and symlinks are followed before the check.
+func secretFilePath(path string) (string, error) {
+	opts := getSecretReferenceOptions()
+	if opts.dir == "" {
+		return "", errors.New("file secret references are disabled, set secretsDir in the config to use them")
+	}
+
+	dir, err := filepath.EvalSymlinks(opts.dir)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read secrets directory")
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read secret file")
+	}
+
+	rel, err := filepath.Rel(dir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("secret file %s is outside of the secrets directory", path)
+	}
+
+	return resolved, nil
+}
+
+// resolveSecrets resolves the given fields in place and stops at the first error
+func resolveSecrets(values ...*string) error {
+	for _, v := range values {
+		resolved, err := ResolveSecret(*v)
+		if err != nil {
+			return err
+		}
+
+		*v = resolved
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setSecretReferenceOptions sets the reference options for a test and restores the previous ones
+func setSecretReferenceOptions(t *testing.T, dir string, envPrefix string) {
+	t.Helper()
+
+	prev := secretReferences.Load()
+	SetSecretReferenceOptions(dir, envPrefix)
+	t.Cleanup(func() { secretReferences.Store(prev) })
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("AUTOBRR_SECRET_TEST", "from-env")
+	t.Setenv("AUTOBRR_TEST_SECRET", "not-allowed")
+	t.Setenv("AUTOBRR__SECRET_KEY", "config-secret")
+
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0600))
+
+	outside := t.TempDir()
+	outsideFile := filepath.Join(outside, "secret")
+	require.NoError(t, os.WriteFile(outsideFile, []byte("outside\n"), 0600))
+	require.NoError(t, os.Symlink(outsideFile, filepath.Join(dir, "link")))
+
+	setSecretReferenceOptions(t, dir, "")
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", value: "hunter2", want: "hunter2"},
+		{name: "empty", value: "", want: ""},
+		{name: "env", value: "env:AUTOBRR_SECRET_TEST", want: "from-env"},
+		{name: "env_missing", value: "env:AUTOBRR_SECRET_TEST_MISSING", wantErr: true},
+		{name: "env_without_name", value: "env:", wantErr: true},
+		{name: "env_without_prefix", value: "env:AUTOBRR_TEST_SECRET", wantErr: true},
+		{name: "env_config", value: "env:AUTOBRR__SECRET_KEY", wantErr: true},
+		{name: "file", value: "file:" + secretFile, want: "from-file"},
+		{name: "file_relative", value: "file:secret", want: "from-file"},
+		{name: "file_missing", value: "file:" + filepath.Join(dir, "missing"), wantErr: true},
+		{name: "file_without_path", value: "file:", wantErr: true},
+		{name: "file_outside_dir", value: "file:" + outsideFile, wantErr: true},
+		{name: "file_traversal", value: "file:../" + filepath.Base(outside) + "/secret", wantErr: true},
+		{name: "file_symlink_outside_dir", value: "file:link", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveSecret(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveSecret_Options(t *testing.T) {
+	t.Setenv("CUSTOM_QBIT_PASS", "qbit")
+	t.Setenv("AUTOBRR__SECRET_KEY", "config-secret")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret"), []byte("from-file"), 0600))
+
+	t.Run("file_disabled", func(t *testing.T) {
+		setSecretReferenceOptions(t, "", "")
+
+		_, err := ResolveSecret("file:" + filepath.Join(dir, "secret"))
+		assert.Error(t, err)
+	})
+
+	t.Run("custom_prefix", func(t *testing.T) {
+		setSecretReferenceOptions(t, "", "CUSTOM_")
+
+		got, err := ResolveSecret("env:CUSTOM_QBIT_PASS")
+		require.NoError(t, err)
+		assert.Equal(t, "qbit", got)
+	})
+
+	t.Run("config_prefix", func(t *testing.T) {
+		setSecretReferenceOptions(t, "", "AUTOBRR_")
+
+		_, err := ResolveSecret("env:AUTOBRR__SECRET_KEY")
+		assert.Error(t, err)
+	})
+}
+
+func TestDownloadClient_ResolveSecrets(t *testing.T) {
+	t.Setenv("AUTOBRR_SECRET_TEST_QBIT_PASS", "qbit")
+
+	client := DownloadClient{
+		Name:     "qbit",
+		Password: "env:AUTOBRR_SECRET_TEST_QBIT_PASS",
+		Settings: DownloadClientSettings{
+			APIKey: "plain",
+		},
+	}
+
+	resolved, err := client.ResolveSecrets()
+	require.NoError(t, err)
+
+	assert.Equal(t, "qbit", resolved.Password)
+	assert.Equal(t, "plain", resolved.Settings.APIKey)
+
+	// the original keeps the reference
+	assert.Equal(t, "env:AUTOBRR_SECRET_TEST_QBIT_PASS", client.Password)
+
+	client.Password = "env:AUTOBRR_SECRET_TEST_QBIT_PASS_MISSING"
+	_, err = client.ResolveSecrets()
+	assert.Error(t, err)
+}
+
+func TestRedactString_SecretReference(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "env:QBIT_PASS", RedactString("env:QBIT_PASS"))
+	assert.Equal(t, "file:/run/secrets/qbit", RedactString("file:/run/secrets/qbit"))
+	assert.Equal(t, RedactedStr, RedactString("hunter2"))
+}
+
+func TestIsSecretField(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"password", "pass", "webhook", "webhook_headers", "exec_args", "external_exec_args", "passkey", "API_KEY"} {
+		assert.True(t, IsSecretField(name), name)
+	}
+
+	for _, name := range []string{"name", "host", "webhook_host", "exec_cmd"} {
+		assert.False(t, IsSecretField(name), name)
+	}
+}
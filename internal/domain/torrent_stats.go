@@ -0,0 +1,156 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+type TorrentStatsRepo interface {
+	Store(ctx context.Context, stats *ReleaseTorrentStats) error
+	Update(ctx context.Context, stats *ReleaseTorrentStats) error
+	FindActive(ctx context.Context) ([]*ReleaseTorrentStats, error)
+	FindByReleaseID(ctx context.Context, releaseID int64) ([]*ReleaseTorrentStats, error)
+	StatsByFilter(ctx context.Context) ([]*TorrentStatsSummary, error)
+	StatsByIndexer(ctx context.Context) ([]*TorrentStatsSummary, error)
+}
+
+// ReleaseTorrentStats is a torrent pushed to a download client and the last known upload, ratio and seed time
+type ReleaseTorrentStats struct {
+	ID            int64              `json:"id"`
+	ReleaseID     int64              `json:"release_id"`
+	FilterID      int                `json:"filter_id"`
+	Indexer       string             `json:"indexer"`
+	ClientID      int32              `json:"client_id"`
+	ClientType    DownloadClientType `json:"-"`
+	TorrentName   string             `json:"torrent_name"`
+	TorrentHash   string             `json:"torrent_hash"`
+	Size          uint64             `json:"size"`
+	Uploaded      int64              `json:"uploaded"`
+	Downloaded    int64              `json:"downloaded"`
+	Ratio         float64            `json:"ratio"`
+	SeedTime      int64              `json:"seed_time"`
+	Progress      float64            `json:"progress"`
+	State         string             `json:"state"`
+	CompletedAt   *time.Time         `json:"completed_at"`
+	Removed       bool               `json:"removed"`
+	LastCheckedAt *time.Time         `json:"last_checked_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// TorrentClientStats is the state of a torrent as reported by a download client
+type TorrentClientStats struct {
+	Hash       string
+	Size       int64
+	Uploaded   int64
+	Downloaded int64
+	Ratio      float64
+	// SeedTime in seconds
+	SeedTime int64
+	// Progress from 0 to 1
+	Progress    float64
+	State       string
+	CompletedAt *time.Time
+}
+
+// TorrentStatsSummary sums up the tracked torrents of a filter or indexer
+type TorrentStatsSummary struct {
+	ID         int64   `json:"id,omitempty"`
+	Name       string  `json:"name"`
+	Identifier string  `json:"identifier,omitempty"`
+	Torrents   int     `json:"torrents"`
+	Completed  int     `json:"completed"`
+	Removed    int     `json:"removed"`
+	Size       int64   `json:"size"`
+	Uploaded   int64   `json:"uploaded"`
+	Downloaded int64   `json:"downloaded"`
+	Ratio      float64 `json:"ratio"`
+	// SeedTime is the total seed time in seconds of all torrents
+	SeedTime    int64 `json:"seed_time"`
+	AvgSeedTime int64 `json:"avg_seed_time"`
+}
+
+// NewReleaseTorrentStats returns the stats to track for a release pushed by the action.
+// Only torrent clients that can be polled are tracked and the info hash has to be known.
+func NewReleaseTorrentStats(action *Action, release *Release) (*ReleaseTorrentStats, bool) {
+	if !action.Type.SupportsTorrentStats() || action.Client == nil {
+		return nil, false
+	}
+
+	hash := release.TorrentHash
+	if hash == "" && release.HasMagnetUri() {
+		m, err := metainfo.ParseMagnetUri(release.MagnetURI)
+		if err != nil {
+			return nil, false
+		}
+		hash = m.InfoHash.HexString()
+	}
+
+	if hash == "" {
+		return nil, false
+	}
+
+	return &ReleaseTorrentStats{
+		ReleaseID:   release.ID,
+		FilterID:    release.FilterID,
+		Indexer:     release.Indexer.Identifier,
+		ClientID:    action.Client.ID,
+		ClientType:  action.Client.Type,
+		TorrentName: release.TorrentName,
+		TorrentHash: strings.ToLower(hash),
+		Size:        release.Size,
+	}, true
+}
+
+// Apply updates the stats with the state reported by the client.
+// A nil state means the torrent is no longer in the client.
+func (s *ReleaseTorrentStats) Apply(state *TorrentClientStats, now time.Time) {
+	s.LastCheckedAt = &now
+
+	if state == nil {
+		s.Removed = true
+		return
+	}
+
+	if state.Size > 0 {
+		s.Size = uint64(state.Size)
+	}
+
+	s.Uploaded = state.Uploaded
+	s.Downloaded = state.Downloaded
+	s.Ratio = state.Ratio
+	s.SeedTime = state.SeedTime
+	s.Progress = state.Progress
+	s.State = state.State
+
+	if s.CompletedAt == nil {
+		if state.CompletedAt != nil {
+			s.CompletedAt = state.CompletedAt
+		} else if state.Progress >= 1 {
+			s.CompletedAt = &now
+		}
+	}
+}
+
+// Calculate sets the ratio and average seed time from the totals.
+// Clients that do not report downloaded bytes fall back to the size.
+func (s *TorrentStatsSummary) Calculate() {
+	downloaded := s.Downloaded
+	if downloaded == 0 {
+		downloaded = s.Size
+	}
+
+	if downloaded > 0 {
+		s.Ratio = float64(s.Uploaded) / float64(downloaded)
+	}
+
+	if s.Torrents > 0 {
+		s.AvgSeedTime = s.SeedTime / int64(s.Torrents)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReleaseTorrentStats(t *testing.T) {
+	t.Parallel()
+
+	client := &DownloadClient{ID: 3, Type: DownloadClientTypeQbittorrent}
+
+	t.Run("torrent_hash", func(t *testing.T) {
+		release := &Release{ID: 10, FilterID: 2, TorrentName: "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP", TorrentHash: "ABCDEF0123456789ABCDEF0123456789ABCDEF01", Size: 1000, Indexer: IndexerMinimal{Identifier: "mock"}}
+
+		stats, ok := NewReleaseTorrentStats(&Action{Type: ActionTypeQbittorrent, Client: client}, release)
+		require.True(t, ok)
+		assert.Equal(t, "abcdef0123456789abcdef0123456789abcdef01", stats.TorrentHash)
+		assert.Equal(t, int64(10), stats.ReleaseID)
+		assert.Equal(t, 2, stats.FilterID)
+		assert.Equal(t, "mock", stats.Indexer)
+		assert.Equal(t, int32(3), stats.ClientID)
+		assert.Equal(t, uint64(1000), stats.Size)
+	})
+
+	t.Run("magnet", func(t *testing.T) {
+		release := &Release{MagnetURI: "magnet:?xt=urn:btih:0123456789abcdef0123456789abcdef01234567&dn=That.Show"}
+
+		stats, ok := NewReleaseTorrentStats(&Action{Type: ActionTypeDelugeV2, Client: client}, release)
+		require.True(t, ok)
+		assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", stats.TorrentHash)
+	})
+
+	t.Run("unsupported_action", func(t *testing.T) {
+		_, ok := NewReleaseTorrentStats(&Action{Type: ActionTypeRTorrent, Client: client}, &Release{TorrentHash: "abc"})
+		assert.False(t, ok)
+	})
+
+	t.Run("missing_hash", func(t *testing.T) {
+		_, ok := NewReleaseTorrentStats(&Action{Type: ActionTypeTransmission, Client: client}, &Release{})
+		assert.False(t, ok)
+	})
+}
+
+func TestReleaseTorrentStats_Apply(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	stats := &ReleaseTorrentStats{Size: 1000}
+	stats.Apply(&TorrentClientStats{Uploaded: 500, Downloaded: 400, Ratio: 1.25, Progress: 0.4, State: "downloading"}, now)
+	assert.Nil(t, stats.CompletedAt)
+	assert.Equal(t, uint64(1000), stats.Size)
+	assert.Equal(t, &now, stats.LastCheckedAt)
+
+	// clients without a completion time are completed once fully downloaded
+	stats.Apply(&TorrentClientStats{Uploaded: 1500, Downloaded: 1000, Ratio: 1.5, SeedTime: 60, Progress: 1, State: "seeding"}, now)
+	require.NotNil(t, stats.CompletedAt)
+	assert.Equal(t, now, *stats.CompletedAt)
+	assert.Equal(t, int64(60), stats.SeedTime)
+
+	stats.Apply(nil, now.Add(time.Hour))
+	assert.True(t, stats.Removed)
+	assert.Equal(t, int64(1500), stats.Uploaded)
+}
+
+func TestTorrentStatsSummary_Calculate(t *testing.T) {
+	t.Parallel()
+
+	summary := &TorrentStatsSummary{Torrents: 4, Size: 4000, Uploaded: 6000, Downloaded: 3000, SeedTime: 400}
+	summary.Calculate()
+	assert.Equal(t, 2.0, summary.Ratio)
+	assert.Equal(t, int64(100), summary.AvgSeedTime)
+
+	// fall back to size when the client does not report downloaded bytes
+	summary = &TorrentStatsSummary{Torrents: 1, Size: 4000, Uploaded: 2000}
+	summary.Calculate()
+	assert.Equal(t, 0.5, summary.Ratio)
+}
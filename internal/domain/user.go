@@ -6,10 +6,20 @@ package domain
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+var (
+	ErrUserExists = errors.New("user already exists")
+	ErrLastAdmin  = errors.New("there must be at least one admin")
 )
 
 type UserRepo interface {
 	GetUserCount(ctx context.Context) (int, error)
+	List(ctx context.Context) ([]User, error)
 	FindByUsername(ctx context.Context, username string) (*User, error)
 	Store(ctx context.Context, req CreateUserRequest) error
 	Update(ctx context.Context, req UpdateUserRequest) error
@@ -17,9 +27,56 @@ type UserRepo interface {
 }
 
 type User struct {
-	ID       int    `json:"id"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	Password  string    `json:"password"`
+	Role      UserRole  `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserRole string
+
+const (
+	// UserRoleAdmin can change everything including users, api keys and the config manifest
+	UserRoleAdmin UserRole = "admin"
+
+	// UserRoleEditor can change filters, clients, indexers and other settings
+	UserRoleEditor UserRole = "editor"
+
+	// UserRoleViewer can browse releases, logs, IRC and settings without changing anything
+	UserRoleViewer UserRole = "viewer"
+)
+
+var UserRoles = []UserRole{UserRoleAdmin, UserRoleEditor, UserRoleViewer}
+
+// userRoleScopes maps a role to the api key scopes it grants
+var userRoleScopes = map[UserRole][]string{
+	UserRoleAdmin:  {APIKeyScopeAdmin},
+	UserRoleEditor: {APIKeyScopeFiltersWrite, APIKeyScopeReleasesWrite, APIKeyScopeIRCAdmin, APIKeyScopeConfigWrite},
+	UserRoleViewer: {APIKeyScopeFiltersRead, APIKeyScopeReleasesRead, APIKeyScopeIRCRead, APIKeyScopeConfigRead},
+}
+
+func (r UserRole) IsValid() bool {
+	return slices.Contains(UserRoles, r)
+}
+
+// HasScope reports whether the role grants the scope, scopes imply each other the same way as for api keys
+func (r UserRole) HasScope(scope string) bool {
+	key := APIKey{Scopes: userRoleScopes[r]}
+	return key.HasScope(scope)
+}
+
+type userContextKey struct{}
+
+// ContextWithUser stores the user that makes a request so changes can be attributed to them
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the user that makes the request, or nil for requests without a user
+func UserFromContext(ctx context.Context) *User {
+	user, _ := ctx.Value(userContextKey{}).(*User)
+	return user
 }
 
 func (u User) MarshalJSON() ([]byte, error) {
@@ -40,14 +97,32 @@ type UserLoginRequest struct {
 }
 
 type UpdateUserRequest struct {
-	UsernameCurrent string `json:"username_username"`
-	UsernameNew     string `json:"username_new"`
-	PasswordCurrent string `json:"password_current"`
-	PasswordNew     string `json:"password_new"`
-	PasswordNewHash string `json:"-"`
+	UsernameCurrent string   `json:"username_username"`
+	UsernameNew     string   `json:"username_new"`
+	PasswordCurrent string   `json:"password_current"`
+	PasswordNew     string   `json:"password_new"`
+	PasswordNewHash string   `json:"-"`
+	Role            UserRole `json:"role,omitempty"`
 }
 
 type CreateUserRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Role     UserRole `json:"role"`
+}
+
+func (r CreateUserRequest) Validate() error {
+	if r.Username == "" {
+		return errors.New("validation error: empty username supplied")
+	}
+
+	if r.Password == "" {
+		return errors.New("validation error: empty password supplied")
+	}
+
+	if r.Role != "" && !r.Role.IsValid() {
+		return errors.New("validation error: invalid role: %s", r.Role)
+	}
+
+	return nil
 }
@@ -0,0 +1,19 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package download_client
+
+import (
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// clientAudit records the changes of download clients
+var clientAudit = audit.Resource[domain.DownloadClient]{
+	Type: domain.AuditResourceDownloadClient,
+	Key: func(c *domain.DownloadClient) (string, string) {
+		return strconv.Itoa(int(c.ID)), c.Name
+	},
+}
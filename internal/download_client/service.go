@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/pkg/arr/lidarr"
@@ -48,16 +49,18 @@ type Service interface {
 type service struct {
 	log       zerolog.Logger
 	repo      domain.DownloadClientRepo
+	auditSvc  audit.Service
 	subLogger *log.Logger
 
 	cache *ClientCache
 	m     sync.RWMutex
 }
 
-func NewService(log logger.Logger, repo domain.DownloadClientRepo) Service {
+func NewService(log logger.Logger, repo domain.DownloadClientRepo, auditSvc audit.Service) Service {
 	s := &service{
-		log:  log.With().Str("module", "download_client").Logger(),
-		repo: repo,
+		log:      log.With().Str("module", "download_client").Logger(),
+		repo:     repo,
+		auditSvc: auditSvc,
 
 		cache: NewClientCache(),
 		m:     sync.RWMutex{},
@@ -161,6 +164,8 @@ func (s *service) Store(ctx context.Context, client *domain.DownloadClient) erro
 
 	s.cache.Set(client.ID, client)
 
+	clientAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, client)
+
 	return err
 }
 
@@ -200,10 +205,14 @@ func (s *service) Update(ctx context.Context, client *domain.DownloadClient) err
 
 	s.cache.Set(client.ID, client)
 
+	clientAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, existingClient, client)
+
 	return err
 }
 
 func (s *service) Delete(ctx context.Context, clientID int32) error {
+	existingClient, _ := s.FindByID(ctx, clientID)
+
 	if err := s.repo.Delete(ctx, clientID); err != nil {
 		s.log.Error().Err(err).Msgf("could not delete download client: %v", clientID)
 		return err
@@ -211,6 +220,10 @@ func (s *service) Delete(ctx context.Context, clientID int32) error {
 
 	s.cache.Pop(clientID)
 
+	if existingClient != nil {
+		clientAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, existingClient, nil)
+	}
+
 	return nil
 }
 
@@ -242,6 +255,11 @@ func (s *service) Test(ctx context.Context, client domain.DownloadClient) error
 		}
 	}
 
+	client, err := client.ResolveSecrets()
+	if err != nil {
+		return err
+	}
+
 	// test
 	if err := s.testConnection(ctx, client); err != nil {
 		s.log.Error().Err(err).Msg("client connection test error")
@@ -274,6 +292,12 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 
 	l.Trace().Msgf("init cache client id %d %s", clientId, client.Name)
 
+	// the cached client keeps the env: and file: references, only the implementation gets the values
+	secrets, err := client.ResolveSecrets()
+	if err != nil {
+		return nil, err
+	}
+
 	switch client.Type {
 	case domain.DownloadClientTypeQbittorrent:
 		clientHost, err := client.BuildLegacyHost()
@@ -284,21 +308,21 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 		client.Client = qbittorrent.NewClient(qbittorrent.Config{
 			Host:          clientHost,
 			Username:      client.Username,
-			Password:      client.Password,
-			APIKey:        client.Settings.APIKey,
+			Password:      secrets.Password,
+			APIKey:        secrets.Settings.APIKey,
 			TLSSkipVerify: client.TLSSkipVerify,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "qBittorrent").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicUser:     client.Settings.Auth.Username,
-			BasicPass:     client.Settings.Auth.Password,
+			BasicPass:     secrets.Settings.Auth.Password,
 		})
 
 	case domain.DownloadClientTypePorla:
 		client.Client = porla.NewClient(porla.Config{
 			Hostname:      client.Host,
-			AuthToken:     client.Settings.APIKey,
+			AuthToken:     secrets.Settings.APIKey,
 			TLSSkipVerify: client.TLSSkipVerify,
 			BasicUser:     client.Settings.Auth.Username,
-			BasicPass:     client.Settings.Auth.Password,
+			BasicPass:     secrets.Settings.Auth.Password,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Porla").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 		})
 
@@ -307,7 +331,7 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 			Hostname:             client.Host,
 			Port:                 uint(client.Port),
 			Login:                client.Username,
-			Password:             client.Password,
+			Password:             secrets.Password,
 			DebugServerResponses: true,
 			ReadWriteTimeout:     time.Second * 60,
 		})
@@ -317,7 +341,7 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 			Hostname:             client.Host,
 			Port:                 uint(client.Port),
 			Login:                client.Username,
-			Password:             client.Password,
+			Password:             secrets.Password,
 			DebugServerResponses: true,
 			ReadWriteTimeout:     time.Second * 60,
 		})
@@ -336,7 +360,7 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 		tbt, err := transmission.New(transmissionURL, &transmission.Config{
 			UserAgent:     "autobrr",
 			Username:      client.Username,
-			Password:      client.Password,
+			Password:      secrets.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 		if err != nil {
@@ -350,14 +374,14 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 				Addr:          client.Host,
 				TLSSkipVerify: client.TLSSkipVerify,
 				BasicUser:     client.Settings.Auth.Username,
-				BasicPass:     client.Settings.Auth.Password,
+				BasicPass:     secrets.Settings.Auth.Password,
 				Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "rTorrent").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			}
 
 			httpClient := &http.Client{
 				Transport: &digest.Transport{
 					Username: client.Settings.Auth.Username,
-					Password: client.Settings.Auth.Password,
+					Password: secrets.Settings.Auth.Password,
 					Transport: &http.Transport{
 						TLSClientConfig: &tls.Config{InsecureSkipVerify: client.TLSSkipVerify},
 					},
@@ -372,7 +396,7 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 				Addr:          client.Host,
 				TLSSkipVerify: client.TLSSkipVerify,
 				BasicUser:     client.Settings.Auth.Username,
-				BasicPass:     client.Settings.Auth.Password,
+				BasicPass:     secrets.Settings.Auth.Password,
 				Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "rTorrent").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			})
 		}
@@ -380,72 +404,72 @@ func (s *service) GetClient(ctx context.Context, clientId int32) (*domain.Downlo
 	case domain.DownloadClientTypeLidarr:
 		client.Client = lidarr.New(lidarr.Config{
 			Hostname:      client.Host,
-			APIKey:        client.Settings.APIKey,
+			APIKey:        secrets.Settings.APIKey,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Lidarr").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicAuth:     client.Settings.Auth.Enabled,
 			Username:      client.Settings.Auth.Username,
-			Password:      client.Settings.Auth.Password,
+			Password:      secrets.Settings.Auth.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 
 	case domain.DownloadClientTypeRadarr:
 		client.Client = radarr.New(radarr.Config{
 			Hostname:      client.Host,
-			APIKey:        client.Settings.APIKey,
+			APIKey:        secrets.Settings.APIKey,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Radarr").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicAuth:     client.Settings.Auth.Enabled,
 			Username:      client.Settings.Auth.Username,
-			Password:      client.Settings.Auth.Password,
+			Password:      secrets.Settings.Auth.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 
 	case domain.DownloadClientTypeReadarr:
 		client.Client = readarr.New(readarr.Config{
 			Hostname:      client.Host,
-			APIKey:        client.Settings.APIKey,
+			APIKey:        secrets.Settings.APIKey,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Readarr").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicAuth:     client.Settings.Auth.Enabled,
 			Username:      client.Settings.Auth.Username,
-			Password:      client.Settings.Auth.Password,
+			Password:      secrets.Settings.Auth.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 
 	case domain.DownloadClientTypeSonarr:
 		client.Client = sonarr.New(sonarr.Config{
 			Hostname:      client.Host,
-			APIKey:        client.Settings.APIKey,
+			APIKey:        secrets.Settings.APIKey,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Sonarr").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicAuth:     client.Settings.Auth.Enabled,
 			Username:      client.Settings.Auth.Username,
-			Password:      client.Settings.Auth.Password,
+			Password:      secrets.Settings.Auth.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 
 	case domain.DownloadClientTypeWhisparr:
 		client.Client = whisparr.New(whisparr.Config{
 			Hostname:      client.Host,
-			APIKey:        client.Settings.APIKey,
+			APIKey:        secrets.Settings.APIKey,
 			Log:           zstdlog.NewStdLoggerWithLevel(s.log.With().Str("type", "Whisparr").Str("client", client.Name).Logger(), zerolog.TraceLevel),
 			BasicAuth:     client.Settings.Auth.Enabled,
 			Username:      client.Settings.Auth.Username,
-			Password:      client.Settings.Auth.Password,
+			Password:      secrets.Settings.Auth.Password,
 			TLSSkipVerify: client.TLSSkipVerify,
 		})
 
 	case domain.DownloadClientTypeSabnzbd:
 		client.Client = sabnzbd.New(sabnzbd.Options{
 			Addr:      client.Host,
-			ApiKey:    client.Settings.APIKey,
+			ApiKey:    secrets.Settings.APIKey,
 			Log:       nil,
 			BasicUser: client.Settings.Auth.Username,
-			BasicPass: client.Settings.Auth.Password,
+			BasicPass: secrets.Settings.Auth.Password,
 		})
 
 	case domain.DownloadClientTypeNzbget:
 		client.Client = nzbget.New(nzbget.Options{
 			Host:     client.Host,
 			Username: client.Username,
-			Password: client.Password,
+			Password: secrets.Password,
 		})
 	}
 
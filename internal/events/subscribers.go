@@ -8,9 +8,11 @@ import (
 
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/feed"
+	"github.com/autobrr/autobrr/internal/irc"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/notification"
 	"github.com/autobrr/autobrr/internal/release"
+	"github.com/autobrr/autobrr/internal/tracking"
 	"github.com/autobrr/autobrr/pkg/errors"
 
 	"github.com/asaskevich/EventBus"
@@ -22,17 +24,21 @@ type Subscriber struct {
 	eventbus EventBus.Bus
 
 	feedSvc         feed.Service
+	ircSvc          irc.Service
 	notificationSvc notification.Sender
 	releaseSvc      release.Service
+	trackingSvc     tracking.Service
 }
 
-func NewSubscribers(log logger.Logger, eventbus EventBus.Bus, feedSvc feed.Service, notificationSvc notification.Sender, releaseSvc release.Service) Subscriber {
+func NewSubscribers(log logger.Logger, eventbus EventBus.Bus, feedSvc feed.Service, ircSvc irc.Service, notificationSvc notification.Sender, releaseSvc release.Service, trackingSvc tracking.Service) Subscriber {
 	s := Subscriber{
 		log:             log.With().Str("module", "events").Logger(),
 		eventbus:        eventbus,
 		feedSvc:         feedSvc,
+		ircSvc:          ircSvc,
 		notificationSvc: notificationSvc,
 		releaseSvc:      releaseSvc,
+		trackingSvc:     trackingSvc,
 	}
 
 	s.Register()
@@ -45,6 +51,8 @@ func (s Subscriber) Register() {
 	s.eventbus.Subscribe(domain.EventReleasePushStatus, s.handleReleasePushStatus)
 	s.eventbus.Subscribe(domain.EventNotificationSend, s.handleSendNotification)
 	s.eventbus.Subscribe(domain.EventIndexerDelete, s.handleIndexerDelete)
+	s.eventbus.Subscribe(domain.EventReleaseTrackTorrent, s.handleReleaseTrackTorrent)
+	s.eventbus.Subscribe(domain.EventIndexerDefinitionReload, s.handleIndexerDefinitionReload)
 }
 
 func (s Subscriber) handleReleaseActionStatus(actionStatus *domain.ReleaseActionStatus) {
@@ -64,6 +72,14 @@ func (s Subscriber) handleReleasePushStatus(actionStatus *domain.ReleaseActionSt
 	}
 }
 
+func (s Subscriber) handleReleaseTrackTorrent(stats *domain.ReleaseTorrentStats) {
+	s.log.Trace().Str("event", domain.EventReleaseTrackTorrent).Msgf("events: 'release:track-torrent' '%+v'", stats)
+
+	if err := s.trackingSvc.Track(context.Background(), stats); err != nil {
+		s.log.Error().Err(err).Msgf("events: 'release:track-torrent' error")
+	}
+}
+
 func (s Subscriber) handleSendNotification(event *domain.NotificationEvent, payload *domain.NotificationPayload) {
 	s.log.Trace().Str("event", domain.EventNotificationSend).Msgf("send notification events: '%v' '%+v'", *event, payload)
 
@@ -94,3 +110,18 @@ func (s Subscriber) handleIndexerDelete(indexer *domain.Indexer) {
 		s.log.Debug().Msgf("successfully removed feed: %s", feedItem.Name)
 	}
 }
+
+// handleIndexerDefinitionReload restarts the irc handlers or feed job of an indexer that uses a reloaded definition
+func (s Subscriber) handleIndexerDefinitionReload(definition *domain.IndexerDefinition) {
+	s.log.Trace().Str("event", domain.EventIndexerDefinitionReload).Msgf("events: 'indexer:definition-reload' '%s'", definition.Identifier)
+
+	switch definition.Implementation {
+	case string(domain.IndexerImplementationIRC):
+		s.ircSvc.ReloadIndexer(definition)
+
+	case string(domain.IndexerImplementationRSS), string(domain.IndexerImplementationTorznab), string(domain.IndexerImplementationNewznab):
+		if err := s.feedSvc.RestartIndexerFeed(context.Background(), definition.ID); err != nil {
+			s.log.Error().Err(err).Msgf("events: 'indexer:definition-reload' error, could not restart feed of indexer: %s", definition.Identifier)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/proxy"
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/sharedhttp"
+
+	"github.com/rs/zerolog"
+)
+
+// maxAPIResponseSize limits how much of a single api response page is read
+const maxAPIResponseSize = 10 << 20
+
+type APIJob struct {
+	Feed       *domain.Feed
+	Name       string
+	Log        zerolog.Logger
+	URL        string
+	Definition *domain.IndexerDefinition
+	Repo       jobFeedRepo
+	CacheRepo  jobFeedCacheRepo
+	ReleaseSvc jobReleaseSvc
+	Timeout    time.Duration
+
+	attempts int
+	errors   []error
+
+	JobID int
+}
+
+// apiItem is an item of an api response with the vars of the mapped fields
+type apiItem struct {
+	GUID string
+	Vars map[string]string
+}
+
+func NewAPIJob(feed *domain.Feed, name string, log zerolog.Logger, url string, definition *domain.IndexerDefinition, repo jobFeedRepo, cacheRepo jobFeedCacheRepo, releaseSvc jobReleaseSvc, timeout time.Duration) RefreshFeedJob {
+	return &APIJob{
+		Feed:       feed,
+		Name:       name,
+		Log:        log,
+		URL:        url,
+		Definition: definition,
+		Repo:       repo,
+		CacheRepo:  cacheRepo,
+		ReleaseSvc: releaseSvc,
+		Timeout:    timeout,
+	}
+}
+
+func (j *APIJob) Run() {
+	ctx := context.Background()
+
+	if err := j.RunE(ctx); err != nil {
+		j.Log.Err(err).Int("attempts", j.attempts).Msg("api feed process error")
+
+		j.errors = append(j.errors, err)
+	}
+
+	j.attempts = 0
+	j.errors = j.errors[:0]
+}
+
+func (j *APIJob) RunE(ctx context.Context) error {
+	if err := j.process(ctx); err != nil {
+		j.Log.Err(err).Msg("api feed process error")
+		return err
+	}
+
+	return nil
+}
+
+func (j *APIJob) process(ctx context.Context) error {
+	items, err := j.getFeed(ctx)
+	if err != nil {
+		j.Log.Error().Err(err).Msgf("error fetching api feed items")
+		return errors.Wrap(err, "error getting api feed items")
+	}
+
+	j.Log.Debug().Msgf("found (%d) new items to process", len(items))
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	releases := j.processItems(items)
+
+	// process all new releases
+	go j.ReleaseSvc.ProcessMultipleFromIndexer(releases, j.Feed.Indexer)
+
+	return nil
+}
+
+func (j *APIJob) processItems(items []apiItem) []*domain.Release {
+	releases := make([]*domain.Release, 0)
+	now := time.Now()
+
+	for _, item := range items {
+		j.Log.Trace().Str("item", item.GUID).Msg("processing item..")
+
+		if j.Feed.MaxAge > 0 {
+			if published, ok := parseAPIPublishDate(item.Vars["publishDate"]); ok {
+				if !isNewerThanMaxAge(j.Feed.MaxAge, published, now) {
+					j.Log.Trace().Msgf("item is older than feed max age, skipping: %s", item.GUID)
+					continue
+				}
+			}
+		}
+
+		rls := domain.NewRelease(j.Feed.Indexer)
+		rls.Implementation = domain.ReleaseImplementationAPIFeed
+
+		if err := j.Definition.API.Parse(j.Definition, item.Vars, rls); err != nil {
+			j.Log.Error().Err(err).Msgf("could not parse api item: %s", item.GUID)
+			continue
+		}
+
+		if j.Feed.Settings != nil && j.Feed.Settings.DownloadType == domain.FeedDownloadTypeMagnet && rls.MagnetURI != "" {
+			rls.DownloadURL = ""
+		}
+
+		releases = append(releases, rls)
+	}
+
+	return releases
+}
+
+func (j *APIJob) getFeed(ctx context.Context) ([]apiItem, error) {
+	if j.Definition == nil || j.Definition.API == nil {
+		return nil, errors.New("missing api definition for feed: %s", j.Feed.Name)
+	}
+
+	client, err := j.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, j.Timeout)
+	defer cancel()
+
+	var (
+		found   []apiItem
+		seen    = map[string]bool{}
+		lastRun string
+	)
+
+	for _, page := range j.Definition.API.Pages() {
+		body, err := j.fetchPage(ctx, client, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "error fetching api feed page %s", page)
+		}
+
+		if lastRun == "" {
+			lastRun = string(body)
+		}
+
+		pageItems, err := parseAPIResponse(j.Definition.API.Response, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing api feed page %s", page)
+		}
+
+		if len(pageItems) == 0 {
+			break
+		}
+
+		for _, vars := range pageItems {
+			guid := apiItemGUID(vars)
+			if guid == "" {
+				j.Log.Error().Msgf("missing guid from api feed item: %s", j.Feed.Name)
+				continue
+			}
+
+			// pages can shift between requests when new items are added
+			if seen[guid] {
+				continue
+			}
+			seen[guid] = true
+
+			found = append(found, apiItem{GUID: guid, Vars: vars})
+		}
+	}
+
+	if err := j.Repo.UpdateLastRunWithData(ctx, j.Feed.ID, lastRun); err != nil {
+		j.Log.Error().Err(err).Msgf("error updating last run for feed id: %v", j.Feed.ID)
+	}
+
+	j.Log.Debug().Msgf("refreshing api feed: %v, found (%d) items", j.Name, len(found))
+
+	items := make([]apiItem, 0)
+	if len(found) == 0 {
+		return items, nil
+	}
+
+	guids := make([]string, 0, len(found))
+	guidItemMap := make(map[string]apiItem, len(found))
+
+	for _, item := range found {
+		guids = append(guids, item.GUID)
+		guidItemMap[item.GUID] = item
+	}
+
+	// reverse order so oldest items are processed first
+	slices.Reverse(guids)
+
+	existingGuids, err := j.CacheRepo.ExistingItems(ctx, j.Feed.ID, guids)
+	if err != nil {
+		j.Log.Error().Err(err).Msg("could not check existing items")
+		return nil, errors.Wrap(err, "could not check existing items")
+	}
+
+	// set ttl to 1 month
+	ttl := time.Now().AddDate(0, 1, 0)
+	toCache := make([]domain.FeedCacheItem, 0)
+
+	for _, guid := range guids {
+		item := guidItemMap[guid]
+		if existingGuids[guid] {
+			j.Log.Trace().Msgf("cache item exists, skipping release: %s", guid)
+			continue
+		}
+
+		j.Log.Debug().Msgf("found new release: %s", item.Vars["torrentName"])
+
+		toCache = append(toCache, domain.FeedCacheItem{
+			FeedId: strconv.Itoa(j.Feed.ID),
+			Key:    guid,
+			Value:  []byte(item.Vars["torrentName"]),
+			TTL:    ttl,
+		})
+
+		items = append(items, item)
+	}
+
+	if len(toCache) > 0 {
+		go func(items []domain.FeedCacheItem) {
+			ctx := context.Background()
+			if err := j.CacheRepo.PutMany(ctx, items); err != nil {
+				j.Log.Error().Err(err).Msg("cache.PutMany: error storing items in cache")
+			}
+		}(toCache)
+	}
+
+	return items, nil
+}
+
+func (j *APIJob) fetchPage(ctx context.Context, client *http.Client, page string) ([]byte, error) {
+	req, err := j.Definition.API.NewRequest(j.URL, j.Definition.SettingsMap, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.Feed.Cookie != "" && req.Header.Get("Cookie") == "" {
+		req.Header.Set("Cookie", j.Feed.Cookie)
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request")
+	}
+	defer sharedhttp.DrainAndClose(res)
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("unexpected status code: %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxAPIResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+
+	return body, nil
+}
+
+func (j *APIJob) httpClient() (*http.Client, error) {
+	// add proxy if enabled and exists
+	if j.Feed.UseProxy && j.Feed.Proxy != nil {
+		proxyClient, err := proxy.GetProxiedHTTPClient(j.Feed.Proxy)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get proxy client")
+		}
+
+		if j.Feed.TLSSkipVerify {
+			if t, ok := proxyClient.Transport.(*http.Transport); ok {
+				t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			}
+		}
+
+		j.Log.Debug().Msgf("using proxy %s for feed %s", j.Feed.Proxy.Name, j.Feed.Name)
+
+		return proxyClient, nil
+	}
+
+	transport := sharedhttp.Transport
+	if j.Feed.TLSSkipVerify {
+		transport = sharedhttp.TransportTLSInsecure
+	}
+
+	return &http.Client{
+		Timeout:   j.Timeout,
+		Transport: transport,
+	}, nil
+}
+
+// apiItemGUID picks the most stable identifier of an item for the feed cache
+func apiItemGUID(vars map[string]string) string {
+	for _, key := range []string{"guid", "torrentId", "torrentHash", "downloadUrl", "torrentName"} {
+		if v := vars[key]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// parseAPIPublishDate parses RFC3339, datetime and unix timestamps
+func parseAPIPublishDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0), true
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.RFC1123Z, time.RFC1123} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
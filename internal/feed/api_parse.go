@@ -0,0 +1,149 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// parseAPIResponse extracts the mapped fields of every item in the response body
+func parseAPIResponse(response domain.IndexerAPIResponse, body []byte) ([]map[string]string, error) {
+	switch response.Type {
+	case domain.IndexerAPIResponseTypeJSON:
+		return parseAPIResponseJSON(response, body)
+	case domain.IndexerAPIResponseTypeHTML:
+		return parseAPIResponseHTML(response, body)
+	default:
+		return nil, errors.New("unsupported response type: %s", response.Type)
+	}
+}
+
+func parseAPIResponseJSON(response domain.IndexerAPIResponse, body []byte) ([]map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var data any
+	if err := dec.Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "could not decode json response")
+	}
+
+	list, ok := jsonPath(data, response.Items).([]any)
+	if !ok {
+		return nil, errors.New("items path %q is not a list", response.Items)
+	}
+
+	items := make([]map[string]string, 0, len(list))
+
+	for _, item := range list {
+		vars := map[string]string{}
+
+		for name, field := range response.Fields {
+			value, ok := jsonValueString(jsonPath(item, field.Path))
+			if !ok {
+				continue
+			}
+
+			if value = field.FieldValue(value); value != "" {
+				vars[name] = value
+			}
+		}
+
+		items = append(items, vars)
+	}
+
+	return items, nil
+}
+
+// jsonPath walks a dotted path like data.items.0.name. An empty path returns the value itself.
+func jsonPath(data any, path string) any {
+	if path == "" {
+		return data
+	}
+
+	current := data
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			current = v[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil
+			}
+			current = v[idx]
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
+
+// jsonValueString converts a json value into a var. Lists of values are joined like tags.
+func jsonValueString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, elem := range v {
+			if s, ok := jsonValueString(elem); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return strings.Join(values, ", "), true
+	default:
+		return "", false
+	}
+}
+
+func parseAPIResponseHTML(response domain.IndexerAPIResponse, body []byte) ([]map[string]string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse html response")
+	}
+
+	items := make([]map[string]string, 0)
+
+	doc.Find(response.Items).Each(func(_ int, row *goquery.Selection) {
+		vars := map[string]string{}
+
+		for name, field := range response.Fields {
+			sel := row.Find(field.Path).First()
+			if sel.Length() == 0 {
+				continue
+			}
+
+			value := sel.Text()
+			if field.Attribute != "" {
+				attr, ok := sel.Attr(field.Attribute)
+				if !ok {
+					continue
+				}
+				value = attr
+			}
+
+			if value = field.FieldValue(value); value != "" {
+				vars[name] = value
+			}
+		}
+
+		items = append(items, vars)
+	})
+
+	return items, nil
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/indexer"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFeedCacheRepoExisting struct {
+	mockFeedCacheRepo
+	existing map[string]bool
+}
+
+func (m *mockFeedCacheRepoExisting) ExistingItems(_ context.Context, _ int, _ []string) (map[string]bool, error) {
+	return m.existing, nil
+}
+
+func newTestAPIJob(t *testing.T, definitionFile string, srvURL string, settings map[string]string, cacheRepo jobFeedCacheRepo) *APIJob {
+	t.Helper()
+
+	definition, err := indexer.OpenAndProcessDefinition(definitionFile)
+	require.NoError(t, err)
+
+	definition.BaseURL = srvURL
+	definition.SettingsMap = settings
+
+	return &APIJob{
+		Feed: &domain.Feed{
+			ID:   1,
+			Name: definition.Name,
+			Indexer: domain.IndexerMinimal{
+				ID:         1,
+				Name:       definition.Name,
+				Identifier: definition.Identifier,
+			},
+		},
+		Name:       definition.Name,
+		Log:        zerolog.New(io.Discard),
+		URL:        srvURL,
+		Definition: definition,
+		Repo:       &mockFeedRepo{},
+		CacheRepo:  cacheRepo,
+		ReleaseSvc: &mockReleaseSvc{},
+		Timeout:    5 * time.Second,
+	}
+}
+
+func TestAPIJob_JSON(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("Authorization") != "Bearer secret-api-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path != "/api/torrents/filter" || r.URL.Query().Get("perPage") != "2" {
+			http.NotFound(w, r)
+			return
+		}
+
+		file := "testdata/api/browse_empty.json"
+		switch r.URL.Query().Get("page") {
+		case "1":
+			file = "testdata/api/browse_page1.json"
+		case "2":
+			file = "testdata/api/browse_page2.json"
+		}
+
+		payload, err := os.ReadFile(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	settings := map[string]string{"apikey": "secret-api-key", "rsskey": "rss123"}
+
+	t.Run("paginate_and_map", func(t *testing.T) {
+		requests.Store(0)
+
+		j := newTestAPIJob(t, "testdata/api/definition_json.yaml", srv.URL, settings, &mockFeedCacheRepo{})
+
+		items, err := j.getFeed(t.Context())
+		require.NoError(t, err)
+
+		// stops at the first empty page and skips the item repeated on page 2
+		assert.Equal(t, int32(3), requests.Load())
+		require.Len(t, items, 3)
+
+		releases := j.processItems(items)
+		require.Len(t, releases, 3)
+
+		// oldest first
+		movie := releases[0]
+		assert.Equal(t, "That.Movie.2024.2160p.UHD.BluRay.x265-GROUP", movie.TorrentName)
+		assert.Equal(t, "100", movie.TorrentID)
+		assert.Equal(t, "Movies", movie.Category)
+		assert.Equal(t, uint64(32212254720), movie.Size)
+		assert.Equal(t, 50, movie.FreeleechPercent)
+		assert.Equal(t, 4, movie.Seeders)
+		assert.Equal(t, 9, movie.Leechers)
+		assert.Equal(t, "2160p", movie.Resolution)
+		assert.Equal(t, domain.ReleaseImplementationAPIFeed, movie.Implementation)
+		assert.Equal(t, srv.URL+"/torrent/download/100.rss123", movie.DownloadURL)
+		assert.Equal(t, srv.URL+"/torrents/100", movie.InfoURL)
+
+		episode := releases[2]
+		assert.Equal(t, "That.Show.S01E02.1080p.WEB-DL.DDP5.1.H.264-GROUP", episode.TorrentName)
+		assert.Equal(t, "TV", episode.Category)
+		assert.True(t, episode.Freeleech)
+		assert.Equal(t, "INTERNAL", episode.Origin)
+		assert.Equal(t, []string{"web", "h264"}, episode.Tags)
+	})
+
+	t.Run("skip_cached", func(t *testing.T) {
+		j := newTestAPIJob(t, "testdata/api/definition_json.yaml", srv.URL, settings, &mockFeedCacheRepoExisting{existing: map[string]bool{"100": true, "101": true}})
+
+		items, err := j.getFeed(t.Context())
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "102", items[0].GUID)
+	})
+
+	t.Run("max_age", func(t *testing.T) {
+		j := newTestAPIJob(t, "testdata/api/definition_json.yaml", srv.URL, settings, &mockFeedCacheRepo{})
+		j.Feed.MaxAge = 3600
+
+		items, err := j.getFeed(t.Context())
+		require.NoError(t, err)
+
+		// fixtures are published in the past
+		assert.Empty(t, j.processItems(items))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		j := newTestAPIJob(t, "testdata/api/definition_json.yaml", srv.URL, map[string]string{"apikey": "wrong"}, &mockFeedCacheRepo{})
+
+		err := j.RunE(t.Context())
+		assert.ErrorContains(t, err, "unexpected status code: 401")
+	})
+}
+
+func TestAPIJob_HTML(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/browse.php" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if c, err := r.Cookie("session"); err != nil || c.Value != "abc" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		payload, err := os.ReadFile("testdata/api/browse.html")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	j := newTestAPIJob(t, "testdata/api/definition_html.yaml", srv.URL, map[string]string{"cookie": "session=abc"}, &mockFeedCacheRepo{})
+
+	items, err := j.getFeed(t.Context())
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	releases := j.processItems(items)
+	require.Len(t, releases, 2)
+
+	episode := releases[0]
+	assert.Equal(t, "That.Show.S02E01.720p.HDTV.x264-GROUP", episode.TorrentName)
+	assert.Equal(t, "200", episode.TorrentID)
+	assert.Equal(t, uint64(1200000000), episode.Size)
+	assert.False(t, episode.Freeleech)
+	assert.Equal(t, "uploader2", episode.Uploader)
+
+	movie := releases[1]
+	assert.Equal(t, "That.Movie.2024.1080p.BluRay.x264-GROUP", movie.TorrentName)
+	assert.Equal(t, "201", movie.TorrentID)
+	assert.Equal(t, uint64(8500000000), movie.Size)
+	assert.True(t, movie.Freeleech)
+	assert.Equal(t, srv.URL+"/download.php?id=201", movie.DownloadURL)
+	assert.Equal(t, srv.URL+"/details.php?id=201", movie.InfoURL)
+	assert.Equal(t, "session=abc", movie.RawCookie)
+}
+
+func Test_jsonPath(t *testing.T) {
+	t.Parallel()
+
+	items, err := parseAPIResponseJSON(domain.IndexerAPIResponse{
+		Type:  domain.IndexerAPIResponseTypeJSON,
+		Items: "",
+		Fields: map[string]domain.IndexerAPIField{
+			"torrentName": {Path: "release.name"},
+			"torrentId":   {Path: "files.0.id"},
+			"scene":       {Path: "scene"},
+			"missing":     {Path: "release.missing.name"},
+			"year":        {Path: "release.name", Pattern: `\.(\d{4})\.`},
+		},
+	}, []byte(`[{"release":{"name":"That.Movie.2024.1080p.BluRay.x264-GROUP"},"files":[{"id":12345678901}],"scene":true}]`))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	assert.Equal(t, map[string]string{
+		"torrentName": "That.Movie.2024.1080p.BluRay.x264-GROUP",
+		"torrentId":   "12345678901",
+		"scene":       "true",
+		"year":        "2024",
+	}, items[0])
+
+	_, err = parseAPIResponseJSON(domain.IndexerAPIResponse{Type: domain.IndexerAPIResponseTypeJSON, Items: "data"}, []byte(`{"data":{}}`))
+	assert.Error(t, err)
+}
+
+type mockDefinitionIndexerSvc struct {
+	indexer.Service
+	definition *domain.IndexerDefinition
+}
+
+func (m *mockDefinitionIndexerSvc) GetMappedDefinitionByName(name string) (*domain.IndexerDefinition, error) {
+	return m.definition, nil
+}
+
+func TestService_testAPI_MissingDefinition(t *testing.T) {
+	s := &service{
+		log:        zerolog.Nop(),
+		indexerSvc: &mockDefinitionIndexerSvc{definition: &domain.IndexerDefinition{Identifier: "mock", Implementation: "api"}},
+	}
+
+	feed := &domain.Feed{Name: "Mock", URL: "https://mock.local/api", Indexer: domain.IndexerMinimal{Identifier: "mock"}}
+
+	err := s.testAPI(context.Background(), feed)
+	assert.ErrorContains(t, err, "has no api definition")
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// feedAudit records feeds without the state of their runs and the capabilities reported by the indexer
+var feedAudit = audit.Resource[domain.Feed]{
+	Type: domain.AuditResourceFeed,
+	Key: func(f *domain.Feed) (string, string) {
+		return strconv.Itoa(f.ID), f.Name
+	},
+	View: func(f *domain.Feed) any {
+		view := *f
+		view.Capabilities = nil
+		view.LastRun = time.Time{}
+		view.LastRunData = ""
+		view.NextRun = time.Time{}
+
+		return &view
+	},
+}
@@ -0,0 +1,480 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// backfillMaxQueries caps the number of queries built from a single filter
+	backfillMaxQueries = 100
+
+	// backfillMaxYears is the widest year range that is still combined with the titles
+	backfillMaxYears = 10
+)
+
+// feedSearcher is implemented by the torznab and newznab jobs
+type feedSearcher interface {
+	Search(ctx context.Context, query string) ([]*domain.Release, error)
+}
+
+var backfillWildcards = regexp.MustCompile(`[*?]+`)
+
+// backfillQueries builds search queries from the titles and years of a filter.
+// Titles synced by lists end up in shows or match releases so they are covered as well.
+func backfillQueries(f *domain.Filter) []string {
+	titles := splitBackfillTitles(f.Shows)
+	if !f.UseRegex {
+		titles = append(titles, splitBackfillTitles(f.MatchReleases)...)
+	}
+
+	years := backfillYears(f.Years)
+
+	queries := make([]string, 0, len(titles))
+	seen := make(map[string]struct{}, len(titles))
+
+	add := func(query string) bool {
+		key := strings.ToLower(query)
+		if _, ok := seen[key]; ok {
+			return true
+		}
+
+		seen[key] = struct{}{}
+		queries = append(queries, query)
+
+		return len(queries) < backfillMaxQueries
+	}
+
+	for _, title := range titles {
+		if len(years) == 0 {
+			if !add(title) {
+				return queries
+			}
+			continue
+		}
+
+		for _, year := range years {
+			if !add(title + " " + year) {
+				return queries
+			}
+		}
+	}
+
+	return queries
+}
+
+func splitBackfillTitles(value string) []string {
+	var titles []string
+
+	for _, title := range strings.Split(value, ",") {
+		// wildcards are replaced since indexers do not support them
+		title = backfillWildcards.ReplaceAllString(title, " ")
+		title = strings.Join(strings.Fields(title), " ")
+
+		if len(title) < 2 {
+			continue
+		}
+
+		titles = append(titles, title)
+	}
+
+	return titles
+}
+
+func backfillYears(value string) []string {
+	var years []string
+
+	for _, part := range strings.Split(value, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(part), "-")
+
+		from, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			continue
+		}
+
+		to := from
+		if isRange {
+			to, err = strconv.Atoi(strings.TrimSpace(end))
+			if err != nil || to < from {
+				continue
+			}
+		}
+
+		// too broad to narrow down the search
+		if to-from >= backfillMaxYears {
+			return nil
+		}
+
+		for year := from; year <= to; year++ {
+			if y := strconv.Itoa(year); !slices.Contains(years, y) {
+				years = append(years, y)
+			}
+		}
+	}
+
+	return years
+}
+
+func (s *service) PlanBackfill(ctx context.Context, req *domain.FeedBackfillRequest) ([]*domain.FeedBackfillPlan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var filterIDs []int
+	if req.FilterID > 0 {
+		filterIDs = append(filterIDs, req.FilterID)
+	}
+
+	if req.ListID > 0 {
+		list, err := s.listSvc.FindByID(ctx, req.ListID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not find list: %d", req.ListID)
+		}
+
+		for _, f := range list.Filters {
+			if !slices.Contains(filterIDs, f.ID) {
+				filterIDs = append(filterIDs, f.ID)
+			}
+		}
+	}
+
+	feeds, err := s.searchableFeeds(ctx, req.FeedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*domain.FeedBackfillPlan, 0, len(filterIDs))
+
+	for _, filterID := range filterIDs {
+		f, err := s.filterSvc.FindByID(ctx, filterID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not find filter: %d", filterID)
+		}
+
+		plans = append(plans, newBackfillPlan(f, feeds))
+	}
+
+	return plans, nil
+}
+
+func newBackfillPlan(f *domain.Filter, feeds []domain.Feed) *domain.FeedBackfillPlan {
+	plan := &domain.FeedBackfillPlan{
+		FilterID:   f.ID,
+		FilterName: f.Name,
+		Queries:    backfillQueries(f),
+		Feeds:      make([]domain.FeedBackfillFeed, 0),
+	}
+
+	for _, feed := range feeds {
+		// releases are only checked against filters of the feed indexer
+		if len(f.Indexers) > 0 && !slices.ContainsFunc(f.Indexers, func(indexer domain.Indexer) bool {
+			return indexer.Identifier == feed.Indexer.Identifier
+		}) {
+			continue
+		}
+
+		plan.Feeds = append(plan.Feeds, domain.FeedBackfillFeed{ID: feed.ID, Name: feed.Name, Indexer: feed.Indexer})
+	}
+
+	return plan
+}
+
+// searchableFeeds returns the enabled torznab and newznab feeds that support search
+func (s *service) searchableFeeds(ctx context.Context, feedIDs []int) ([]domain.Feed, error) {
+	feeds, err := s.repo.Find(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find feeds")
+	}
+
+	searchable := make([]domain.Feed, 0)
+
+	for _, feed := range feeds {
+		if !feed.Enabled {
+			continue
+		}
+
+		if feed.Type != string(domain.FeedTypeTorznab) && feed.Type != string(domain.FeedTypeNewznab) {
+			continue
+		}
+
+		if len(feedIDs) > 0 && !slices.Contains(feedIDs, feed.ID) {
+			continue
+		}
+
+		// capabilities are stored when fetched from the feed settings so fetch them for older feeds
+		if feed.Capabilities == nil {
+			caps, err := s.FetchCapsByID(ctx, feed.ID)
+			if err != nil {
+				s.log.Warn().Err(err).Msgf("could not fetch capabilities for feed: %s", feed.Name)
+				continue
+			}
+			feed.Capabilities = caps
+		}
+
+		if !feed.Capabilities.SupportsSearch() {
+			s.log.Debug().Msgf("feed does not support search, skipping backfill: %s", feed.Name)
+			continue
+		}
+
+		searchable = append(searchable, feed)
+	}
+
+	return searchable, nil
+}
+
+// Backfill searches the planned feeds and sends new releases through the filters.
+// Feeds are searched concurrently while searches on the same indexer are rate limited.
+func (s *service) Backfill(ctx context.Context, plans []*domain.FeedBackfillPlan) (*domain.FeedBackfillResult, error) {
+	if !s.backfillMu.TryLock() {
+		s.log.Warn().Msg("backfill already running")
+		return nil, domain.ErrFeedBackfillRunning
+	}
+	defer s.backfillMu.Unlock()
+
+	return s.backfill(ctx, plans)
+}
+
+// StartBackfill runs the backfill in the background, it fails right away if a backfill is already running
+func (s *service) StartBackfill(plans []*domain.FeedBackfillPlan) error {
+	if !s.backfillMu.TryLock() {
+		return domain.ErrFeedBackfillRunning
+	}
+
+	go func() {
+		defer s.backfillMu.Unlock()
+
+		if _, err := s.backfill(context.Background(), plans); err != nil {
+			s.log.Error().Err(err).Msg("could not run backfill")
+		}
+	}()
+
+	return nil
+}
+
+// backfill runs the plans, s.backfillMu must be held
+func (s *service) backfill(ctx context.Context, plans []*domain.FeedBackfillPlan) (*domain.FeedBackfillResult, error) {
+	// merge the queries of all plans per feed
+	feedQueries := make(map[int][]string)
+	for _, plan := range plans {
+		for _, feed := range plan.Feeds {
+			for _, query := range plan.Queries {
+				if !slices.Contains(feedQueries[feed.ID], query) {
+					feedQueries[feed.ID] = append(feedQueries[feed.ID], query)
+				}
+			}
+		}
+	}
+
+	result := &domain.FeedBackfillResult{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for feedID, queries := range feedQueries {
+		feed, err := s.repo.FindByID(ctx, feedID)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("could not find feed: %d", feedID)
+			result.Errors++
+			continue
+		}
+
+		searcher, err := s.newSearcher(ctx, feed)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("could not create backfill search for feed: %s", feed.Name)
+			result.Errors++
+			continue
+		}
+
+		wg.Add(1)
+		go func(feed *domain.Feed, searcher feedSearcher, queries []string) {
+			defer wg.Done()
+
+			feedResult := s.backfillFeed(ctx, feed, searcher, queries)
+
+			mu.Lock()
+			result.Searches += feedResult.Searches
+			result.Releases += feedResult.Releases
+			result.Errors += feedResult.Errors
+			mu.Unlock()
+		}(feed, searcher, queries)
+	}
+
+	wg.Wait()
+
+	s.log.Info().Msgf("backfill finished: %d searches, %d new releases, %d errors", result.Searches, result.Releases, result.Errors)
+
+	return result, ctx.Err()
+}
+
+func (s *service) backfillFeed(ctx context.Context, feed *domain.Feed, searcher feedSearcher, queries []string) *domain.FeedBackfillResult {
+	l := s.log.With().Str("feed", feed.Name).Str("job", "backfill").Logger()
+
+	limiter := s.backfillLimiter(feed.Indexer)
+	result := &domain.FeedBackfillResult{}
+
+	for _, query := range queries {
+		if err := limiter.Wait(ctx); err != nil {
+			return result
+		}
+
+		result.Searches++
+
+		releases, err := searcher.Search(ctx, query)
+		if err != nil {
+			l.Error().Err(err).Msgf("error searching feed for: %s", query)
+			result.Errors++
+			continue
+		}
+
+		l.Debug().Msgf("found (%d) new releases for: %s", len(releases), query)
+
+		if len(releases) == 0 {
+			continue
+		}
+
+		result.Releases += len(releases)
+
+		if err := s.releaseSvc.ProcessMultipleFromIndexer(releases, feed.Indexer); err != nil {
+			l.Error().Err(err).Msgf("error processing releases for: %s", query)
+			result.Errors++
+		}
+	}
+
+	return result
+}
+
+// backfillLimiter returns the shared rate limiter of an indexer
+func (s *service) backfillLimiter(indexer domain.IndexerMinimal) *rate.Limiter {
+	s.limitersMu.Lock()
+	defer s.limitersMu.Unlock()
+
+	limiter, ok := s.limiters[indexer.Identifier]
+	if !ok {
+		delay := time.Duration(s.config.BackfillSearchDelay) * time.Second
+		limiter = rate.NewLimiter(rate.Every(delay), 1)
+		s.limiters[indexer.Identifier] = limiter
+	}
+
+	return limiter
+}
+
+func (s *service) createSearcher(ctx context.Context, feed *domain.Feed) (feedSearcher, error) {
+	if feed.UseProxy {
+		proxyConf, err := s.proxySvc.FindByID(ctx, feed.ProxyID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not find proxy for indexer feed")
+		}
+
+		if proxyConf.Enabled {
+			feed.Proxy = proxyConf
+		}
+	}
+
+	fi, err := newFeedInstance(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.initializeFeedJob(fi)
+	if err != nil {
+		return nil, err
+	}
+
+	searcher, ok := job.(feedSearcher)
+	if !ok {
+		return nil, errors.New("feed type does not support search: %s", feed.Type)
+	}
+
+	return searcher, nil
+}
+
+// backfillScheduled plans and runs the backfill for all enabled filters with backfill enabled
+func (s *service) backfillScheduled(ctx context.Context) error {
+	filters, err := s.filterSvc.ListFilters(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list filters")
+	}
+
+	feeds, err := s.searchableFeeds(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(feeds) == 0 {
+		s.log.Debug().Msg("found no feeds that support search, skipping backfill")
+		return nil
+	}
+
+	var plans []*domain.FeedBackfillPlan
+
+	for _, listed := range filters {
+		if !listed.Enabled {
+			continue
+		}
+
+		f, err := s.filterSvc.FindByID(ctx, listed.ID)
+		if err != nil {
+			return errors.Wrap(err, "could not find filter: %d", listed.ID)
+		}
+
+		if !f.Backfill {
+			continue
+		}
+
+		plans = append(plans, newBackfillPlan(f, feeds))
+	}
+
+	if len(plans) == 0 {
+		return nil
+	}
+
+	_, err = s.Backfill(ctx, plans)
+
+	return err
+}
+
+type BackfillJob struct {
+	log zerolog.Logger
+	svc feedBackfiller
+}
+
+type feedBackfiller interface {
+	backfillScheduled(ctx context.Context) error
+}
+
+func NewBackfillJob(log zerolog.Logger, svc feedBackfiller) *BackfillJob {
+	return &BackfillJob{
+		log: log,
+		svc: svc,
+	}
+}
+
+func (j *BackfillJob) Run() {
+	j.log.Info().Msg("running feed-backfill job..")
+
+	if err := j.svc.backfillScheduled(context.Background()); err != nil {
+		if errors.Is(err, domain.ErrFeedBackfillRunning) {
+			j.log.Warn().Msg("backfill already running, skipping scheduled run")
+			return
+		}
+
+		j.log.Error().Err(err).Msg("error when running feed backfill job")
+		return
+	}
+
+	j.log.Info().Msg("successfully ran feed-backfill job")
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package feed
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/release"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func Test_backfillQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter *domain.Filter
+		want   []string
+	}{
+		{
+			name:   "shows",
+			filter: &domain.Filter{Shows: "That Show, Other?s Show,*Third*Show*"},
+			want:   []string{"That Show", "Other s Show", "Third Show"},
+		},
+		{
+			name:   "match_releases",
+			filter: &domain.Filter{Shows: "That Show", MatchReleases: "*That*Show*,*Movie*"},
+			want:   []string{"That Show", "Movie"},
+		},
+		{
+			name:   "match_releases_regex",
+			filter: &domain.Filter{MatchReleases: "^Movie.*$", UseRegex: true},
+			want:   []string{},
+		},
+		{
+			name:   "years",
+			filter: &domain.Filter{MatchReleases: "*Movie*", Years: "2019,2021-2022"},
+			want:   []string{"Movie 2019", "Movie 2021", "Movie 2022"},
+		},
+		{
+			name:   "years_too_broad",
+			filter: &domain.Filter{MatchReleases: "*Movie*", Years: "1990-2025"},
+			want:   []string{"Movie"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backfillQueries(tt.filter))
+		})
+	}
+}
+
+type mockBackfillFeedRepo struct {
+	domain.FeedRepo
+	feeds map[int]*domain.Feed
+}
+
+func (m *mockBackfillFeedRepo) FindByID(_ context.Context, id int) (*domain.Feed, error) {
+	feed, ok := m.feeds[id]
+	if !ok {
+		return nil, domain.ErrRecordNotFound
+	}
+	return feed, nil
+}
+
+type mockBackfillReleaseSvc struct {
+	release.Service
+	mu        sync.Mutex
+	processed map[string]int
+}
+
+func (m *mockBackfillReleaseSvc) ProcessMultipleFromIndexer(releases []*domain.Release, indexer domain.IndexerMinimal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.processed[indexer.Identifier] += len(releases)
+	return nil
+}
+
+type mockSearcher struct {
+	mu      sync.Mutex
+	queries []string
+	search  func(query string) ([]*domain.Release, error)
+}
+
+func (m *mockSearcher) Search(_ context.Context, query string) ([]*domain.Release, error) {
+	m.mu.Lock()
+	m.queries = append(m.queries, query)
+	m.mu.Unlock()
+	return m.search(query)
+}
+
+func TestService_Backfill(t *testing.T) {
+	t.Parallel()
+
+	indexer := domain.IndexerMinimal{ID: 1, Name: "Mock", Identifier: "mock"}
+
+	repo := &mockBackfillFeedRepo{feeds: map[int]*domain.Feed{
+		1: {ID: 1, Name: "mock torznab", Type: string(domain.FeedTypeTorznab), Indexer: indexer},
+		2: {ID: 2, Name: "mock newznab", Type: string(domain.FeedTypeNewznab), Indexer: domain.IndexerMinimal{ID: 2, Name: "Other", Identifier: "other"}},
+	}}
+	releaseSvc := &mockBackfillReleaseSvc{processed: map[string]int{}}
+
+	searchers := map[int]*mockSearcher{
+		1: {search: func(query string) ([]*domain.Release, error) {
+			return []*domain.Release{{TorrentName: query + " S01E01"}}, nil
+		}},
+		2: {search: func(query string) ([]*domain.Release, error) {
+			return nil, errors.New("unavailable")
+		}},
+	}
+
+	s := &service{
+		log:        zerolog.Nop(),
+		config:     &domain.Config{},
+		repo:       repo,
+		releaseSvc: releaseSvc,
+		limiters:   map[string]*rate.Limiter{},
+	}
+	s.newSearcher = func(_ context.Context, feed *domain.Feed) (feedSearcher, error) {
+		return searchers[feed.ID], nil
+	}
+
+	plans := []*domain.FeedBackfillPlan{
+		{FilterID: 1, Queries: []string{"That Show", "Other Show"}, Feeds: []domain.FeedBackfillFeed{{ID: 1}, {ID: 2}}},
+		// queries shared with another filter are only searched once
+		{FilterID: 2, Queries: []string{"That Show"}, Feeds: []domain.FeedBackfillFeed{{ID: 1}}},
+	}
+
+	result, err := s.Backfill(context.Background(), plans)
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.FeedBackfillResult{Searches: 4, Releases: 2, Errors: 2}, result)
+	assert.ElementsMatch(t, []string{"That Show", "Other Show"}, searchers[1].queries)
+	assert.Equal(t, map[string]int{"mock": 2}, releaseSvc.processed)
+}
+
+func TestService_StartBackfill_Running(t *testing.T) {
+	t.Parallel()
+
+	s := &service{log: zerolog.Nop(), config: &domain.Config{}}
+
+	s.backfillMu.Lock()
+
+	err := s.StartBackfill(nil)
+	assert.ErrorIs(t, err, domain.ErrFeedBackfillRunning)
+
+	_, err = s.Backfill(context.Background(), nil)
+	assert.ErrorIs(t, err, domain.ErrFeedBackfillRunning)
+
+	s.backfillMu.Unlock()
+
+	// the lock is released once the background run is done
+	require.NoError(t, s.StartBackfill(nil))
+	assert.Eventually(t, func() bool {
+		if !s.backfillMu.TryLock() {
+			return false
+		}
+		s.backfillMu.Unlock()
+		return true
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_newBackfillPlan(t *testing.T) {
+	t.Parallel()
+
+	feeds := []domain.Feed{
+		{ID: 1, Name: "mock", Indexer: domain.IndexerMinimal{Identifier: "mock"}},
+		{ID: 2, Name: "other", Indexer: domain.IndexerMinimal{Identifier: "other"}},
+	}
+
+	plan := newBackfillPlan(&domain.Filter{ID: 3, Name: "shows", Shows: "That Show", Indexers: []domain.Indexer{{Identifier: "other"}}}, feeds)
+	assert.Equal(t, []string{"That Show"}, plan.Queries)
+	require.Len(t, plan.Feeds, 1)
+	assert.Equal(t, 2, plan.Feeds[0].ID)
+
+	// filters without indexers search every feed
+	plan = newBackfillPlan(&domain.Filter{ID: 4, Shows: "That Show"}, feeds)
+	assert.Len(t, plan.Feeds, 2)
+}
@@ -9,9 +9,14 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/filter"
+	"github.com/autobrr/autobrr/internal/indexer"
+	"github.com/autobrr/autobrr/internal/list"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/proxy"
 	"github.com/autobrr/autobrr/internal/release"
@@ -23,6 +28,7 @@ import (
 	"github.com/dcarbone/zadapters/zstdlog"
 	"github.com/robfig/cron/v3"
 	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
 )
 
 type Service interface {
@@ -41,6 +47,10 @@ type Service interface {
 	ForceRun(ctx context.Context, id int) error
 	FetchCaps(ctx context.Context, feed *domain.Feed) (*domain.FeedCapabilities, error)
 	FetchCapsByID(ctx context.Context, id int) (*domain.FeedCapabilities, error)
+	PlanBackfill(ctx context.Context, req *domain.FeedBackfillRequest) ([]*domain.FeedBackfillPlan, error)
+	Backfill(ctx context.Context, plans []*domain.FeedBackfillPlan) (*domain.FeedBackfillResult, error)
+	StartBackfill(plans []*domain.FeedBackfillPlan) error
+	RestartIndexerFeed(ctx context.Context, indexerID int) error
 
 	Start() error
 }
@@ -67,26 +77,46 @@ func (k feedKey) ToString() string {
 }
 
 type service struct {
-	log  zerolog.Logger
-	jobs map[string]int
+	log    zerolog.Logger
+	config *domain.Config
+	jobs   map[string]int
 
 	repo       domain.FeedRepo
 	cacheRepo  domain.FeedCacheRepo
 	releaseSvc release.Service
+	indexerSvc indexer.Service
+	filterSvc  filter.Service
+	listSvc    list.Service
 	proxySvc   proxy.Service
 	scheduler  scheduler.Service
+	auditSvc   audit.Service
+
+	backfillMu  sync.Mutex
+	limitersMu  sync.Mutex
+	limiters    map[string]*rate.Limiter
+	newSearcher func(ctx context.Context, feed *domain.Feed) (feedSearcher, error)
 }
 
-func NewService(log logger.Logger, repo domain.FeedRepo, cacheRepo domain.FeedCacheRepo, releaseSvc release.Service, proxySvc proxy.Service, scheduler scheduler.Service) Service {
-	return &service{
+func NewService(log logger.Logger, config *domain.Config, repo domain.FeedRepo, cacheRepo domain.FeedCacheRepo, releaseSvc release.Service, indexerSvc indexer.Service, filterSvc filter.Service, listSvc list.Service, proxySvc proxy.Service, scheduler scheduler.Service, auditSvc audit.Service) Service {
+	s := &service{
 		log:        log.With().Str("module", "feed").Logger(),
+		config:     config,
 		jobs:       map[string]int{},
 		repo:       repo,
 		cacheRepo:  cacheRepo,
 		releaseSvc: releaseSvc,
+		indexerSvc: indexerSvc,
+		filterSvc:  filterSvc,
+		listSvc:    listSvc,
 		proxySvc:   proxySvc,
 		scheduler:  scheduler,
+		auditSvc:   auditSvc,
+		limiters:   map[string]*rate.Limiter{},
 	}
+
+	s.newSearcher = s.createSearcher
+
+	return s
 }
 
 func (s *service) FindOne(ctx context.Context, params domain.FindOneParams) (*domain.Feed, error) {
@@ -120,7 +150,13 @@ func (s *service) GetCacheByID(ctx context.Context, feedId int) ([]domain.FeedCa
 }
 
 func (s *service) Store(ctx context.Context, feed *domain.Feed) error {
-	return s.repo.Store(ctx, feed)
+	if err := s.repo.Store(ctx, feed); err != nil {
+		return err
+	}
+
+	feedAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, feed)
+
+	return nil
 }
 
 func (s *service) Update(ctx context.Context, feed *domain.Feed) error {
@@ -151,6 +187,19 @@ func (s *service) Start() error {
 	return s.start()
 }
 
+// RestartIndexerFeed restarts the job of the feed of an indexer so it picks up a reloaded definition
+func (s *service) RestartIndexerFeed(ctx context.Context, indexerID int) error {
+	feed, err := s.repo.FindOne(ctx, domain.FindOneParams{IndexerID: indexerID})
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return s.restartJob(feed)
+}
+
 func (s *service) update(ctx context.Context, feed *domain.Feed) error {
 	existingFeed, err := s.repo.FindOne(ctx, domain.FindOneParams{FeedID: feed.ID})
 	if err != nil {
@@ -177,6 +226,8 @@ func (s *service) update(ctx context.Context, feed *domain.Feed) error {
 		return err
 	}
 
+	feedAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, existingFeed, feed)
+
 	if err := s.restartJob(feed); err != nil {
 		s.log.Error().Err(err).Msg("error restarting feed")
 		return err
@@ -210,6 +261,8 @@ func (s *service) delete(ctx context.Context, id int) error {
 		s.log.Error().Err(err).Msgf("error deleting feed cache: %s", f.Name)
 	}
 
+	feedAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, f, nil)
+
 	return nil
 }
 
@@ -225,6 +278,10 @@ func (s *service) toggleEnabled(ctx context.Context, id int, enabled bool) error
 		return err
 	}
 
+	toggled := *f
+	toggled.Enabled = enabled
+	feedAudit.Record(ctx, s.auditSvc, domain.AuditActionToggle, f, &toggled)
+
 	if f.Enabled != enabled {
 		if enabled {
 			// override enabled
@@ -281,6 +338,12 @@ func (s *service) test(ctx context.Context, feed *domain.Feed) error {
 		}
 	}
 
+	resolved, err := feed.ResolveSecrets()
+	if err != nil {
+		return err
+	}
+	feed = &resolved
+
 	// create sub logger
 	subLogger := zstdlog.NewStdLoggerWithLevel(s.log.With().Logger(), zerolog.DebugLevel)
 
@@ -301,6 +364,14 @@ func (s *service) test(ctx context.Context, feed *domain.Feed) error {
 			return err
 		}
 
+	case string(domain.FeedTypeAPI):
+		// the request body only has the feed settings
+		feed.Indexer = existingFeed.Indexer
+
+		if err := s.testAPI(ctx, feed); err != nil {
+			return err
+		}
+
 	default:
 		return errors.New("unsupported feed type: %s", feed.Type)
 	}
@@ -342,6 +413,51 @@ func (s *service) testRSS(ctx context.Context, feed *domain.Feed) error {
 	return nil
 }
 
+func (s *service) testAPI(ctx context.Context, feed *domain.Feed) error {
+	definition, err := s.indexerSvc.GetMappedDefinitionByName(feed.Indexer.Identifier)
+	if err != nil {
+		return errors.Wrap(err, "could not find definition for api feed")
+	}
+
+	if definition.API == nil {
+		return errors.New("indexer %s has no api definition", feed.Indexer.Identifier)
+	}
+
+	// setup logger
+	l := s.log.With().Str("feed", feed.Name).Str("implementation", feed.Type).Logger()
+
+	job := &APIJob{
+		Feed:       feed,
+		Name:       feed.Name,
+		Log:        l,
+		URL:        feed.URL,
+		Definition: definition,
+		Timeout:    time.Duration(feed.Timeout) * time.Second,
+	}
+
+	client, err := job.httpClient()
+	if err != nil {
+		return err
+	}
+
+	pages := definition.API.Pages()
+
+	body, err := job.fetchPage(ctx, client, pages[0])
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting api feed")
+		return errors.Wrap(err, "error fetching api feed items")
+	}
+
+	items, err := parseAPIResponse(definition.API.Response, body)
+	if err != nil {
+		return errors.Wrap(err, "error parsing api feed items")
+	}
+
+	s.log.Info().Msgf("refreshing api feed: %s, found (%d) items", feed.Name, len(items))
+
+	return nil
+}
+
 func (s *service) testTorznab(ctx context.Context, feed *domain.Feed, subLogger *log.Logger) error {
 	// setup torznab Client
 	c := torznab.NewClient(torznab.Config{Host: feed.URL, ApiKey: feed.ApiKey, TLSSkipVerify: feed.TLSSkipVerify, Log: subLogger})
@@ -414,6 +530,12 @@ func (s *service) start() error {
 		s.log.Error().Err(err).Msg("could not start feed cache cleanup job")
 	}
 
+	if s.config.BackfillInterval > 0 {
+		if err := s.createBackfillJob(); err != nil {
+			s.log.Error().Err(err).Msg("could not start feed backfill job")
+		}
+	}
+
 	// get all feeds
 	feeds, err := s.repo.Find(context.TODO())
 	if err != nil {
@@ -469,20 +591,26 @@ func (s *service) restartJob(f *domain.Feed) error {
 
 	return nil
 }
-func newFeedInstance(f *domain.Feed) feedInstance {
+func newFeedInstance(f *domain.Feed) (feedInstance, error) {
+	// jobs get the values of env: and file: references, the stored feed keeps the references
+	feed, err := f.ResolveSecrets()
+	if err != nil {
+		return feedInstance{}, err
+	}
+
 	// cron schedule to run every X minutes
 	fi := feedInstance{
-		Feed:           f,
-		Name:           f.Name,
-		Indexer:        f.Indexer,
-		Implementation: f.Type,
-		URL:            f.URL,
-		ApiKey:         f.ApiKey,
-		CronSchedule:   time.Duration(f.Interval) * time.Minute,
-		Timeout:        time.Duration(f.Timeout) * time.Second,
+		Feed:           &feed,
+		Name:           feed.Name,
+		Indexer:        feed.Indexer,
+		Implementation: feed.Type,
+		URL:            feed.URL,
+		ApiKey:         feed.ApiKey,
+		CronSchedule:   time.Duration(feed.Interval) * time.Minute,
+		Timeout:        time.Duration(feed.Timeout) * time.Second,
 	}
 
-	return fi
+	return fi, nil
 }
 
 func (s *service) initializeFeedJob(fi feedInstance) (RefreshFeedJob, error) {
@@ -499,6 +627,9 @@ func (s *service) initializeFeedJob(fi feedInstance) (RefreshFeedJob, error) {
 	case string(domain.FeedTypeRSS):
 		job, err = s.createRSSJob(fi)
 
+	case string(domain.FeedTypeAPI):
+		job, err = s.createAPIJob(fi)
+
 	default:
 		return nil, errors.New("unsupported feed type: %s", fi.Implementation)
 	}
@@ -534,7 +665,10 @@ func (s *service) startJob(f *domain.Feed) error {
 		}
 	}
 
-	fi := newFeedInstance(f)
+	fi, err := newFeedInstance(f)
+	if err != nil {
+		return errors.Wrap(err, "initialize job %s failed", f.Name)
+	}
 
 	job, err := s.initializeFeedJob(fi)
 	if err != nil {
@@ -627,6 +761,31 @@ func (s *service) createRSSJob(f feedInstance) (RefreshFeedJob, error) {
 	return job, nil
 }
 
+func (s *service) createAPIJob(f feedInstance) (RefreshFeedJob, error) {
+	s.log.Debug().Msgf("create api job: %s", f.Name)
+
+	if f.URL == "" {
+		return nil, errors.New("api feed requires URL")
+	}
+
+	definition, err := s.indexerSvc.GetMappedDefinitionByName(f.Indexer.Identifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find definition for api feed")
+	}
+
+	if definition.API == nil {
+		return nil, errors.New("indexer %s has no api definition", f.Indexer.Identifier)
+	}
+
+	// setup logger
+	l := s.log.With().Str("feed", f.Name).Str("implementation", f.Implementation).Logger()
+
+	// create job
+	job := NewAPIJob(f.Feed, f.Name, l, f.URL, definition, s.repo, s.cacheRepo, s.releaseSvc, f.Timeout)
+
+	return job, nil
+}
+
 func (s *service) createCleanupJob() error {
 	// setup logger
 	l := s.log.With().Str("job", "feed-cache-cleanup").Logger()
@@ -648,6 +807,26 @@ func (s *service) createCleanupJob() error {
 	return nil
 }
 
+func (s *service) createBackfillJob() error {
+	// setup logger
+	l := s.log.With().Str("job", "feed-backfill").Logger()
+
+	// create job
+	job := NewBackfillJob(l, s)
+
+	identifierKey := "feed-backfill"
+
+	id, err := s.scheduler.ScheduleJob(job, time.Duration(s.config.BackfillInterval)*time.Hour, identifierKey)
+	if err != nil {
+		return errors.Wrap(err, "add job %s failed", identifierKey)
+	}
+
+	// add to job map
+	s.jobs[identifierKey] = id
+
+	return nil
+}
+
 func (s *service) stopFeedJob(id int) error {
 	// remove job from scheduler
 	if err := s.scheduler.RemoveJobByIdentifier(feedKey{id}.ToString()); err != nil {
@@ -689,7 +868,10 @@ func (s *service) ForceRun(ctx context.Context, id int) error {
 		}
 	}
 
-	fi := newFeedInstance(feed)
+	fi, err := newFeedInstance(feed)
+	if err != nil {
+		return err
+	}
 
 	job, err := s.initializeFeedJob(fi)
 	if err != nil {
@@ -729,6 +911,12 @@ func (s *service) FetchCaps(ctx context.Context, feed *domain.Feed) (*domain.Fee
 		}
 	}
 
+	resolved, err := feed.ResolveSecrets()
+	if err != nil {
+		return nil, err
+	}
+	feed = &resolved
+
 	switch feed.Type {
 	case string(domain.FeedTypeTorznab):
 		client := torznab.NewClient(torznab.Config{Host: feed.URL, ApiKey: feed.ApiKey, Timeout: time.Duration(feed.Timeout) * time.Second, TLSSkipVerify: feed.TLSSkipVerify})
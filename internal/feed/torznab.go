@@ -242,11 +242,53 @@ func mapFreeleechToBonus(percentage int) (string, bool) {
 }
 
 func (j *TorznabJob) getFeed(ctx context.Context) ([]torznab.FeedItem, error) {
+	if err := j.setupProxy(); err != nil {
+		return nil, err
+	}
+
+	// get feed
+	feed, err := j.Client.Search(ctx, "", j.Feed.Categories)
+	if err != nil {
+		j.Log.Error().Err(err).Msgf("error fetching feed items")
+		return nil, errors.Wrap(err, "error fetching feed items")
+	}
+
+	if err := j.Repo.UpdateLastRunWithData(ctx, j.Feed.ID, feed.Raw); err != nil {
+		j.Log.Error().Err(err).Msgf("error updating last run for feed id: %v", j.Feed.ID)
+	}
+
+	j.Log.Debug().Msgf("refreshing feed: %v, found (%d) items", j.Name, len(feed.Items))
+
+	return j.uncachedItems(ctx, feed.Items)
+}
+
+// Search queries the feed and returns releases for the items not already in the feed cache
+func (j *TorznabJob) Search(ctx context.Context, query string) ([]*domain.Release, error) {
+	if err := j.setupProxy(); err != nil {
+		return nil, err
+	}
+
+	res, err := j.Client.Search(ctx, query, j.Feed.Categories)
+	if err != nil {
+		return nil, errors.Wrap(err, "error searching feed")
+	}
+
+	j.Log.Debug().Msgf("searching feed: %s query: %q, found (%d) items", j.Name, query, len(res.Items))
+
+	items, err := j.uncachedItems(ctx, res.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.processItems(items)
+}
+
+func (j *TorznabJob) setupProxy() error {
 	// add proxy if enabled and exists
 	if j.Feed.UseProxy && j.Feed.Proxy != nil {
 		proxyClient, err := proxy.GetProxiedHTTPClient(j.Feed.Proxy)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not get proxy client")
+			return errors.Wrap(err, "could not get proxy client")
 		}
 
 		if j.Feed.TLSSkipVerify {
@@ -260,21 +302,12 @@ func (j *TorznabJob) getFeed(ctx context.Context) ([]torznab.FeedItem, error) {
 		j.Log.Debug().Msgf("using proxy %s for feed %s", j.Feed.Proxy.Name, j.Feed.Name)
 	}
 
-	// get feed
-	feed, err := j.Client.Search(ctx, "", j.Feed.Categories)
-	if err != nil {
-		j.Log.Error().Err(err).Msgf("error fetching feed items")
-		return nil, errors.Wrap(err, "error fetching feed items")
-	}
-
-	if err := j.Repo.UpdateLastRunWithData(ctx, j.Feed.ID, feed.Raw); err != nil {
-		j.Log.Error().Err(err).Msgf("error updating last run for feed id: %v", j.Feed.ID)
-	}
-
-	j.Log.Debug().Msgf("refreshing feed: %v, found (%d) items", j.Name, len(feed.Items))
+	return nil
+}
 
+func (j *TorznabJob) uncachedItems(ctx context.Context, feedItems []*torznab.FeedItem) ([]torznab.FeedItem, error) {
 	items := make([]torznab.FeedItem, 0)
-	if len(feed.Items) == 0 {
+	if len(feedItems) == 0 {
 		return items, nil
 	}
 
@@ -282,7 +315,7 @@ func (j *TorznabJob) getFeed(ctx context.Context) ([]torznab.FeedItem, error) {
 	guidItemMap := make(map[string]*torznab.FeedItem)
 	var guids []string
 
-	for _, item := range feed.Items {
+	for _, item := range feedItems {
 		if item.GUID == "" {
 			j.Log.Error().Msgf("missing GUID from feed: %s", j.Feed.Name)
 			continue
@@ -106,3 +106,54 @@ func TestTorznabJob_RunE(t *testing.T) {
 		})
 	}
 }
+
+type mockExistingFeedCacheRepo struct {
+	existing map[string]bool
+}
+
+func (m *mockExistingFeedCacheRepo) ExistingItems(_ context.Context, _ int, _ []string) (map[string]bool, error) {
+	return m.existing, nil
+}
+
+func (m *mockExistingFeedCacheRepo) PutMany(_ context.Context, _ []domain.FeedCacheItem) error {
+	return nil
+}
+
+func TestTorznabJob_Search(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file := "testdata/torznab/caps_response.xml"
+		if r.URL.Query().Get("t") == "search" {
+			if r.URL.Query().Get("q") != "That Show" {
+				http.NotFound(w, r)
+				return
+			}
+			file = "testdata/torznab/torznab_response.xml"
+		}
+
+		payload, err := os.ReadFile(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	j := &TorznabJob{
+		Name:      "test",
+		Log:       zerolog.New(io.Discard),
+		Feed:      &domain.Feed{Indexer: domain.IndexerMinimal{Name: "Mock Feed", Identifier: "mock-feed"}},
+		URL:       srv.URL,
+		Client:    torznab.NewClient(torznab.Config{Host: srv.URL}),
+		Repo:      &mockFeedRepo{},
+		CacheRepo: &mockExistingFeedCacheRepo{existing: map[string]bool{"Mock-71862": true}},
+	}
+
+	releases, err := j.Search(t.Context(), "That Show")
+	assert.NoError(t, err)
+
+	// items already in the feed cache are skipped
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "mock-feed", releases[0].Indexer.Identifier)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package filter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// auditFilter is the filter as it is recorded in the audit log.
+// Actions are recorded by the action service and indexers are reduced to their identifiers.
+type auditFilter struct {
+	domain.Filter
+	Indexers      []string                    `json:"indexers"`
+	Notifications []domain.FilterNotification `json:"notifications"`
+}
+
+// filterAudit records filters as auditFilter
+var filterAudit = audit.Resource[domain.Filter]{
+	Type: domain.AuditResourceFilter,
+	Key: func(f *domain.Filter) (string, string) {
+		return strconv.Itoa(f.ID), f.Name
+	},
+	View: func(f *domain.Filter) any {
+		return newAuditFilter(f)
+	},
+}
+
+func newAuditFilter(f *domain.Filter) *auditFilter {
+	view := &auditFilter{Filter: *f}
+	view.Filter.Actions = nil
+	view.Filter.Downloads = nil
+	view.Filter.Indexers = nil
+	view.Filter.Notifications = nil
+
+	for _, indexer := range f.Indexers {
+		view.Indexers = append(view.Indexers, indexer.Identifier)
+	}
+
+	for _, n := range f.Notifications {
+		view.Notifications = append(view.Notifications, domain.FilterNotification{NotificationID: n.NotificationID, Events: n.Events})
+	}
+
+	return view
+}
+
+// findForAudit loads the filter before a change, it returns nil if the filter can not be found
+func (s *service) findForAudit(ctx context.Context, filterID int) *domain.Filter {
+	filter, err := s.FindByID(ctx, filterID)
+	if err != nil {
+		return nil
+	}
+
+	return filter
+}
+
+// recordChange loads the stored filter, records the difference to before and stores a new revision.
+// Loading it again makes updates and partial updates look the same in the audit log.
+func (s *service) recordChange(ctx context.Context, action domain.AuditAction, filterID int, before *domain.Filter) {
+	var after *domain.Filter
+	if action != domain.AuditActionDelete {
+		after = s.findForAudit(ctx, filterID)
+	}
+
+	if after != nil {
+		s.storeRevision(ctx, before, after)
+	}
+
+	filterAudit.Record(ctx, s.auditSvc, action, before, after)
+}
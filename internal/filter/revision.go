@@ -0,0 +1,140 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package filter
+
+import (
+	"context"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// storeRevision stores the filter as a new revision unless it is the same as the latest one.
+// Filters saved for the first time since revisions were added also get the state before the change stored.
+// Errors are only logged so saving the filter never fails because of it.
+func (s *service) storeRevision(ctx context.Context, before, after *domain.Filter) {
+	ctx = context.WithoutCancel(ctx)
+
+	revision := &domain.FilterRevision{
+		FilterID: after.ID,
+		Filter:   domain.NewFilterRevisionSnapshot(after),
+	}
+	revision.Actor, _ = domain.AuditActorFromContext(ctx)
+
+	latest, err := s.repo.FindLatestRevision(ctx, after.ID)
+	switch {
+	case errors.Is(err, domain.ErrRecordNotFound):
+		latest = nil
+		if before != nil {
+			initial := &domain.FilterRevision{
+				FilterID: before.ID,
+				Actor:    "system",
+				Filter:   domain.NewFilterRevisionSnapshot(before),
+			}
+
+			if err := s.repo.StoreRevision(ctx, initial); err != nil {
+				s.log.Error().Err(err).Msgf("could not store initial revision for filter: %d", before.ID)
+			} else {
+				latest = initial
+			}
+		}
+
+	case err != nil:
+		s.log.Error().Err(err).Msgf("could not find latest revision for filter: %d", after.ID)
+	}
+
+	if latest != nil && len(domain.DiffFilterRevisions(latest, revision).Changes) == 0 {
+		return
+	}
+
+	if err := s.repo.StoreRevision(ctx, revision); err != nil {
+		s.log.Error().Err(err).Msgf("could not store revision for filter: %d", after.ID)
+	}
+}
+
+func (s *service) FindRevisions(ctx context.Context, filterID int) ([]domain.FilterRevision, error) {
+	return s.repo.FindRevisions(ctx, filterID)
+}
+
+func (s *service) FindRevision(ctx context.Context, filterID int, revision int) (*domain.FilterRevision, error) {
+	return s.repo.FindRevision(ctx, filterID, revision)
+}
+
+func (s *service) DiffRevisions(ctx context.Context, filterID int, from int, to int) (*domain.FilterRevisionDiff, error) {
+	fromRevision, err := s.repo.FindRevision(ctx, filterID, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find revision %d", from)
+	}
+
+	toRevision, err := s.repo.FindRevision(ctx, filterID, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find revision %d", to)
+	}
+
+	return domain.DiffFilterRevisions(fromRevision, toRevision), nil
+}
+
+// RestoreRevision saves the filter as it was in the revision, which is stored as a new revision.
+// Actions that have been deleted since are created again and indexers that no longer exist are left out.
+func (s *service) RestoreRevision(ctx context.Context, filterID int, revision int) (*domain.Filter, error) {
+	rev, err := s.repo.FindRevision(ctx, filterID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.FindByID(ctx, filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentActions := make(map[int]bool, len(current.Actions))
+	for _, action := range current.Actions {
+		currentActions[action.ID] = true
+	}
+
+	filter := rev.Filter
+	filter.ID = filterID
+
+	// empty lists are left out of the stored revision but these columns can not be null
+	for _, list := range []*[]string{&filter.Resolutions, &filter.Codecs, &filter.Sources, &filter.Containers} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+
+	for _, action := range filter.Actions {
+		if !currentActions[action.ID] {
+			action.ID = 0
+		}
+		action.FilterID = filterID
+	}
+
+	indexers, err := s.indexerSvc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingIndexers := make(map[int64]bool, len(indexers))
+	for _, indexer := range indexers {
+		existingIndexers[indexer.ID] = true
+	}
+
+	filterIndexers := make([]domain.Indexer, 0, len(filter.Indexers))
+	for _, indexer := range filter.Indexers {
+		if !existingIndexers[indexer.ID] {
+			s.log.Warn().Msgf("filter %d revision %d: indexer %s no longer exists and is not restored", filterID, revision, indexer.Identifier)
+			continue
+		}
+		filterIndexers = append(filterIndexers, indexer)
+	}
+	filter.Indexers = filterIndexers
+
+	if err := s.Update(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Msgf("filter %d restored to revision %d", filterID, revision)
+
+	return s.FindByID(ctx, filterID)
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/autobrr/autobrr/internal/action"
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/indexer"
 	"github.com/autobrr/autobrr/internal/logger"
@@ -34,6 +35,7 @@ type Service interface {
 	FindByIndexerIdentifier(ctx context.Context, indexer string) ([]*domain.Filter, error)
 	Find(ctx context.Context, params domain.FilterQueryParams) ([]*domain.Filter, error)
 	CheckFilter(ctx context.Context, f *domain.Filter, release *domain.Release) (bool, error)
+	DryRun(ctx context.Context, req *domain.FilterTestRequest) (*domain.FilterTestResult, error)
 	ListFilters(ctx context.Context) ([]domain.Filter, error)
 	Store(ctx context.Context, filter *domain.Filter) error
 	Update(ctx context.Context, filter *domain.Filter) error
@@ -46,6 +48,10 @@ type Service interface {
 	AdditionalRecordLabelCheck(ctx context.Context, f *domain.Filter, release *domain.Release) (bool, error)
 	CheckSmartEpisodeCanDownload(ctx context.Context, params *domain.SmartEpisodeParams) (bool, error)
 	CheckIsDuplicateRelease(ctx context.Context, profile *domain.DuplicateReleaseProfile, release *domain.Release) (bool, error)
+	FindRevisions(ctx context.Context, filterID int) ([]domain.FilterRevision, error)
+	FindRevision(ctx context.Context, filterID int, revision int) (*domain.FilterRevision, error)
+	DiffRevisions(ctx context.Context, filterID int, from int, to int) (*domain.FilterRevisionDiff, error)
+	RestoreRevision(ctx context.Context, filterID int, revision int) (*domain.Filter, error)
 }
 
 type service struct {
@@ -57,11 +63,12 @@ type service struct {
 	apiService      indexer.APIService
 	downloadSvc     *releasedownload.DownloadService
 	notificationSvc notification.FilterStorer
+	auditSvc        audit.Service
 
 	httpClient *http.Client
 }
 
-func NewService(log logger.Logger, repo domain.FilterRepo, actionSvc action.Service, releaseRepo domain.ReleaseRepo, apiService indexer.APIService, indexerSvc indexer.Service, downloadSvc *releasedownload.DownloadService, notificationSvc notification.FilterStorer) Service {
+func NewService(log logger.Logger, repo domain.FilterRepo, actionSvc action.Service, releaseRepo domain.ReleaseRepo, apiService indexer.APIService, indexerSvc indexer.Service, downloadSvc *releasedownload.DownloadService, notificationSvc notification.FilterStorer, auditSvc audit.Service) Service {
 	return &service{
 		log:             log.With().Str("module", "filter").Logger(),
 		repo:            repo,
@@ -71,6 +78,7 @@ func NewService(log logger.Logger, repo domain.FilterRepo, actionSvc action.Serv
 		indexerSvc:      indexerSvc,
 		downloadSvc:     downloadSvc,
 		notificationSvc: notificationSvc,
+		auditSvc:        auditSvc,
 		httpClient: &http.Client{
 			Timeout:   time.Second * 120,
 			Transport: sharedhttp.TransportTLSInsecure,
@@ -193,6 +201,8 @@ func (s *service) Store(ctx context.Context, filter *domain.Filter) error {
 		return err
 	}
 
+	s.recordChange(ctx, domain.AuditActionCreate, filter.ID, nil)
+
 	return nil
 }
 
@@ -209,6 +219,8 @@ func (s *service) Update(ctx context.Context, filter *domain.Filter) error {
 		return err
 	}
 
+	before := s.findForAudit(ctx, filter.ID)
+
 	// update
 	err = s.repo.Update(ctx, filter)
 	if err != nil {
@@ -246,10 +258,19 @@ func (s *service) Update(ctx context.Context, filter *domain.Filter) error {
 		return err
 	}
 
+	s.recordChange(ctx, domain.AuditActionUpdate, filter.ID, before)
+
 	return nil
 }
 
 func (s *service) UpdatePartial(ctx context.Context, filter domain.FilterUpdate) error {
+	if filter.Expression != nil && *filter.Expression != "" {
+		if _, err := domain.CompileExpression(*filter.Expression); err != nil {
+			s.log.Error().Err(err).Msgf("invalid filter expression: %v", filter.ID)
+			return errors.Wrap(err, "validation: invalid expression")
+		}
+	}
+
 	// cleanup
 	if filter.Shows != nil {
 		// replace newline with comma
@@ -259,6 +280,8 @@ func (s *service) UpdatePartial(ctx context.Context, filter domain.FilterUpdate)
 		filter.Shows = &clean
 	}
 
+	before := s.findForAudit(ctx, filter.ID)
+
 	// update
 	if err := s.repo.UpdatePartial(ctx, filter); err != nil {
 		s.log.Error().Err(err).Msgf("could not update partial filter: %v", filter.ID)
@@ -297,6 +320,8 @@ func (s *service) UpdatePartial(ctx context.Context, filter domain.FilterUpdate)
 		}
 	}
 
+	s.recordChange(ctx, domain.AuditActionUpdate, filter.ID, before)
+
 	return nil
 }
 
@@ -344,10 +369,14 @@ func (s *service) Duplicate(ctx context.Context, filterID int) (*domain.Filter,
 		return nil, err
 	}
 
+	s.recordChange(ctx, domain.AuditActionCreate, filter.ID, nil)
+
 	return filter, nil
 }
 
 func (s *service) ToggleEnabled(ctx context.Context, filterID int, enabled bool) error {
+	before := s.findForAudit(ctx, filterID)
+
 	if err := s.repo.ToggleEnabled(ctx, filterID, enabled); err != nil {
 		s.log.Error().Err(err).Msg("could not update filter enabled")
 		return err
@@ -355,6 +384,8 @@ func (s *service) ToggleEnabled(ctx context.Context, filterID int, enabled bool)
 
 	s.log.Debug().Msgf("filter.toggle_enabled: update filter '%v' to '%v'", filterID, enabled)
 
+	s.recordChange(ctx, domain.AuditActionToggle, filterID, before)
+
 	return nil
 }
 
@@ -363,6 +394,8 @@ func (s *service) Delete(ctx context.Context, filterID int) error {
 		return nil
 	}
 
+	before := s.findForAudit(ctx, filterID)
+
 	// take care of filter actions
 	if err := s.actionService.DeleteByFilterID(ctx, filterID); err != nil {
 		s.log.Error().Err(err).Msg("could not delete filter actions")
@@ -392,6 +425,8 @@ func (s *service) Delete(ctx context.Context, filterID int) error {
 		return err
 	}
 
+	s.recordChange(ctx, domain.AuditActionDelete, filterID, before)
+
 	return nil
 }
 
@@ -543,6 +578,55 @@ func (s *service) CheckFilter(ctx context.Context, f *domain.Filter, release *do
 	return true, nil
 }
 
+// DryRun replays stored releases, or the release names in the request, through the filter.
+// It never runs actions, external filters or touches the max downloads counters.
+func (s *service) DryRun(ctx context.Context, req *domain.FilterTestRequest) (*domain.FilterTestResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	f, err := s.repo.FindByID(ctx, req.FilterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []*domain.Release
+
+	if len(req.ReleaseNames) > 0 {
+		for _, name := range req.ReleaseNames {
+			if name == "" {
+				continue
+			}
+
+			rls := domain.NewRelease(domain.IndexerMinimal{})
+			rls.ParseString(name)
+
+			releases = append(releases, rls)
+		}
+	} else {
+		// default to the indexers the filter is used with
+		if len(req.Indexers) == 0 {
+			indexers, err := s.indexerSvc.FindByFilterID(ctx, f.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not find indexers for filter: %s", f.Name)
+			}
+
+			for _, indexer := range indexers {
+				req.Indexers = append(req.Indexers, indexer.Identifier)
+			}
+		}
+
+		releases, err = s.releaseRepo.FindForFilterTest(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not find releases")
+		}
+	}
+
+	s.log.Debug().Msgf("testing filter %s against %d releases", f.Name, len(releases))
+
+	return f.DryRun(releases), nil
+}
+
 // AdditionalSizeCheck performs additional out-of-band checks to determine the
 // values of a torrent. Some indexers do not announce torrent size, so it is
 // necessary to determine the size of the torrent in some other way. Some
@@ -614,6 +698,12 @@ func (s *service) AdditionalSizeCheck(ctx context.Context, f *domain.Filter, rel
 		return false, nil
 	}
 
+	// expressions using the size were skipped until it is known
+	if f.ExpressionRequiresSize() && !f.CheckReleaseExpression(release) {
+		l.Debug().Msgf("(%s) filter expression did not match after additional size check, trying next", f.Name)
+		return false, nil
+	}
+
 	return true, nil
 }
 
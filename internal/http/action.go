@@ -19,7 +19,7 @@ type actionService interface {
 	List(ctx context.Context) ([]domain.Action, error)
 	Store(ctx context.Context, action *domain.Action) error
 	Delete(ctx context.Context, req *domain.DeleteActionRequest) error
-	ToggleEnabled(actionID int) error
+	ToggleEnabled(ctx context.Context, actionID int) error
 }
 
 type actionHandler struct {
@@ -109,7 +109,7 @@ func (h actionHandler) toggleActionEnabled(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.service.ToggleEnabled(actionID); err != nil {
+	if err := h.service.ToggleEnabled(r.Context(), actionID); err != nil {
 		h.encoder.Error(w, err)
 		return
 	}
@@ -19,7 +19,7 @@ type apikeyService interface {
 	List(ctx context.Context) ([]domain.APIKey, error)
 	Store(ctx context.Context, key *domain.APIKey) error
 	Delete(ctx context.Context, key string) error
-	ValidateAPIKey(ctx context.Context, token string) bool
+	ValidateAPIKey(ctx context.Context, token string) (*domain.APIKey, bool)
 }
 
 type apikeyHandler struct {
@@ -37,6 +37,7 @@ func newAPIKeyHandler(encoder encoder, service apikeyService) *apikeyHandler {
 func (h apikeyHandler) Routes(r chi.Router) {
 	r.Get("/", h.list)
 	r.Post("/", h.store)
+	r.Get("/scopes", h.scopes)
 	r.Delete("/{apikey}", h.delete)
 }
 
@@ -57,6 +58,11 @@ func (h apikeyHandler) store(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	if err := h.service.Store(r.Context(), &data); err != nil {
 		h.encoder.Error(w, err)
 		return
@@ -65,6 +71,10 @@ func (h apikeyHandler) store(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusCreated, data)
 }
 
+func (h apikeyHandler) scopes(w http.ResponseWriter, r *http.Request) {
+	h.encoder.StatusResponse(w, http.StatusOK, domain.APIKeyScopes)
+}
+
 func (h apikeyHandler) delete(w http.ResponseWriter, r *http.Request) {
 	apiKey := chi.URLParam(r, "apikey")
 
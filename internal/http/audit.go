@@ -0,0 +1,77 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type auditService interface {
+	Find(ctx context.Context, params domain.AuditQueryParams) (*domain.FindAuditResponse, error)
+}
+
+type auditHandler struct {
+	encoder encoder
+	service auditService
+}
+
+func newAuditHandler(encoder encoder, service auditService) *auditHandler {
+	return &auditHandler{
+		encoder: encoder,
+		service: service,
+	}
+}
+
+func (h auditHandler) Routes(r chi.Router) {
+	r.Get("/", h.find)
+}
+
+func (h auditHandler) find(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	params := domain.AuditQueryParams{Limit: 50}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || limit == 0 || limit > 500 {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "BAD_REQUEST_PARAMS",
+				"message": "limit parameter is invalid",
+			})
+			return
+		}
+		params.Limit = limit
+	}
+
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "BAD_REQUEST_PARAMS",
+				"message": "offset parameter is invalid",
+			})
+			return
+		}
+		params.Offset = offset
+	}
+
+	params.Filters.Actor = query.Get("actor")
+	params.Filters.Action = domain.AuditAction(query.Get("action"))
+	params.Filters.ResourceType = domain.AuditResource(query.Get("resource_type"))
+	params.Filters.ResourceID = query.Get("resource_id")
+
+	resp, err := h.service.Find(r.Context(), params)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, resp)
+}
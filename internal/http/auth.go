@@ -22,6 +22,8 @@ type authService interface {
 	Login(ctx context.Context, username, password string) (*domain.User, error)
 	CreateUser(ctx context.Context, req domain.CreateUserRequest) error
 	UpdateUser(ctx context.Context, req domain.UpdateUserRequest) error
+	GetUser(ctx context.Context, username string) (*domain.User, error)
+	userService
 }
 
 type authHandler struct {
@@ -193,6 +195,10 @@ func (h *authHandler) validate(w http.ResponseWriter, r *http.Request) {
 		"auth_method": authMethod,
 	}
 
+	if user := domain.UserFromContext(ctx); user != nil {
+		response["role"] = user.Role
+	}
+
 	if profilePicture != "" {
 		response["profile_picture"] = profilePicture
 	}
@@ -209,6 +215,12 @@ func (h *authHandler) updateUser(w http.ResponseWriter, r *http.Request) {
 
 	data.UsernameCurrent = chi.URLParam(r, "username")
 
+	// admins manage other users through /api/users
+	if user := domain.UserFromContext(r.Context()); user != nil && user.Username != data.UsernameCurrent {
+		h.encoder.StatusError(w, http.StatusForbidden, errors.New("users can only update their own account"))
+		return
+	}
+
 	if err := h.service.UpdateUser(r.Context(), data); err != nil {
 		h.encoder.StatusError(w, http.StatusForbidden, err)
 		return
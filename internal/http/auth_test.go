@@ -76,6 +76,66 @@ func (a authServiceMock) UpdateUser(ctx context.Context, req domain.UpdateUserRe
 	return nil
 }
 
+func (a authServiceMock) GetUser(ctx context.Context, username string) (*domain.User, error) {
+	u, ok := a.users[username]
+	if !ok {
+		return nil, domain.ErrRecordNotFound
+	}
+
+	return u, nil
+}
+
+func (a authServiceMock) ListUsers(ctx context.Context) ([]domain.User, error) {
+	users := make([]domain.User, 0, len(a.users))
+	for _, u := range a.users {
+		users = append(users, *u)
+	}
+
+	return users, nil
+}
+
+func (a authServiceMock) AddUser(ctx context.Context, req domain.CreateUserRequest) error {
+	if _, ok := a.users[req.Username]; ok {
+		return domain.ErrUserExists
+	}
+
+	a.users[req.Username] = &domain.User{
+		ID:       len(a.users) + 1,
+		Username: req.Username,
+		Password: req.Password,
+		Role:     req.Role,
+	}
+
+	return nil
+}
+
+func (a authServiceMock) EditUser(ctx context.Context, req domain.UpdateUserRequest) error {
+	u, ok := a.users[req.UsernameCurrent]
+	if !ok {
+		return domain.ErrRecordNotFound
+	}
+
+	if req.Role != "" {
+		u.Role = req.Role
+	}
+
+	if req.PasswordNew != "" {
+		u.Password = req.PasswordNew
+	}
+
+	return nil
+}
+
+func (a authServiceMock) DeleteUser(ctx context.Context, username string) error {
+	if _, ok := a.users[username]; !ok {
+		return domain.ErrRecordNotFound
+	}
+
+	delete(a.users, username)
+
+	return nil
+}
+
 type oidcAuthServiceMock struct {
 }
 
@@ -111,6 +171,10 @@ func (o *oidcAuthServiceMock) SupportsPKCE() bool {
 	return false
 }
 
+func (o *oidcAuthServiceMock) RoleFromClaims(claims ...map[string]any) (domain.UserRole, bool) {
+	return domain.UserRoleAdmin, true
+}
+
 func newHttpTestClient() *http.Client {
 	c := &http.Client{}
 
@@ -165,6 +229,7 @@ func TestAuthHandlerLogin(t *testing.T) {
 	server := &Server{
 		log:            logger,
 		sessionManager: sessionManager,
+		authService:    service,
 	}
 
 	handler := newAuthHandler(encoder, logger, server, &domain.Config{}, server.sessionManager, service, oidcServiceMock)
@@ -222,6 +287,7 @@ func TestAuthHandlerValidateOK(t *testing.T) {
 	server := &Server{
 		log:            logger,
 		sessionManager: sessionManager,
+		authService:    service,
 	}
 
 	handler := newAuthHandler(encoder, logger, server, &domain.Config{}, server.sessionManager, service, oidcServiceMock)
@@ -289,6 +355,7 @@ func TestAuthHandlerValidateBad(t *testing.T) {
 	server := &Server{
 		log:            logger,
 		sessionManager: sessionManager,
+		authService:    service,
 	}
 
 	handler := newAuthHandler(encoder, logger, server, &domain.Config{}, server.sessionManager, service, oidcServiceMock)
@@ -332,6 +399,7 @@ func TestAuthHandlerLoginBad(t *testing.T) {
 	server := &Server{
 		log:            logger,
 		sessionManager: sessionManager,
+		authService:    service,
 	}
 
 	handler := newAuthHandler(encoder, logger, server, &domain.Config{}, server.sessionManager, service, oidcServiceMock)
@@ -384,6 +452,7 @@ func TestAuthHandlerLogout(t *testing.T) {
 	server := &Server{
 		log:            logger,
 		sessionManager: sessionManager,
+		authService:    service,
 	}
 
 	handler := newAuthHandler(encoder, logger, server, &domain.Config{}, server.sessionManager, service, oidcServiceMock)
@@ -28,6 +28,8 @@ type feedService interface {
 	ForceRun(ctx context.Context, id int) error
 	FetchCaps(ctx context.Context, feed *domain.Feed) (*domain.FeedCapabilities, error)
 	FetchCapsByID(ctx context.Context, id int) (*domain.FeedCapabilities, error)
+	PlanBackfill(ctx context.Context, req *domain.FeedBackfillRequest) ([]*domain.FeedBackfillPlan, error)
+	StartBackfill(plans []*domain.FeedBackfillPlan) error
 }
 
 type feedHandler struct {
@@ -47,6 +49,7 @@ func (h feedHandler) Routes(r chi.Router) {
 	r.Post("/", h.store)
 	r.Post("/test", h.test)
 	r.Post("/caps", h.caps)
+	r.Post("/backfill", h.backfill)
 
 	r.Route("/{feedID}", func(r chi.Router) {
 		r.Get("/", h.findByID)
@@ -174,6 +177,49 @@ func (h feedHandler) forceRun(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusNoContent, nil)
 }
 
+// backfill plans the searches and runs them in the background, with dry_run only the plan is returned.
+// A backfill that is already running is reported with 409 Conflict.
+func (h feedHandler) backfill(w http.ResponseWriter, r *http.Request) {
+	var data domain.FeedBackfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	plans, err := h.service.PlanBackfill(r.Context(), &data)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, err)
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		h.encoder.StatusResponse(w, http.StatusOK, plans)
+		return
+	}
+
+	if err := h.service.StartBackfill(plans); err != nil {
+		if errors.Is(err, domain.ErrFeedBackfillRunning) {
+			h.encoder.StatusError(w, http.StatusConflict, err)
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusAccepted, plans)
+}
+
 func (h feedHandler) capsByID(w http.ResponseWriter, r *http.Request) {
 	feedID, err := strconv.Atoi(chi.URLParam(r, "feedID"))
 	if err != nil {
@@ -6,6 +6,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -27,6 +28,11 @@ type filterService interface {
 	UpdatePartial(ctx context.Context, filter domain.FilterUpdate) error
 	Duplicate(ctx context.Context, filterID int) (*domain.Filter, error)
 	ToggleEnabled(ctx context.Context, filterID int, enabled bool) error
+	DryRun(ctx context.Context, req *domain.FilterTestRequest) (*domain.FilterTestResult, error)
+	FindRevisions(ctx context.Context, filterID int) ([]domain.FilterRevision, error)
+	FindRevision(ctx context.Context, filterID int, revision int) (*domain.FilterRevision, error)
+	DiffRevisions(ctx context.Context, filterID int, from int, to int) (*domain.FilterRevisionDiff, error)
+	RestoreRevision(ctx context.Context, filterID int, revision int) (*domain.Filter, error)
 }
 
 type filterHandler struct {
@@ -51,13 +57,20 @@ func (h filterHandler) Routes(r chi.Router) {
 		r.Patch("/", h.updatePartial)
 		r.Delete("/", h.delete)
 
-		r.Get("/duplicate", h.duplicate)
+		r.With(RequireScope(domain.APIKeyScopeFiltersWrite)).Get("/duplicate", h.duplicate)
 		r.Put("/enabled", h.toggleEnabled)
 		
 		r.Route("/notifications", func(r chi.Router) {
 			r.Get("/", h.getFilterNotifications)
 			r.Put("/", h.updateFilterNotifications)
 		})
+
+		r.Route("/revisions", func(r chi.Router) {
+			r.Get("/", h.getRevisions)
+			r.Get("/diff", h.diffRevisions)
+			r.Get("/{revision}", h.getRevision)
+			r.Post("/{revision}/restore", h.restoreRevision)
+		})
 	})
 }
 
@@ -128,6 +141,42 @@ func (h filterHandler) getByID(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusOK, filter)
 }
 
+func (h filterHandler) test(w http.ResponseWriter, r *http.Request) {
+	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param id"))
+		return
+	}
+
+	var req domain.FilterTestRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+			return
+		}
+	}
+
+	req.FilterID = filterID
+
+	if err := req.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.service.DryRun(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, errors.New("filter with id %d not found", filterID))
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, result)
+}
+
 func (h filterHandler) duplicate(w http.ResponseWriter, r *http.Request) {
 	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
 	if err != nil {
@@ -283,3 +332,106 @@ func (h filterHandler) updateFilterNotifications(w http.ResponseWriter, r *http.
 
 	h.encoder.NoContent(w)
 }
+
+func (h filterHandler) getRevisions(w http.ResponseWriter, r *http.Request) {
+	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param id"))
+		return
+	}
+
+	revisions, err := h.service.FindRevisions(r.Context(), filterID)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, revisions)
+}
+
+func (h filterHandler) getRevision(w http.ResponseWriter, r *http.Request) {
+	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param id"))
+		return
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param revision"))
+		return
+	}
+
+	rev, err := h.service.FindRevision(r.Context(), filterID, revision)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, errors.New("revision %d of filter with id %d not found", revision, filterID))
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, rev)
+}
+
+func (h filterHandler) diffRevisions(w http.ResponseWriter, r *http.Request) {
+	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param id"))
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param from"))
+		return
+	}
+
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param to"))
+		return
+	}
+
+	diff, err := h.service.DiffRevisions(r.Context(), filterID, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, err)
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, diff)
+}
+
+func (h filterHandler) restoreRevision(w http.ResponseWriter, r *http.Request) {
+	filterID, err := strconv.Atoi(chi.URLParam(r, "filterID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param id"))
+		return
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("bad param revision"))
+		return
+	}
+
+	filter, err := h.service.RestoreRevision(r.Context(), filterID, revision)
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, errors.New("revision %d of filter with id %d not found", revision, filterID))
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, filter)
+}
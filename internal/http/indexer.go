@@ -6,6 +6,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -25,8 +26,13 @@ type indexerService interface {
 	Delete(ctx context.Context, id int) error
 	TestApi(ctx context.Context, req domain.IndexerTestApiRequest) error
 	ToggleEnabled(ctx context.Context, indexerID int, enabled bool) error
+	ValidateDefinitions(ctx context.Context, data []byte) ([]domain.IndexerDefinitionReport, error)
+	ReloadCustomDefinitions(ctx context.Context) (*domain.IndexerDefinitionReload, error)
 }
 
+// definitionMaxBodySize limits the size of definitions sent for validation
+const definitionMaxBodySize = 1 << 20
+
 type indexerHandler struct {
 	encoder encoder
 	service indexerService
@@ -46,6 +52,8 @@ func (h indexerHandler) Routes(r chi.Router) {
 	r.Post("/", h.store)
 	r.Get("/", h.getAll)
 	r.Get("/options", h.list)
+	r.Post("/definitions/validate", h.validateDefinitions)
+	r.Post("/definitions/reload", h.reloadDefinitions)
 
 	r.Route("/{indexerID}", func(r chi.Router) {
 		r.Get("/", h.findByID)
@@ -67,6 +75,35 @@ func (h indexerHandler) getSchema(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusOK, indexers)
 }
 
+// validateDefinitions validates the definition yaml in the body,
+// or all built-in and custom definitions if the body is empty
+func (h indexerHandler) validateDefinitions(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, definitionMaxBodySize))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reports, err := h.service.ValidateDefinitions(r.Context(), data)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, reports)
+}
+
+// reloadDefinitions reloads the changed custom definitions and the indexers using them
+func (h indexerHandler) reloadDefinitions(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.ReloadCustomDefinitions(r.Context())
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, result)
+}
+
 func (h indexerHandler) store(w http.ResponseWriter, r *http.Request) {
 	var data domain.Indexer
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
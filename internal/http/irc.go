@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -29,6 +30,9 @@ type ircService interface {
 	RestartNetwork(ctx context.Context, id int64) error
 	SendCmd(ctx context.Context, req *domain.SendIrcCmdRequest) error
 	ManualProcessAnnounce(ctx context.Context, req *domain.IRCManualProcessRequest) error
+	AnnounceLines(ctx context.Context, networkID int64, channel string) ([]domain.AnnounceLine, error)
+	ReplayAnnounces(ctx context.Context, req *domain.AnnounceReplayRequest) (*domain.AnnounceReplayResult, error)
+	ParseFailures(ctx context.Context, indexer string) []domain.AnnounceParseFailure
 }
 
 type ircHandler struct {
@@ -57,11 +61,15 @@ func (h ircHandler) Routes(r chi.Router) {
 
 		r.Post("/cmd", h.sendCmd)
 		r.Post("/channel", h.storeChannel)
-		r.Get("/restart", h.restartNetwork)
+		r.With(RequireScope(domain.APIKeyScopeIRCAdmin)).Get("/restart", h.restartNetwork)
 
 		r.Post("/channel/{channel}/announce/process", h.announceProcess)
+		r.Get("/channel/{channel}/announces", h.announceLines)
+		r.Post("/channel/{channel}/announces/replay", h.announceReplay)
 	})
 
+	r.Get("/parse-failures", h.parseFailures)
+
 	r.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
 
 		// inject CORS headers to bypass checks
@@ -195,12 +203,7 @@ func (h ircHandler) announceProcess(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data.NetworkId = int64(networkID)
-	data.Channel = chi.URLParam(r, "channel")
-
-	// we cant pass # as an url parameter so the frontend has to strip it
-	if !strings.HasPrefix(data.Channel, "#") {
-		data.Channel = fmt.Sprintf("#%s", data.Channel)
-	}
+	data.Channel = channelURLParam(r)
 
 	if err := h.service.ManualProcessAnnounce(r.Context(), &data); err != nil {
 		if errors.Is(err, domain.ErrRecordNotFound) {
@@ -215,6 +218,68 @@ func (h ircHandler) announceProcess(w http.ResponseWriter, r *http.Request) {
 	h.encoder.NoContent(w)
 }
 
+func (h ircHandler) announceLines(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.Atoi(chi.URLParam(r, "networkID"))
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	lines, err := h.service.AnnounceLines(r.Context(), int64(networkID), channelURLParam(r))
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, lines)
+}
+
+func (h ircHandler) announceReplay(w http.ResponseWriter, r *http.Request) {
+	networkID, err := strconv.Atoi(chi.URLParam(r, "networkID"))
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	var data domain.AnnounceReplayRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil && !errors.Is(err, io.EOF) {
+			h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+			return
+		}
+	}
+
+	data.NetworkID = int64(networkID)
+	data.Channel = channelURLParam(r)
+
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.service.ReplayAnnounces(r.Context(), &data)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, result)
+}
+
+func (h ircHandler) parseFailures(w http.ResponseWriter, r *http.Request) {
+	h.encoder.StatusResponse(w, http.StatusOK, h.service.ParseFailures(r.Context(), r.URL.Query().Get("indexer")))
+}
+
+// channelURLParam returns the channel url parameter with the # the frontend has to strip
+func channelURLParam(r *http.Request) string {
+	channel := chi.URLParam(r, "channel")
+	if !strings.HasPrefix(channel, "#") {
+		channel = fmt.Sprintf("#%s", channel)
+	}
+
+	return channel
+}
+
 func (h ircHandler) storeChannel(w http.ResponseWriter, r *http.Request) {
 	networkID, err := strconv.Atoi(chi.URLParam(r, "networkID"))
 	if err != nil {
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/manifest"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// manifestMaxBodySize limits the size of uploaded manifests
+const manifestMaxBodySize = 10 << 20
+
+type manifestService interface {
+	Export(ctx context.Context, opts domain.ManifestExportOptions) (*domain.Manifest, error)
+	Plan(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error)
+	Apply(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error)
+}
+
+type manifestHandler struct {
+	encoder encoder
+	service manifestService
+}
+
+func newManifestHandler(encoder encoder, service manifestService) *manifestHandler {
+	return &manifestHandler{
+		encoder: encoder,
+		service: service,
+	}
+}
+
+func (h manifestHandler) Routes(r chi.Router) {
+	r.Get("/", h.export)
+	r.Post("/plan", h.plan)
+	r.Post("/apply", h.apply)
+}
+
+func (h manifestHandler) export(w http.ResponseWriter, r *http.Request) {
+	format := domain.ManifestFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = domain.ManifestFormatYAML
+	}
+
+	opts := domain.ManifestExportOptions{
+		Secrets: domain.ManifestSecrets(r.URL.Query().Get("secrets")),
+	}
+
+	if err := opts.Validate(); err != nil {
+		h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+			"code":    "BAD_REQUEST_PARAMS",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	doc, err := h.service.Export(r.Context(), opts)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	data, err := manifest.Marshal(doc, format)
+	if err != nil {
+		h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+			"code":    "BAD_REQUEST_PARAMS",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	contentType := "application/yaml; charset=utf-8"
+	if format == domain.ManifestFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=autobrr."+string(format))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (h manifestHandler) plan(w http.ResponseWriter, r *http.Request) {
+	doc, opts, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := h.service.Plan(r.Context(), doc, opts)
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, plan)
+}
+
+func (h manifestHandler) apply(w http.ResponseWriter, r *http.Request) {
+	doc, opts, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := h.service.Apply(r.Context(), doc, opts)
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	if !plan.Valid() {
+		h.encoder.StatusResponse(w, http.StatusUnprocessableEntity, plan)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, plan)
+}
+
+// decode reads the yaml or json manifest from the body and the plan options from the query
+func (h manifestHandler) decode(w http.ResponseWriter, r *http.Request) (*domain.Manifest, domain.ManifestPlanOptions, bool) {
+	var opts domain.ManifestPlanOptions
+
+	if prune := r.URL.Query().Get("prune"); prune != "" {
+		var err error
+		if opts.Prune, err = strconv.ParseBool(prune); err != nil {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "BAD_REQUEST_PARAMS",
+				"message": "prune parameter is invalid",
+			})
+			return nil, opts, false
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, manifestMaxBodySize))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return nil, opts, false
+	}
+
+	doc, err := manifest.Unmarshal(data)
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return nil, opts, false
+	}
+
+	return doc, opts, true
+}
@@ -4,12 +4,17 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"runtime/debug"
 	"strings"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 	"golang.org/x/crypto/bcrypt"
@@ -19,17 +24,23 @@ func (s *Server) IsAuthenticated(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if token := r.Header.Get("X-API-Token"); token != "" {
 			// check header
-			if !s.apiService.ValidateAPIKey(r.Context(), token) {
+			apiKey, ok := s.apiService.ValidateAPIKey(r.Context(), token)
+			if !ok {
 				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 				return
 			}
 
+			r = r.WithContext(domain.ContextWithAPIKey(r.Context(), apiKey))
+
 		} else if key := r.URL.Query().Get("apikey"); key != "" {
 			// check query param like ?apikey=TOKEN
-			if !s.apiService.ValidateAPIKey(r.Context(), key) {
+			apiKey, ok := s.apiService.ValidateAPIKey(r.Context(), key)
+			if !ok {
 				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 				return
 			}
+
+			r = r.WithContext(domain.ContextWithAPIKey(r.Context(), apiKey))
 		} else {
 			// check session
 			authenticated := s.sessionManager.GetBool(r.Context(), "authenticated")
@@ -42,6 +53,18 @@ func (s *Server) IsAuthenticated(next http.Handler) http.Handler {
 				return
 			}
 
+			user, err := s.sessionUser(r.Context())
+			if err != nil {
+				s.log.Debug().Err(err).Msg("session user not found")
+				if err := s.sessionManager.Destroy(r.Context()); err != nil {
+					s.log.Error().Err(err).Msg("failed to destroy session")
+				}
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			r = r.WithContext(domain.ContextWithUser(r.Context(), user))
+
 			deadline := s.sessionManager.Deadline(r.Context())
 			if time.Until(deadline) <= 7*24*time.Hour {
 				s.log.Trace().Msgf("session is expiring in less than 7 days on %s - extending session", deadline.Format("2006-01-02 15:04:05"))
@@ -58,6 +81,75 @@ func (s *Server) IsAuthenticated(next http.Handler) http.Handler {
 	})
 }
 
+// sessionUser returns the user of an authenticated session. Password users are looked up
+// on every request so role changes and deleted users take effect right away.
+func (s *Server) sessionUser(ctx context.Context) (*domain.User, error) {
+	username := s.sessionManager.GetString(ctx, "username")
+
+	if s.sessionManager.GetString(ctx, "auth_method") == "oidc" {
+		role := domain.UserRole(s.sessionManager.GetString(ctx, "role"))
+		if role == "" {
+			// sessions from before roles keep admin unless groups are mapped to roles
+			var ok bool
+			if role, ok = s.oidcService.RoleFromClaims(); !ok {
+				return nil, errors.New("oidc session without role")
+			}
+		}
+
+		return &domain.User{Username: username, Role: role}, nil
+	}
+
+	user, err := s.authService.GetUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// RequireScope rejects requests made with an api key or by a user whose role does not grant scope.
+func RequireScope(scope string) func(next http.Handler) http.Handler {
+	return RequireScopes(scope, scope)
+}
+
+// RequireScopes works like RequireScope but checks the read scope for GET, HEAD and OPTIONS requests
+// and the write scope for every other method.
+func RequireScopes(read, write string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := write
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = read
+			}
+
+			if apiKey := domain.APIKeyFromContext(r.Context()); apiKey != nil {
+				if !apiKey.HasScope(scope) {
+					scopeForbidden(w, fmt.Sprintf("api key is missing required scope: %s", scope))
+					return
+				}
+			} else if user := domain.UserFromContext(r.Context()); user != nil {
+				if !user.Role.HasScope(scope) {
+					scopeForbidden(w, fmt.Sprintf("role %s is missing required scope: %s", user.Role, scope))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func scopeForbidden(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+
+	_ = json.NewEncoder(w).Encode(errorResponse{
+		Message: message,
+		Status:  http.StatusForbidden,
+	})
+}
+
 func LoggerMiddleware(logger *zerolog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
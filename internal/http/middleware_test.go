@@ -0,0 +1,126 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build integration
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type apikeyServiceMock struct {
+	keys map[string]domain.APIKey
+}
+
+func (m apikeyServiceMock) List(ctx context.Context) ([]domain.APIKey, error) {
+	keys := make([]domain.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m apikeyServiceMock) Store(ctx context.Context, key *domain.APIKey) error {
+	m.keys[key.Key] = *key
+	return nil
+}
+
+func (m apikeyServiceMock) Delete(ctx context.Context, key string) error {
+	delete(m.keys, key)
+	return nil
+}
+
+func (m apikeyServiceMock) ValidateAPIKey(ctx context.Context, token string) (*domain.APIKey, bool) {
+	key, ok := m.keys[token]
+	if !ok {
+		return nil, false
+	}
+	return &key, true
+}
+
+func TestRequireScopes(t *testing.T) {
+	t.Parallel()
+
+	server := &Server{
+		log: zerolog.Nop(),
+		apiService: apikeyServiceMock{keys: map[string]domain.APIKey{
+			"read":  {Name: "read", Key: "read", Scopes: []string{domain.APIKeyScopeFiltersRead}},
+			"write": {Name: "write", Key: "write", Scopes: []string{domain.APIKeyScopeFiltersWrite}},
+			"admin": {Name: "admin", Key: "admin", Scopes: []string{domain.APIKeyScopeAdmin}},
+			"push":  {Name: "push", Key: "push", Scopes: []string{domain.APIKeyScopeReleasesPush}},
+		}},
+	}
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	r := chi.NewRouter()
+	r.Group(func(r chi.Router) {
+		r.Use(server.IsAuthenticated)
+
+		r.With(RequireScopes(domain.APIKeyScopeFiltersRead, domain.APIKeyScopeFiltersWrite)).Route("/filters", func(r chi.Router) {
+			r.Get("/", ok)
+			r.Post("/", ok)
+			r.With(RequireScope(domain.APIKeyScopeFiltersWrite)).Get("/duplicate", ok)
+		})
+		r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/keys", func(r chi.Router) {
+			r.Get("/", ok)
+		})
+	})
+
+	testServer := httptest.NewServer(r)
+	defer testServer.Close()
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		token        string
+		wantStatus   int
+		missingScope string
+	}{
+		{name: "invalid_key", method: http.MethodGet, path: "/filters", token: "invalid", wantStatus: http.StatusUnauthorized},
+		{name: "read_get", method: http.MethodGet, path: "/filters", token: "read", wantStatus: http.StatusOK},
+		{name: "read_post", method: http.MethodPost, path: "/filters", token: "read", wantStatus: http.StatusForbidden, missingScope: domain.APIKeyScopeFiltersWrite},
+		{name: "read_get_override", method: http.MethodGet, path: "/filters/duplicate", token: "read", wantStatus: http.StatusForbidden, missingScope: domain.APIKeyScopeFiltersWrite},
+		{name: "write_get", method: http.MethodGet, path: "/filters", token: "write", wantStatus: http.StatusOK},
+		{name: "write_post", method: http.MethodPost, path: "/filters", token: "write", wantStatus: http.StatusOK},
+		{name: "write_get_override", method: http.MethodGet, path: "/filters/duplicate", token: "write", wantStatus: http.StatusOK},
+		{name: "push_get", method: http.MethodGet, path: "/filters", token: "push", wantStatus: http.StatusForbidden, missingScope: domain.APIKeyScopeFiltersRead},
+		{name: "write_keys", method: http.MethodGet, path: "/keys", token: "write", wantStatus: http.StatusForbidden, missingScope: domain.APIKeyScopeAdmin},
+		{name: "admin_keys", method: http.MethodGet, path: "/keys", token: "admin", wantStatus: http.StatusOK},
+		{name: "admin_post", method: http.MethodPost, path: "/filters", token: "admin", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, testServer.URL+tt.path, nil)
+			assert.NoError(t, err)
+			req.Header.Set("X-API-Token", tt.token)
+
+			resp, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if tt.missingScope != "" {
+				var res errorResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+				assert.Equal(t, "api key is missing required scope: "+tt.missingScope, res.Message)
+			}
+		})
+	}
+}
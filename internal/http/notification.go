@@ -23,6 +23,7 @@ type notificationService interface {
 	Update(ctx context.Context, notification *domain.Notification) error
 	Delete(ctx context.Context, id int) error
 	Test(ctx context.Context, notification *domain.Notification) error
+	Preview(ctx context.Context, notification *domain.Notification) ([]domain.NotificationPreview, error)
 }
 
 type notificationHandler struct {
@@ -67,6 +68,11 @@ func (h notificationHandler) store(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	err := h.service.Store(r.Context(), data)
 	if err != nil {
 		h.encoder.Error(w, err)
@@ -104,6 +110,11 @@ func (h notificationHandler) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	err := h.service.Update(r.Context(), data)
 	if err != nil {
 		h.encoder.Error(w, err)
@@ -128,6 +139,7 @@ func (h notificationHandler) delete(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusNoContent, nil)
 }
 
+// test sends the enabled test events, with preview=true they are rendered and returned instead
 func (h notificationHandler) test(w http.ResponseWriter, r *http.Request) {
 	var data *domain.Notification
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -135,6 +147,22 @@ func (h notificationHandler) test(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if preview, _ := strconv.ParseBool(r.URL.Query().Get("preview")); preview {
+		previews, err := h.service.Preview(r.Context(), data)
+		if err != nil {
+			h.encoder.Error(w, err)
+			return
+		}
+
+		h.encoder.StatusResponse(w, http.StatusOK, previews)
+		return
+	}
+
 	if err := h.service.Test(r.Context(), data); err != nil {
 		h.encoder.Error(w, err)
 		return
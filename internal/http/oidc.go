@@ -31,6 +31,7 @@ type oidcService interface {
 	OAuthExchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 	OauthAuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string
 	SupportsPKCE() bool
+	RoleFromClaims(claims ...map[string]any) (domain.UserRole, bool)
 }
 
 type OIDCConfig struct {
@@ -174,11 +175,26 @@ func (h *OIDCHandler) handleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// groups are read from both the ID token and userinfo claims
+	var groupClaims []map[string]any
+
+	var idTokenClaims map[string]any
+	if err := idToken.Claims(&idTokenClaims); err == nil {
+		groupClaims = append(groupClaims, idTokenClaims)
+	}
+
 	userInfo, err := h.oidcService.UserInfo(r.Context(), oauth2.StaticTokenSource(oauth2Token))
 	if err != nil {
 		h.log.Error().Err(err).Msg("failed to get userinfo")
 	}
 
+	if userInfo != nil {
+		var userInfoClaims map[string]any
+		if err := userInfo.Claims(&userInfoClaims); err == nil {
+			groupClaims = append(groupClaims, userInfoClaims)
+		}
+	}
+
 	if userInfo != nil {
 		var userInfoClaims struct {
 			Email    string `json:"email"`
@@ -226,6 +242,13 @@ func (h *OIDCHandler) handleCallback(w http.ResponseWriter, r *http.Request) {
 
 	h.log.Debug().Str("email", claims.Email).Str("preferred_username", claims.Username).Str("nickname", claims.Nickname).Str("name", claims.Name).Str("sub", claims.Sub).Msg("successfully processed OIDC claims")
 
+	role, ok := h.oidcService.RoleFromClaims(groupClaims...)
+	if !ok {
+		h.log.Warn().Msgf("Auth: OIDC user [%s] is not a member of any group mapped to a role ip: %s", claims.Username, r.RemoteAddr)
+		h.encoder.StatusError(w, http.StatusForbidden, errors.New("no role: not a member of any allowed group"))
+		return
+	}
+
 	// Create new session
 	if err := h.sessionManager.RenewToken(r.Context()); err != nil {
 		h.log.Error().Err(err).Msgf("Auth: Failed to renew session token for username: [%s] ip: %s", claims.Username, r.RemoteAddr)
@@ -249,6 +272,7 @@ func (h *OIDCHandler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	h.sessionManager.Put(r.Context(), "username", claims.Username)
 	h.sessionManager.Put(r.Context(), "created", time.Now().Unix())
 	h.sessionManager.Put(r.Context(), "auth_method", "oidc")
+	h.sessionManager.Put(r.Context(), "role", string(role))
 	h.sessionManager.Put(r.Context(), "profile_picture", claims.Picture)
 	h.sessionManager.RememberMe(r.Context(), true)
 
@@ -4,12 +4,14 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/pkg/errors"
@@ -22,9 +24,14 @@ type releaseService interface {
 	Get(ctx context.Context, req *domain.GetReleaseRequest) (*domain.Release, error)
 	GetIndexerOptions(ctx context.Context) ([]string, error)
 	Stats(ctx context.Context) (*domain.ReleaseStats, error)
+	StatsTimeseries(ctx context.Context, params *domain.ReleaseStatsTimeseriesParams) (*domain.ReleaseStatsTimeseries, error)
 	Delete(ctx context.Context, req *domain.DeleteReleaseRequest) error
 	Retry(ctx context.Context, req *domain.ReleaseActionRetryReq) error
 	ProcessManual(ctx context.Context, req *domain.ReleaseProcessReq) error
+	Push(ctx context.Context, reqs []*domain.ReleasePushRequest) []*domain.ReleasePushResult
+	QueueStats() domain.ReleaseQueueStats
+	ListDelayedJobs(ctx context.Context) []domain.ReleaseDelayedJob
+	CancelDelayedJob(ctx context.Context, id string) error
 
 	StoreReleaseProfileDuplicate(ctx context.Context, profile *domain.DuplicateReleaseProfile) error
 	FindDuplicateReleaseProfiles(ctx context.Context) ([]*domain.DuplicateReleaseProfile, error)
@@ -55,9 +62,16 @@ func (h releaseHandler) Routes(r chi.Router) {
 	r.Get("/", h.findReleases)
 	r.Get("/recent", h.findRecentReleases)
 	r.Get("/stats", h.getStats)
+	r.Get("/stats/timeseries", h.getStatsTimeseries)
 	r.Get("/indexers", h.getIndexerOptions)
 	r.Delete("/", h.deleteReleases)
 
+	r.Route("/queue", func(r chi.Router) {
+		r.Get("/", h.getQueueStats)
+		r.Get("/delayed", h.listDelayedJobs)
+		r.Delete("/delayed/{jobID}", h.cancelDelayedJob)
+	})
+
 	//r.Post("/process", h.retryAction)
 
 	r.Route("/{releaseID}", func(r chi.Router) {
@@ -231,6 +245,74 @@ func (h releaseHandler) getStats(w http.ResponseWriter, r *http.Request) {
 	h.encoder.StatusResponse(w, http.StatusOK, stats)
 }
 
+func (h releaseHandler) getStatsTimeseries(w http.ResponseWriter, r *http.Request) {
+	params := &domain.ReleaseStatsTimeseriesParams{
+		Bucket:  domain.ReleaseStatsBucket(r.URL.Query().Get("bucket")),
+		GroupBy: domain.ReleaseStatsGroupBy(r.URL.Query().Get("group_by")),
+	}
+
+	for key, dst := range map[string]*time.Time{"from": &params.From, "to": &params.To} {
+		value := r.URL.Query().Get(key)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "BAD_REQUEST_PARAMS",
+				"message": fmt.Sprintf("%s parameter is invalid", key),
+			})
+			return
+		}
+
+		*dst = t
+	}
+
+	if err := params.Validate(); err != nil {
+		h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+			"code":    "BAD_REQUEST_PARAMS",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.service.StatsTimeseries(r.Context(), params)
+	if err != nil {
+		h.encoder.StatusResponse(w, http.StatusInternalServerError, map[string]any{
+			"code":    "INTERNAL_SERVER_ERROR",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, stats)
+}
+
+func (h releaseHandler) getQueueStats(w http.ResponseWriter, r *http.Request) {
+	h.encoder.StatusResponse(w, http.StatusOK, h.service.QueueStats())
+}
+
+func (h releaseHandler) listDelayedJobs(w http.ResponseWriter, r *http.Request) {
+	h.encoder.StatusResponse(w, http.StatusOK, h.service.ListDelayedJobs(r.Context()))
+}
+
+func (h releaseHandler) cancelDelayedJob(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "jobID")
+
+	if err := h.service.CancelDelayedJob(r.Context(), jobID); err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			h.encoder.NotFoundErr(w, errors.New("could not find delayed job with id %s", jobID))
+			return
+		}
+
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.NoContent(w)
+}
+
 func (h releaseHandler) deleteReleases(w http.ResponseWriter, r *http.Request) {
 	req := domain.DeleteReleaseRequest{}
 
@@ -312,6 +394,68 @@ func (h releaseHandler) process(w http.ResponseWriter, r *http.Request) {
 	h.encoder.NoContent(w)
 }
 
+// push accepts a single release object or an array of releases
+func (h releaseHandler) push(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+
+	var reqs []*domain.ReleasePushRequest
+	if batch {
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+			return
+		}
+	} else {
+		var req domain.ReleasePushRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+			return
+		}
+		reqs = append(reqs, &req)
+	}
+
+	if len(reqs) == 0 {
+		h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+			"code":    "VALIDATION_ERROR",
+			"message": "no releases",
+		})
+		return
+	}
+
+	for idx, req := range reqs {
+		if req == nil {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "VALIDATION_ERROR",
+				"message": fmt.Sprintf("release %d: empty release", idx),
+			})
+			return
+		}
+
+		if err := req.Validate(); err != nil {
+			h.encoder.StatusResponse(w, http.StatusBadRequest, map[string]any{
+				"code":    "VALIDATION_ERROR",
+				"message": fmt.Sprintf("release %d: %s", idx, err.Error()),
+			})
+			return
+		}
+	}
+
+	results := h.service.Push(r.Context(), reqs)
+
+	if !batch {
+		h.encoder.StatusResponse(w, http.StatusOK, results[0])
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, results)
+}
+
 func (h releaseHandler) retryAction(w http.ResponseWriter, r *http.Request) {
 	releaseID, err := strconv.Atoi(chi.URLParam(r, "releaseID"))
 	if err != nil {
@@ -24,12 +24,14 @@ import (
 // Mock releaseService for testing cleanup job endpoints
 type releaseServiceMock struct {
 	cleanupJobs map[int]*domain.ReleaseCleanupJob
+	delayedJobs map[string]domain.ReleaseDelayedJob
 	nextID      int
 }
 
 func newReleaseServiceMock() *releaseServiceMock {
 	return &releaseServiceMock{
 		cleanupJobs: make(map[int]*domain.ReleaseCleanupJob),
+		delayedJobs: make(map[string]domain.ReleaseDelayedJob),
 		nextID:      1,
 	}
 }
@@ -115,6 +117,18 @@ func (m *releaseServiceMock) Stats(ctx context.Context) (*domain.ReleaseStats, e
 	return nil, errors.New("not implemented")
 }
 
+func (m *releaseServiceMock) StatsTimeseries(ctx context.Context, params *domain.ReleaseStatsTimeseriesParams) (*domain.ReleaseStatsTimeseries, error) {
+	return &domain.ReleaseStatsTimeseries{
+		From:    params.From,
+		To:      params.To,
+		Bucket:  params.Bucket,
+		GroupBy: params.GroupBy,
+		Points: []*domain.ReleaseStatsTimeseriesPoint{
+			{Time: params.From, Group: "btn", TotalCount: 2, PushApprovedCount: 1},
+		},
+	}, nil
+}
+
 func (m *releaseServiceMock) Delete(ctx context.Context, req *domain.DeleteReleaseRequest) error {
 	return errors.New("not implemented")
 }
@@ -127,6 +141,38 @@ func (m *releaseServiceMock) ProcessManual(ctx context.Context, req *domain.Rele
 	return errors.New("not implemented")
 }
 
+func (m *releaseServiceMock) Push(ctx context.Context, reqs []*domain.ReleasePushRequest) []*domain.ReleasePushResult {
+	results := make([]*domain.ReleasePushResult, 0, len(reqs))
+	for _, req := range reqs {
+		results = append(results, &domain.ReleasePushResult{
+			Name:         req.Name,
+			Indexer:      req.IndexerIdentifier,
+			FilterStatus: domain.ReleaseStatusFilterApproved,
+		})
+	}
+	return results
+}
+
+func (m *releaseServiceMock) QueueStats() domain.ReleaseQueueStats {
+	return domain.ReleaseQueueStats{Workers: 10, QueueCapacity: 1000, Delayed: len(m.delayedJobs)}
+}
+
+func (m *releaseServiceMock) ListDelayedJobs(ctx context.Context) []domain.ReleaseDelayedJob {
+	jobs := make([]domain.ReleaseDelayedJob, 0, len(m.delayedJobs))
+	for _, job := range m.delayedJobs {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+func (m *releaseServiceMock) CancelDelayedJob(ctx context.Context, id string) error {
+	if _, ok := m.delayedJobs[id]; !ok {
+		return domain.ErrRecordNotFound
+	}
+	delete(m.delayedJobs, id)
+	return nil
+}
+
 func (m *releaseServiceMock) StoreReleaseProfileDuplicate(ctx context.Context, profile *domain.DuplicateReleaseProfile) error {
 	return errors.New("not implemented")
 }
@@ -529,3 +575,178 @@ func TestReleaseHandler_ForceRunCleanupJob_NotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
+
+func setupReleasePushHandler(service releaseService) chi.Router {
+	handler := newReleaseHandler(encoder{}, service)
+
+	r := chi.NewRouter()
+	r.Post("/api/release/push", handler.push)
+
+	return r
+}
+
+func TestReleaseHandler_Push(t *testing.T) {
+	t.Parallel()
+
+	router := setupReleasePushHandler(newReleaseServiceMock())
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	body := `{"name": "That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", "indexer_identifier": "mock", "download_url": "https://example.com/dl/1"}`
+
+	resp, err := http.Post(testServer.URL+"/api/release/push", "application/json", bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result domain.ReleasePushResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, "That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", result.Name)
+	assert.Equal(t, "mock", result.Indexer)
+	assert.Equal(t, domain.ReleaseStatusFilterApproved, result.FilterStatus)
+}
+
+func TestReleaseHandler_Push_Batch(t *testing.T) {
+	t.Parallel()
+
+	router := setupReleasePushHandler(newReleaseServiceMock())
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	body := `[
+		{"name": "That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", "indexer_identifier": "mock", "download_url": "https://example.com/dl/1"},
+		{"name": "That.Show.S01E02.1080p.WEB-DL.H.264-GROUP", "indexer_identifier": "mock", "magnet_uri": "magnet:?xt=urn:btih:abc"}
+	]`
+
+	resp, err := http.Post(testServer.URL+"/api/release/push", "application/json", bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results []domain.ReleasePushResult
+	err = json.NewDecoder(resp.Body).Decode(&results)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "That.Show.S01E02.1080p.WEB-DL.H.264-GROUP", results[1].Name)
+}
+
+func TestReleaseHandler_Push_Invalid(t *testing.T) {
+	t.Parallel()
+
+	router := setupReleasePushHandler(newReleaseServiceMock())
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "bad_json", body: `{"name": `},
+		{name: "empty_batch", body: `[]`},
+		{name: "missing_name", body: `{"indexer_identifier": "mock", "download_url": "https://example.com/dl/1"}`},
+		{name: "missing_download", body: `{"name": "Test", "indexer_identifier": "mock"}`},
+		{name: "invalid_in_batch", body: `[{"name": "Test", "indexer_identifier": "mock", "download_url": "https://example.com/dl/1"}, {"name": "Test"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(testServer.URL+"/api/release/push", "application/json", bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestReleaseHandler_QueueStats(t *testing.T) {
+	t.Parallel()
+
+	service := newReleaseServiceMock()
+	service.delayedJobs["1-1"] = domain.ReleaseDelayedJob{ID: "1-1", ReleaseID: 1, FilterID: 1}
+
+	router := setupReleaseHandler(service)
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.URL + "/api/releases/queue")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats domain.ReleaseQueueStats
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stats.Workers)
+	assert.Equal(t, 1, stats.Delayed)
+}
+
+func TestReleaseHandler_StatsTimeseries(t *testing.T) {
+	t.Parallel()
+
+	router := setupReleaseHandler(newReleaseServiceMock())
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.URL + "/api/releases/stats/timeseries?bucket=hour&group_by=indexer&from=2024-05-15T00:00:00Z&to=2024-05-16T00:00:00Z")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats domain.ReleaseStatsTimeseries
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReleaseStatsBucketHour, stats.Bucket)
+	assert.Equal(t, domain.ReleaseStatsGroupByIndexer, stats.GroupBy)
+	assert.Len(t, stats.Points, 1)
+	assert.Equal(t, "btn", stats.Points[0].Group)
+
+	for _, query := range []string{"bucket=month", "group_by=uploader", "from=yesterday"} {
+		resp, err := http.Get(testServer.URL + "/api/releases/stats/timeseries?" + query)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestReleaseHandler_CancelDelayedJob(t *testing.T) {
+	t.Parallel()
+
+	service := newReleaseServiceMock()
+	service.delayedJobs["1-1"] = domain.ReleaseDelayedJob{ID: "1-1", ReleaseID: 1, FilterID: 1}
+
+	router := setupReleaseHandler(service)
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.URL + "/api/releases/queue/delayed")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var jobs []domain.ReleaseDelayedJob
+	err = json.NewDecoder(resp.Body).Decode(&jobs)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	req, err := http.NewRequest(http.MethodDelete, testServer.URL+"/api/releases/queue/delayed/1-1", nil)
+	assert.NoError(t, err)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, service.delayedJobs)
+
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/autobrr/autobrr/internal/config"
+	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/web"
 
@@ -34,6 +35,7 @@ type Server struct {
 
 	actionService         actionService
 	apiService            apikeyService
+	auditService          auditService
 	authService           authService
 	downloadClientService downloadClientService
 	filterService         filterService
@@ -41,10 +43,12 @@ type Server struct {
 	indexerService        indexerService
 	ircService            ircService
 	listService           listService
+	manifestService       manifestService
 	notificationService   notificationService
 	oidcService           oidcService
 	proxyService          proxyService
 	releaseService        releaseService
+	trackingService       trackingService
 	updateService         updateService
 }
 
@@ -62,6 +66,7 @@ type Deps struct {
 
 	ActionService         actionService
 	ApiService            apikeyService
+	AuditService          auditService
 	AuthService           authService
 	DownloadClientService downloadClientService
 	FilterService         filterService
@@ -69,10 +74,12 @@ type Deps struct {
 	IndexerService        indexerService
 	IrcService            ircService
 	ListService           listService
+	ManifestService       manifestService
 	NotificationService   notificationService
 	OIDCService           oidcService
 	ProxyService          proxyService
 	ReleaseService        releaseService
+	TrackingService       trackingService
 	UpdateService         updateService
 }
 
@@ -104,6 +111,7 @@ func NewServer(deps Deps) *Server {
 
 		actionService:         deps.ActionService,
 		apiService:            deps.ApiService,
+		auditService:          deps.AuditService,
 		authService:           deps.AuthService,
 		downloadClientService: deps.DownloadClientService,
 		filterService:         deps.FilterService,
@@ -111,10 +119,12 @@ func NewServer(deps Deps) *Server {
 		indexerService:        deps.IndexerService,
 		ircService:            deps.IrcService,
 		listService:           deps.ListService,
+		manifestService:       deps.ManifestService,
 		notificationService:   deps.NotificationService,
 		oidcService:           deps.OIDCService,
 		proxyService:          deps.ProxyService,
 		releaseService:        deps.ReleaseService,
+		trackingService:       deps.TrackingService,
 		updateService:         deps.UpdateService,
 	}
 
@@ -187,23 +197,37 @@ func (s *Server) Handler() http.Handler {
 		r.Group(func(r chi.Router) {
 			r.Use(s.IsAuthenticated)
 
-			r.Route("/actions", newActionHandler(encoder, s.actionService).Routes)
-			r.Route("/config", newConfigHandler(encoder, s.buildInfo, s.config).Routes)
-			r.Route("/download_clients", newDownloadClientHandler(encoder, s.downloadClientService).Routes)
-			r.Route("/filters", newFilterHandler(encoder, s.filterService).Routes)
-			r.Route("/feeds", newFeedHandler(encoder, s.feedService).Routes)
-			r.Route("/irc", newIrcHandler(encoder, s.sse, s.ircService).Routes)
-			r.Route("/indexer", newIndexerHandler(encoder, s.indexerService, s.ircService).Routes)
-			r.Route("/lists", newListHandler(encoder, s.listService).Routes)
-			r.Route("/keys", newAPIKeyHandler(encoder, s.apiService).Routes)
-			r.Route("/logs", newLogsHandler(s.config).Routes)
-			r.Route("/notification", newNotificationHandler(encoder, s.notificationService).Routes)
-			r.Route("/proxy", newProxyHandler(encoder, s.proxyService).Routes)
-			r.Route("/release", newReleaseHandler(encoder, s.releaseService).Routes)
-			r.Route("/updates", newUpdateHandler(encoder, s.updateService).Routes)
-			r.Route("/webhook", newWebhookHandler(encoder, s.listService).Routes)
-
-			r.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+			r.With(RequireScopes(domain.APIKeyScopeFiltersRead, domain.APIKeyScopeFiltersWrite)).Route("/actions", newActionHandler(encoder, s.actionService).Routes)
+			r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/audit", newAuditHandler(encoder, s.auditService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/config", newConfigHandler(encoder, s.buildInfo, s.config).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/download_clients", newDownloadClientHandler(encoder, s.downloadClientService).Routes)
+
+			filterHandler := newFilterHandler(encoder, s.filterService)
+			// filter test is a dry run without side effects so read access is enough
+			r.With(RequireScope(domain.APIKeyScopeFiltersRead)).Post("/filters/{filterID}/test", filterHandler.test)
+			r.With(RequireScopes(domain.APIKeyScopeFiltersRead, domain.APIKeyScopeFiltersWrite)).Route("/filters", filterHandler.Routes)
+
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/feeds", newFeedHandler(encoder, s.feedService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeIRCRead, domain.APIKeyScopeIRCAdmin)).Route("/irc", newIrcHandler(encoder, s.sse, s.ircService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/indexer", newIndexerHandler(encoder, s.indexerService, s.ircService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/lists", newListHandler(encoder, s.listService).Routes)
+			r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/keys", newAPIKeyHandler(encoder, s.apiService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/logs", newLogsHandler(s.config).Routes)
+			r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/manifest", newManifestHandler(encoder, s.manifestService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/notification", newNotificationHandler(encoder, s.notificationService).Routes)
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/proxy", newProxyHandler(encoder, s.proxyService).Routes)
+
+			releaseHandler := newReleaseHandler(encoder, s.releaseService)
+			// push has its own scope so keys for external announce sources don't need releases:write
+			r.With(RequireScope(domain.APIKeyScopeReleasesPush)).Post("/release/push", releaseHandler.push)
+			r.With(RequireScopes(domain.APIKeyScopeReleasesRead, domain.APIKeyScopeReleasesWrite)).Route("/release", releaseHandler.Routes)
+			r.With(RequireScopes(domain.APIKeyScopeReleasesRead, domain.APIKeyScopeReleasesWrite)).Route("/tracking", newTrackingHandler(encoder, s.trackingService).Routes)
+
+			r.With(RequireScopes(domain.APIKeyScopeConfigRead, domain.APIKeyScopeConfigWrite)).Route("/updates", newUpdateHandler(encoder, s.updateService).Routes)
+			r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/users", newUserHandler(encoder, s.authService).Routes)
+			r.With(RequireScope(domain.APIKeyScopeListsRefresh)).Route("/webhook", newWebhookHandler(encoder, s.listService).Routes)
+
+			r.With(RequireScope(domain.APIKeyScopeConfigRead)).HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
 
 				// inject CORS headers to bypass checks
 				s.sse.Headers = map[string]string{
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type trackingService interface {
+	Refresh(ctx context.Context) error
+	FindByReleaseID(ctx context.Context, releaseID int64) ([]*domain.ReleaseTorrentStats, error)
+	StatsByFilter(ctx context.Context) ([]*domain.TorrentStatsSummary, error)
+	StatsByIndexer(ctx context.Context) ([]*domain.TorrentStatsSummary, error)
+}
+
+type trackingHandler struct {
+	encoder encoder
+	service trackingService
+}
+
+func newTrackingHandler(encoder encoder, service trackingService) *trackingHandler {
+	return &trackingHandler{
+		encoder: encoder,
+		service: service,
+	}
+}
+
+func (h trackingHandler) Routes(r chi.Router) {
+	r.Get("/filters", h.statsByFilter)
+	r.Get("/indexers", h.statsByIndexer)
+	r.Get("/releases/{releaseID}", h.findByReleaseID)
+	r.Post("/refresh", h.refresh)
+}
+
+func (h trackingHandler) statsByFilter(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.StatsByFilter(r.Context())
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, stats)
+}
+
+func (h trackingHandler) statsByIndexer(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.StatsByIndexer(r.Context())
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, stats)
+}
+
+func (h trackingHandler) findByReleaseID(w http.ResponseWriter, r *http.Request) {
+	releaseID, err := strconv.Atoi(chi.URLParam(r, "releaseID"))
+	if err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := h.service.FindByReleaseID(r.Context(), int64(releaseID))
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, stats)
+}
+
+func (h trackingHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Refresh(r.Context()); err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.NoContent(w)
+}
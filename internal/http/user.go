@@ -0,0 +1,136 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type userService interface {
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	AddUser(ctx context.Context, req domain.CreateUserRequest) error
+	EditUser(ctx context.Context, req domain.UpdateUserRequest) error
+	DeleteUser(ctx context.Context, username string) error
+}
+
+type userHandler struct {
+	encoder encoder
+	service userService
+}
+
+func newUserHandler(encoder encoder, service userService) *userHandler {
+	return &userHandler{
+		encoder: encoder,
+		service: service,
+	}
+}
+
+func (h userHandler) Routes(r chi.Router) {
+	r.Get("/", h.list)
+	r.Post("/", h.store)
+	r.Get("/roles", h.roles)
+	r.Put("/{username}", h.update)
+	r.Delete("/{username}", h.delete)
+}
+
+func (h userHandler) list(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.ListUsers(r.Context())
+	if err != nil {
+		h.encoder.Error(w, err)
+		return
+	}
+
+	h.encoder.StatusResponse(w, http.StatusOK, users)
+}
+
+func (h userHandler) store(w http.ResponseWriter, r *http.Request) {
+	var data domain.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+		return
+	}
+
+	if err := data.Validate(); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.AddUser(r.Context(), data); err != nil {
+		h.error(w, data.Username, err)
+		return
+	}
+
+	h.encoder.StatusResponseMessage(w, http.StatusCreated, "user successfully created")
+}
+
+func (h userHandler) roles(w http.ResponseWriter, r *http.Request) {
+	h.encoder.StatusResponse(w, http.StatusOK, domain.UserRoles)
+}
+
+func (h userHandler) update(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		Role     domain.UserRole `json:"role"`
+		Password string          `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode json"))
+		return
+	}
+
+	if data.Role == "" && data.Password == "" {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("validation error: role or password required"))
+		return
+	}
+
+	if data.Role != "" && !data.Role.IsValid() {
+		h.encoder.StatusError(w, http.StatusBadRequest, errors.New("validation error: invalid role: %s", data.Role))
+		return
+	}
+
+	username := chi.URLParam(r, "username")
+
+	req := domain.UpdateUserRequest{
+		UsernameCurrent: username,
+		PasswordNew:     data.Password,
+		Role:            data.Role,
+	}
+
+	if err := h.service.EditUser(r.Context(), req); err != nil {
+		h.error(w, username, err)
+		return
+	}
+
+	h.encoder.StatusResponseMessage(w, http.StatusOK, "user successfully updated")
+}
+
+func (h userHandler) delete(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+
+	if err := h.service.DeleteUser(r.Context(), username); err != nil {
+		h.error(w, username, err)
+		return
+	}
+
+	h.encoder.NoContent(w)
+}
+
+func (h userHandler) error(w http.ResponseWriter, username string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrRecordNotFound):
+		h.encoder.NotFoundErr(w, errors.New("user %s not found", username))
+	case errors.Is(err, domain.ErrUserExists):
+		h.encoder.StatusError(w, http.StatusConflict, err)
+	case errors.Is(err, domain.ErrLastAdmin):
+		h.encoder.StatusError(w, http.StatusBadRequest, err)
+	default:
+		h.encoder.Error(w, err)
+	}
+}
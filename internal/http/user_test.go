@@ -0,0 +1,136 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+//go:build integration
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoles(t *testing.T) {
+	t.Parallel()
+	logger := zerolog.Nop()
+	encoder := encoder{}
+
+	service := authServiceMock{
+		users: map[string]*domain.User{
+			"admin":  {ID: 1, Username: "admin", Password: "pass", Role: domain.UserRoleAdmin},
+			"editor": {ID: 2, Username: "editor", Password: "pass", Role: domain.UserRoleEditor},
+			"viewer": {ID: 3, Username: "viewer", Password: "pass", Role: domain.UserRoleViewer},
+		},
+	}
+
+	server := &Server{
+		log:            logger,
+		sessionManager: scs.New(),
+		authService:    service,
+	}
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	s := setupServer(server)
+	s.Route("/auth", newAuthHandler(encoder, logger, server, &domain.Config{BaseURL: "/"}, server.sessionManager, service, &oidcAuthServiceMock{}).Routes)
+	s.Group(func(r chi.Router) {
+		r.Use(server.IsAuthenticated)
+
+		r.With(RequireScopes(domain.APIKeyScopeFiltersRead, domain.APIKeyScopeFiltersWrite)).Route("/filters", func(r chi.Router) {
+			r.Get("/", ok)
+			r.Post("/", ok)
+		})
+		r.With(RequireScope(domain.APIKeyScopeAdmin)).Route("/users", newUserHandler(encoder, service).Routes)
+	})
+
+	testServer := runTestServer(s)
+	defer testServer.Close()
+
+	login := func(t *testing.T, username string) *http.Client {
+		client := newHttpTestClient()
+
+		body, err := json.Marshal(map[string]string{"username": username, "password": "pass"})
+		require.NoError(t, err)
+
+		resp, err := client.Post(testServer.URL+"/auth/login", "application/json", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		return client
+	}
+
+	do := func(t *testing.T, client *http.Client, method, path string, body any) int {
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+
+		req, err := http.NewRequest(method, testServer.URL+path, &buf)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	t.Run("viewer", func(t *testing.T) {
+		client := login(t, "viewer")
+
+		resp, err := client.Get(testServer.URL + "/auth/validate")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var validate map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&validate))
+		assert.Equal(t, "viewer", validate["role"])
+
+		assert.Equal(t, http.StatusOK, do(t, client, http.MethodGet, "/filters", nil))
+		assert.Equal(t, http.StatusForbidden, do(t, client, http.MethodPost, "/filters", nil))
+		assert.Equal(t, http.StatusForbidden, do(t, client, http.MethodGet, "/users", nil))
+		assert.Equal(t, http.StatusForbidden, do(t, client, http.MethodPatch, "/auth/user/admin", map[string]string{"password_current": "pass", "password_new": "new"}))
+	})
+
+	t.Run("editor", func(t *testing.T) {
+		client := login(t, "editor")
+
+		assert.Equal(t, http.StatusOK, do(t, client, http.MethodPost, "/filters", nil))
+		assert.Equal(t, http.StatusForbidden, do(t, client, http.MethodPost, "/users", map[string]string{"username": "new", "password": "pass"}))
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		client := login(t, "admin")
+
+		assert.Equal(t, http.StatusBadRequest, do(t, client, http.MethodPost, "/users", map[string]string{"username": "new", "password": "pass", "role": "owner"}))
+		assert.Equal(t, http.StatusCreated, do(t, client, http.MethodPost, "/users", map[string]string{"username": "new", "password": "pass", "role": "viewer"}))
+		assert.Equal(t, http.StatusConflict, do(t, client, http.MethodPost, "/users", map[string]string{"username": "new", "password": "pass"}))
+
+		viewer := login(t, "new")
+		assert.Equal(t, http.StatusForbidden, do(t, viewer, http.MethodPost, "/filters", nil))
+
+		// role changes apply to existing sessions
+		assert.Equal(t, http.StatusOK, do(t, client, http.MethodPut, "/users/new", map[string]string{"role": "editor"}))
+		assert.Equal(t, http.StatusOK, do(t, viewer, http.MethodPost, "/filters", nil))
+
+		// deleted users lose their session
+		assert.Equal(t, http.StatusNoContent, do(t, client, http.MethodDelete, "/users/new", nil))
+		assert.Equal(t, http.StatusForbidden, do(t, viewer, http.MethodGet, "/filters", nil))
+
+		assert.Equal(t, http.StatusNotFound, do(t, client, http.MethodDelete, "/users/new", nil))
+	})
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package indexer
+
+import (
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// indexerAudit records indexers without their proxy, the proxy is referenced by id
+var indexerAudit = audit.Resource[domain.Indexer]{
+	Type: domain.AuditResourceIndexer,
+	Key: func(i *domain.Indexer) (string, string) {
+		return strconv.FormatInt(i.ID, 10), i.Name
+	},
+	View: func(i *domain.Indexer) any {
+		view := *i
+		view.Proxy = nil
+
+		return &view
+	},
+}
@@ -4,10 +4,19 @@
 package indexer
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/asaskevich/EventBus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestYamlExpectations(t *testing.T) {
@@ -37,3 +46,205 @@ func TestYamlExpectations(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDefinitions(t *testing.T) {
+	t.Parallel()
+
+	reports, err := ValidateDefinitions("")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reports)
+
+	for _, r := range reports {
+		assert.True(t, r.Valid, "%s: %v", r.File, r.Errors)
+		assert.Equal(t, r.Tests, r.TestsPassed, r.File)
+	}
+}
+
+func TestValidateDefinitionPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	definition := `---
+name: Mock
+identifier: mock
+urls:
+  - https://mock.local/
+protocol: torrent
+settings:
+  - name: passkey
+    type: secret
+    required: true
+    label: Passkey
+irc:
+  network: Mock
+  server: irc.mock.local
+  port: 6697
+  channels:
+    - "#announce"
+  announcers:
+    - Bot
+  parse:
+    type: single
+    lines:
+      - tests:
+          - line: "New: That.Show.S01E01 - https://mock.local/1"
+            expect:
+              torrentName: That.Show.S01E01
+              torrentId: "1"
+          - line: "New: That.Show.S01E02 - https://mock.local/2"
+            expect:
+              torrentName: That.Show.S01E02
+              torrentId: "3"
+        pattern: 'New: (.+) - https?://.+/(\d+)'
+        vars:
+          - torrentName
+          - torrentId
+    match:
+      torrenturl: "/download/{{ .torrentId }}?key={{ .authkey }}"
+`
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mock.yaml"), []byte(definition), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "unknown.yml"), []byte("name: Unknown\nunknownField: true\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a definition"), 0o644))
+
+	reports, err := ValidateDefinitionPath(dir)
+	assert.NoError(t, err)
+	assert.Len(t, reports, 2)
+
+	mock := reports[0]
+	assert.Equal(t, "mock", mock.Identifier)
+	assert.False(t, mock.Valid)
+	assert.Equal(t, 2, mock.Tests)
+	assert.Equal(t, 1, mock.TestsPassed)
+	assert.Len(t, mock.Errors, 2)
+	assert.Equal(t, `irc.parse.lines[0].tests[1]: torrentId: expected "3", got "2"`, mock.Errors[0])
+	assert.Contains(t, mock.Errors[1], `irc.parse.match.torrenturl:`)
+	assert.Contains(t, mock.Errors[1], `"authkey"`)
+
+	fixed := strings.NewReplacer(`torrentId: "3"`, `torrentId: "2"`, `.authkey`, `.passkey`).Replace(definition)
+	report := ValidateDefinition(domain.IndexerDefinitionSourceCustom, "mock.yaml", []byte(fixed))
+	assert.True(t, report.Valid, report.Errors)
+	assert.Equal(t, 2, report.TestsPassed)
+
+	unknown := reports[1]
+	assert.False(t, unknown.Valid)
+	assert.Contains(t, unknown.Errors[0], "field unknownField not found")
+}
+
+type reloadIndexerRepo struct {
+	domain.IndexerRepo
+	indexers []domain.Indexer
+}
+
+func (r reloadIndexerRepo) List(ctx context.Context) ([]domain.Indexer, error) {
+	return r.indexers, nil
+}
+
+func TestService_ReloadCustomDefinitions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "mock.yaml")
+
+	definition := `---
+name: Mock
+identifier: mock
+urls:
+  - https://mock.local/
+protocol: torrent
+irc:
+  network: Mock
+  server: irc.mock.local
+  port: 6697
+  channels:
+    - "#announce"
+  announcers:
+    - Bot
+  parse:
+    type: single
+    lines:
+      - pattern: 'New: (.+) - https?://.+/(\d+)'
+        vars:
+          - torrentName
+          - torrentId
+    match:
+      torrenturl: "/download/{{ .torrentId }}"
+`
+
+	require.NoError(t, os.WriteFile(file, []byte(definition), 0o644))
+
+	bus := EventBus.New()
+	s := NewService(logger.Mock(), &domain.Config{CustomDefinitions: dir}, bus, reloadIndexerRepo{
+		indexers: []domain.Indexer{{ID: 1, Name: "Mock", Identifier: "mock", Implementation: "irc", Enabled: true}},
+	}, nil, nil, nil, nil).(*service)
+
+	require.NoError(t, s.LoadCustomIndexerDefinitions())
+	_, err := s.mapIndexers()
+	require.NoError(t, err)
+
+	var reloaded []*domain.IndexerDefinition
+	require.NoError(t, bus.Subscribe(domain.EventIndexerDefinitionReload, func(d *domain.IndexerDefinition) {
+		// subscribers look up the indexers again while handling the event
+		assert.Len(t, s.GetIndexersByIRCNetwork(d.IRC.Server), 1)
+		reloaded = append(reloaded, d)
+	}))
+
+	// handlers and the web ui read the definitions while they are reloaded
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_, _ = s.GetAll()
+				_, _ = s.GetTemplates()
+				s.GetIndexersByIRCNetwork("irc.mock.local")
+			}
+		}
+	}()
+
+	// nothing changed
+	result, err := s.ReloadCustomDefinitions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Definitions)
+	assert.Empty(t, reloaded)
+
+	require.NoError(t, os.WriteFile(file, []byte(strings.Replace(definition, "irc.mock.local", "irc.other.local", 1)), 0o644))
+
+	result, err = s.ReloadCustomDefinitions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mock"}, result.Definitions)
+	assert.Equal(t, []string{"mock"}, result.Indexers)
+	require.Len(t, reloaded, 1)
+	assert.Equal(t, "irc.other.local", reloaded[0].IRC.Server)
+	assert.Equal(t, 1, reloaded[0].ID)
+	assert.Empty(t, s.GetIndexersByIRCNetwork("irc.mock.local"))
+	assert.Len(t, s.GetIndexersByIRCNetwork("irc.other.local"), 1)
+
+	close(stop)
+	readers.Wait()
+
+	// invalid changes keep the current definition
+	require.NoError(t, os.WriteFile(file, []byte("name: Mock\nidentifier: mock\n"), 0o644))
+
+	result, err = s.ReloadCustomDefinitions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Definitions)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, file, result.Failed[0].File)
+	assert.Equal(t, "irc.other.local", s.definitions["mock"].IRC.Server)
+
+	require.NoError(t, os.Remove(file))
+
+	result, err = s.ReloadCustomDefinitions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mock"}, result.Definitions)
+	assert.Empty(t, result.Indexers)
+	assert.NotContains(t, s.definitions, "mock")
+	assert.Len(t, reloaded, 1)
+}
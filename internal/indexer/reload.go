@@ -0,0 +1,262 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package indexer
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce is how long the watcher waits for more changes before reloading,
+// editors often write a file in several steps
+const reloadDebounce = time.Second
+
+// customDefinitionFile is a loaded custom definition file
+type customDefinitionFile struct {
+	identifier string
+	checksum   [sha256.Size]byte
+}
+
+// ReloadCustomDefinitions loads the custom definition files that were added, changed or removed since they were last loaded.
+// Changed files are validated first and the current definition is kept if they are invalid.
+// Configured indexers of the reloaded definitions are remapped and published so their irc handlers and feed jobs restart.
+func (s *service) ReloadCustomDefinitions(ctx context.Context) (*domain.IndexerDefinitionReload, error) {
+	if s.config.CustomDefinitions == "" {
+		return nil, errors.New("customDefinitions is not set")
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	entries, err := os.ReadDir(s.config.CustomDefinitions)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read directory: %s", s.config.CustomDefinitions)
+	}
+
+	result := &domain.IndexerDefinitionReload{
+		Definitions: []string{},
+		Indexers:    []string{},
+		Failed:      []domain.IndexerDefinitionReport{},
+	}
+
+	changed := make(map[string]struct{})
+	files := make(map[string]struct{})
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isValidExtension(filepath.Ext(entry.Name())) {
+			continue
+		}
+
+		file := filepath.Join(s.config.CustomDefinitions, entry.Name())
+		files[file] = struct{}{}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("could not read definition file: %s", file)
+			continue
+		}
+
+		checksum := sha256.Sum256(data)
+
+		current, loaded := s.customFiles[file]
+		if loaded && current.checksum == checksum {
+			continue
+		}
+
+		report := ValidateDefinition(domain.IndexerDefinitionSourceCustom, file, data)
+		if !report.Valid {
+			s.log.Error().Msgf("invalid definition file: %s: %s", file, strings.Join(report.Errors, "; "))
+			result.Failed = append(result.Failed, report)
+			continue
+		}
+
+		definition, err := processDefinition(file, data)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("could not process definition file: %s", file)
+			report.AddError("%v", err)
+			result.Failed = append(result.Failed, report)
+			continue
+		}
+
+		s.customFiles[file] = customDefinitionFile{identifier: definition.Identifier, checksum: checksum}
+		changed[definition.Identifier] = struct{}{}
+
+		s.m.Lock()
+		s.definitions[definition.Identifier] = *definition
+
+		// the file used to define another indexer
+		if loaded && current.identifier != definition.Identifier {
+			s.removeCustomDefinition(current.identifier)
+			changed[current.identifier] = struct{}{}
+		}
+		s.m.Unlock()
+
+		s.log.Debug().Msgf("reloaded custom definition %s from file: %s", definition.Identifier, file)
+	}
+
+	for file, current := range s.customFiles {
+		if _, ok := files[file]; ok {
+			continue
+		}
+
+		delete(s.customFiles, file)
+
+		s.m.Lock()
+		s.removeCustomDefinition(current.identifier)
+		s.m.Unlock()
+
+		changed[current.identifier] = struct{}{}
+
+		s.log.Debug().Msgf("removed custom definition %s of file: %s", current.identifier, file)
+	}
+
+	for identifier := range changed {
+		result.Definitions = append(result.Definitions, identifier)
+	}
+	slices.Sort(result.Definitions)
+
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	indexers, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list indexers")
+	}
+
+	for _, indexer := range indexers {
+		definitionName := indexer.Identifier
+		if isImplFeed(indexer.Implementation) {
+			definitionName = indexer.Implementation
+		}
+
+		if _, ok := changed[definitionName]; !ok {
+			continue
+		}
+
+		s.m.RLock()
+		definition := s.getDefinitionByName(definitionName)
+		s.m.RUnlock()
+
+		if definition == nil {
+			s.log.Warn().Msgf("definition %s of indexer %s was removed, the indexer keeps using it until restart", definitionName, indexer.Name)
+			continue
+		}
+
+		if err := s.reloadIndexer(indexer); err != nil {
+			s.log.Error().Err(err).Msgf("could not reload indexer: %s", indexer.Name)
+			continue
+		}
+
+		result.Indexers = append(result.Indexers, indexer.Identifier)
+	}
+
+	s.log.Info().Msgf("Reloaded %d custom indexer definitions and %d indexers", len(result.Definitions), len(result.Indexers))
+
+	return result, nil
+}
+
+// removeCustomDefinition falls back to the built-in definition of a custom definition that was removed, s.m must be held
+func (s *service) removeCustomDefinition(identifier string) {
+	// another file can define the same indexer
+	for _, f := range s.customFiles {
+		if f.identifier == identifier {
+			return
+		}
+	}
+
+	if builtin, ok := s.builtinDefinitions[identifier]; ok {
+		s.definitions[identifier] = builtin
+		return
+	}
+
+	delete(s.definitions, identifier)
+}
+
+// reloadIndexer maps the indexer with its new definition and publishes it
+func (s *service) reloadIndexer(indexer domain.Indexer) error {
+	s.m.Lock()
+	// the irc server can change with the definition
+	if current := s.getMappedDefinitionByName(indexer.Identifier); current != nil && current.IRC != nil {
+		delete(s.lookupIRCServerDefinition[current.IRC.Server], indexer.Identifier)
+	}
+	s.m.Unlock()
+
+	if err := s.addIndexer(indexer); err != nil {
+		return err
+	}
+
+	definition, err := s.GetMappedDefinitionByName(indexer.Identifier)
+	if err != nil {
+		return err
+	}
+
+	// subscribers look up the indexers of the network again, publish without holding the lock
+	s.bus.Publish(domain.EventIndexerDefinitionReload, definition)
+
+	return nil
+}
+
+// watchCustomDefinitions reloads the custom definitions when files in the directory change
+func (s *service) watchCustomDefinitions() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "could not create watcher")
+	}
+
+	if err := watcher.Add(s.config.CustomDefinitions); err != nil {
+		watcher.Close()
+		return errors.Wrap(err, "could not watch directory: %s", s.config.CustomDefinitions)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		timer := time.NewTimer(reloadDebounce)
+		timer.Stop()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if !isValidExtension(filepath.Ext(event.Name)) || event.Op == fsnotify.Chmod {
+					continue
+				}
+
+				s.log.Trace().Msgf("custom definitions changed: %s", event)
+
+				timer.Reset(reloadDebounce)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				s.log.Error().Err(err).Msg("custom definitions watcher error")
+
+			case <-timer.C:
+				if _, err := s.ReloadCustomDefinitions(context.Background()); err != nil {
+					s.log.Error().Err(err).Msg("could not reload custom definitions")
+				}
+			}
+		}
+	}()
+
+	s.log.Debug().Msgf("watching custom definitions directory: %s", s.config.CustomDefinitions)
+
+	return nil
+}
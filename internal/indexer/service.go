@@ -6,13 +6,16 @@ package indexer
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/scheduler"
@@ -42,6 +45,8 @@ type Service interface {
 	Start() error
 	TestApi(ctx context.Context, req domain.IndexerTestApiRequest) error
 	ToggleEnabled(ctx context.Context, indexerID int, enabled bool) error
+	ValidateDefinitions(ctx context.Context, data []byte) ([]domain.IndexerDefinitionReport, error)
+	ReloadCustomDefinitions(ctx context.Context) (*domain.IndexerDefinitionReload, error)
 }
 
 type service struct {
@@ -52,9 +57,19 @@ type service struct {
 	ApiService  APIService
 	scheduler   scheduler.Service
 	bus         EventBus.Bus
+	auditSvc    audit.Service
 
+	// m guards the definitions and the indexer lookup tables below,
+	// they are read by handlers and feeds while indexers are changed or definitions reloaded
+	m sync.RWMutex
 	// contains all raw indexer definitions
 	definitions map[string]domain.IndexerDefinition
+	// built-in definitions to fall back to when a custom definition is removed
+	builtinDefinitions map[string]domain.IndexerDefinition
+	// custom definition files by path
+	customFiles map[string]customDefinitionFile
+	// serializes reloads of the custom definitions
+	reloadMu sync.Mutex
 	// definition with indexer data
 	mappedDefinitions map[string]*domain.IndexerDefinition
 	// map server:channel:announce to indexer.Identifier
@@ -67,7 +82,7 @@ type service struct {
 	rssIndexers map[string]*domain.IndexerDefinition
 }
 
-func NewService(log logger.Logger, config *domain.Config, bus EventBus.Bus, repo domain.IndexerRepo, releaseRepo domain.ReleaseRepo, apiService APIService, scheduler scheduler.Service) Service {
+func NewService(log logger.Logger, config *domain.Config, bus EventBus.Bus, repo domain.IndexerRepo, releaseRepo domain.ReleaseRepo, apiService APIService, scheduler scheduler.Service, auditSvc audit.Service) Service {
 	return &service{
 		log:                       log.With().Str("module", "indexer").Logger(),
 		config:                    config,
@@ -76,11 +91,14 @@ func NewService(log logger.Logger, config *domain.Config, bus EventBus.Bus, repo
 		ApiService:                apiService,
 		scheduler:                 scheduler,
 		bus:                       bus,
+		auditSvc:                  auditSvc,
 		lookupIRCServerDefinition: make(map[string]map[string]*domain.IndexerDefinition),
 		torznabIndexers:           make(map[string]*domain.IndexerDefinition),
 		newznabIndexers:           make(map[string]*domain.IndexerDefinition),
 		rssIndexers:               make(map[string]*domain.IndexerDefinition),
 		definitions:               make(map[string]domain.IndexerDefinition),
+		builtinDefinitions:        make(map[string]domain.IndexerDefinition),
+		customFiles:               make(map[string]customDefinitionFile),
 		mappedDefinitions:         make(map[string]*domain.IndexerDefinition),
 	}
 }
@@ -118,6 +136,8 @@ func (s *service) Store(ctx context.Context, indexer domain.Indexer) (*domain.In
 		return nil, err
 	}
 
+	indexerAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, i)
+
 	return i, nil
 }
 
@@ -181,6 +201,8 @@ func (s *service) Update(ctx context.Context, indexer domain.Indexer) (*domain.I
 
 	s.log.Debug().Msgf("successfully updated indexer: %s", indexer.Name)
 
+	indexerAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, currentIndexer, i)
+
 	return i, nil
 }
 
@@ -204,6 +226,8 @@ func (s *service) Delete(ctx context.Context, id int) error {
 
 	s.bus.Publish(domain.EventIndexerDelete, indexer)
 
+	indexerAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, indexer, nil)
+
 	return nil
 }
 
@@ -250,6 +274,7 @@ func (s *service) GetBy(ctx context.Context, req domain.GetIndexerRequest) (*dom
 func (s *service) GetAll() ([]*domain.IndexerDefinition, error) {
 	var res = make([]*domain.IndexerDefinition, 0)
 
+	s.m.RLock()
 	for _, indexer := range s.mappedDefinitions {
 		if indexer == nil {
 			continue
@@ -257,6 +282,7 @@ func (s *service) GetAll() ([]*domain.IndexerDefinition, error) {
 
 		res = append(res, indexer)
 	}
+	s.m.RUnlock()
 
 	// sort by name
 	sort.SliceStable(res, func(i, j int) bool {
@@ -266,6 +292,7 @@ func (s *service) GetAll() ([]*domain.IndexerDefinition, error) {
 	return res, nil
 }
 
+// mapIndexers maps the indexers of the user with their definitions, s.m must be held
 func (s *service) mapIndexers() (map[string]*domain.IndexerDefinition, error) {
 	indexers, err := s.repo.List(context.Background())
 	if err != nil {
@@ -276,6 +303,7 @@ func (s *service) mapIndexers() (map[string]*domain.IndexerDefinition, error) {
 	for _, indexer := range indexers {
 		indexerDefinition, err := s.mapIndexer(indexer)
 		if err != nil {
+			s.log.Error().Err(err).Msgf("could not map indexer: %s", indexer.Identifier)
 			continue
 		}
 
@@ -289,6 +317,7 @@ func (s *service) mapIndexers() (map[string]*domain.IndexerDefinition, error) {
 	return s.mappedDefinitions, nil
 }
 
+// mapIndexer returns a copy of the definition of the indexer with its settings, s.m must be held
 func (s *service) mapIndexer(indexer domain.Indexer) (*domain.IndexerDefinition, error) {
 	definitionName := indexer.Identifier
 
@@ -327,7 +356,13 @@ func (s *service) mapIndexer(indexer domain.Indexer) (*domain.IndexerDefinition,
 		if v, ok := indexer.Settings[setting.Name]; ok {
 			setting.Value = v
 
-			d.SettingsMap[setting.Name] = v
+			// the settings map is used at runtime and gets the value of env: and file: references
+			resolved, err := domain.ResolveSecret(v)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not resolve setting %s of indexer: %s", setting.Name, indexer.Name)
+			}
+
+			d.SettingsMap[setting.Name] = resolved
 		}
 
 		d.Settings[i] = setting
@@ -336,6 +371,7 @@ func (s *service) mapIndexer(indexer domain.Indexer) (*domain.IndexerDefinition,
 	return d, nil
 }
 
+// updateMapIndexer updates the mapped definition of the indexer with its settings, s.m must be held
 func (s *service) updateMapIndexer(indexer domain.Indexer) (*domain.IndexerDefinition, error) {
 	d, ok := s.mappedDefinitions[indexer.Identifier]
 	if !ok {
@@ -367,7 +403,13 @@ func (s *service) updateMapIndexer(indexer domain.Indexer) (*domain.IndexerDefin
 		if v, ok := indexer.Settings[setting.Name]; ok {
 			setting.Value = v
 
-			d.SettingsMap[setting.Name] = v
+			// the settings map is used at runtime and gets the value of env: and file: references
+			resolved, err := domain.ResolveSecret(v)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not resolve setting %s of indexer: %s", setting.Name, indexer.Name)
+			}
+
+			d.SettingsMap[setting.Name] = resolved
 		}
 
 		d.Settings[i] = setting
@@ -377,10 +419,11 @@ func (s *service) updateMapIndexer(indexer domain.Indexer) (*domain.IndexerDefin
 }
 
 func (s *service) GetTemplates() ([]domain.IndexerDefinition, error) {
-	definitions := s.definitions
+	s.m.RLock()
+	defer s.m.RUnlock()
 
-	ret := make([]domain.IndexerDefinition, 0)
-	for _, definition := range definitions {
+	ret := make([]domain.IndexerDefinition, 0, len(s.definitions))
+	for _, definition := range s.definitions {
 		ret = append(ret, definition)
 	}
 
@@ -399,8 +442,15 @@ func (s *service) Start() error {
 		if err := s.LoadCustomIndexerDefinitions(); err != nil {
 			return errors.Wrap(err, "could not load custom indexer definitions")
 		}
+
+		if err := s.watchCustomDefinitions(); err != nil {
+			s.log.Error().Err(err).Msg("could not watch custom indexer definitions, reload them via the api instead")
+		}
 	}
 
+	s.m.Lock()
+	defer s.m.Unlock()
+
 	// load the indexers' setup by the user
 	indexerDefinitions, err := s.mapIndexers()
 	if err != nil {
@@ -438,6 +488,9 @@ func (s *service) Start() error {
 }
 
 func (s *service) removeIndexer(indexer domain.Indexer) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
 	// handle feeds
 	switch indexer.Implementation {
 	case string(domain.IndexerImplementationRSS):
@@ -455,6 +508,9 @@ func (s *service) removeIndexer(indexer domain.Indexer) {
 }
 
 func (s *service) addIndexer(indexer domain.Indexer) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
 	indexerDefinition, err := s.mapIndexer(indexer)
 	if err != nil {
 		return err
@@ -493,6 +549,9 @@ func (s *service) addIndexer(indexer domain.Indexer) error {
 }
 
 func (s *service) updateIndexer(indexer domain.Indexer) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
 	indexerDefinition, err := s.updateMapIndexer(indexer)
 	if err != nil {
 		return err
@@ -533,6 +592,7 @@ func (s *service) updateIndexer(indexer domain.Indexer) error {
 // mapIRCServerDefinitionLookup map irc stuff to indexer.name
 // map[irc.network.test][indexer1] = indexer1
 // map[irc.network.test][indexer2] = indexer2
+// s.m must be held
 func (s *service) mapIRCServerDefinitionLookup(ircServer string, indexerDefinition *domain.IndexerDefinition) {
 	if indexerDefinition.IRC != nil {
 		// check if already exists, if ok add it to existing, otherwise create new
@@ -557,6 +617,10 @@ func (s *service) LoadIndexerDefinitions() error {
 		return errors.Wrap(err, "could not read directory")
 	}
 
+	if s.builtinDefinitions == nil {
+		s.builtinDefinitions = make(map[string]domain.IndexerDefinition)
+	}
+
 	for _, f := range entries {
 		fileExtension := filepath.Ext(f.Name())
 		if fileExtension != ".yaml" {
@@ -586,10 +650,20 @@ func (s *service) LoadIndexerDefinitions() error {
 			d.Implementation = "irc"
 		}
 
+		if err := validateDefinition(&d); err != nil {
+			s.log.Error().Err(err).Msgf("invalid definition file: %s", file)
+			return errors.Wrap(err, "invalid definition file: %s", file)
+		}
+
+		s.m.Lock()
 		s.definitions[d.Identifier] = d
+		s.builtinDefinitions[d.Identifier] = d
+		s.m.Unlock()
 	}
 
+	s.m.RLock()
 	s.log.Debug().Msgf("Loaded %d indexer definitions", len(s.definitions))
+	s.m.RUnlock()
 
 	return nil
 }
@@ -601,18 +675,21 @@ func isValidExtension(ext string) bool {
 }
 
 func OpenAndProcessDefinition(file string) (*domain.IndexerDefinition, error) {
-	f, err := os.Open(file)
+	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not open file: %s", file)
 	}
-	defer f.Close()
 
+	return processDefinition(file, data)
+}
+
+func processDefinition(file string, data []byte) (*domain.IndexerDefinition, error) {
 	var d *domain.IndexerDefinitionCustom
 
-	dec := yaml.NewDecoder(f)
+	dec := yaml.NewDecoder(bytes.NewReader(data))
 	dec.KnownFields(false)
 
-	if err = dec.Decode(&d); err != nil {
+	if err := dec.Decode(&d); err != nil {
 		return nil, errors.Wrap(err, "could not decode definition file: %s", file)
 	}
 
@@ -631,7 +708,47 @@ func OpenAndProcessDefinition(file string) (*domain.IndexerDefinition, error) {
 	//	}
 	//}
 
-	return d.ToIndexerDefinition(), nil
+	definition := d.ToIndexerDefinition()
+
+	if err := validateDefinition(definition); err != nil {
+		return nil, errors.Wrap(err, "invalid definition file: %s", file)
+	}
+
+	return definition, nil
+}
+
+// validateDefinition checks the implementation specific parts of a definition
+func validateDefinition(d *domain.IndexerDefinition) error {
+	if d.Implementation == string(domain.IndexerImplementationAPI) {
+		if d.API == nil {
+			return errors.New("api implementation requires api section")
+		}
+
+		if err := d.API.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateDefinitions validates data as a custom definition, or the built-in and custom definitions if data is empty
+func (s *service) ValidateDefinitions(ctx context.Context, data []byte) ([]domain.IndexerDefinitionReport, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ValidateDefinitions(s.config.CustomDefinitions)
+	}
+
+	report := ValidateDefinition(domain.IndexerDefinitionSourceCustom, "", data)
+
+	s.m.RLock()
+	_, loaded := s.definitions[report.Identifier]
+	s.m.RUnlock()
+
+	if loaded {
+		report.AddWarning("replaces the loaded definition %s", report.Identifier)
+	}
+
+	return []domain.IndexerDefinitionReport{report}, nil
 }
 
 // LoadCustomIndexerDefinitions load definitions from custom path
@@ -667,13 +784,23 @@ func (s *service) LoadCustomIndexerDefinitions() error {
 
 		s.log.Trace().Msgf("parsing custom definition: %s", file)
 
-		definition, err := OpenAndProcessDefinition(file)
+		data, err := os.ReadFile(file)
 		if err != nil {
 			s.log.Error().Err(err).Msgf("could not open definition file: %s", file)
 			continue
 		}
 
+		definition, err := processDefinition(file, data)
+		if err != nil {
+			s.log.Error().Err(err).Msgf("could not open definition file: %s", file)
+			continue
+		}
+
+		s.m.Lock()
 		s.definitions[definition.Identifier] = *definition
+		s.m.Unlock()
+
+		s.customFiles[file] = customDefinitionFile{identifier: definition.Identifier, checksum: sha256.Sum256(data)}
 
 		customCount++
 	}
@@ -688,6 +815,9 @@ func (s *service) GetIndexersByIRCNetwork(server string) []*domain.IndexerDefini
 
 	var indexerDefinitions []*domain.IndexerDefinition
 
+	s.m.RLock()
+	defer s.m.RUnlock()
+
 	// get indexer definitions matching irc network from lookup table
 	if srv, idOk := s.lookupIRCServerDefinition[server]; idOk {
 		for _, definition := range srv {
@@ -701,6 +831,9 @@ func (s *service) GetIndexersByIRCNetwork(server string) []*domain.IndexerDefini
 func (s *service) GetTorznabIndexers() []domain.IndexerDefinition {
 	indexerDefinitions := make([]domain.IndexerDefinition, 0)
 
+	s.m.RLock()
+	defer s.m.RUnlock()
+
 	for _, definition := range s.torznabIndexers {
 		if definition != nil {
 			indexerDefinitions = append(indexerDefinitions, *definition)
@@ -713,6 +846,9 @@ func (s *service) GetTorznabIndexers() []domain.IndexerDefinition {
 func (s *service) GetRSSIndexers() []domain.IndexerDefinition {
 	indexerDefinitions := make([]domain.IndexerDefinition, 0)
 
+	s.m.RLock()
+	defer s.m.RUnlock()
+
 	for _, definition := range s.rssIndexers {
 		if definition != nil {
 			indexerDefinitions = append(indexerDefinitions, *definition)
@@ -722,6 +858,7 @@ func (s *service) GetRSSIndexers() []domain.IndexerDefinition {
 	return indexerDefinitions
 }
 
+// getDefinitionByName returns a copy of the raw definition, s.m must be held
 func (s *service) getDefinitionByName(name string) *domain.IndexerDefinition {
 	if v, ok := s.definitions[name]; ok {
 		return &v
@@ -731,6 +868,9 @@ func (s *service) getDefinitionByName(name string) *domain.IndexerDefinition {
 }
 
 func (s *service) GetMappedDefinitionByName(name string) (*domain.IndexerDefinition, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
 	v, ok := s.mappedDefinitions[name]
 	if !ok {
 		return nil, errors.New("unknown indexer identifier: %s", name)
@@ -739,6 +879,7 @@ func (s *service) GetMappedDefinitionByName(name string) (*domain.IndexerDefinit
 	return v, nil
 }
 
+// getMappedDefinitionByName returns the mapped definition of the indexer, s.m must be held
 func (s *service) getMappedDefinitionByName(name string) *domain.IndexerDefinition {
 	if v, ok := s.mappedDefinitions[name]; ok {
 		return v
@@ -748,10 +889,13 @@ func (s *service) getMappedDefinitionByName(name string) *domain.IndexerDefiniti
 }
 
 func (s *service) stopFeed(indexer string) {
-	// verify indexer is torznab indexer
+	s.m.RLock()
 	_, ok := s.torznabIndexers[indexer]
+	_, rssOK := s.rssIndexers[indexer]
+	s.m.RUnlock()
+
+	// verify indexer is torznab indexer
 	if !ok {
-		_, rssOK := s.rssIndexers[indexer]
 		if !rssOK {
 			return
 		}
@@ -778,9 +922,13 @@ func (s *service) TestApi(ctx context.Context, req domain.IndexerTestApiRequest)
 		req.ApiKey = apikey
 	}
 
-	def := s.getMappedDefinitionByName(indexer.Identifier)
-	if def == nil {
-		return errors.New("could not find definition: %s", indexer.Identifier)
+	if req.ApiKey, err = domain.ResolveSecret(req.ApiKey); err != nil {
+		return err
+	}
+
+	def, err := s.GetMappedDefinitionByName(indexer.Identifier)
+	if err != nil {
+		return errors.Wrap(err, "could not find definition: %s", indexer.Identifier)
 	}
 
 	if !def.HasApi() {
@@ -812,6 +960,7 @@ func (s *service) ToggleEnabled(ctx context.Context, indexerID int, enabled bool
 		return err
 	}
 
+	before := *indexer
 	indexer.Enabled = enabled
 
 	// update indexerInstances
@@ -828,6 +977,8 @@ func (s *service) ToggleEnabled(ctx context.Context, indexerID int, enabled bool
 
 	s.log.Debug().Msgf("indexer.toggle_enabled: update indexer '%d' to '%v'", indexerID, enabled)
 
+	indexerAudit.Record(ctx, s.auditSvc, domain.AuditActionToggle, &before, indexer)
+
 	return nil
 }
 
@@ -0,0 +1,481 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package indexer
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"text/template"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/Masterminds/sprig/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateDefinitions validates the built-in definitions and the custom definitions in customPath,
+// which can be a directory or a single file. An empty customPath only validates the built-in definitions.
+func ValidateDefinitions(customPath string) ([]domain.IndexerDefinitionReport, error) {
+	reports, err := validateBuiltinDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	if customPath == "" {
+		return reports, nil
+	}
+
+	custom, err := ValidateDefinitionPath(customPath)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin := make(map[string]bool, len(reports))
+	for _, r := range reports {
+		builtin[r.Identifier] = true
+	}
+
+	for i := range custom {
+		if custom[i].Identifier != "" && builtin[custom[i].Identifier] {
+			custom[i].AddWarning("overrides the built-in definition %s", custom[i].Identifier)
+		}
+	}
+
+	return append(reports, custom...), nil
+}
+
+func validateBuiltinDefinitions() ([]domain.IndexerDefinitionReport, error) {
+	entries, err := fs.ReadDir(Definitions, "definitions")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read built-in definitions")
+	}
+
+	reports := make([]domain.IndexerDefinitionReport, 0, len(entries))
+	for _, f := range entries {
+		if filepath.Ext(f.Name()) != ".yaml" {
+			continue
+		}
+
+		file := "definitions/" + f.Name()
+
+		data, err := fs.ReadFile(Definitions, file)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read file: %s", file)
+		}
+
+		reports = append(reports, ValidateDefinition(domain.IndexerDefinitionSourceBuiltin, file, data))
+	}
+
+	return reports, nil
+}
+
+// ValidateDefinitionPath validates a custom definition file or every definition file in a directory
+func ValidateDefinitionPath(path string) ([]domain.IndexerDefinitionReport, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open definitions path: %s", path)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read directory: %s", path)
+		}
+
+		files = files[:0]
+		for _, f := range entries {
+			if f.IsDir() || !isValidExtension(filepath.Ext(f.Name())) {
+				continue
+			}
+			files = append(files, filepath.Join(path, f.Name()))
+		}
+	}
+
+	reports := make([]domain.IndexerDefinitionReport, 0, len(files))
+	seen := make(map[string]string)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read file: %s", file)
+		}
+
+		report := ValidateDefinition(domain.IndexerDefinitionSourceCustom, file, data)
+
+		if other, ok := seen[report.Identifier]; ok && report.Identifier != "" {
+			report.AddError("identifier %s is also used by %s", report.Identifier, other)
+		}
+		seen[report.Identifier] = file
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// ValidateDefinition checks the schema, settings and url templates of a definition and runs its embedded line tests
+func ValidateDefinition(source string, file string, data []byte) domain.IndexerDefinitionReport {
+	report := domain.IndexerDefinitionReport{
+		File:     file,
+		Source:   source,
+		Valid:    true,
+		Errors:   []string{},
+		Warnings: []string{},
+	}
+
+	var custom domain.IndexerDefinitionCustom
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&custom); err != nil {
+		if errors.Is(err, io.EOF) {
+			report.AddError("empty definition file")
+		} else {
+			report.AddError("invalid definition: %v", err)
+		}
+		return report
+	}
+
+	d := custom.ToIndexerDefinition()
+	if d.Implementation == "" {
+		d.Implementation = string(domain.IndexerImplementationIRC)
+	}
+
+	report.Identifier = d.Identifier
+	report.Name = d.Name
+
+	if d.Name == "" {
+		report.AddError("name is required")
+	}
+	if d.Identifier == "" {
+		report.AddError("identifier is required")
+	}
+	if d.Protocol == "" {
+		report.AddError("protocol is required")
+	}
+
+	if len(d.URLS) == 0 {
+		report.AddError("urls is required")
+	}
+	for i, u := range d.URLS {
+		if !isAbsoluteURL(u) {
+			report.AddError("urls[%d]: %q is not an absolute url", i, u)
+		}
+	}
+
+	validateSettings(&report, "settings", d.Settings)
+
+	switch domain.IndexerImplementation(d.Implementation) {
+	case domain.IndexerImplementationIRC:
+		validateIRC(&report, d)
+	case domain.IndexerImplementationTorznab:
+		if d.Torznab == nil {
+			report.AddError("torznab implementation requires torznab section")
+		} else {
+			validateSettings(&report, "torznab.settings", d.Torznab.Settings)
+		}
+	case domain.IndexerImplementationNewznab:
+		if d.Newznab == nil {
+			report.AddError("newznab implementation requires newznab section")
+		} else {
+			validateSettings(&report, "newznab.settings", d.Newznab.Settings)
+		}
+	case domain.IndexerImplementationRSS:
+		if d.RSS == nil {
+			report.AddError("rss implementation requires rss section")
+		} else {
+			validateSettings(&report, "rss.settings", d.RSS.Settings)
+		}
+	case domain.IndexerImplementationAPI:
+		if err := validateDefinition(d); err != nil {
+			report.AddError("%v", err)
+		}
+	default:
+		report.AddError("unknown implementation: %s", d.Implementation)
+	}
+
+	return report
+}
+
+func validateSettings(report *domain.IndexerDefinitionReport, section string, settings []domain.IndexerSetting) {
+	names := make(map[string]bool, len(settings))
+
+	for i, s := range settings {
+		if s.Name == "" {
+			report.AddError("%s[%d]: name is required", section, i)
+			continue
+		}
+
+		if names[s.Name] {
+			report.AddError("%s[%d]: duplicate setting %s", section, i, s.Name)
+		}
+		names[s.Name] = true
+
+		switch s.Type {
+		case "text", "secret":
+		default:
+			report.AddError("%s[%d] %s: unsupported type %q", section, i, s.Name, s.Type)
+		}
+
+		if s.Required && s.Label == "" {
+			report.AddError("%s[%d] %s: required setting needs a label", section, i, s.Name)
+		}
+
+		if s.Regex != "" {
+			if _, err := regexp.Compile(s.Regex); err != nil {
+				report.AddError("%s[%d] %s: invalid regex: %v", section, i, s.Name, err)
+			}
+		}
+	}
+}
+
+func validateIRC(report *domain.IndexerDefinitionReport, d *domain.IndexerDefinition) {
+	if d.IRC == nil {
+		report.AddError("irc implementation requires irc section")
+		return
+	}
+
+	if d.IRC.Network == "" {
+		report.AddError("irc.network is required")
+	}
+	if d.IRC.Server == "" {
+		report.AddError("irc.server is required")
+	}
+	if d.IRC.Port <= 0 {
+		report.AddError("irc.port is required")
+	}
+	if len(d.IRC.Channels) == 0 {
+		report.AddError("irc.channels is required")
+	}
+	if len(d.IRC.Announcers) == 0 {
+		report.AddError("irc.announcers is required")
+	}
+
+	validateSettings(report, "irc.settings", d.IRC.Settings)
+
+	parse := d.IRC.Parse
+	if parse == nil {
+		report.AddError("irc.parse is required")
+		return
+	}
+
+	switch parse.Type {
+	case "single", "multi":
+	default:
+		report.AddError("irc.parse.type: must be single or multi, got %q", parse.Type)
+	}
+
+	if len(parse.Lines) == 0 {
+		report.AddError("irc.parse.lines is required")
+		return
+	}
+
+	if parse.Match.TorrentURL == "" && parse.Match.MagnetURI == "" {
+		report.AddError("irc.parse.match: torrenturl or magneturi is required")
+	}
+
+	tested := true
+
+	for i, line := range parse.Lines {
+		if !validatePattern(report, i, line) {
+			report.Tests += len(line.Tests)
+			tested = false
+			continue
+		}
+
+		if len(line.Tests) == 0 {
+			report.AddWarning("irc.parse.lines[%d]: no tests", i)
+			tested = false
+		}
+
+		for j, test := range line.Tests {
+			report.Tests++
+
+			if runLineTest(report, i, j, line, test) {
+				report.TestsPassed++
+			}
+		}
+	}
+
+	if !tested {
+		report.AddWarning("irc.parse.match: templates are not checked unless every line has tests")
+		return
+	}
+
+	validateMatchTemplates(report, d)
+}
+
+// validatePattern compiles the pattern the same way ParseLine does, which panics on invalid patterns without vars
+func validatePattern(report *domain.IndexerDefinitionReport, i int, line domain.IndexerIRCParseLine) bool {
+	pattern := line.Pattern
+	if len(line.Vars) == 0 {
+		pattern = `(?mi)` + pattern
+	}
+
+	if line.Pattern == "" {
+		report.AddError("irc.parse.lines[%d]: pattern is required", i)
+		return false
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		report.AddError("irc.parse.lines[%d]: invalid pattern: %v", i, err)
+		return false
+	}
+
+	if len(line.Vars) > re.NumSubexp() {
+		report.AddError("irc.parse.lines[%d]: %d vars but the pattern only has %d capture groups", i, len(line.Vars), re.NumSubexp())
+		return false
+	}
+
+	return true
+}
+
+func runLineTest(report *domain.IndexerDefinitionReport, i int, j int, line domain.IndexerIRCParseLine, test domain.LineTest) bool {
+	vars := map[string]string{}
+
+	match, err := ParseLine(nil, line.Pattern, line.Vars, vars, test.Line, line.Ignore)
+	if err != nil {
+		report.AddError("irc.parse.lines[%d].tests[%d]: %v", i, j, err)
+		return false
+	}
+
+	if !match {
+		report.AddError("irc.parse.lines[%d].tests[%d]: line does not match pattern: %s", i, j, test.Line)
+		return false
+	}
+
+	keys := slices.Sorted(maps.Keys(mergeMaps(test.Expect, vars)))
+
+	passed := true
+	for _, key := range keys {
+		expected, expectedOk := test.Expect[key]
+		got, gotOk := vars[key]
+
+		switch {
+		case !expectedOk:
+			report.AddError("irc.parse.lines[%d].tests[%d]: %s: unexpected value %q", i, j, key, got)
+		case !gotOk:
+			report.AddError("irc.parse.lines[%d].tests[%d]: %s: expected %q, got nothing", i, j, key, expected)
+		case expected != got:
+			report.AddError("irc.parse.lines[%d].tests[%d]: %s: expected %q, got %q", i, j, key, expected, got)
+		default:
+			continue
+		}
+
+		passed = false
+	}
+
+	return passed
+}
+
+// validateMatchTemplates renders the match templates with the vars of the line tests and a placeholder for each setting.
+// Multi line announces combine the tests of every line by index, reusing the last test of lines that have fewer.
+func validateMatchTemplates(report *domain.IndexerDefinitionReport, d *domain.IndexerDefinition) {
+	parse := d.IRC.Parse
+
+	baseURL := d.BaseURL
+	if baseURL == "" && len(d.URLS) > 0 {
+		baseURL = d.URLS[0]
+	}
+
+	settings := make(map[string]string, len(d.Settings))
+	for _, s := range d.Settings {
+		settings[s.Name] = s.Name
+	}
+
+	combinations := 0
+	for _, line := range parse.Lines {
+		combinations = max(combinations, len(line.Tests))
+	}
+
+	templates := map[string]string{
+		"torrenturl":  parse.Match.TorrentURL,
+		"torrentname": parse.Match.TorrentName,
+		"magneturi":   parse.Match.MagnetURI,
+		"infourl":     parse.Match.InfoURL,
+	}
+
+	for n := 0; n < combinations; n++ {
+		vars := map[string]string{}
+		for _, line := range parse.Lines {
+			maps.Copy(vars, line.Tests[min(n, len(line.Tests)-1)].Expect)
+		}
+
+		if err := parse.MapCustomVariables(vars); err != nil {
+			report.AddError("irc.parse.mappings: %v", err)
+			return
+		}
+
+		vars = mergeMaps(vars, settings)
+
+		for _, name := range slices.Sorted(maps.Keys(templates)) {
+			if templates[name] == "" {
+				continue
+			}
+
+			if err := executeTemplate(name, templates[name], vars); err != nil {
+				report.AddError("irc.parse.match.%s: %v", name, err)
+				return
+			}
+		}
+
+		rls := &domain.Release{}
+		if err := parse.Match.ParseURLs(baseURL, maps.Clone(vars), rls); err != nil {
+			report.AddError("irc.parse.match: %v", err)
+			return
+		}
+
+		if rls.DownloadURL != "" && !isAbsoluteURL(rls.DownloadURL) {
+			report.AddError("irc.parse.match.torrenturl: %q is not an absolute url", rls.DownloadURL)
+			return
+		}
+
+		if rls.InfoURL != "" && !isAbsoluteURL(rls.InfoURL) {
+			report.AddError("irc.parse.match.infourl: %q is not an absolute url", rls.InfoURL)
+			return
+		}
+	}
+}
+
+// executeTemplate fails on vars that are neither captured by the patterns nor defined as settings
+func executeTemplate(name string, text string, vars map[string]string) error {
+	tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(io.Discard, vars)
+}
+
+func mergeMaps(a map[string]string, b map[string]string) map[string]string {
+	merged := maps.Clone(a)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+
+	maps.Copy(merged, b)
+
+	return merged
+}
+
+// isAbsoluteURL does not require a host since some definitions get the whole url from the announce
+func isAbsoluteURL(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "http" || u.Scheme == "https"
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package irc
+
+import (
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// networkAudit records networks without their connection state and proxy, the proxy is referenced by id
+var networkAudit = audit.Resource[domain.IrcNetwork]{
+	Type: domain.AuditResourceIrcNetwork,
+	Key: func(n *domain.IrcNetwork) (string, string) {
+		return strconv.FormatInt(n.ID, 10), n.Name
+	},
+	View: func(n *domain.IrcNetwork) any {
+		view := *n
+		view.Proxy = nil
+		view.Connected = false
+		view.ConnectedSince = nil
+
+		return &view
+	},
+}
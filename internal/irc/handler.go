@@ -86,6 +86,7 @@ type Handler struct {
 	notificationService notification.Sender
 	announceProcessors  map[string]announce.Processor
 	definitions         map[string]*domain.IndexerDefinition
+	recorder            *announce.Recorder
 
 	client      *ircevent.Connection
 	clientState ircState
@@ -107,7 +108,7 @@ type Handler struct {
 	saslauthed    bool
 }
 
-func NewHandler(log zerolog.Logger, sse *sse.Server, network domain.IrcNetwork, definitions []*domain.IndexerDefinition, releaseSvc release.Service, notificationSvc notification.Sender) *Handler {
+func NewHandler(log zerolog.Logger, sse *sse.Server, network domain.IrcNetwork, definitions []*domain.IndexerDefinition, releaseSvc release.Service, notificationSvc notification.Sender, recorder *announce.Recorder) *Handler {
 	h := &Handler{
 		log:                 log.With().Str("network", network.Server).Logger(),
 		sse:                 sse,
@@ -117,6 +118,7 @@ func NewHandler(log zerolog.Logger, sse *sse.Server, network domain.IrcNetwork,
 		notificationService: notificationSvc,
 		definitions:         map[string]*domain.IndexerDefinition{},
 		announceProcessors:  map[string]announce.Processor{},
+		recorder:            recorder,
 		validAnnouncers:     map[string]struct{}{},
 		validChannels:       map[string]struct{}{},
 		channelHealth:       map[string]*channelHealth{},
@@ -132,50 +134,115 @@ func NewHandler(log zerolog.Logger, sse *sse.Server, network domain.IrcNetwork,
 }
 
 func (h *Handler) InitIndexers(definitions []*domain.IndexerDefinition) {
-	// Networks can be shared by multiple indexers but channels are unique
-	// so let's add a new AnnounceProcessor per channel
+	h.m.Lock()
+	defer h.m.Unlock()
+
 	for _, definition := range definitions {
 		if _, ok := h.definitions[definition.Identifier]; ok {
 			continue
 		}
 
-		h.definitions[definition.Identifier] = definition
+		h.addIndexer(definition)
+	}
+}
+
+// ReloadIndexer replaces the definition and announce processors of an indexer without reconnecting.
+// Lines that are already queued are still parsed with the previous definition.
+func (h *Handler) ReloadIndexer(definition *domain.IndexerDefinition) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.removeIndexer(definition.Identifier)
+	h.addIndexer(definition)
+}
+
+// RemoveIndexer stops parsing the announces of an indexer, the channels are not left
+func (h *Handler) RemoveIndexer(identifier string) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.removeIndexer(identifier)
+}
+
+func (h *Handler) HasIndexer(identifier string) bool {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	_, ok := h.definitions[identifier]
+	return ok
+}
+
+// addIndexer sets up the announce processors of the indexer, callers must hold the lock
+func (h *Handler) addIndexer(definition *domain.IndexerDefinition) {
+	// Networks can be shared by multiple indexers but channels are unique
+	// so let's add a new AnnounceProcessor per channel
+	h.definitions[definition.Identifier] = definition
 
-		// indexers can use multiple channels, but it's not common, but let's handle that anyway.
-		for _, channel := range definition.IRC.Channels {
-			// some channels are defined in mixed case
-			channel = strings.ToLower(channel)
+	// indexers can use multiple channels, but it's not common, but let's handle that anyway.
+	for _, channel := range definition.IRC.Channels {
+		// some channels are defined in mixed case
+		channel = strings.ToLower(channel)
 
-			h.announceProcessors[channel] = announce.NewAnnounceProcessor(h.log, h.releaseSvc, definition)
+		h.announceProcessors[channel] = announce.NewAnnounceProcessor(h.log, h.releaseSvc, definition, h.recorder)
 
+		// keep the health of channels when the indexer is reloaded
+		if _, ok := h.channelHealth[channel]; !ok {
 			h.channelHealth[channel] = &channelHealth{
 				name:       channel,
 				monitoring: false,
 			}
+		}
+
+		// create map of valid channels
+		h.validChannels[channel] = struct{}{}
+	}
 
-			// create map of valid channels
-			h.validChannels[channel] = struct{}{}
+	// create map of valid announcers
+	for _, announcer := range definition.IRC.Announcers {
+		h.validAnnouncers[strings.ToLower(announcer)] = struct{}{}
+	}
+}
+
+// removeIndexer stops the announce processors of the indexer, callers must hold the lock
+func (h *Handler) removeIndexer(identifier string) {
+	definition, ok := h.definitions[identifier]
+	if !ok {
+		return
+	}
+
+	delete(h.definitions, identifier)
+
+	for _, channel := range definition.IRC.Channels {
+		channel = strings.ToLower(channel)
+
+		if processor, ok := h.announceProcessors[channel]; ok {
+			processor.Stop()
+			delete(h.announceProcessors, channel)
 		}
 
-		// create map of valid announcers
-		for _, announcer := range definition.IRC.Announcers {
+		delete(h.validChannels, channel)
+	}
+
+	// announcers can be shared by the indexers of a network
+	h.validAnnouncers = make(map[string]struct{})
+	for _, d := range h.definitions {
+		for _, announcer := range d.IRC.Announcers {
 			h.validAnnouncers[strings.ToLower(announcer)] = struct{}{}
 		}
 	}
 }
 
-func (h *Handler) removeIndexer() {
-	// TODO remove validAnnouncers
-	// TODO remove validChannels
-	// TODO remove definition
-	// TODO remove announceProcessor
-}
-
 func (h *Handler) Run() (err error) {
 	// TODO validate
 	// check if network requires nickserv
 	// check if network or channels requires invite command
 
+	// resolve env: and file: references, the stored network keeps them to compare with updates
+	network, err := h.network.ResolveSecrets()
+	if err != nil {
+		return err
+	}
+
 	addr := fmt.Sprintf("%s:%d", h.network.Server, h.network.Port)
 
 	if h.network.UseBouncer && h.network.BouncerAddr != "" {
@@ -212,7 +279,7 @@ func (h *Handler) Run() (err error) {
 		Nick:          h.network.Nick,
 		User:          h.network.Auth.Account,
 		RealName:      h.network.Auth.Account,
-		Password:      h.network.Pass,
+		Password:      network.Pass,
 		Server:        addr,
 		KeepAlive:     4 * time.Minute,
 		Timeout:       2 * time.Minute,
@@ -237,8 +304,8 @@ func (h *Handler) Run() (err error) {
 			}
 
 			// set user and pass if not empty
-			if h.network.Proxy.User != "" && h.network.Proxy.Pass != "" {
-				proxyUrl.User = url.UserPassword(h.network.Proxy.User, h.network.Proxy.Pass)
+			if network.Proxy.User != "" && network.Proxy.Pass != "" {
+				proxyUrl.User = url.UserPassword(network.Proxy.User, network.Proxy.Pass)
 			}
 
 			var proxyDialer proxy.Dialer
@@ -265,10 +332,14 @@ func (h *Handler) Run() (err error) {
 		}
 	}
 
+	if h.playbackEnabled() {
+		client.RequestCaps = append(client.RequestCaps, playbackCaps...)
+	}
+
 	if h.network.Auth.Mechanism == domain.IRCAuthMechanismSASLPlain {
 		if h.network.Auth.Account != "" && h.network.Auth.Password != "" {
 			client.SASLLogin = h.network.Auth.Account
-			client.SASLPassword = h.network.Auth.Password
+			client.SASLPassword = network.Auth.Password
 			client.SASLOptional = true
 			client.UseSASL = true
 		}
@@ -608,7 +679,13 @@ func (h *Handler) handleNickServ(msg ircmsg.Message) {
 	if contains(msg.Params[1], "invalid parameters", "help identify") {
 		h.log.Debug().Msgf("NOTICE nickserv invalid: %v", msg.Params)
 
-		h.Send("PRIVMSG", "NickServ", fmt.Sprintf("IDENTIFY %s %s", h.network.Auth.Account, h.network.Auth.Password))
+		password, err := domain.ResolveSecret(h.network.Auth.Password)
+		if err != nil {
+			h.log.Error().Err(err).Msg("could not resolve nickserv password")
+			return
+		}
+
+		h.Send("PRIVMSG", "NickServ", fmt.Sprintf("IDENTIFY %s %s", h.network.Auth.Account, password))
 	}
 }
 
@@ -742,8 +819,10 @@ func (h *Handler) onMessage(msg ircmsg.Message) {
 	// clean message
 	cleanedMsg := h.cleanMessage(message)
 
+	timestamp, playback := h.messageTime(msg)
+
 	// publish to SSE stream
-	h.publishSSEMsg(domain.IrcMessage{Channel: channel, Nick: nick, Message: cleanedMsg, Time: time.Now()})
+	h.publishSSEMsg(domain.IrcMessage{Channel: channel, Nick: nick, Message: cleanedMsg, Time: timestamp})
 
 	// check if message is from a valid channel, if not return
 	if validChannel := h.isValidChannel(channel); !validChannel {
@@ -755,9 +834,25 @@ func (h *Handler) onMessage(msg ircmsg.Message) {
 		return
 	}
 
-	h.log.Debug().Str("channel", channel).Str("nick", nick).Msg(cleanedMsg)
+	if playback {
+		if time.Since(timestamp) > h.recorder.PlaybackMaxAge() {
+			h.log.Debug().Str("channel", channel).Msgf("skip played back announce from %s: %s", timestamp.Format(time.RFC3339), cleanedMsg)
+			return
+		}
+
+		h.log.Debug().Str("channel", channel).Str("nick", nick).Msgf("played back from %s: %s", timestamp.Format(time.RFC3339), cleanedMsg)
+	} else {
+		h.log.Debug().Str("channel", channel).Str("nick", nick).Msg(cleanedMsg)
+	}
+
+	h.recorder.RecordLine(domain.AnnounceLine{Channel: channel, Nick: nick, Line: cleanedMsg, Timestamp: timestamp})
 
-	if err := h.sendToAnnounceProcessor(channel, cleanedMsg); err != nil {
+	// only played back lines keep their time, live lines are timestamped when the release is created
+	if !playback {
+		timestamp = time.Time{}
+	}
+
+	if err := h.sendToAnnounceProcessor(channel, cleanedMsg, timestamp); err != nil {
 		h.log.Error().Stack().Err(err).Msgf("could not queue line: %s", cleanedMsg)
 		return
 	}
@@ -766,13 +861,17 @@ func (h *Handler) onMessage(msg ircmsg.Message) {
 }
 
 func (h *Handler) SendToAnnounceProcessor(channel string, msg string) error {
-	return h.sendToAnnounceProcessor(channel, msg)
+	return h.sendToAnnounceProcessor(channel, msg, time.Time{})
 }
 
-// send the msg to announce processor
-func (h *Handler) sendToAnnounceProcessor(channel string, msg string) error {
+// send the msg to announce processor, timestamp is only set for messages played back by a bouncer
+func (h *Handler) sendToAnnounceProcessor(channel string, msg string, timestamp time.Time) error {
 	channel = strings.ToLower(channel)
 
+	// hold the lock while the line is queued so the processor is not stopped by a reload in between
+	h.m.RLock()
+	defer h.m.RUnlock()
+
 	// check if queue exists
 	queue, ok := h.announceProcessors[channel]
 	if !ok {
@@ -780,7 +879,14 @@ func (h *Handler) sendToAnnounceProcessor(channel string, msg string) error {
 	}
 
 	// if it exists, add msg
-	if err := queue.AddLineToQueue(channel, msg); err != nil {
+	var err error
+	if timestamp.IsZero() {
+		err = queue.AddLineToQueue(channel, msg)
+	} else {
+		err = queue.AddPlaybackLineToQueue(channel, msg, timestamp)
+	}
+
+	if err != nil {
 		h.log.Error().Stack().Err(err).Msgf("could not queue line: %s", msg)
 		return err
 	}
@@ -792,6 +898,50 @@ func (h *Handler) sendToAnnounceProcessor(channel string, msg string) error {
 	return nil
 }
 
+// ReplayAnnounces parses the lines recorded from the channel within the window, the releases are checked against the filters by the service
+func (h *Handler) ReplayAnnounces(req *domain.AnnounceReplayRequest) (*domain.AnnounceReplayResult, error) {
+	channel := strings.ToLower(req.Channel)
+
+	var definition *domain.IndexerDefinition
+	h.m.RLock()
+	for _, d := range h.definitions {
+		if slices.ContainsFunc(d.IRC.Channels, func(c string) bool { return strings.EqualFold(c, channel) }) {
+			definition = d
+			break
+		}
+	}
+	h.m.RUnlock()
+
+	if definition == nil {
+		return nil, errors.New("no indexer found for channel '%s'", channel)
+	}
+
+	processor := announce.NewDryRunProcessor(h.log, definition)
+
+	lines := 0
+	for _, line := range h.recorder.Lines(channel) {
+		if !req.Contains(line) {
+			continue
+		}
+
+		if err := processor.AddLineToQueue(channel, line.Line); err != nil {
+			processor.Close()
+			return nil, err
+		}
+		lines++
+	}
+
+	releases, failures := processor.Close()
+
+	return &domain.AnnounceReplayResult{
+		Indexer:  definition.Identifier,
+		Channel:  channel,
+		Lines:    lines,
+		Releases: releases,
+		Failures: failures,
+	}, nil
+}
+
 // JoinChannels sends multiple join commands
 func (h *Handler) JoinChannels() {
 	for _, channel := range h.network.Channels {
@@ -804,6 +954,11 @@ func (h *Handler) JoinChannels() {
 
 // JoinChannel sends join command
 func (h *Handler) JoinChannel(channel string, password string) error {
+	password, err := domain.ResolveSecret(password)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve password of channel %s", channel)
+	}
+
 	params := []string{channel}
 	// support channel password
 	if password != "" {
@@ -899,6 +1054,9 @@ func (h *Handler) handleJoined(msg ircmsg.Message) {
 	}
 
 	h.log.Info().Msgf("Monitoring channel %s", channel)
+
+	// ask the bouncer for the announces missed while disconnected
+	h.requestPlayback(channel)
 }
 
 // sendConnectCommands sends invite commands
@@ -973,6 +1131,12 @@ func (h *Handler) handleInvite(msg ircmsg.Message) {
 
 // NickServIdentify sends NickServ Identify commands
 func (h *Handler) NickServIdentify(password string) error {
+	password, err := domain.ResolveSecret(password)
+	if err != nil {
+		h.log.Error().Err(err).Msg("could not resolve nickserv password")
+		return err
+	}
+
 	if err := h.Send("PRIVMSG", "NickServ", fmt.Sprintf("IDENTIFY %s", password)); err != nil {
 		h.log.Error().Stack().Err(err).Msgf("error identifying with nickserv")
 		return err
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package irc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ergochat/irc-go/ircmsg"
+)
+
+const (
+	// capChatHistory is the IRCv3 chathistory extension, see https://ircv3.net/specs/extensions/chathistory
+	capChatHistory = "draft/chathistory"
+	// capZNCPlayback is the capability of the ZNC playback module, see https://wiki.znc.in/Playback
+	capZNCPlayback = "znc.in/playback"
+
+	// chatHistoryLimit is how many messages are requested if the bouncer does not advertise its limit
+	chatHistoryLimit = 100
+
+	// serverTimeLayout is the format of the IRCv3 server-time tag and chathistory timestamps
+	serverTimeLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// playbackCaps are requested from bouncers, server-time tags played back messages with their original time
+var playbackCaps = []string{"server-time", "batch", capChatHistory, capZNCPlayback}
+
+// playbackEnabled reports if missed announces are played back, only bouncers keep them
+func (h *Handler) playbackEnabled() bool {
+	return h.network.UseBouncer && h.recorder != nil && h.recorder.PlaybackMaxAge() > 0
+}
+
+// requestPlayback asks the bouncer for the announces of the channel since the last one that was seen.
+// Bouncers without chathistory or the playback module may still play back their buffer on their own.
+func (h *Handler) requestPlayback(channel string) {
+	client := h.getClient()
+	if client == nil || !h.playbackEnabled() {
+		return
+	}
+
+	since := h.recorder.PlaybackSince(channel)
+	caps := client.AcknowledgedCaps()
+
+	if _, ok := caps[capChatHistory]; ok {
+		limit := chatHistoryLimit
+		if v, err := strconv.Atoi(client.ISupport()["CHATHISTORY"]); err == nil && v > 0 {
+			limit = v
+		}
+
+		h.log.Debug().Msgf("request chathistory of %s since %s", channel, since.Format(time.RFC3339))
+
+		if err := client.Send("CHATHISTORY", "AFTER", channel, "timestamp="+since.UTC().Format(serverTimeLayout), strconv.Itoa(limit)); err != nil {
+			h.log.Error().Err(err).Msgf("could not request chathistory of %s", channel)
+		}
+		return
+	}
+
+	if _, ok := caps[capZNCPlayback]; ok {
+		h.log.Debug().Msgf("request znc playback of %s since %s", channel, since.Format(time.RFC3339))
+
+		if err := client.Send("PRIVMSG", "*playback", fmt.Sprintf("PLAY %s %d", channel, since.Unix())); err != nil {
+			h.log.Error().Err(err).Msgf("could not request znc playback of %s", channel)
+		}
+		return
+	}
+
+	h.log.Debug().Msgf("bouncer supports neither %s nor %s, skip playback request for %s", capChatHistory, capZNCPlayback, channel)
+}
+
+// messageTime returns the server-time of the message and whether it was played back,
+// which is when it was sent before the current connection was made
+func (h *Handler) messageTime(msg ircmsg.Message) (time.Time, bool) {
+	now := time.Now()
+
+	if !h.playbackEnabled() {
+		return now, false
+	}
+
+	ok, value := msg.GetTag("time")
+	if !ok {
+		return now, false
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		h.log.Trace().Err(err).Msgf("could not parse server-time: %s", value)
+		return now, false
+	}
+
+	h.m.RLock()
+	connectedSince := h.connectedSince
+	h.m.RUnlock()
+
+	return timestamp, !connectedSince.IsZero() && timestamp.Before(connectedSince)
+}
@@ -10,7 +10,10 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/autobrr/autobrr/internal/announce"
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/indexer"
 	"github.com/autobrr/autobrr/internal/logger"
@@ -39,20 +42,27 @@ type Service interface {
 	StoreChannel(ctx context.Context, networkID int64, channel *domain.IrcChannel) error
 	SendCmd(ctx context.Context, req *domain.SendIrcCmdRequest) error
 	ManualProcessAnnounce(ctx context.Context, req *domain.IRCManualProcessRequest) error
+	AnnounceLines(ctx context.Context, networkID int64, channel string) ([]domain.AnnounceLine, error)
+	ReplayAnnounces(ctx context.Context, req *domain.AnnounceReplayRequest) (*domain.AnnounceReplayResult, error)
+	ParseFailures(ctx context.Context, indexer string) []domain.AnnounceParseFailure
+	ReloadIndexer(definition *domain.IndexerDefinition)
 }
 
 type service struct {
-	log zerolog.Logger
-	sse *sse.Server
+	log    zerolog.Logger
+	config *domain.Config
+	sse    *sse.Server
 
 	repo                domain.IrcRepo
 	releaseService      release.Service
 	indexerService      indexer.Service
 	notificationService notification.Sender
 	proxyService        proxy.Service
+	auditService        audit.Service
 
 	indexerMap map[string]string
 	handlers   map[int64]*Handler
+	recorders  map[int64]*announce.Recorder
 
 	stopWG sync.WaitGroup
 	lock   sync.RWMutex
@@ -60,16 +70,19 @@ type service struct {
 
 const sseMaxEntries = 1000
 
-func NewService(log logger.Logger, sse *sse.Server, repo domain.IrcRepo, releaseSvc release.Service, indexerSvc indexer.Service, notificationSvc notification.Sender, proxySvc proxy.Service) Service {
+func NewService(log logger.Logger, config *domain.Config, sse *sse.Server, repo domain.IrcRepo, releaseSvc release.Service, indexerSvc indexer.Service, notificationSvc notification.Sender, proxySvc proxy.Service, auditSvc audit.Service) Service {
 	return &service{
 		log:                 log.With().Str("module", "irc").Logger(),
+		config:              config,
 		sse:                 sse,
 		repo:                repo,
 		releaseService:      releaseSvc,
 		indexerService:      indexerSvc,
 		notificationService: notificationSvc,
 		proxyService:        proxySvc,
+		auditService:        auditSvc,
 		handlers:            make(map[int64]*Handler),
+		recorders:           make(map[int64]*announce.Recorder),
 	}
 }
 
@@ -110,7 +123,7 @@ func (s *service) StartHandlers() {
 		network.Channels = channels
 
 		// init new irc handler
-		handler := NewHandler(s.log, s.sse, network, definitions, s.releaseService, s.notificationService)
+		handler := NewHandler(s.log, s.sse, network, definitions, s.releaseService, s.notificationService, s.recorder(network))
 
 		// use network.Server + nick to use multiple indexers with different nick per network
 		// this allows for multiple handlers to one network
@@ -170,7 +183,7 @@ func (s *service) startNetwork(network domain.IrcNetwork) error {
 	network.Channels = channels
 
 	// init new irc handler
-	handler := NewHandler(s.log, s.sse, network, definitions, s.releaseService, s.notificationService)
+	handler := NewHandler(s.log, s.sse, network, definitions, s.releaseService, s.notificationService, s.recorder(network))
 
 	s.handlers[network.ID] = handler
 	s.lock.Unlock()
@@ -363,6 +376,25 @@ func (s *service) checkIfNetworkRestartNeeded(network *domain.IrcNetwork) error
 	return nil
 }
 
+// ReloadIndexer swaps the definition of an indexer in the handlers of its network without reconnecting.
+// Handlers of other networks that had the indexer, because its server changed, stop parsing its announces.
+func (s *service) ReloadIndexer(definition *domain.IndexerDefinition) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, handler := range s.handlers {
+		switch {
+		case definition.IRC != nil && strings.EqualFold(handler.network.Server, definition.IRC.Server):
+			handler.ReloadIndexer(definition)
+			s.log.Debug().Msgf("reloaded indexer %s on network: %s", definition.Identifier, handler.network.Name)
+
+		case handler.HasIndexer(definition.Identifier):
+			handler.RemoveIndexer(definition.Identifier)
+			s.log.Debug().Msgf("removed indexer %s from network: %s", definition.Identifier, handler.network.Name)
+		}
+	}
+}
+
 func (s *service) RestartNetwork(ctx context.Context, id int64) error {
 	network, err := s.repo.GetNetworkByID(ctx, id)
 	if err != nil {
@@ -449,7 +481,7 @@ func (s *service) ManualProcessAnnounce(ctx context.Context, req *domain.IRCManu
 		return errors.New("could not find irc handler with id: %d", network.ID)
 	}
 
-	err = handler.sendToAnnounceProcessor(req.Channel, req.Message)
+	err = handler.sendToAnnounceProcessor(req.Channel, req.Message, time.Time{})
 	if err != nil {
 		return errors.Wrap(err, "could not send manual announce to processor")
 	}
@@ -457,6 +489,102 @@ func (s *service) ManualProcessAnnounce(ctx context.Context, req *domain.IRCManu
 	return nil
 }
 
+// recorder returns the announce recorder of the network, it is kept when the handler is restarted.
+// Callers must hold the lock.
+func (s *service) recorder(network domain.IrcNetwork) *announce.Recorder {
+	recorder, ok := s.recorders[network.ID]
+	if !ok {
+		recorder = announce.NewRecorder(s.config.IRCAnnounceRecordLines, announce.ParseAlert{
+			Failures: s.config.AnnounceParseFailures,
+			Silence:  time.Duration(s.config.AnnounceParseSilence) * time.Minute,
+			OnFailing: func(health domain.AnnounceParserHealth) {
+				s.announceParseFailing(network.Name, health)
+			},
+		}, time.Duration(s.config.IRCBouncerPlaybackMaxAge)*time.Minute)
+		s.recorders[network.ID] = recorder
+	}
+
+	return recorder
+}
+
+func (s *service) announceParseFailing(networkName string, health domain.AnnounceParserHealth) {
+	s.log.Warn().Msgf("announces of indexer %s on network %s stopped matching the definition: %d failed in a row", health.IndexerName, networkName, health.ConsecutiveFailures)
+
+	lastSuccess := "never"
+	if !health.LastSuccess.IsZero() {
+		lastSuccess = health.LastSuccess.Format(time.RFC3339)
+	}
+
+	s.notificationService.Send(domain.NotificationEventAnnounceParseFailing, domain.NotificationPayload{
+		Subject: "Announce parsing failing",
+		Message: fmt.Sprintf("Network: %s\nIndexer: %s\nFailed announces in a row: %d\nLast matched announce: %s", networkName, health.IndexerName, health.ConsecutiveFailures, lastSuccess),
+		Indexer: health.IndexerName,
+	})
+}
+
+func (s *service) AnnounceLines(ctx context.Context, networkID int64, channel string) ([]domain.AnnounceLine, error) {
+	s.lock.RLock()
+	recorder, ok := s.recorders[networkID]
+	s.lock.RUnlock()
+
+	if !ok {
+		return nil, errors.New("could not find irc handler with id: %d", networkID)
+	}
+
+	return recorder.Lines(channel), nil
+}
+
+func (s *service) ReplayAnnounces(ctx context.Context, req *domain.AnnounceReplayRequest) (*domain.AnnounceReplayResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	handler, ok := s.handlers[req.NetworkID]
+	s.lock.RUnlock()
+
+	if !ok {
+		return nil, errors.New("could not find irc handler with id: %d", req.NetworkID)
+	}
+
+	result, err := handler.ReplayAnnounces(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not replay announces")
+	}
+
+	result.Filters, err = s.releaseService.DryRunFilters(ctx, result.Indexer, result.Releases)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check replayed releases")
+	}
+
+	s.log.Debug().Msgf("replayed %d lines from %s: %d releases, %d parse failures, %d filters checked", result.Lines, result.Channel, len(result.Releases), len(result.Failures), len(result.Filters))
+
+	return result, nil
+}
+
+// ParseFailures returns the latest lines of all networks that did not match the parse patterns, newest first.
+// If indexer is set only the failures of that indexer are returned.
+func (s *service) ParseFailures(ctx context.Context, indexer string) []domain.AnnounceParseFailure {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	failures := make([]domain.AnnounceParseFailure, 0)
+	for _, recorder := range s.recorders {
+		for _, failure := range recorder.ParseFailures() {
+			if indexer != "" && failure.Indexer != indexer {
+				continue
+			}
+			failures = append(failures, failure)
+		}
+	}
+
+	slices.SortFunc(failures, func(a, b domain.AnnounceParseFailure) int {
+		return b.Timestamp.Compare(a.Timestamp)
+	})
+
+	return failures
+}
+
 func (s *service) ListNetworks(ctx context.Context) ([]domain.IrcNetwork, error) {
 	networks, err := s.repo.ListNetworks(ctx)
 	if err != nil {
@@ -510,15 +638,21 @@ func (s *service) GetNetworksWithHealth(ctx context.Context) ([]domain.IrcNetwor
 			Connected:        false,
 			Channels:         []domain.ChannelWithHealth{},
 			ConnectionErrors: []string{},
+			Parsers:          []domain.AnnounceParserHealth{},
 		}
 
 		s.lock.RLock()
 		handler, ok := s.handlers[n.ID]
+		recorder, hasRecorder := s.recorders[n.ID]
 		s.lock.RUnlock()
 		if ok {
 			handler.ReportStatus(&netw)
 		}
 
+		if hasRecorder {
+			netw.Parsers = recorder.ParserHealth()
+		}
+
 		channels, err := s.repo.ListChannels(n.ID)
 		if err != nil {
 			s.log.Error().Err(err).Msgf("failed to list channels for network: %s", n.Server)
@@ -584,6 +718,12 @@ func (s *service) DeleteNetwork(ctx context.Context, id int64) error {
 		return err
 	}
 
+	s.lock.Lock()
+	delete(s.recorders, network.ID)
+	s.lock.Unlock()
+
+	networkAudit.Record(ctx, s.auditService, domain.AuditActionDelete, network, nil)
+
 	return nil
 }
 
@@ -659,6 +799,8 @@ func (s *service) UpdateNetwork(ctx context.Context, network *domain.IrcNetwork)
 		}
 	}
 
+	networkAudit.Record(ctx, s.auditService, domain.AuditActionUpdate, existingNetwork, network)
+
 	return nil
 }
 
@@ -695,6 +837,8 @@ func (s *service) StoreNetwork(ctx context.Context, network *domain.IrcNetwork)
 			network.Proxy = networkProxy
 		}
 
+		networkAudit.Record(ctx, s.auditService, domain.AuditActionCreate, nil, network)
+
 		// if network is enabled, start it immediately
 		if network.Enabled {
 			if err := s.startNetwork(*network); err != nil {
@@ -713,6 +857,8 @@ func (s *service) StoreNetwork(ctx context.Context, network *domain.IrcNetwork)
 	}
 	existingNetwork.Channels = existingChannels
 
+	before := *existingNetwork
+
 	if network.Channels != nil {
 		for _, channel := range network.Channels {
 			// add channels. Make sure it doesn't delete before
@@ -744,6 +890,8 @@ func (s *service) StoreNetwork(ctx context.Context, network *domain.IrcNetwork)
 		}
 	}
 
+	networkAudit.Record(ctx, s.auditService, domain.AuditActionUpdate, &before, existingNetwork)
+
 	return nil
 }
 
@@ -0,0 +1,28 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package list
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// listAudit records lists without the state of the last refresh
+var listAudit = audit.Resource[domain.List]{
+	Type: domain.AuditResourceList,
+	Key: func(l *domain.List) (string, string) {
+		return strconv.FormatInt(l.ID, 10), l.Name
+	},
+	View: func(l *domain.List) any {
+		view := *l
+		view.LastRefreshTime = time.Time{}
+		view.LastRefreshData = ""
+		view.LastRefreshStatus = ""
+
+		return &view
+	},
+}
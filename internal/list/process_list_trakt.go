@@ -31,8 +31,13 @@ func (s *service) trakt(ctx context.Context, list *domain.List) error {
 
 	req.Header.Set("trakt-api-version", "2")
 
-	if list.APIKey != "" {
-		req.Header.Set("trakt-api-key", list.APIKey)
+	apiKey, err := domain.ResolveSecret(list.APIKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not resolve api key for trakt: %s", list.Name)
+	}
+
+	if apiKey != "" {
+		req.Header.Set("trakt-api-key", apiKey)
 	}
 
 	list.SetRequestHeaders(req)
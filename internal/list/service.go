@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/download_client"
 	"github.com/autobrr/autobrr/internal/filter"
@@ -40,9 +41,10 @@ type service struct {
 	scheduler         scheduler.Service
 	downloadClientSvc download_client.Service
 	filterSvc         filter.Service
+	auditSvc          audit.Service
 }
 
-func NewService(log logger.Logger, repo domain.ListRepo, downloadClientSvc download_client.Service, filterSvc filter.Service, schedulerSvc scheduler.Service) Service {
+func NewService(log logger.Logger, repo domain.ListRepo, downloadClientSvc download_client.Service, filterSvc filter.Service, schedulerSvc scheduler.Service, auditSvc audit.Service) Service {
 	return &service{
 		log:  log.With().Str("module", "list").Logger(),
 		repo: repo,
@@ -52,6 +54,7 @@ func NewService(log logger.Logger, repo domain.ListRepo, downloadClientSvc downl
 		downloadClientSvc: downloadClientSvc,
 		filterSvc:         filterSvc,
 		scheduler:         schedulerSvc,
+		auditSvc:          auditSvc,
 	}
 }
 
@@ -104,6 +107,8 @@ func (s *service) Store(ctx context.Context, list *domain.List) error {
 
 	s.log.Debug().Msgf("successfully created list %s", list.Name)
 
+	listAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, list)
+
 	if list.Enabled {
 		if err := s.refreshList(ctx, list); err != nil {
 			s.log.Error().Err(err).Msgf("could not refresh list %s", list.Name)
@@ -137,6 +142,8 @@ func (s *service) Update(ctx context.Context, list *domain.List) error {
 
 	s.log.Debug().Msgf("successfully updated list %s", list.Name)
 
+	listAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, existingList, list)
+
 	if list.Enabled {
 		if err := s.refreshList(ctx, list); err != nil {
 			s.log.Error().Err(err).Msgf("could not refresh list %s", list.Name)
@@ -148,6 +155,8 @@ func (s *service) Update(ctx context.Context, list *domain.List) error {
 }
 
 func (s *service) Delete(ctx context.Context, id int64) error {
+	existingList, _ := s.repo.FindByID(ctx, id)
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		s.log.Error().Err(err).Msgf("could not delete list by id %d", id)
@@ -156,6 +165,8 @@ func (s *service) Delete(ctx context.Context, id int64) error {
 
 	s.log.Debug().Msgf("successfully deleted list %d", id)
 
+	listAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, existingList, nil)
+
 	return nil
 }
 
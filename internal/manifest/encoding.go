@@ -0,0 +1,105 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Marshal encodes the manifest. YAML documents use the same field names as JSON.
+func Marshal(manifest *domain.Manifest, format domain.ManifestFormat) ([]byte, error) {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal manifest")
+	}
+
+	switch format {
+	case domain.ManifestFormatJSON:
+		return append(data, '\n'), nil
+
+	case domain.ManifestFormatYAML, "":
+		// json is valid yaml, decoding it into a node keeps the field order
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, errors.Wrap(err, "could not convert manifest to yaml")
+		}
+
+		blockStyle(&node)
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(&node); err != nil {
+			return nil, errors.Wrap(err, "could not marshal manifest")
+		}
+
+		if err := enc.Close(); err != nil {
+			return nil, errors.Wrap(err, "could not marshal manifest")
+		}
+
+		return buf.Bytes(), nil
+
+	default:
+		return nil, errors.New("unsupported manifest format: %s", format)
+	}
+}
+
+// Unmarshal decodes a YAML or JSON manifest
+func Unmarshal(data []byte) (*domain.Manifest, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "could not parse manifest")
+	}
+
+	if doc == nil {
+		return nil, errors.New("manifest is empty")
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse manifest")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+
+	var manifest domain.Manifest
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, errors.Wrap(err, "invalid manifest")
+	}
+
+	return &manifest, nil
+}
+
+// blockStyle clears the flow style of the decoded json and moves the
+// identifying fields of resources to the top.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	if node.Kind == yaml.MappingNode {
+		for _, key := range []string{"identifier", "name"} {
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				if node.Content[i].Value != key || i == 0 {
+					continue
+				}
+
+				pair := []*yaml.Node{node.Content[i], node.Content[i+1]}
+				node.Content = append(pair, append(node.Content[:i:i], node.Content[i+2:]...)...)
+				break
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestService()
+	ctx := context.Background()
+
+	manifest, err := s.Export(ctx, domain.ManifestExportOptions{})
+	require.NoError(t, err)
+
+	for _, format := range []domain.ManifestFormat{domain.ManifestFormatYAML, domain.ManifestFormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := Marshal(manifest, format)
+			require.NoError(t, err)
+
+			decoded, err := Unmarshal(data)
+			require.NoError(t, err)
+
+			// the decoded manifest matches the current state
+			plan, err := s.Plan(ctx, decoded, domain.ManifestPlanOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, plan.Errors)
+			assert.Empty(t, plan.Changes)
+			assert.Equal(t, 5, plan.Unchanged)
+		})
+	}
+
+	data, err := Marshal(manifest, domain.ManifestFormatYAML)
+	require.NoError(t, err)
+
+	doc := string(data)
+	assert.True(t, strings.HasPrefix(doc, "version: 1\n"))
+	assert.Contains(t, doc, "proxies:\n  - name: socks\n")
+	assert.Contains(t, doc, "indexers:\n  - name: Mock\n    identifier: mock\n")
+	assert.Contains(t, doc, "pass: <redacted>\n")
+	assert.Contains(t, doc, "feeds: []\n")
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := Unmarshal([]byte(`
+version: 1
+filters:
+  - name: shows
+    shows: That Show
+    max_downloads: 2
+`))
+	require.NoError(t, err)
+
+	assert.Nil(t, manifest.Proxies)
+	require.Len(t, manifest.Filters, 1)
+	assert.Equal(t, "That Show", manifest.Filters[0]["shows"])
+
+	_, err = Unmarshal([]byte(`{"version": 1, "filter": []}`))
+	assert.Error(t, err)
+
+	_, err = Unmarshal([]byte(``))
+	assert.Error(t, err)
+}
@@ -0,0 +1,1009 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+// resourceKind converts resources of one kind between the manifest and the services
+type resourceKind interface {
+	kind() domain.ManifestKind
+	key() string
+	current(ctx context.Context, refs *resolver) ([]currentResource, error)
+	canonical(refs *resolver, res domain.ManifestResource) (domain.ManifestResource, error)
+	create(ctx context.Context, refs *resolver, res domain.ManifestResource) (int64, error)
+	update(ctx context.Context, refs *resolver, id int64, res domain.ManifestResource) error
+	remove(ctx context.Context, id int64) error
+}
+
+type currentResource struct {
+	id       int64
+	name     string
+	resource domain.ManifestResource
+}
+
+// typedKind implements resourceKind for a domain type
+type typedKind[T any] struct {
+	manifestKind domain.ManifestKind
+	keyField     string
+
+	list   func(ctx context.Context) ([]T, error)
+	id     func(T) int64
+	encode func(refs *resolver, v T) (domain.ManifestResource, error)
+	decode func(refs *resolver, res domain.ManifestResource) (T, error)
+	store  func(ctx context.Context, v T) (int64, error)
+	save   func(ctx context.Context, id int64, v T) error
+	delete func(ctx context.Context, id int64) error
+}
+
+func (k *typedKind[T]) kind() domain.ManifestKind {
+	return k.manifestKind
+}
+
+func (k *typedKind[T]) key() string {
+	return k.keyField
+}
+
+func (k *typedKind[T]) current(ctx context.Context, refs *resolver) ([]currentResource, error) {
+	values, err := k.list(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list %s resources", k.manifestKind)
+	}
+
+	resources := make([]currentResource, 0, len(values))
+	for _, v := range values {
+		res, err := k.encode(refs, v)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, currentResource{
+			id:       k.id(v),
+			name:     resourceName(res, k.keyField),
+			resource: res,
+		})
+	}
+
+	return resources, nil
+}
+
+func (k *typedKind[T]) canonical(refs *resolver, res domain.ManifestResource) (domain.ManifestResource, error) {
+	v, err := k.decode(refs, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.encode(refs, v)
+}
+
+func (k *typedKind[T]) create(ctx context.Context, refs *resolver, res domain.ManifestResource) (int64, error) {
+	v, err := k.decode(refs, res)
+	if err != nil {
+		return 0, err
+	}
+
+	return k.store(ctx, v)
+}
+
+func (k *typedKind[T]) update(ctx context.Context, refs *resolver, id int64, res domain.ManifestResource) error {
+	v, err := k.decode(refs, res)
+	if err != nil {
+		return err
+	}
+
+	return k.save(ctx, id, v)
+}
+
+func (k *typedKind[T]) remove(ctx context.Context, id int64) error {
+	return k.delete(ctx, id)
+}
+
+func resourceName(res domain.ManifestResource, key string) string {
+	name, _ := res[key].(string)
+	return name
+}
+
+// resolver maps the names of referenced resources to ids and back.
+// Resources that are planned but not created yet get negative placeholder ids.
+type resolver struct {
+	ids         map[domain.ManifestKind]map[string]int64
+	names       map[domain.ManifestKind]map[int64]string
+	placeholder int64
+}
+
+func newResolver() *resolver {
+	return &resolver{
+		ids:   make(map[domain.ManifestKind]map[string]int64),
+		names: make(map[domain.ManifestKind]map[int64]string),
+	}
+}
+
+func (r *resolver) add(kind domain.ManifestKind, name string, id int64) {
+	if r.ids[kind] == nil {
+		r.ids[kind] = make(map[string]int64)
+		r.names[kind] = make(map[int64]string)
+	}
+
+	if previous, ok := r.ids[kind][name]; ok {
+		delete(r.names[kind], previous)
+	}
+
+	r.ids[kind][name] = id
+	r.names[kind][id] = name
+}
+
+func (r *resolver) addPlaceholder(kind domain.ManifestKind, name string) {
+	r.placeholder--
+	r.add(kind, name, r.placeholder)
+}
+
+func (r *resolver) remove(kind domain.ManifestKind, name string) {
+	if id, ok := r.ids[kind][name]; ok {
+		delete(r.ids[kind], name)
+		delete(r.names[kind], id)
+	}
+}
+
+func (r *resolver) id(kind domain.ManifestKind, name string) (int64, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	id, ok := r.ids[kind][name]
+	if !ok {
+		return 0, errors.New("unknown %s: %s", kind, name)
+	}
+
+	return id, nil
+}
+
+// name returns the name for the id, or an empty string for unset and dangling references
+func (r *resolver) name(kind domain.ManifestKind, id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return r.names[kind][id]
+}
+
+// toResource converts v into a resource through its json format and drops the omitted fields
+func toResource(v any, omit ...string) (domain.ManifestResource, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal resource")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	res := domain.ManifestResource{}
+	if err := dec.Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal resource")
+	}
+
+	for _, key := range omit {
+		delete(res, key)
+	}
+
+	return res, nil
+}
+
+// fromResource decodes the resource into v and rejects unknown fields.
+// Reference fields are skipped since they are resolved by the caller.
+func fromResource(res domain.ManifestResource, v any, refs ...string) error {
+	fields := maps.Clone(res)
+	for _, key := range refs {
+		delete(fields, key)
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal resource")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return errors.Wrap(err, "invalid resource")
+	}
+
+	return nil
+}
+
+// emptySlices replaces the nil slices of a struct with empty ones. Omitted
+// list fields are stored as empty arrays, never as NULL.
+func emptySlices(v any) {
+	value := reflect.ValueOf(v).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() == reflect.Slice && field.IsNil() && field.CanSet() {
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+		}
+	}
+}
+
+func stringField(res domain.ManifestResource, key string) (string, error) {
+	switch v := res[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", errors.New("invalid %s: expected string", key)
+	}
+}
+
+func stringsField(res domain.ManifestResource, key string) ([]string, error) {
+	switch v := res[key].(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return v, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("invalid %s: expected list of strings", key)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	default:
+		return nil, errors.New("invalid %s: expected list of strings", key)
+	}
+}
+
+func resourcesField(res domain.ManifestResource, key string) ([]domain.ManifestResource, error) {
+	switch v := res[key].(type) {
+	case nil:
+		return nil, nil
+	case []domain.ManifestResource:
+		return v, nil
+	case []any:
+		values := make([]domain.ManifestResource, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, errors.New("invalid %s: expected list of objects", key)
+			}
+			values = append(values, m)
+		}
+		return values, nil
+	default:
+		return nil, errors.New("invalid %s: expected list of objects", key)
+	}
+}
+
+// resolveNames resolves a list of references, unknown names are returned as an error
+func resolveNames(refs *resolver, kind domain.ManifestKind, names []string) ([]int64, error) {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		id, err := refs.id(kind, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (s *service) resourceKinds() []resourceKind {
+	return []resourceKind{
+		s.proxyKind(),
+		s.downloadClientKind(),
+		s.indexerKind(),
+		s.ircNetworkKind(),
+		s.feedKind(),
+		s.notificationKind(),
+		s.duplicateProfileKind(),
+		s.filterKind(),
+		s.listKind(),
+		s.cleanupJobKind(),
+	}
+}
+
+// the alias types drop the MarshalJSON methods of the domain types that redact secrets
+type (
+	proxyFields          domain.Proxy
+	downloadClientFields domain.DownloadClient
+	indexerFields        domain.Indexer
+	ircNetworkFields     domain.IrcNetwork
+	ircChannelFields     domain.IrcChannel
+	ircAuthFields        domain.IRCAuth
+	feedFields           domain.Feed
+	notificationFields   domain.Notification
+	listFields           domain.List
+)
+
+func (s *service) proxyKind() resourceKind {
+	return &typedKind[domain.Proxy]{
+		manifestKind: domain.ManifestKindProxy,
+		keyField:     "name",
+		list:         s.proxies.List,
+		id:           func(p domain.Proxy) int64 { return p.ID },
+		encode: func(_ *resolver, p domain.Proxy) (domain.ManifestResource, error) {
+			return toResource(proxyFields(p), "id")
+		},
+		decode: func(_ *resolver, res domain.ManifestResource) (domain.Proxy, error) {
+			var p proxyFields
+			err := fromResource(res, &p)
+			return domain.Proxy(p), err
+		},
+		store: func(ctx context.Context, p domain.Proxy) (int64, error) {
+			err := s.proxies.Store(ctx, &p)
+			return p.ID, err
+		},
+		save: func(ctx context.Context, id int64, p domain.Proxy) error {
+			p.ID = id
+			return s.proxies.Update(ctx, &p)
+		},
+		delete: s.proxies.Delete,
+	}
+}
+
+func (s *service) downloadClientKind() resourceKind {
+	return &typedKind[domain.DownloadClient]{
+		manifestKind: domain.ManifestKindDownloadClient,
+		keyField:     "name",
+		list:         s.downloadClients.List,
+		id:           func(c domain.DownloadClient) int64 { return int64(c.ID) },
+		encode: func(_ *resolver, c domain.DownloadClient) (domain.ManifestResource, error) {
+			return toResource(downloadClientFields(c), "id")
+		},
+		decode: func(_ *resolver, res domain.ManifestResource) (domain.DownloadClient, error) {
+			var c downloadClientFields
+			err := fromResource(res, &c)
+			return domain.DownloadClient(c), err
+		},
+		store: func(ctx context.Context, c domain.DownloadClient) (int64, error) {
+			err := s.downloadClients.Store(ctx, &c)
+			return int64(c.ID), err
+		},
+		save: func(ctx context.Context, id int64, c domain.DownloadClient) error {
+			c.ID = int32(id)
+			return s.downloadClients.Update(ctx, &c)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.downloadClients.Delete(ctx, int32(id))
+		},
+	}
+}
+
+func (s *service) indexerKind() resourceKind {
+	return &typedKind[domain.Indexer]{
+		manifestKind: domain.ManifestKindIndexer,
+		keyField:     "identifier",
+		list:         s.indexers.List,
+		id:           func(i domain.Indexer) int64 { return i.ID },
+		encode: func(refs *resolver, i domain.Indexer) (domain.ManifestResource, error) {
+			res, err := toResource(indexerFields(i), "id", "proxy", "proxy_id")
+			if err != nil {
+				return nil, err
+			}
+
+			res["proxy"] = refs.name(domain.ManifestKindProxy, i.ProxyID)
+
+			return res, nil
+		},
+		decode: func(refs *resolver, res domain.ManifestResource) (domain.Indexer, error) {
+			var i indexerFields
+			if err := fromResource(res, &i, "proxy"); err != nil {
+				return domain.Indexer{}, err
+			}
+
+			proxy, err := stringField(res, "proxy")
+			if err != nil {
+				return domain.Indexer{}, err
+			}
+
+			if i.ProxyID, err = refs.id(domain.ManifestKindProxy, proxy); err != nil {
+				return domain.Indexer{}, err
+			}
+
+			return domain.Indexer(i), nil
+		},
+		store: func(ctx context.Context, i domain.Indexer) (int64, error) {
+			stored, err := s.indexers.Store(ctx, i)
+			if err != nil {
+				return 0, err
+			}
+			return stored.ID, nil
+		},
+		save: func(ctx context.Context, id int64, i domain.Indexer) error {
+			i.ID = id
+			_, err := s.indexers.Update(ctx, i)
+			return err
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.indexers.Delete(ctx, int(id))
+		},
+	}
+}
+
+func (s *service) ircNetworkKind() resourceKind {
+	return &typedKind[domain.IrcNetwork]{
+		manifestKind: domain.ManifestKindIrcNetwork,
+		keyField:     "name",
+		list:         s.ircNetworks.ListNetworks,
+		id:           func(n domain.IrcNetwork) int64 { return n.ID },
+		encode: func(refs *resolver, n domain.IrcNetwork) (domain.ManifestResource, error) {
+			res, err := toResource(ircNetworkFields(n), "id", "proxy", "proxy_id", "connected", "connected_since", "auth", "channels")
+			if err != nil {
+				return nil, err
+			}
+
+			res["proxy"] = refs.name(domain.ManifestKindProxy, n.ProxyId)
+
+			if res["auth"], err = toResource(ircAuthFields(n.Auth)); err != nil {
+				return nil, err
+			}
+
+			channels := make([]any, 0, len(n.Channels))
+			for _, channel := range n.Channels {
+				c, err := toResource(ircChannelFields(channel), "id")
+				if err != nil {
+					return nil, err
+				}
+				channels = append(channels, map[string]any(c))
+			}
+			res["channels"] = channels
+
+			// normalize the nested values to their json format
+			return toResource(res)
+		},
+		decode: func(refs *resolver, res domain.ManifestResource) (domain.IrcNetwork, error) {
+			var n ircNetworkFields
+			if err := fromResource(res, &n, "proxy", "auth", "channels"); err != nil {
+				return domain.IrcNetwork{}, err
+			}
+
+			proxy, err := stringField(res, "proxy")
+			if err != nil {
+				return domain.IrcNetwork{}, err
+			}
+
+			if n.ProxyId, err = refs.id(domain.ManifestKindProxy, proxy); err != nil {
+				return domain.IrcNetwork{}, err
+			}
+
+			if auth, ok := res["auth"].(map[string]any); ok {
+				var a ircAuthFields
+				if err := fromResource(auth, &a); err != nil {
+					return domain.IrcNetwork{}, errors.Wrap(err, "invalid auth")
+				}
+				n.Auth = domain.IRCAuth(a)
+			}
+
+			channels, err := resourcesField(res, "channels")
+			if err != nil {
+				return domain.IrcNetwork{}, err
+			}
+
+			n.Channels = make([]domain.IrcChannel, 0, len(channels))
+			for _, channel := range channels {
+				var c ircChannelFields
+				if err := fromResource(channel, &c); err != nil {
+					return domain.IrcNetwork{}, errors.Wrap(err, "invalid channel")
+				}
+				c.ID = 0
+				n.Channels = append(n.Channels, domain.IrcChannel(c))
+			}
+
+			return domain.IrcNetwork(n), nil
+		},
+		store: func(ctx context.Context, n domain.IrcNetwork) (int64, error) {
+			err := s.ircNetworks.StoreNetwork(ctx, &n)
+			return n.ID, err
+		},
+		save: func(ctx context.Context, id int64, n domain.IrcNetwork) error {
+			n.ID = id
+			return s.ircNetworks.UpdateNetwork(ctx, &n)
+		},
+		delete: s.ircNetworks.DeleteNetwork,
+	}
+}
+
+func (s *service) feedKind() resourceKind {
+	return &typedKind[domain.Feed]{
+		manifestKind: domain.ManifestKindFeed,
+		keyField:     "name",
+		list:         s.feeds.Find,
+		id:           func(f domain.Feed) int64 { return int64(f.ID) },
+		encode: func(refs *resolver, f domain.Feed) (domain.ManifestResource, error) {
+			res, err := toResource(feedFields(f), "id", "indexer", "indexer_id", "capabilities", "created_at", "updated_at", "last_run", "last_run_data", "next_run")
+			if err != nil {
+				return nil, err
+			}
+
+			indexerID := int64(f.IndexerID)
+			if indexerID == 0 {
+				indexerID = int64(f.Indexer.ID)
+			}
+			res["indexer"] = refs.name(domain.ManifestKindIndexer, indexerID)
+
+			return res, nil
+		},
+		decode: func(refs *resolver, res domain.ManifestResource) (domain.Feed, error) {
+			var f feedFields
+			if err := fromResource(res, &f, "indexer"); err != nil {
+				return domain.Feed{}, err
+			}
+
+			indexer, err := stringField(res, "indexer")
+			if err != nil {
+				return domain.Feed{}, err
+			}
+
+			if indexer == "" {
+				return domain.Feed{}, errors.New("indexer is required")
+			}
+
+			indexerID, err := refs.id(domain.ManifestKindIndexer, indexer)
+			if err != nil {
+				return domain.Feed{}, err
+			}
+
+			f.IndexerID = int(indexerID)
+			f.Indexer = domain.IndexerMinimal{ID: int(indexerID), Identifier: indexer}
+
+			return domain.Feed(f), nil
+		},
+		store: func(ctx context.Context, f domain.Feed) (int64, error) {
+			if err := s.feeds.Store(ctx, &f); err != nil {
+				return 0, err
+			}
+
+			// storing does not schedule the feed, updating it does
+			if f.Enabled {
+				if err := s.feeds.Update(ctx, &f); err != nil {
+					return 0, err
+				}
+			}
+
+			return int64(f.ID), nil
+		},
+		save: func(ctx context.Context, id int64, f domain.Feed) error {
+			f.ID = int(id)
+			return s.feeds.Update(ctx, &f)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.feeds.Delete(ctx, int(id))
+		},
+	}
+}
+
+func (s *service) notificationKind() resourceKind {
+	return &typedKind[domain.Notification]{
+		manifestKind: domain.ManifestKindNotification,
+		keyField:     "name",
+		list: func(ctx context.Context) ([]domain.Notification, error) {
+			notifications, _, err := s.notifications.Find(ctx, domain.NotificationQueryParams{})
+			return notifications, err
+		},
+		id: func(n domain.Notification) int64 { return int64(n.ID) },
+		encode: func(_ *resolver, n domain.Notification) (domain.ManifestResource, error) {
+			return toResource(notificationFields(n), "id", "used_by_filters", "created_at", "updated_at")
+		},
+		decode: func(_ *resolver, res domain.ManifestResource) (domain.Notification, error) {
+			var n notificationFields
+			err := fromResource(res, &n)
+			return domain.Notification(n), err
+		},
+		store: func(ctx context.Context, n domain.Notification) (int64, error) {
+			err := s.notifications.Store(ctx, &n)
+			return int64(n.ID), err
+		},
+		save: func(ctx context.Context, id int64, n domain.Notification) error {
+			n.ID = int(id)
+			return s.notifications.Update(ctx, &n)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.notifications.Delete(ctx, int(id))
+		},
+	}
+}
+
+func (s *service) duplicateProfileKind() resourceKind {
+	return &typedKind[*domain.DuplicateReleaseProfile]{
+		manifestKind: domain.ManifestKindDuplicateProfile,
+		keyField:     "name",
+		list:         s.duplicateProfiles.FindDuplicateReleaseProfiles,
+		id:           func(p *domain.DuplicateReleaseProfile) int64 { return p.ID },
+		encode: func(_ *resolver, p *domain.DuplicateReleaseProfile) (domain.ManifestResource, error) {
+			return toResource(p, "id")
+		},
+		decode: func(_ *resolver, res domain.ManifestResource) (*domain.DuplicateReleaseProfile, error) {
+			var p domain.DuplicateReleaseProfile
+			err := fromResource(res, &p)
+			return &p, err
+		},
+		store: func(ctx context.Context, p *domain.DuplicateReleaseProfile) (int64, error) {
+			p.ID = 0
+			err := s.duplicateProfiles.StoreDuplicateProfile(ctx, p)
+			return p.ID, err
+		},
+		save: func(ctx context.Context, id int64, p *domain.DuplicateReleaseProfile) error {
+			p.ID = id
+			return s.duplicateProfiles.StoreDuplicateProfile(ctx, p)
+		},
+		delete: s.duplicateProfiles.DeleteReleaseProfileDuplicate,
+	}
+}
+
+func (s *service) filterKind() resourceKind {
+	return &typedKind[*domain.Filter]{
+		manifestKind: domain.ManifestKindFilter,
+		keyField:     "name",
+		list: func(ctx context.Context) ([]*domain.Filter, error) {
+			listed, err := s.filters.ListFilters(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			filters := make([]*domain.Filter, 0, len(listed))
+			for _, f := range listed {
+				filter, err := s.filters.FindByID(ctx, f.ID)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not find filter: %s", f.Name)
+				}
+				filters = append(filters, filter)
+			}
+
+			return filters, nil
+		},
+		id:     func(f *domain.Filter) int64 { return int64(f.ID) },
+		encode: encodeFilter,
+		decode: decodeFilter,
+		store: func(ctx context.Context, f *domain.Filter) (int64, error) {
+			if err := s.filters.Store(ctx, f); err != nil {
+				return 0, err
+			}
+
+			// storing only creates the filter, the connected indexers, actions
+			// and notifications are stored on update
+			if err := s.filters.Update(ctx, f); err != nil {
+				return 0, err
+			}
+
+			return int64(f.ID), nil
+		},
+		save: func(ctx context.Context, id int64, f *domain.Filter) error {
+			f.ID = int(id)
+
+			// keep the ids of existing actions so their history stays connected
+			current, err := s.filters.FindByID(ctx, f.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, action := range f.Actions {
+				idx := slices.IndexFunc(current.Actions, func(a *domain.Action) bool {
+					return a.Name == action.Name
+				})
+				if idx != -1 {
+					action.ID = current.Actions[idx].ID
+				}
+			}
+
+			return s.filters.Update(ctx, f)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.filters.Delete(ctx, int(id))
+		},
+	}
+}
+
+var filterOmitFields = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"actions_count",
+	"actions_enabled_count",
+	"is_auto_updated",
+	"downloads",
+	"release_profile_duplicate",
+	"release_profile_duplicate_id",
+	"indexers",
+	"actions",
+	"external",
+	"notifications",
+}
+
+func encodeFilter(refs *resolver, f *domain.Filter) (domain.ManifestResource, error) {
+	res, err := toResource(f, filterOmitFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	res["duplicate_profile"] = refs.name(domain.ManifestKindDuplicateProfile, f.ReleaseProfileDuplicateID)
+
+	indexers := make([]string, 0, len(f.Indexers))
+	for _, indexer := range f.Indexers {
+		indexers = append(indexers, indexer.Identifier)
+	}
+	slices.Sort(indexers)
+	res["indexers"] = indexers
+
+	actions := make([]any, 0, len(f.Actions))
+	for _, action := range f.Actions {
+		a, err := toResource(action, "id", "filter_id", "client_id", "failover_client_ids", "client")
+		if err != nil {
+			return nil, err
+		}
+
+		a["client"] = refs.name(domain.ManifestKindDownloadClient, int64(action.ClientID))
+
+		failover := make([]string, 0, len(action.FailoverClientIDs))
+		for _, id := range action.FailoverClientIDs {
+			failover = append(failover, refs.name(domain.ManifestKindDownloadClient, int64(id)))
+		}
+		a["failover_clients"] = failover
+
+		actions = append(actions, map[string]any(a))
+	}
+	res["actions"] = actions
+
+	external := make([]any, 0, len(f.External))
+	for _, e := range f.External {
+		ext, err := toResource(e, "id")
+		if err != nil {
+			return nil, err
+		}
+		external = append(external, map[string]any(ext))
+	}
+	res["external"] = external
+
+	notifications := make([]any, 0, len(f.Notifications))
+	for _, n := range f.Notifications {
+		events := slices.Clone(n.Events)
+		slices.Sort(events)
+
+		notifications = append(notifications, map[string]any{
+			"notification": refs.name(domain.ManifestKindNotification, int64(n.NotificationID)),
+			"events":       events,
+		})
+	}
+	slices.SortFunc(notifications, func(a, b any) int {
+		return strings.Compare(a.(map[string]any)["notification"].(string), b.(map[string]any)["notification"].(string))
+	})
+	res["notifications"] = notifications
+
+	// normalize the nested values to their json format
+	return toResource(res)
+}
+
+func decodeFilter(refs *resolver, res domain.ManifestResource) (*domain.Filter, error) {
+	var f domain.Filter
+	if err := fromResource(res, &f, "duplicate_profile", "indexers", "actions", "external", "notifications"); err != nil {
+		return nil, err
+	}
+
+	profile, err := stringField(res, "duplicate_profile")
+	if err != nil {
+		return nil, err
+	}
+
+	if f.ReleaseProfileDuplicateID, err = refs.id(domain.ManifestKindDuplicateProfile, profile); err != nil {
+		return nil, err
+	}
+
+	indexers, err := stringsField(res, "indexers")
+	if err != nil {
+		return nil, err
+	}
+
+	f.Indexers = make([]domain.Indexer, 0, len(indexers))
+	for _, identifier := range indexers {
+		id, err := refs.id(domain.ManifestKindIndexer, identifier)
+		if err != nil {
+			return nil, err
+		}
+		f.Indexers = append(f.Indexers, domain.Indexer{ID: id, Identifier: identifier})
+	}
+
+	actions, err := resourcesField(res, "actions")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range actions {
+		var action domain.Action
+		if err := fromResource(a, &action, "client", "failover_clients"); err != nil {
+			return nil, errors.Wrap(err, "invalid action")
+		}
+		action.ID = 0
+		action.FilterID = 0
+		emptySlices(&action)
+
+		client, err := stringField(a, "client")
+		if err != nil {
+			return nil, err
+		}
+
+		clientID, err := refs.id(domain.ManifestKindDownloadClient, client)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid action: %s", action.Name)
+		}
+		action.ClientID = int32(clientID)
+
+		failover, err := stringsField(a, "failover_clients")
+		if err != nil {
+			return nil, err
+		}
+
+		failoverIDs, err := resolveNames(refs, domain.ManifestKindDownloadClient, failover)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid action: %s", action.Name)
+		}
+
+		action.FailoverClientIDs = make([]int32, 0, len(failoverIDs))
+		for _, id := range failoverIDs {
+			action.FailoverClientIDs = append(action.FailoverClientIDs, int32(id))
+		}
+
+		f.Actions = append(f.Actions, &action)
+	}
+
+	external, err := resourcesField(res, "external")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range external {
+		var ext domain.FilterExternal
+		if err := fromResource(e, &ext); err != nil {
+			return nil, errors.Wrap(err, "invalid external filter")
+		}
+		ext.ID = 0
+		f.External = append(f.External, ext)
+	}
+
+	notifications, err := resourcesField(res, "notifications")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range notifications {
+		name, err := stringField(n, "notification")
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := refs.id(domain.ManifestKindNotification, name)
+		if err != nil {
+			return nil, err
+		}
+
+		events, err := stringsField(n, "events")
+		if err != nil {
+			return nil, err
+		}
+
+		f.Notifications = append(f.Notifications, domain.FilterNotification{NotificationID: int(id), Events: events})
+	}
+
+	emptySlices(&f)
+
+	// match what the filter service stores
+	if len(f.AnnounceTypes) == 0 {
+		f.AnnounceTypes = []string{string(domain.AnnounceTypeNew)}
+	}
+
+	if err := f.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (s *service) listKind() resourceKind {
+	return &typedKind[*domain.List]{
+		manifestKind: domain.ManifestKindList,
+		keyField:     "name",
+		list:         s.lists.List,
+		id:           func(l *domain.List) int64 { return l.ID },
+		encode: func(refs *resolver, l *domain.List) (domain.ManifestResource, error) {
+			res, err := toResource(listFields(*l), "id", "client_id", "filters", "last_refresh_time", "last_refresh_error", "last_refresh_status", "created_at", "updated_at")
+			if err != nil {
+				return nil, err
+			}
+
+			res["client"] = refs.name(domain.ManifestKindDownloadClient, int64(l.ClientID))
+
+			filters := make([]string, 0, len(l.Filters))
+			for _, f := range l.Filters {
+				filters = append(filters, refs.name(domain.ManifestKindFilter, int64(f.ID)))
+			}
+			slices.Sort(filters)
+			res["filters"] = filters
+
+			return toResource(res)
+		},
+		decode: func(refs *resolver, res domain.ManifestResource) (*domain.List, error) {
+			var l listFields
+			if err := fromResource(res, &l, "client", "filters"); err != nil {
+				return nil, err
+			}
+
+			client, err := stringField(res, "client")
+			if err != nil {
+				return nil, err
+			}
+
+			clientID, err := refs.id(domain.ManifestKindDownloadClient, client)
+			if err != nil {
+				return nil, err
+			}
+			l.ClientID = int(clientID)
+
+			filters, err := stringsField(res, "filters")
+			if err != nil {
+				return nil, err
+			}
+
+			filterIDs, err := resolveNames(refs, domain.ManifestKindFilter, filters)
+			if err != nil {
+				return nil, err
+			}
+
+			l.Filters = make([]domain.ListFilter, 0, len(filterIDs))
+			for i, id := range filterIDs {
+				l.Filters = append(l.Filters, domain.ListFilter{ID: int(id), Name: filters[i]})
+			}
+
+			list := domain.List(l)
+			return &list, nil
+		},
+		store: func(ctx context.Context, l *domain.List) (int64, error) {
+			err := s.lists.Store(ctx, l)
+			return l.ID, err
+		},
+		save: func(ctx context.Context, id int64, l *domain.List) error {
+			l.ID = id
+			return s.lists.Update(ctx, l)
+		},
+		delete: s.lists.Delete,
+	}
+}
+
+func (s *service) cleanupJobKind() resourceKind {
+	return &typedKind[*domain.ReleaseCleanupJob]{
+		manifestKind: domain.ManifestKindCleanupJob,
+		keyField:     "name",
+		list:         s.cleanupJobs.ListCleanupJobs,
+		id:           func(j *domain.ReleaseCleanupJob) int64 { return int64(j.ID) },
+		encode: func(_ *resolver, j *domain.ReleaseCleanupJob) (domain.ManifestResource, error) {
+			return toResource(j, "id", "last_run", "last_run_status", "last_run_data", "next_run", "created_at", "updated_at")
+		},
+		decode: func(_ *resolver, res domain.ManifestResource) (*domain.ReleaseCleanupJob, error) {
+			var j domain.ReleaseCleanupJob
+			err := fromResource(res, &j)
+			return &j, err
+		},
+		store: func(ctx context.Context, j *domain.ReleaseCleanupJob) (int64, error) {
+			err := s.cleanupJobs.StoreCleanupJob(ctx, j)
+			return int64(j.ID), err
+		},
+		save: func(ctx context.Context, id int64, j *domain.ReleaseCleanupJob) error {
+			j.ID = int(id)
+			return s.cleanupJobs.UpdateCleanupJob(ctx, j)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return s.cleanupJobs.DeleteCleanupJob(ctx, int(id))
+		},
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+var envNameInvalidChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// secretFunc returns the value to replace a secret with, current holds the
+// value at the same path of the current resource if there is one.
+type secretFunc func(path []string, value string, current any) (string, error)
+
+// walkSecrets calls fn for every non-empty secret string of the resource and
+// returns a copy of the resource with the replaced values. Secrets are found by
+// field name like in the audit log, items of a list use the name of the list.
+func walkSecrets(res domain.ManifestResource, current domain.ManifestResource, fn secretFunc) (domain.ManifestResource, error) {
+	// work on a copy in its json format
+	res, err := toResource(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var cur any
+	if current != nil {
+		cur = map[string]any(current)
+	}
+
+	walked, err := walkSecretValue(map[string]any(res), cur, nil, "", fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return walked.(map[string]any), nil
+}
+
+func walkSecretValue(value any, current any, path []string, field string, fn secretFunc) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		currentMap, _ := current.(map[string]any)
+		for key, item := range v {
+			walked, err := walkSecretValue(item, currentMap[key], append(path, key), key, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = walked
+		}
+		return v, nil
+
+	case []any:
+		currentList, _ := current.([]any)
+		for i, item := range v {
+			segment, currentItem := listItem(item, i, currentList)
+
+			walked, err := walkSecretValue(item, currentItem, append(path, segment), field, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = walked
+		}
+		return v, nil
+
+	case string:
+		if v == "" || !domain.IsSecretField(field) {
+			return v, nil
+		}
+		return fn(path, v, current)
+
+	default:
+		return v, nil
+	}
+}
+
+// listItem returns the path segment of a list item and the matching current item.
+// Items with a name like irc channels are matched by name, others by index.
+func listItem(item any, index int, current []any) (string, any) {
+	if m, ok := item.(map[string]any); ok {
+		if name, ok := m["name"].(string); ok && name != "" {
+			for _, c := range current {
+				if cm, ok := c.(map[string]any); ok && cm["name"] == name {
+					return name, c
+				}
+			}
+			return name, nil
+		}
+	}
+
+	if index < len(current) {
+		return strconv.Itoa(index), current[index]
+	}
+
+	return strconv.Itoa(index), nil
+}
+
+// exportSecrets redacts or references the secrets of a resource. Secrets
+// already pointing to an env: or file: reference are exported as is.
+func exportSecrets(kind resourceKind, name string, res domain.ManifestResource, mode domain.ManifestSecrets) (domain.ManifestResource, error) {
+	if mode == domain.ManifestSecretsInclude {
+		return res, nil
+	}
+
+	return walkSecrets(res, nil, func(path []string, value string, _ any) (string, error) {
+		if domain.IsSecretReference(value) {
+			return value, nil
+		}
+
+		if mode == domain.ManifestSecretsReference {
+			return domain.SecretRefEnv + secretEnvName(kind.kind(), name, path), nil
+		}
+
+		return domain.RedactedStr, nil
+	})
+}
+
+// resolveSecrets resolves env: and file: references of secrets and keeps the
+// current value of redacted secrets. Secrets equal to the current value are
+// kept as is, so stored references stay references.
+func resolveSecrets(res domain.ManifestResource, current domain.ManifestResource) (domain.ManifestResource, error) {
+	return walkSecrets(res, current, func(path []string, value string, currentValue any) (string, error) {
+		existing, _ := currentValue.(string)
+
+		if domain.IsRedactedString(value) {
+			if existing == "" {
+				return "", errors.New("secret %s is redacted and has no current value", strings.Join(path, "."))
+			}
+			return existing, nil
+		}
+
+		if value == existing {
+			return value, nil
+		}
+
+		resolved, err := domain.ResolveSecret(value)
+		if err != nil {
+			return "", errors.Wrap(err, "could not resolve secret %s", strings.Join(path, "."))
+		}
+
+		return resolved, nil
+	})
+}
+
+// secretEnvName builds the environment variable name for a referenced secret like
+// AUTOBRR_SECRET_DOWNLOAD_CLIENT_QBITTORRENT_PASSWORD, it starts with the
+// allowed prefix so the reference resolves.
+func secretEnvName(kind domain.ManifestKind, name string, path []string) string {
+	parts := append([]string{string(kind), name}, path...)
+
+	envName := envNameInvalidChars.ReplaceAllString(strings.ToUpper(strings.Join(parts, "_")), "_")
+
+	return domain.SecretEnvPrefix() + strings.Trim(envName, "_")
+}
@@ -0,0 +1,410 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+)
+
+type proxyService interface {
+	List(ctx context.Context) ([]domain.Proxy, error)
+	Store(ctx context.Context, p *domain.Proxy) error
+	Update(ctx context.Context, p *domain.Proxy) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type downloadClientService interface {
+	List(ctx context.Context) ([]domain.DownloadClient, error)
+	Store(ctx context.Context, client *domain.DownloadClient) error
+	Update(ctx context.Context, client *domain.DownloadClient) error
+	Delete(ctx context.Context, clientID int32) error
+}
+
+type indexerService interface {
+	List(ctx context.Context) ([]domain.Indexer, error)
+	Store(ctx context.Context, indexer domain.Indexer) (*domain.Indexer, error)
+	Update(ctx context.Context, indexer domain.Indexer) (*domain.Indexer, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type ircService interface {
+	ListNetworks(ctx context.Context) ([]domain.IrcNetwork, error)
+	StoreNetwork(ctx context.Context, network *domain.IrcNetwork) error
+	UpdateNetwork(ctx context.Context, network *domain.IrcNetwork) error
+	DeleteNetwork(ctx context.Context, id int64) error
+}
+
+type feedService interface {
+	Find(ctx context.Context) ([]domain.Feed, error)
+	Store(ctx context.Context, feed *domain.Feed) error
+	Update(ctx context.Context, feed *domain.Feed) error
+	Delete(ctx context.Context, id int) error
+}
+
+type notificationService interface {
+	Find(ctx context.Context, params domain.NotificationQueryParams) ([]domain.Notification, int, error)
+	Store(ctx context.Context, notification *domain.Notification) error
+	Update(ctx context.Context, notification *domain.Notification) error
+	Delete(ctx context.Context, notificationID int) error
+}
+
+type duplicateProfileStore interface {
+	FindDuplicateReleaseProfiles(ctx context.Context) ([]*domain.DuplicateReleaseProfile, error)
+	StoreDuplicateProfile(ctx context.Context, profile *domain.DuplicateReleaseProfile) error
+	DeleteReleaseProfileDuplicate(ctx context.Context, id int64) error
+}
+
+type filterService interface {
+	ListFilters(ctx context.Context) ([]domain.Filter, error)
+	FindByID(ctx context.Context, filterID int) (*domain.Filter, error)
+	Store(ctx context.Context, filter *domain.Filter) error
+	Update(ctx context.Context, filter *domain.Filter) error
+	Delete(ctx context.Context, filterID int) error
+}
+
+type listService interface {
+	List(ctx context.Context) ([]*domain.List, error)
+	Store(ctx context.Context, list *domain.List) error
+	Update(ctx context.Context, list *domain.List) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type cleanupJobService interface {
+	ListCleanupJobs(ctx context.Context) ([]*domain.ReleaseCleanupJob, error)
+	StoreCleanupJob(ctx context.Context, job *domain.ReleaseCleanupJob) error
+	UpdateCleanupJob(ctx context.Context, job *domain.ReleaseCleanupJob) error
+	DeleteCleanupJob(ctx context.Context, id int) error
+}
+
+type Service interface {
+	Export(ctx context.Context, opts domain.ManifestExportOptions) (*domain.Manifest, error)
+	Plan(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error)
+	Apply(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error)
+}
+
+// Deps holds the services the manifest is applied through. The running
+// application passes its services so changes take effect right away,
+// autobrrctl passes repositories for offline use.
+type Deps struct {
+	Proxies           proxyService
+	DownloadClients   downloadClientService
+	Indexers          indexerService
+	IrcNetworks       ircService
+	Feeds             feedService
+	Notifications     notificationService
+	DuplicateProfiles duplicateProfileStore
+	Filters           filterService
+	Lists             listService
+	CleanupJobs       cleanupJobService
+}
+
+type service struct {
+	log zerolog.Logger
+
+	proxies           proxyService
+	downloadClients   downloadClientService
+	indexers          indexerService
+	ircNetworks       ircService
+	feeds             feedService
+	notifications     notificationService
+	duplicateProfiles duplicateProfileStore
+	filters           filterService
+	lists             listService
+	cleanupJobs       cleanupJobService
+}
+
+func NewService(log logger.Logger, deps Deps) Service {
+	return &service{
+		log:               log.With().Str("module", "manifest").Logger(),
+		proxies:           deps.Proxies,
+		downloadClients:   deps.DownloadClients,
+		indexers:          deps.Indexers,
+		ircNetworks:       deps.IrcNetworks,
+		feeds:             deps.Feeds,
+		notifications:     deps.Notifications,
+		duplicateProfiles: deps.DuplicateProfiles,
+		filters:           deps.Filters,
+		lists:             deps.Lists,
+		cleanupJobs:       deps.CleanupJobs,
+	}
+}
+
+func (s *service) Export(ctx context.Context, opts domain.ManifestExportOptions) (*domain.Manifest, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.Manifest{Version: domain.ManifestVersion}
+	refs := newResolver()
+
+	for _, kind := range s.resourceKinds() {
+		current, err := kind.current(ctx, refs)
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]domain.ManifestResource, 0, len(current))
+		for _, c := range current {
+			refs.add(kind.kind(), c.name, c.id)
+
+			res, err := exportSecrets(kind, c.name, c.resource, opts.Secrets)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not export %s: %s", kind.kind(), c.name)
+			}
+
+			resources = append(resources, res)
+		}
+
+		slices.SortStableFunc(resources, func(a, b domain.ManifestResource) int {
+			return strings.Compare(resourceName(a, kind.key()), resourceName(b, kind.key()))
+		})
+
+		manifest.SetSection(kind.kind(), resources)
+	}
+
+	s.log.Debug().Msgf("exported manifest with secrets: %s", opts.Secrets)
+
+	return manifest, nil
+}
+
+func (s *service) Plan(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error) {
+	plan, _, err := s.plan(ctx, manifest, opts)
+	return plan, err
+}
+
+func (s *service) Apply(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, error) {
+	plan, changes, err := s.plan(ctx, manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.Valid() || !plan.HasChanges() {
+		return plan, nil
+	}
+
+	refs := changes.refs
+
+	// create and update in dependency order, delete in reverse
+	for _, change := range changes.changes {
+		if change.Type == domain.ManifestChangeDelete {
+			continue
+		}
+
+		if err := s.applyChange(ctx, refs, change); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range slices.Backward(changes.changes) {
+		if change.Type != domain.ManifestChangeDelete {
+			continue
+		}
+
+		if err := s.applyChange(ctx, refs, change); err != nil {
+			return nil, err
+		}
+	}
+
+	plan.Applied = true
+
+	s.log.Info().Msgf("applied manifest with %d changes", len(plan.Changes))
+
+	return plan, nil
+}
+
+func (s *service) applyChange(ctx context.Context, refs *resolver, change plannedChange) error {
+	switch change.Type {
+	case domain.ManifestChangeCreate:
+		id, err := change.kind.create(ctx, refs, change.resource)
+		if err != nil {
+			return errors.Wrap(err, "could not create %s: %s", change.Kind, change.Name)
+		}
+		refs.add(change.Kind, change.Name, id)
+
+	case domain.ManifestChangeUpdate:
+		if err := change.kind.update(ctx, refs, change.id, change.resource); err != nil {
+			return errors.Wrap(err, "could not update %s: %s", change.Kind, change.Name)
+		}
+
+	case domain.ManifestChangeDelete:
+		if err := change.kind.remove(ctx, change.id); err != nil {
+			return errors.Wrap(err, "could not delete %s: %s", change.Kind, change.Name)
+		}
+	}
+
+	s.log.Debug().Msgf("%s %s: %s", change.Type, change.Kind, change.Name)
+
+	return nil
+}
+
+type plannedChange struct {
+	domain.ManifestChange
+
+	kind     resourceKind
+	id       int64
+	resource domain.ManifestResource
+}
+
+type plannedChanges struct {
+	refs    *resolver
+	changes []plannedChange
+}
+
+// plan compares the manifest with the current resources. Problems with single
+// resources are collected in the plan errors so all of them are reported at once.
+func (s *service) plan(ctx context.Context, manifest *domain.Manifest, opts domain.ManifestPlanOptions) (*domain.ManifestPlan, *plannedChanges, error) {
+	if err := manifest.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	kinds := s.resourceKinds()
+	refs := newResolver()
+
+	// load everything first so references to unmanaged sections resolve
+	current := make(map[domain.ManifestKind][]currentResource, len(kinds))
+	for _, kind := range kinds {
+		resources, err := kind.current(ctx, refs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, c := range resources {
+			refs.add(kind.kind(), c.name, c.id)
+		}
+
+		current[kind.kind()] = resources
+	}
+
+	plan := &domain.ManifestPlan{Changes: []domain.ManifestChange{}}
+	changes := &plannedChanges{refs: refs}
+
+	for _, kind := range kinds {
+		desired := manifest.Section(kind.kind())
+		if desired == nil {
+			continue
+		}
+
+		existing := make(map[string]currentResource, len(current[kind.kind()]))
+		for _, c := range current[kind.kind()] {
+			existing[c.name] = c
+		}
+
+		seen := make(map[string]bool, len(desired))
+		valid := make([]domain.ManifestResource, 0, len(desired))
+
+		for i, res := range desired {
+			name := resourceName(res, kind.key())
+			if name == "" {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s #%d: %s is required", kind.kind(), i+1, kind.key()))
+				continue
+			}
+
+			if seen[name] {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s %s: defined more than once", kind.kind(), name))
+				continue
+			}
+			seen[name] = true
+
+			if _, ok := existing[name]; !ok {
+				refs.addPlaceholder(kind.kind(), name)
+			}
+
+			valid = append(valid, res)
+		}
+
+		if opts.Prune {
+			for _, c := range current[kind.kind()] {
+				if seen[c.name] {
+					continue
+				}
+
+				// resources that are deleted can not be referenced anymore
+				refs.remove(kind.kind(), c.name)
+
+				changes.add(plan, plannedChange{
+					ManifestChange: domain.ManifestChange{Kind: kind.kind(), Name: c.name, Type: domain.ManifestChangeDelete},
+					kind:           kind,
+					id:             c.id,
+				})
+			}
+		}
+
+		for _, res := range valid {
+			name := resourceName(res, kind.key())
+			c, exists := existing[name]
+
+			resolved, err := resolveSecrets(res, c.resource)
+			if err != nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s %s: %v", kind.kind(), name, err))
+				continue
+			}
+
+			canonical, err := kind.canonical(refs, resolved)
+			if err != nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s %s: %v", kind.kind(), name, err))
+				continue
+			}
+
+			if !exists {
+				changes.add(plan, plannedChange{
+					ManifestChange: domain.ManifestChange{Kind: kind.kind(), Name: name, Type: domain.ManifestChangeCreate},
+					kind:           kind,
+					resource:       resolved,
+				})
+				continue
+			}
+
+			fields := changedFields(canonical, c.resource)
+			if len(fields) == 0 {
+				plan.Unchanged++
+				continue
+			}
+
+			changes.add(plan, plannedChange{
+				ManifestChange: domain.ManifestChange{Kind: kind.kind(), Name: name, Type: domain.ManifestChangeUpdate, Fields: fields},
+				kind:           kind,
+				id:             c.id,
+				resource:       resolved,
+			})
+		}
+	}
+
+	return plan, changes, nil
+}
+
+func (c *plannedChanges) add(plan *domain.ManifestPlan, change plannedChange) {
+	c.changes = append(c.changes, change)
+	plan.Changes = append(plan.Changes, change.ManifestChange)
+}
+
+// changedFields returns the top level fields that differ
+func changedFields(desired, current domain.ManifestResource) []string {
+	var fields []string
+
+	for key, value := range desired {
+		if !reflect.DeepEqual(value, current[key]) {
+			fields = append(fields, key)
+		}
+	}
+
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			fields = append(fields, key)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProxies struct {
+	proxies []domain.Proxy
+}
+
+func (m *mockProxies) List(_ context.Context) ([]domain.Proxy, error) {
+	return slices.Clone(m.proxies), nil
+}
+
+func (m *mockProxies) Store(_ context.Context, p *domain.Proxy) error {
+	p.ID = int64(len(m.proxies) + 100)
+	m.proxies = append(m.proxies, *p)
+	return nil
+}
+
+func (m *mockProxies) Update(_ context.Context, p *domain.Proxy) error {
+	for i := range m.proxies {
+		if m.proxies[i].ID == p.ID {
+			m.proxies[i] = *p
+			return nil
+		}
+	}
+	return domain.ErrRecordNotFound
+}
+
+func (m *mockProxies) Delete(_ context.Context, id int64) error {
+	m.proxies = slices.DeleteFunc(m.proxies, func(p domain.Proxy) bool { return p.ID == id })
+	return nil
+}
+
+type mockDownloadClients struct {
+	clients []domain.DownloadClient
+}
+
+func (m *mockDownloadClients) List(_ context.Context) ([]domain.DownloadClient, error) {
+	return slices.Clone(m.clients), nil
+}
+
+func (m *mockDownloadClients) Store(_ context.Context, c *domain.DownloadClient) error {
+	c.ID = int32(len(m.clients) + 100)
+	m.clients = append(m.clients, *c)
+	return nil
+}
+
+func (m *mockDownloadClients) Update(_ context.Context, c *domain.DownloadClient) error {
+	for i := range m.clients {
+		if m.clients[i].ID == c.ID {
+			m.clients[i] = *c
+			return nil
+		}
+	}
+	return domain.ErrRecordNotFound
+}
+
+func (m *mockDownloadClients) Delete(_ context.Context, id int32) error {
+	m.clients = slices.DeleteFunc(m.clients, func(c domain.DownloadClient) bool { return c.ID == id })
+	return nil
+}
+
+type mockIndexers struct {
+	indexers []domain.Indexer
+}
+
+func (m *mockIndexers) List(_ context.Context) ([]domain.Indexer, error) {
+	return slices.Clone(m.indexers), nil
+}
+
+func (m *mockIndexers) Store(_ context.Context, indexer domain.Indexer) (*domain.Indexer, error) {
+	indexer.ID = int64(len(m.indexers) + 100)
+	m.indexers = append(m.indexers, indexer)
+	return &indexer, nil
+}
+
+func (m *mockIndexers) Update(_ context.Context, indexer domain.Indexer) (*domain.Indexer, error) {
+	for i := range m.indexers {
+		if m.indexers[i].ID == indexer.ID {
+			m.indexers[i] = indexer
+			return &indexer, nil
+		}
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+func (m *mockIndexers) Delete(_ context.Context, id int) error {
+	m.indexers = slices.DeleteFunc(m.indexers, func(i domain.Indexer) bool { return i.ID == int64(id) })
+	return nil
+}
+
+type mockFilters struct {
+	filters []*domain.Filter
+}
+
+func (m *mockFilters) ListFilters(_ context.Context) ([]domain.Filter, error) {
+	filters := make([]domain.Filter, 0, len(m.filters))
+	for _, f := range m.filters {
+		filters = append(filters, domain.Filter{ID: f.ID, Name: f.Name})
+	}
+	return filters, nil
+}
+
+func (m *mockFilters) FindByID(_ context.Context, id int) (*domain.Filter, error) {
+	for _, f := range m.filters {
+		if f.ID == id {
+			filter := *f
+			return &filter, nil
+		}
+	}
+	return nil, domain.ErrRecordNotFound
+}
+
+func (m *mockFilters) Store(_ context.Context, f *domain.Filter) error {
+	f.ID = len(m.filters) + 100
+	filter := *f
+	m.filters = append(m.filters, &filter)
+	return nil
+}
+
+func (m *mockFilters) Update(_ context.Context, f *domain.Filter) error {
+	for i := range m.filters {
+		if m.filters[i].ID == f.ID {
+			filter := *f
+			m.filters[i] = &filter
+			return nil
+		}
+	}
+	return domain.ErrRecordNotFound
+}
+
+func (m *mockFilters) Delete(_ context.Context, id int) error {
+	m.filters = slices.DeleteFunc(m.filters, func(f *domain.Filter) bool { return f.ID == id })
+	return nil
+}
+
+// mockEmpty serves the kinds that are not part of the tests
+type mockEmpty struct{}
+
+var errReadOnly = errors.New("read only")
+
+func (mockEmpty) ListNetworks(_ context.Context) ([]domain.IrcNetwork, error) { return nil, nil }
+func (mockEmpty) StoreNetwork(_ context.Context, _ *domain.IrcNetwork) error  { return errReadOnly }
+func (mockEmpty) UpdateNetwork(_ context.Context, _ *domain.IrcNetwork) error { return errReadOnly }
+func (mockEmpty) DeleteNetwork(_ context.Context, _ int64) error              { return errReadOnly }
+func (mockEmpty) Find(_ context.Context, _ domain.NotificationQueryParams) ([]domain.Notification, int, error) {
+	return nil, 0, nil
+}
+func (mockEmpty) Store(_ context.Context, _ *domain.Notification) error  { return errReadOnly }
+func (mockEmpty) Update(_ context.Context, _ *domain.Notification) error { return errReadOnly }
+func (mockEmpty) Delete(_ context.Context, _ int) error                  { return errReadOnly }
+func (mockEmpty) FindDuplicateReleaseProfiles(_ context.Context) ([]*domain.DuplicateReleaseProfile, error) {
+	return nil, nil
+}
+func (mockEmpty) StoreDuplicateProfile(_ context.Context, _ *domain.DuplicateReleaseProfile) error {
+	return errReadOnly
+}
+func (mockEmpty) DeleteReleaseProfileDuplicate(_ context.Context, _ int64) error { return errReadOnly }
+func (mockEmpty) ListCleanupJobs(_ context.Context) ([]*domain.ReleaseCleanupJob, error) {
+	return nil, nil
+}
+func (mockEmpty) StoreCleanupJob(_ context.Context, _ *domain.ReleaseCleanupJob) error {
+	return errReadOnly
+}
+func (mockEmpty) UpdateCleanupJob(_ context.Context, _ *domain.ReleaseCleanupJob) error {
+	return errReadOnly
+}
+func (mockEmpty) DeleteCleanupJob(_ context.Context, _ int) error { return errReadOnly }
+
+type mockNotifications struct {
+	mockEmpty
+	notifications []domain.Notification
+}
+
+func (m *mockNotifications) Find(_ context.Context, _ domain.NotificationQueryParams) ([]domain.Notification, int, error) {
+	return m.notifications, len(m.notifications), nil
+}
+
+type mockEmptyFeeds struct{}
+
+func (mockEmptyFeeds) Find(_ context.Context) ([]domain.Feed, error)  { return nil, nil }
+func (mockEmptyFeeds) Store(_ context.Context, _ *domain.Feed) error  { return errReadOnly }
+func (mockEmptyFeeds) Update(_ context.Context, _ *domain.Feed) error { return errReadOnly }
+func (mockEmptyFeeds) Delete(_ context.Context, _ int) error          { return errReadOnly }
+
+type mockEmptyLists struct{}
+
+func (mockEmptyLists) List(_ context.Context) ([]*domain.List, error) { return nil, nil }
+func (mockEmptyLists) Store(_ context.Context, _ *domain.List) error  { return errReadOnly }
+func (mockEmptyLists) Update(_ context.Context, _ *domain.List) error { return errReadOnly }
+func (mockEmptyLists) Delete(_ context.Context, _ int64) error        { return errReadOnly }
+
+type testServices struct {
+	proxies *mockProxies
+	clients *mockDownloadClients
+	filters *mockFilters
+}
+
+func newTestService() (*service, *testServices) {
+	deps := &testServices{
+		proxies: &mockProxies{proxies: []domain.Proxy{
+			{ID: 1, Name: "socks", Enabled: true, Type: domain.ProxyTypeSocks5, Addr: "socks5://127.0.0.1:1080", User: "user", Pass: "secret"},
+		}},
+		clients: &mockDownloadClients{clients: []domain.DownloadClient{
+			{ID: 1, Name: "qbit", Type: domain.DownloadClientTypeQbittorrent, Enabled: true, Host: "http://127.0.0.1:8080", Username: "admin", Password: "hunter2"},
+		}},
+		filters: &mockFilters{filters: []*domain.Filter{
+			{
+				ID:            1,
+				Name:          "shows",
+				Enabled:       true,
+				Shows:         "That Show",
+				AnnounceTypes: []string{string(domain.AnnounceTypeNew)},
+				Indexers:      []domain.Indexer{{ID: 1, Identifier: "mock"}},
+				Actions: []*domain.Action{
+					{ID: 1, Name: "push", Type: domain.ActionTypeQbittorrent, Enabled: true, ClientID: 1, FilterID: 1},
+					{ID: 2, Name: "notify", Type: domain.ActionTypeWebhook, Enabled: true, FilterID: 1, WebhookHost: "http://127.0.0.1:9000", WebhookHeaders: []string{"Authorization=Bearer token"}},
+				},
+				External: []domain.FilterExternal{
+					{ID: 1, Name: "check", Type: domain.ExternalFilterTypeWebhook, Enabled: true, WebhookHost: "http://127.0.0.1:9001", WebhookHeaders: "X-Api-Key=key", ExecArgs: "--api-key key"},
+				},
+			},
+		}},
+	}
+
+	indexers := &mockIndexers{indexers: []domain.Indexer{
+		{ID: 1, Name: "Mock", Identifier: "mock", Enabled: true, Implementation: "irc", ProxyID: 1, UseProxy: true, Settings: map[string]string{"passkey": "abc", "uid": "1"}},
+	}}
+
+	s := NewService(logger.New(&domain.Config{LogLevel: "ERROR"}), Deps{
+		Proxies:         deps.proxies,
+		DownloadClients: deps.clients,
+		Indexers:        indexers,
+		IrcNetworks:     mockEmpty{},
+		Feeds:           mockEmptyFeeds{},
+		Notifications: &mockNotifications{notifications: []domain.Notification{
+			{ID: 1, Name: "discord", Type: domain.NotificationTypeDiscord, Enabled: true, Webhook: "https://discord.com/api/webhooks/1/token", Events: []string{"PUSH_APPROVED"}},
+		}},
+		DuplicateProfiles: mockEmpty{},
+		Filters:           deps.filters,
+		Lists:             mockEmptyLists{},
+		CleanupJobs:       mockEmpty{},
+	}).(*service)
+
+	return s, deps
+}
+
+func TestService_Export(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestService()
+
+	manifest, err := s.Export(context.Background(), domain.ManifestExportOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ManifestVersion, manifest.Version)
+	assert.NotNil(t, manifest.Feeds)
+
+	require.Len(t, manifest.Proxies, 1)
+	assert.Equal(t, domain.RedactedStr, manifest.Proxies[0]["pass"])
+	assert.Equal(t, "user", manifest.Proxies[0]["user"])
+	assert.NotContains(t, manifest.Proxies[0], "id")
+
+	require.Len(t, manifest.Indexers, 1)
+	assert.Equal(t, "socks", manifest.Indexers[0]["proxy"])
+	assert.Equal(t, map[string]any{"passkey": domain.RedactedStr, "uid": domain.RedactedStr}, manifest.Indexers[0]["settings"])
+
+	require.Len(t, manifest.Filters, 1)
+	assert.Equal(t, []any{"mock"}, manifest.Filters[0]["indexers"])
+	actions := manifest.Filters[0]["actions"].([]any)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "qbit", actions[0].(map[string]any)["client"])
+	assert.Equal(t, []any{domain.RedactedStr}, actions[1].(map[string]any)["webhook_headers"])
+	external := manifest.Filters[0]["external"].([]any)
+	require.Len(t, external, 1)
+	assert.Equal(t, domain.RedactedStr, external[0].(map[string]any)["webhook_headers"])
+	assert.Equal(t, domain.RedactedStr, external[0].(map[string]any)["exec_args"])
+
+	// webhook urls hold a token
+	require.Len(t, manifest.Notifications, 1)
+	assert.Equal(t, domain.RedactedStr, manifest.Notifications[0]["webhook"])
+	assert.NotContains(t, fmt.Sprint(manifest.Notifications[0]), "discord.com")
+
+	manifest, err = s.Export(context.Background(), domain.ManifestExportOptions{Secrets: domain.ManifestSecretsReference})
+	require.NoError(t, err)
+	assert.Equal(t, "env:AUTOBRR_SECRET_DOWNLOAD_CLIENT_QBIT_PASSWORD", manifest.DownloadClients[0]["password"])
+
+	manifest, err = s.Export(context.Background(), domain.ManifestExportOptions{Secrets: domain.ManifestSecretsInclude})
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", manifest.DownloadClients[0]["password"])
+}
+
+func TestService_Apply(t *testing.T) {
+	s, deps := newTestService()
+	ctx := context.Background()
+
+	manifest, err := s.Export(ctx, domain.ManifestExportOptions{})
+	require.NoError(t, err)
+
+	// an exported manifest matches the current state
+	plan, err := s.Plan(ctx, manifest, domain.ManifestPlanOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Errors)
+	assert.Empty(t, plan.Changes)
+	assert.Equal(t, 5, plan.Unchanged)
+
+	t.Setenv("AUTOBRR_SECRET_TEST_MANIFEST_DELUGE_PASSWORD", "from-env")
+
+	manifest.DownloadClients = append(manifest.DownloadClients, domain.ManifestResource{
+		"name":     "deluge",
+		"type":     string(domain.DownloadClientTypeDelugeV2),
+		"enabled":  true,
+		"host":     "127.0.0.1",
+		"password": "env:AUTOBRR_SECRET_TEST_MANIFEST_DELUGE_PASSWORD",
+	})
+
+	filter := manifest.Filters[0]
+	filter["min_size"] = "1GB"
+	filter["actions"].([]any)[0].(map[string]any)["failover_clients"] = []any{"deluge"}
+
+	plan, err = s.Plan(ctx, manifest, domain.ManifestPlanOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, []domain.ManifestChange{
+		{Kind: domain.ManifestKindDownloadClient, Name: "deluge", Type: domain.ManifestChangeCreate},
+		{Kind: domain.ManifestKindFilter, Name: "shows", Type: domain.ManifestChangeUpdate, Fields: []string{"actions", "min_size"}},
+	}, plan.Changes)
+
+	// planning does not change anything
+	assert.Len(t, deps.clients.clients, 1)
+
+	plan, err = s.Apply(ctx, manifest, domain.ManifestPlanOptions{})
+	require.NoError(t, err)
+	assert.True(t, plan.Applied)
+
+	require.Len(t, deps.clients.clients, 2)
+	deluge := deps.clients.clients[1]
+	assert.Equal(t, "from-env", deluge.Password)
+
+	// redacted secrets keep their value
+	assert.Equal(t, "hunter2", deps.clients.clients[0].Password)
+	assert.Equal(t, "secret", deps.proxies.proxies[0].Pass)
+
+	f := deps.filters.filters[0]
+	assert.Equal(t, "1GB", f.MinSize)
+	assert.NotNil(t, f.Resolutions)
+	require.Len(t, f.Actions, 2)
+	assert.Equal(t, 1, f.Actions[0].ID)
+	assert.Equal(t, []int32{deluge.ID}, f.Actions[0].FailoverClientIDs)
+	assert.Equal(t, []string{"Authorization=Bearer token"}, f.Actions[1].WebhookHeaders)
+	require.Len(t, f.External, 1)
+	assert.Equal(t, "X-Api-Key=key", f.External[0].WebhookHeaders)
+	assert.Equal(t, "--api-key key", f.External[0].ExecArgs)
+
+	plan, err = s.Plan(ctx, manifest, domain.ManifestPlanOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+}
+
+func TestService_Plan_Prune(t *testing.T) {
+	t.Parallel()
+
+	s, deps := newTestService()
+	ctx := context.Background()
+
+	manifest := &domain.Manifest{
+		Version: domain.ManifestVersion,
+		Proxies: []domain.ManifestResource{},
+	}
+
+	// the indexer still references the pruned proxy but it is not part of the manifest
+	plan, err := s.Apply(ctx, manifest, domain.ManifestPlanOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ManifestChange{
+		{Kind: domain.ManifestKindProxy, Name: "socks", Type: domain.ManifestChangeDelete},
+	}, plan.Changes)
+
+	assert.Empty(t, deps.proxies.proxies)
+	assert.Len(t, deps.clients.clients, 1)
+}
+
+func TestService_Plan_Errors(t *testing.T) {
+	t.Parallel()
+
+	s, deps := newTestService()
+	ctx := context.Background()
+
+	manifest := &domain.Manifest{
+		Version: domain.ManifestVersion,
+		DownloadClients: []domain.ManifestResource{
+			{"name": "new", "type": string(domain.DownloadClientTypeQbittorrent), "password": domain.RedactedStr},
+			{"name": "twice"},
+			{"name": "twice"},
+			{"host": "no name"},
+			{"name": "env", "type": string(domain.DownloadClientTypeQbittorrent), "password": "env:AUTOBRR__SECRET_KEY"},
+		},
+		Filters: []domain.ManifestResource{
+			{"name": "other", "indexers": []any{"unknown"}},
+			{"name": "typo", "min_sise": "1GB"},
+		},
+	}
+
+	plan, err := s.Apply(ctx, manifest, domain.ManifestPlanOptions{})
+	require.NoError(t, err)
+	assert.False(t, plan.Applied)
+	assert.Len(t, plan.Errors, 6)
+	assert.Contains(t, plan.Errors, "download_client new: secret password is redacted and has no current value")
+	assert.Contains(t, plan.Errors, "download_client twice: defined more than once")
+	assert.Contains(t, plan.Errors, "download_client #4: name is required")
+	assert.Contains(t, plan.Errors, "download_client env: could not resolve secret password: environment variable AUTOBRR__SECRET_KEY can not be referenced, only variables starting with AUTOBRR_SECRET_")
+	assert.Contains(t, plan.Errors, "filter other: unknown indexer: unknown")
+
+	assert.Len(t, deps.clients.clients, 1)
+	assert.Len(t, deps.filters.filters, 1)
+
+	_, err = s.Plan(ctx, &domain.Manifest{Version: domain.ManifestVersion + 1}, domain.ManifestPlanOptions{})
+	assert.Error(t, err)
+}
@@ -21,6 +21,9 @@ type ircCollector struct {
 	channelEnabledCount           *prometheus.Desc
 	channelMonitoringCount        *prometheus.Desc
 	channelLastAnnouncedTimestamp *prometheus.Desc
+	announceParseSuccessCount     *prometheus.Desc
+	announceParseFailureCount     *prometheus.Desc
+	announceParseFailing          *prometheus.Desc
 	errorMetric                   *prometheus.Desc
 }
 
@@ -32,6 +35,9 @@ func (collector *ircCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.channelEnabledCount
 	ch <- collector.channelMonitoringCount
 	ch <- collector.channelLastAnnouncedTimestamp
+	ch <- collector.announceParseSuccessCount
+	ch <- collector.announceParseFailureCount
+	ch <- collector.announceParseFailing
 	ch <- collector.errorMetric
 }
 
@@ -72,6 +78,16 @@ func (collector *ircCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(collector.channelCount, prometheus.GaugeValue, float64(len(n.Channels)), n.Name)
 		ch <- prometheus.MustNewConstMetric(collector.channelEnabledCount, prometheus.GaugeValue, float64(channelsEnabled), n.Name)
 		ch <- prometheus.MustNewConstMetric(collector.channelMonitoringCount, prometheus.GaugeValue, float64(channelsMonitoring), n.Name)
+
+		for _, p := range n.Parsers {
+			failing := 0
+			if p.Failing {
+				failing = 1
+			}
+			ch <- prometheus.MustNewConstMetric(collector.announceParseSuccessCount, prometheus.CounterValue, float64(p.Successes), n.Name, p.Indexer)
+			ch <- prometheus.MustNewConstMetric(collector.announceParseFailureCount, prometheus.CounterValue, float64(p.Failures), n.Name, p.Indexer)
+			ch <- prometheus.MustNewConstMetric(collector.announceParseFailing, prometheus.GaugeValue, float64(failing), n.Name, p.Indexer)
+		}
 	}
 	ch <- prometheus.MustNewConstMetric(collector.totalCount, prometheus.GaugeValue, float64(len(networks)))
 	ch <- prometheus.MustNewConstMetric(collector.enabledCount, prometheus.GaugeValue, float64(enabled))
@@ -130,6 +146,24 @@ func NewIRCCollector(ircService irc.Service) *ircCollector {
 			[]string{"network", "channel"},
 			nil,
 		),
+		announceParseSuccessCount: prometheus.NewDesc(
+			"autobrr_irc_announce_parse_success_total",
+			"Number of announces that matched the indexer definition",
+			[]string{"network", "indexer"},
+			nil,
+		),
+		announceParseFailureCount: prometheus.NewDesc(
+			"autobrr_irc_announce_parse_failure_total",
+			"Number of announces that did not match the indexer definition",
+			[]string{"network", "indexer"},
+			nil,
+		),
+		announceParseFailing: prometheus.NewDesc(
+			"autobrr_irc_announce_parse_failing",
+			"Whether announces of the indexer stopped matching its definition",
+			[]string{"network", "indexer"},
+			nil,
+		),
 		errorMetric: prometheus.NewDesc(
 			"autobrr_irc_collector_error",
 			"Error while collecting irc metrics",
@@ -5,20 +5,37 @@ package collector
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/release"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// breakdownCacheTTL is how long the breakdown is reused, it groups the whole release table
+const breakdownCacheTTL = time.Minute
+
 type releaseCollector struct {
 	releaseService release.Service
 
+	breakdownMu sync.Mutex
+	breakdown   *domain.ReleaseStatsBreakdown
+	breakdownAt time.Time
+
 	totalCount          *prometheus.Desc
 	filteredCount       *prometheus.Desc
 	filterRejectedCount *prometheus.Desc
 	pushApprovedCount   *prometheus.Desc
 	pushRejectedCount   *prometheus.Desc
 	pushErrorCount      *prometheus.Desc
+	queueDepth          *prometheus.Desc
+	queueInFlight       *prometheus.Desc
+	queueDelayed        *prometheus.Desc
+	queueWorkers        *prometheus.Desc
+	queueProcessed      *prometheus.Desc
+	releaseBreakdown    *prometheus.Desc
+	pushBreakdown       *prometheus.Desc
 	errorMetric         *prometheus.Desc
 }
 
@@ -29,10 +46,25 @@ func (collector *releaseCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.pushApprovedCount
 	ch <- collector.pushRejectedCount
 	ch <- collector.pushErrorCount
+	ch <- collector.queueDepth
+	ch <- collector.queueInFlight
+	ch <- collector.queueDelayed
+	ch <- collector.queueWorkers
+	ch <- collector.queueProcessed
+	ch <- collector.releaseBreakdown
+	ch <- collector.pushBreakdown
 	ch <- collector.errorMetric
 }
 
 func (collector *releaseCollector) Collect(ch chan<- prometheus.Metric) {
+	queue := collector.releaseService.QueueStats()
+
+	ch <- prometheus.MustNewConstMetric(collector.queueDepth, prometheus.GaugeValue, float64(queue.QueueDepth))
+	ch <- prometheus.MustNewConstMetric(collector.queueInFlight, prometheus.GaugeValue, float64(queue.InFlight))
+	ch <- prometheus.MustNewConstMetric(collector.queueDelayed, prometheus.GaugeValue, float64(queue.Delayed))
+	ch <- prometheus.MustNewConstMetric(collector.queueWorkers, prometheus.GaugeValue, float64(queue.Workers))
+	ch <- prometheus.MustNewConstMetric(collector.queueProcessed, prometheus.CounterValue, float64(queue.Processed))
+
 	stats, err := collector.releaseService.Stats(context.TODO())
 	if err != nil {
 		ch <- prometheus.NewInvalidMetric(collector.errorMetric, err)
@@ -45,6 +77,40 @@ func (collector *releaseCollector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(collector.pushApprovedCount, prometheus.GaugeValue, float64(stats.PushApprovedCount))
 	ch <- prometheus.MustNewConstMetric(collector.pushRejectedCount, prometheus.GaugeValue, float64(stats.PushRejectedCount))
 	ch <- prometheus.MustNewConstMetric(collector.pushErrorCount, prometheus.GaugeValue, float64(stats.PushErrorCount))
+
+	breakdown, err := collector.statsBreakdown(context.TODO())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(collector.errorMetric, err)
+		return
+	}
+
+	for _, c := range breakdown.Releases {
+		ch <- prometheus.MustNewConstMetric(collector.releaseBreakdown, prometheus.GaugeValue, float64(c.Count), c.Indexer, c.Filter, c.FilterStatus)
+	}
+
+	for _, c := range breakdown.Pushes {
+		ch <- prometheus.MustNewConstMetric(collector.pushBreakdown, prometheus.GaugeValue, float64(c.Count), c.Indexer, c.Filter, c.Action, c.Client, c.Status)
+	}
+}
+
+// statsBreakdown returns the cached breakdown while it is fresh so scrapes do not query the release table each time
+func (collector *releaseCollector) statsBreakdown(ctx context.Context) (*domain.ReleaseStatsBreakdown, error) {
+	collector.breakdownMu.Lock()
+	defer collector.breakdownMu.Unlock()
+
+	if collector.breakdown != nil && time.Since(collector.breakdownAt) < breakdownCacheTTL {
+		return collector.breakdown, nil
+	}
+
+	breakdown, err := collector.releaseService.StatsBreakdown(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collector.breakdown = breakdown
+	collector.breakdownAt = time.Now()
+
+	return breakdown, nil
 }
 
 func NewReleaseCollector(releaseService release.Service) *releaseCollector {
@@ -86,6 +152,48 @@ func NewReleaseCollector(releaseService release.Service) *releaseCollector {
 			nil,
 			nil,
 		),
+		queueDepth: prometheus.NewDesc(
+			"autobrr_release_queue_depth",
+			"Number of releases waiting in the processing queue",
+			nil,
+			nil,
+		),
+		queueInFlight: prometheus.NewDesc(
+			"autobrr_release_queue_in_flight",
+			"Number of releases currently being processed",
+			nil,
+			nil,
+		),
+		queueDelayed: prometheus.NewDesc(
+			"autobrr_release_queue_delayed",
+			"Number of matched releases waiting for the filter delay",
+			nil,
+			nil,
+		),
+		queueWorkers: prometheus.NewDesc(
+			"autobrr_release_queue_workers",
+			"Number of release processing workers",
+			nil,
+			nil,
+		),
+		queueProcessed: prometheus.NewDesc(
+			"autobrr_release_queue_processed_total",
+			"Number of release jobs processed since start",
+			nil,
+			nil,
+		),
+		releaseBreakdown: prometheus.NewDesc(
+			"autobrr_release_by_indexer",
+			"Number of stored releases by indexer, filter and filter status",
+			[]string{"indexer", "filter", "filter_status"},
+			nil,
+		),
+		pushBreakdown: prometheus.NewDesc(
+			"autobrr_release_push_by_status",
+			"Number of stored release pushes by indexer, filter, action, download client and push status",
+			[]string{"indexer", "filter", "action", "client", "status"},
+			nil,
+		),
 		errorMetric: prometheus.NewDesc(
 			"autobrr_release_collector_error",
 			"Error while collecting release metrics",
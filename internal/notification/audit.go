@@ -0,0 +1,25 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// notificationAudit records notifications without the filters using them, those are recorded with the filter
+var notificationAudit = audit.Resource[domain.Notification]{
+	Type: domain.AuditResourceNotification,
+	Key: func(n *domain.Notification) (string, string) {
+		return strconv.Itoa(n.ID), n.Name
+	},
+	View: func(n *domain.Notification) any {
+		view := *n
+		view.UsedByFilters = nil
+
+		return &view
+	},
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+)
+
+type Digester interface {
+	FlushDigests(ctx context.Context) error
+}
+
+// setLimits tracks the rate limit and digest settings of a notification
+func (s *Service) setLimits(notification *domain.Notification) {
+	s.limitsLock.Lock()
+	defer s.limitsLock.Unlock()
+
+	if s.limits == nil {
+		s.limits = make(map[int]*domain.Notification)
+	}
+
+	if notification.RateLimit > 0 || notification.DigestInterval > 0 {
+		s.limits[notification.ID] = notification
+		return
+	}
+
+	delete(s.limits, notification.ID)
+}
+
+func (s *Service) getLimits(notificationID int) *domain.Notification {
+	s.limitsLock.Lock()
+	defer s.limitsLock.Unlock()
+
+	return s.limits[notificationID]
+}
+
+// shouldSendNow returns false when the event is collected for the next digest,
+// either because digests are enabled for the event or the rate limit is reached.
+// Events are sent right away if they can not be stored.
+func (s *Service) shouldSendNow(ctx context.Context, notificationID int, event domain.NotificationEvent, payload domain.NotificationPayload) bool {
+	settings := s.getLimits(notificationID)
+	if settings == nil {
+		return true
+	}
+
+	if settings.DigestEnabled(event) {
+		return !s.collect(ctx, notificationID, event, payload, false)
+	}
+
+	if settings.RateLimit <= 0 {
+		return true
+	}
+
+	allowed, err := s.allowSend(ctx, notificationID, settings.RateLimit)
+	if err != nil {
+		s.log.Error().Err(err).Int("notification_id", notificationID).Msg("could not check notification rate limit")
+		return true
+	}
+
+	if allowed {
+		return true
+	}
+
+	s.log.Debug().Str("event", string(event)).Int("notification_id", notificationID).Msg("notification rate limit reached, event added to digest")
+
+	return !s.collect(ctx, notificationID, event, payload, true)
+}
+
+// allowSend records a sent message if the notification is below its rate limit.
+// Sent messages are stored so the limit holds across restarts.
+func (s *Service) allowSend(ctx context.Context, notificationID int, limit int) (bool, error) {
+	s.limitsLock.Lock()
+	defer s.limitsLock.Unlock()
+
+	now := time.Now()
+
+	count, err := s.repo.CountSent(ctx, notificationID, now.Add(-domain.NotificationRateLimitWindow))
+	if err != nil {
+		return false, err
+	}
+
+	if count >= limit {
+		return false, nil
+	}
+
+	if err := s.repo.StoreSent(ctx, notificationID, now); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// collect stores the event for the next digest and reports whether it was stored
+func (s *Service) collect(ctx context.Context, notificationID int, event domain.NotificationEvent, payload domain.NotificationPayload, rateLimited bool) bool {
+	item := domain.NewNotificationDigestItem(notificationID, event, payload, rateLimited)
+
+	if err := s.repo.StoreDigestItem(ctx, item); err != nil {
+		s.log.Error().Err(err).Int("notification_id", notificationID).Msg("could not store notification digest item")
+		return false
+	}
+
+	return true
+}
+
+// FlushDigests sends a digest for every notification whose oldest collected event
+// is older than its digest window. Items are only deleted once the digest is sent.
+func (s *Service) FlushDigests(ctx context.Context) error {
+	items, err := s.repo.FindDigestItems(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not find notification digest items")
+	}
+
+	now := time.Now()
+
+	// items are ordered by notification
+	for start := 0; start < len(items); {
+		end := start
+		for end < len(items) && items[end].NotificationID == items[start].NotificationID {
+			end++
+		}
+
+		group := items[start:end]
+		start = end
+
+		notificationID := group[0].NotificationID
+		lastID := group[len(group)-1].ID
+
+		sender, ok := s.senders[notificationID]
+		if !ok {
+			// the notification was disabled, drop what was collected
+			if err := s.repo.DeleteDigestItems(ctx, notificationID, lastID); err != nil {
+				s.log.Error().Err(err).Int("notification_id", notificationID).Msg("could not delete notification digest items")
+			}
+			continue
+		}
+
+		// items left after digests were turned off are sent right away
+		if settings := s.getLimits(notificationID); settings != nil && now.Sub(group[0].CreatedAt) < settings.DigestWindow() {
+			continue
+		}
+
+		digest := domain.NewNotificationDigest(group)
+
+		payload := domain.NotificationPayload{
+			Subject:   "Notification digest",
+			Message:   digest.String(),
+			Event:     domain.NotificationEventDigest,
+			Timestamp: now,
+			Digest:    digest,
+		}
+
+		s.log.Debug().Str("sender", sender.Name()).Int("events", digest.Total).Msg("sending notification digest")
+
+		if err := sender.Send(domain.NotificationEventDigest, payload); err != nil {
+			s.log.Error().Err(err).Msgf("could not send %s notification digest", sender.Name())
+			continue
+		}
+
+		if err := s.repo.DeleteDigestItems(ctx, notificationID, lastID); err != nil {
+			s.log.Error().Err(err).Int("notification_id", notificationID).Msg("could not delete notification digest items")
+		}
+	}
+
+	if err := s.repo.DeleteSentBefore(ctx, now.Add(-domain.NotificationRateLimitWindow)); err != nil {
+		return errors.Wrap(err, "could not prune sent notifications")
+	}
+
+	return nil
+}
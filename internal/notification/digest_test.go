@@ -0,0 +1,213 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// digestRepo keeps digest items and sent messages in memory, the other repo methods are not used
+type digestRepo struct {
+	domain.NotificationRepo
+
+	mu     sync.Mutex
+	nextID int64
+	items  []domain.NotificationDigestItem
+	sent   map[int][]time.Time
+}
+
+func (r *digestRepo) StoreDigestItem(_ context.Context, item *domain.NotificationDigestItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	item.ID = r.nextID
+	r.items = append(r.items, *item)
+	return nil
+}
+
+func (r *digestRepo) FindDigestItems(_ context.Context) ([]domain.NotificationDigestItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.NotificationDigestItem(nil), r.items...), nil
+}
+
+func (r *digestRepo) DeleteDigestItems(_ context.Context, notificationID int, maxID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.items[:0]
+	for _, item := range r.items {
+		if item.NotificationID == notificationID && item.ID <= maxID {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	r.items = kept
+	return nil
+}
+
+func (r *digestRepo) StoreSent(_ context.Context, notificationID int, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sent[notificationID] = append(r.sent[notificationID], sentAt)
+	return nil
+}
+
+func (r *digestRepo) CountSent(_ context.Context, notificationID int, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, t := range r.sent[notificationID] {
+		if t.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *digestRepo) DeleteSentBefore(_ context.Context, before time.Time) error {
+	return nil
+}
+
+// age moves all collected items back in time
+func (r *digestRepo) age(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.items {
+		r.items[i].CreatedAt = r.items[i].CreatedAt.Add(-d)
+	}
+}
+
+func newDigestTestService(sender domain.NotificationSender, settings *domain.Notification) (*Service, *digestRepo) {
+	repo := &digestRepo{sent: map[int][]time.Time{}}
+
+	svc := &Service{
+		log:     zerolog.Nop(),
+		repo:    repo,
+		senders: map[int]domain.NotificationSender{settings.ID: sender},
+	}
+	svc.setLimits(settings)
+
+	return svc, repo
+}
+
+func TestService_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	sender := new(mockSender)
+
+	svc, repo := newDigestTestService(sender, &domain.Notification{ID: 1, Enabled: true, RateLimit: 2})
+
+	payload := domain.NotificationPayload{Event: domain.NotificationEventPushRejected, Filter: "TV", Indexer: "MockIndexer", Rejections: []string{"size"}}
+
+	assert.True(t, svc.shouldSendNow(ctx, 1, payload.Event, payload))
+	assert.True(t, svc.shouldSendNow(ctx, 1, payload.Event, payload))
+	assert.False(t, svc.shouldSendNow(ctx, 1, payload.Event, payload))
+
+	require.Len(t, repo.items, 1)
+	assert.True(t, repo.items[0].RateLimited)
+
+	// other notifications are not limited
+	assert.True(t, svc.shouldSendNow(ctx, 2, payload.Event, payload))
+
+	// held back events are sent once the rate limit window passed
+	require.NoError(t, svc.FlushDigests(ctx))
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+	repo.age(domain.NotificationRateLimitWindow)
+
+	sender.On("Send", domain.NotificationEventDigest, mock.MatchedBy(func(p domain.NotificationPayload) bool {
+		return p.Digest != nil && p.Digest.Total == 1 && p.Digest.RateLimited == 1
+	})).Return(nil).Once()
+
+	require.NoError(t, svc.FlushDigests(ctx))
+	sender.AssertExpectations(t)
+	assert.Empty(t, repo.items)
+}
+
+func TestService_Digest(t *testing.T) {
+	ctx := context.Background()
+	sender := new(mockSender)
+
+	svc, repo := newDigestTestService(sender, &domain.Notification{
+		ID:             1,
+		Enabled:        true,
+		DigestInterval: 15,
+		DigestEvents:   []string{string(domain.NotificationEventPushRejected), string(domain.NotificationEventReleaseNew)},
+	})
+
+	rejected := domain.NotificationPayload{Event: domain.NotificationEventPushRejected, Filter: "TV", Indexer: "MockIndexer", Rejections: []string{"size"}}
+	released := domain.NotificationPayload{Event: domain.NotificationEventReleaseNew, Filter: "Movies", Indexer: "MockIndexer"}
+	approved := domain.NotificationPayload{Event: domain.NotificationEventPushApproved, Filter: "TV", Indexer: "MockIndexer"}
+
+	assert.False(t, svc.shouldSendNow(ctx, 1, rejected.Event, rejected))
+	assert.False(t, svc.shouldSendNow(ctx, 1, rejected.Event, rejected))
+	assert.False(t, svc.shouldSendNow(ctx, 1, released.Event, released))
+	assert.True(t, svc.shouldSendNow(ctx, 1, approved.Event, approved))
+
+	require.Len(t, repo.items, 3)
+
+	// the window is not over yet
+	require.NoError(t, svc.FlushDigests(ctx))
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+	repo.age(15 * time.Minute)
+
+	var sent domain.NotificationPayload
+	sender.On("Send", domain.NotificationEventDigest, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).(domain.NotificationPayload)
+	}).Return(nil).Once()
+
+	require.NoError(t, svc.FlushDigests(ctx))
+	sender.AssertExpectations(t)
+
+	require.NotNil(t, sent.Digest)
+	assert.Equal(t, 3, sent.Digest.Total)
+	assert.Equal(t, []domain.NotificationDigestCount{{Name: "TV", Count: 2}, {Name: "Movies", Count: 1}}, sent.Digest.Filters)
+	assert.Equal(t, []domain.NotificationDigestCount{{Name: "size", Count: 2}}, sent.Digest.Rejections)
+	assert.Contains(t, sent.Message, "3 events between")
+	assert.Empty(t, repo.items)
+}
+
+func TestService_Digest_SendError(t *testing.T) {
+	ctx := context.Background()
+	sender := new(mockSender)
+
+	svc, repo := newDigestTestService(sender, &domain.Notification{
+		ID:             1,
+		Enabled:        true,
+		DigestInterval: 5,
+		DigestEvents:   []string{string(domain.NotificationEventPushRejected)},
+	})
+
+	payload := domain.NotificationPayload{Event: domain.NotificationEventPushRejected}
+	assert.False(t, svc.shouldSendNow(ctx, 1, payload.Event, payload))
+
+	repo.age(5 * time.Minute)
+
+	sender.On("Send", domain.NotificationEventDigest, mock.Anything).Return(assert.AnError).Once()
+
+	// items are kept for the next run
+	require.NoError(t, svc.FlushDigests(ctx))
+	assert.Len(t, repo.items, 1)
+
+	// items of disabled notifications are dropped
+	delete(svc.senders, 1)
+	require.NoError(t, svc.FlushDigests(ctx))
+	assert.Empty(t, repo.items)
+}
@@ -70,9 +70,26 @@ func NewDiscordSender(log zerolog.Logger, settings *domain.Notification) domain.
 }
 
 func (s *discordSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	embed := s.buildEmbed(event, payload)
+
+	// templates replace the embed title and description, the fields are kept
+	if s.Settings.TitleTemplate != "" || s.Settings.BodyTemplate != "" {
+		title, description, err := renderTemplates(s.Settings, event, payload)
+		if err != nil {
+			return err
+		}
+
+		if s.Settings.TitleTemplate != "" {
+			embed.Title = title
+		}
+		if s.Settings.BodyTemplate != "" {
+			embed.Description = description
+		}
+	}
+
 	m := DiscordMessage{
 		Content: nil,
-		Embeds:  []DiscordEmbeds{s.buildEmbed(event, payload)},
+		Embeds:  []DiscordEmbeds{embed},
 	}
 
 	jsonData, err := json.Marshal(m)
@@ -168,23 +185,34 @@ func (s *discordSender) isEnabledEvent(event domain.NotificationEvent) bool {
 	return s.Settings.EventEnabled(string(event))
 }
 
-func (s *discordSender) buildEmbed(event domain.NotificationEvent, payload domain.NotificationPayload) DiscordEmbeds {
+// Hex returns the color in #rrggbb notation
+func (c EmbedColors) Hex() string {
+	return fmt.Sprintf("#%06x", int(c))
+}
 
-	color := LIGHT_BLUE
+// eventColor returns the accent color of an event
+func eventColor(event domain.NotificationEvent) EmbedColors {
 	switch event {
 	case domain.NotificationEventPushApproved:
-		color = GREEN
+		return GREEN
 	case domain.NotificationEventPushRejected:
-		color = GRAY
+		return GRAY
 	case domain.NotificationEventPushError:
-		color = RED
+		return RED
 	case domain.NotificationEventIRCDisconnected:
-		color = RED
+		return RED
 	case domain.NotificationEventIRCReconnected:
-		color = GREEN
-	case domain.NotificationEventTest:
-		color = LIGHT_BLUE
+		return GREEN
+	case domain.NotificationEventAnnounceParseFailing:
+		return RED
+	default:
+		return LIGHT_BLUE
 	}
+}
+
+func (s *discordSender) buildEmbed(event domain.NotificationEvent, payload domain.NotificationPayload) DiscordEmbeds {
+
+	color := eventColor(event)
 
 	var fields []DiscordEmbedsFields
 
@@ -0,0 +1,350 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// emailDefaultPort is the submission port used when the host has no port
+	emailDefaultPort = "587"
+
+	// emailImplicitTLSPort expects tls from the start instead of STARTTLS
+	emailImplicitTLSPort = "465"
+)
+
+type emailSender struct {
+	log      zerolog.Logger
+	Settings *domain.Notification
+	builder  MessageBuilderPlainText
+
+	timeout time.Duration
+}
+
+func (s *emailSender) Name() string {
+	return "email"
+}
+
+func NewEmailSender(log zerolog.Logger, settings *domain.Notification) domain.NotificationSender {
+	return &emailSender{
+		log:      log.With().Str("sender", "email").Str("name", settings.Name).Logger(),
+		Settings: settings,
+		builder:  MessageBuilderPlainText{},
+		timeout:  time.Second * 30,
+	}
+}
+
+func (s *emailSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	from, err := mail.ParseAddress(s.Settings.FromAddress)
+	if err != nil {
+		return errors.Wrap(err, "invalid from address: %s", s.Settings.FromAddress)
+	}
+
+	recipients, err := mail.ParseAddressList(s.Settings.Targets)
+	if err != nil {
+		return errors.Wrap(err, "invalid recipients: %s", s.Settings.Targets)
+	}
+
+	msg, err := s.buildMessage(event, payload, from, recipients)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sendMail(from, recipients, msg); err != nil {
+		return errors.Wrap(err, "could not send email for event: %v", event)
+	}
+
+	s.log.Debug().Str("event", string(event)).Msg("notification successfully sent to email")
+
+	return nil
+}
+
+// sendMail delivers the message over SMTP. Port 465 uses implicit tls, other
+// ports upgrade with STARTTLS when the server supports it.
+func (s *emailSender) sendMail(from *mail.Address, recipients []*mail.Address, msg []byte) error {
+	host, port, err := splitEmailHost(s.Settings.Host)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(host, port)
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	var conn net.Conn
+	if port == emailImplicitTLSPort {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not connect to smtp server: %s", addr)
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		conn.Close()
+		return errors.Wrap(err, "could not set deadline")
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "could not create smtp client")
+	}
+
+	defer c.Close()
+
+	if port != emailImplicitTLSPort {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return errors.Wrap(err, "could not start tls")
+			}
+		}
+	}
+
+	if s.Settings.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support authentication")
+		}
+
+		password, err := domain.ResolveSecret(s.Settings.Password)
+		if err != nil {
+			return errors.Wrap(err, "could not resolve password")
+		}
+
+		// PlainAuth refuses to send credentials without tls, except to localhost
+		if err := c.Auth(smtp.PlainAuth("", s.Settings.Username, password, host)); err != nil {
+			return errors.Wrap(err, "could not authenticate")
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return errors.Wrap(err, "server rejected sender: %s", from.Address)
+	}
+
+	for _, rcpt := range recipients {
+		if err := c.Rcpt(rcpt.Address); err != nil {
+			return errors.Wrap(err, "server rejected recipient: %s", rcpt.Address)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "could not start data")
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return errors.Wrap(err, "could not write message")
+	}
+
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "server rejected message")
+	}
+
+	return c.Quit()
+}
+
+// buildMessage returns a multipart message with a plain text and a html part
+func (s *emailSender) buildMessage(event domain.NotificationEvent, payload domain.NotificationPayload, from *mail.Address, recipients []*mail.Address) ([]byte, error) {
+	title, text, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := title
+	if s.Settings.TitleTemplate == "" {
+		if headline, _ := messageHeadline(payload); headline != "" {
+			subject = fmt.Sprintf("%s: %s", title, headline)
+		}
+	}
+
+	var htmlBody string
+	if s.Settings.BodyTemplate != "" {
+		htmlBody = emailHTML(event, title, strings.ReplaceAll(html.EscapeString(text), "\n", "<br>"), nil)
+	} else {
+		headline, description := messageHeadline(payload)
+		intro := fmt.Sprintf("<p><strong>%s</strong><br>%s</p>", html.EscapeString(headline), html.EscapeString(description))
+		htmlBody = emailHTML(event, title, intro, buildFields(payload))
+	}
+
+	to := make([]string, 0, len(recipients))
+	for _, rcpt := range recipients {
+		to = append(to, rcpt.String())
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	domainPart := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	headers := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%d.autobrr@%s>", time.Now().UnixNano(), domainPart)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.key, h.value)
+	}
+	buf.WriteString("\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", htmlBody},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create message part")
+		}
+
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(p.body)); err != nil {
+			return nil, errors.Wrap(err, "could not write message part")
+		}
+		if err := qw.Close(); err != nil {
+			return nil, errors.Wrap(err, "could not write message part")
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, errors.Wrap(err, "could not close message")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// emailHTML renders the html part with the event color, the content and a table of the release details
+func emailHTML(event domain.NotificationEvent, title string, content string, fields []messageField) string {
+	var b strings.Builder
+
+	b.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;color:#1f2937">`)
+	fmt.Fprintf(&b, `<div style="border-left:4px solid %s;padding:4px 12px">`, eventColor(event).Hex())
+	fmt.Fprintf(&b, `<h2 style="margin:0 0 8px 0">%s</h2>%s`, html.EscapeString(title), content)
+
+	if len(fields) > 0 {
+		b.WriteString(`<table style="border-collapse:collapse">`)
+		for _, f := range fields {
+			fmt.Fprintf(&b, `<tr><td style="padding:2px 12px 2px 0;font-weight:bold">%s</td><td style="padding:2px 0">%s</td></tr>`, html.EscapeString(f.Name), html.EscapeString(f.Value))
+		}
+		b.WriteString(`</table>`)
+	}
+
+	b.WriteString(`</div><p style="color:#6b7280;font-size:12px">Sent by autobrr</p></body></html>`)
+
+	return b.String()
+}
+
+// splitEmailHost returns the host and port of the smtp server, the port defaults to 587
+func splitEmailHost(value string) (string, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", errors.New("smtp host is empty")
+	}
+
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		// no port
+		return strings.Trim(value, "[]"), emailDefaultPort, nil
+	}
+
+	if host == "" {
+		return "", "", errors.New("invalid smtp host: %s", value)
+	}
+
+	return host, port, nil
+}
+
+func (s *emailSender) CanSend(event domain.NotificationEvent) bool {
+	if s.IsEnabled() && s.isEnabledEvent(event) {
+		return true
+	}
+	return false
+}
+
+func (s *emailSender) CanSendPayload(event domain.NotificationEvent, payload domain.NotificationPayload) bool {
+	if !s.IsEnabled() {
+		return false
+	}
+
+	if payload.FilterID > 0 {
+		if s.Settings.FilterMuted(payload.FilterID) {
+			s.log.Trace().Str("event", string(event)).Int("filter_id", payload.FilterID).Str("filter", payload.Filter).Msg("notification muted by filter")
+			return false
+		}
+
+		// Check if the filter has custom notifications configured
+		if s.Settings.FilterEventEnabled(payload.FilterID, event) {
+			return true
+		}
+
+		// If the filter has custom notifications but the event is not enabled, don't fall back to global
+		if s.Settings.HasFilterNotifications(payload.FilterID) {
+			return false
+		}
+	}
+
+	// Fall back to global events for non-filter events or filters without custom notifications
+	if s.isEnabledEvent(event) {
+		return true
+	}
+
+	return false
+}
+
+func (s *emailSender) HasFilterEvents(filterID int) bool {
+	if s.Settings.HasFilterNotifications(filterID) {
+		return true
+	}
+	return false
+}
+
+// IsEnabled requires a smtp host, a valid from address and at least one valid recipient
+func (s *emailSender) IsEnabled() bool {
+	if !s.Settings.IsEnabled() {
+		return false
+	}
+
+	if _, _, err := splitEmailHost(s.Settings.Host); err != nil {
+		return false
+	}
+
+	if _, err := mail.ParseAddress(s.Settings.FromAddress); err != nil {
+		return false
+	}
+
+	if _, err := mail.ParseAddressList(s.Settings.Targets); err != nil {
+		return false
+	}
+
+	return true
+}
+
+func (s *emailSender) isEnabledEvent(event domain.NotificationEvent) bool {
+	return s.Settings.EventEnabled(string(event))
+}
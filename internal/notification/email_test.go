@@ -0,0 +1,238 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSession is what the stand-in smtp server received
+type smtpSession struct {
+	auth string
+	from string
+	rcpt []string
+	data string
+}
+
+// startSMTPServer runs a minimal smtp server that accepts a single message
+func startSMTPServer(t *testing.T) (string, <-chan smtpSession) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { ln.Close() })
+
+	sessions := make(chan smtpSession, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var session smtpSession
+
+		_ = tp.PrintfLine("220 localhost ESMTP")
+
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250 AUTH PLAIN")
+			case "AUTH":
+				session.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+				_ = tp.PrintfLine("235 2.7.0 Authentication successful")
+			case "MAIL":
+				session.from = line
+				_ = tp.PrintfLine("250 OK")
+			case "RCPT":
+				session.rcpt = append(session.rcpt, line)
+				_ = tp.PrintfLine("250 OK")
+			case "DATA":
+				_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				session.data = string(data)
+				_ = tp.PrintfLine("250 OK")
+			case "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				sessions <- session
+				return
+			default:
+				_ = tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), sessions
+}
+
+func TestEmailSender_Send(t *testing.T) {
+	addr, sessions := startSMTPServer(t)
+
+	settings := &domain.Notification{
+		Name:        "Email",
+		Type:        domain.NotificationTypeEmail,
+		Enabled:     true,
+		Host:        addr,
+		Username:    "autobrr",
+		Password:    "hunter2",
+		FromAddress: "autobrr <autobrr@example.org>",
+		Targets:     "me@example.org, Someone Else <else@example.org>",
+		Events:      []string{string(domain.NotificationEventPushApproved)},
+	}
+
+	sender := NewEmailSender(logger.Mock().With().Logger(), settings)
+	require.True(t, sender.IsEnabled())
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushApproved,
+		ReleaseName: "Best.Show.S01E01.1080p.WEB-DL-GROUP",
+		Filter:      "<TV>",
+		Indexer:     "MockIndexer",
+		Status:      domain.ReleasePushStatusApproved,
+		Size:        1500000000,
+		Timestamp:   time.Now(),
+	}
+
+	require.NoError(t, sender.Send(payload.Event, payload))
+
+	var session smtpSession
+	select {
+	case session = <-sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp server did not receive a message")
+	}
+
+	auth, err := base64.StdEncoding.DecodeString(session.auth)
+	require.NoError(t, err)
+	assert.Equal(t, "\x00autobrr\x00hunter2", string(auth))
+
+	assert.Equal(t, "MAIL FROM:<autobrr@example.org>", session.from)
+	assert.Equal(t, []string{"RCPT TO:<me@example.org>", "RCPT TO:<else@example.org>"}, session.rcpt)
+
+	msg, err := mail.ReadMessage(strings.NewReader(session.data))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Push Approved: Best.Show.S01E01.1080p.WEB-DL-GROUP", msg.Header.Get("Subject"))
+	assert.Equal(t, `"autobrr" <autobrr@example.org>`, msg.Header.Get("From"))
+	assert.Equal(t, `<me@example.org>, "Someone Else" <else@example.org>`, msg.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+
+	require.Len(t, parts, 2)
+	assert.Contains(t, parts["text/plain"], "New release: Best.Show.S01E01.1080p.WEB-DL-GROUP\n")
+	assert.Contains(t, parts["text/plain"], "Filter: <TV>\n")
+	assert.Contains(t, parts["text/html"], "border-left:4px solid #57f287")
+	assert.Contains(t, parts["text/html"], "&lt;TV&gt;")
+	assert.Contains(t, parts["text/html"], "1.5 GB")
+}
+
+func TestEmailSender_Send_Templates(t *testing.T) {
+	addr, sessions := startSMTPServer(t)
+
+	settings := &domain.Notification{
+		Type:          domain.NotificationTypeEmail,
+		Enabled:       true,
+		Host:          addr,
+		FromAddress:   "autobrr@example.org",
+		Targets:       "me@example.org",
+		TitleTemplate: "[{{ .Indexer }}] {{ .ReleaseName }}",
+		BodyTemplate:  "grabbed by {{ .Filter }}",
+	}
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushApproved,
+		ReleaseName: "Best.Show.S01E01.1080p.WEB-DL-GROUP",
+		Filter:      "TV",
+		Indexer:     "MockIndexer",
+	}
+
+	require.NoError(t, NewEmailSender(logger.Mock().With().Logger(), settings).Send(payload.Event, payload))
+
+	session := <-sessions
+	assert.Empty(t, session.auth)
+
+	msg, err := mail.ReadMessage(strings.NewReader(session.data))
+	require.NoError(t, err)
+	assert.Equal(t, "[MockIndexer] Best.Show.S01E01.1080p.WEB-DL-GROUP", msg.Header.Get("Subject"))
+
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "grabbed by TV")
+}
+
+func TestEmailSender_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		from     string
+		targets  string
+		expected bool
+	}{
+		{name: "valid", host: "smtp.example.org:587", from: "autobrr@example.org", targets: "me@example.org", expected: true},
+		{name: "default_port", host: "smtp.example.org", from: "autobrr@example.org", targets: "me@example.org,you@example.org", expected: true},
+		{name: "invalid_from", host: "smtp.example.org", from: "autobrr", targets: "me@example.org", expected: false},
+		{name: "invalid_target", host: "smtp.example.org", from: "autobrr@example.org", targets: "me@example.org, nope", expected: false},
+		{name: "no_host", host: "", from: "autobrr@example.org", targets: "me@example.org", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &domain.Notification{
+				Type:        domain.NotificationTypeEmail,
+				Enabled:     true,
+				Host:        tt.host,
+				FromAddress: tt.from,
+				Targets:     tt.targets,
+			}
+
+			assert.Equal(t, tt.expected, NewEmailSender(logger.Mock().With().Logger(), settings).IsEnabled())
+		})
+	}
+}
@@ -47,9 +47,14 @@ func NewGotifySender(log zerolog.Logger, settings *domain.Notification) domain.N
 }
 
 func (s *gotifySender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	title, message, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
+
 	m := gotifyMessage{
-		Message: s.builder.BuildBody(payload),
-		Title:   BuildTitle(event),
+		Message: message,
+		Title:   title,
 	}
 
 	data := url.Values{}
@@ -60,7 +65,12 @@ func (s *gotifySender) Send(event domain.NotificationEvent, payload domain.Notif
 	if err != nil {
 		return err
 	}
-	urlPath += "?token=" + url.QueryEscape(s.Settings.Token)
+	token, err := domain.ResolveSecret(s.Settings.Token)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve token")
+	}
+
+	urlPath += "?token=" + url.QueryEscape(token)
 
 	req, err := http.NewRequest(http.MethodPost, urlPath, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -58,9 +58,14 @@ func NewLunaSeaSender(log zerolog.Logger, settings *domain.Notification) domain.
 }
 
 func (s *lunaSeaSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	title, body, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
+
 	m := LunaSeaMessage{
-		Title: BuildTitle(event),
-		Body:  s.builder.BuildBody(payload),
+		Title: title,
+		Body:  body,
 		Image: defaultImageURL,
 	}
 
@@ -0,0 +1,286 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/sharedhttp"
+
+	"github.com/rs/zerolog"
+)
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+type matrixRoomAlias struct {
+	RoomID string `json:"room_id"`
+}
+
+// matrixTxnCounter keeps transaction ids unique when messages are sent within the same nanosecond
+var matrixTxnCounter atomic.Uint64
+
+type matrixSender struct {
+	log      zerolog.Logger
+	Settings *domain.Notification
+	builder  MessageBuilderHTML
+
+	httpClient *http.Client
+}
+
+func (s *matrixSender) Name() string {
+	return "matrix"
+}
+
+func NewMatrixSender(log zerolog.Logger, settings *domain.Notification) domain.NotificationSender {
+	return &matrixSender{
+		log:      log.With().Str("sender", "matrix").Str("name", settings.Name).Logger(),
+		Settings: settings,
+		builder:  MessageBuilderHTML{},
+		httpClient: &http.Client{
+			Timeout:   time.Second * 30,
+			Transport: sharedhttp.Transport,
+		},
+	}
+}
+
+func (s *matrixSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	m, err := s.buildMessage(event, payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := domain.ResolveSecret(s.Settings.Token)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve access token")
+	}
+
+	jsonData, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal json request for event: %v payload: %v", event, payload)
+	}
+
+	for _, room := range matrixRooms(s.Settings.Rooms) {
+		roomID, err := s.resolveRoom(room, token)
+		if err != nil {
+			return err
+		}
+
+		txnID := fmt.Sprintf("autobrr-%d-%d", time.Now().UnixNano(), matrixTxnCounter.Add(1))
+
+		endpoint, err := url.JoinPath(s.Settings.Host, "_matrix/client/v3/rooms", roomID, "send/m.room.message", txnID)
+		if err != nil {
+			return errors.Wrap(err, "could not build url for room: %s", room)
+		}
+
+		req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(jsonData))
+		if err != nil {
+			return errors.Wrap(err, "could not create request for event: %v payload: %v", event, payload)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("User-Agent", "autobrr")
+
+		if err := s.do(req); err != nil {
+			return errors.Wrap(err, "could not send message to room: %s", room)
+		}
+	}
+
+	s.log.Debug().Str("event", string(event)).Msg("notification successfully sent to matrix")
+
+	return nil
+}
+
+// resolveRoom returns the room id of a room alias, room ids are returned as is
+func (s *matrixSender) resolveRoom(room string, token string) (string, error) {
+	if !strings.HasPrefix(room, "#") {
+		return room, nil
+	}
+
+	endpoint, err := url.JoinPath(s.Settings.Host, "_matrix/client/v3/directory/room", room)
+	if err != nil {
+		return "", errors.Wrap(err, "could not build url for room alias: %s", room)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create request for room alias: %s", room)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", "autobrr")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "client request error for room alias: %s", room)
+	}
+
+	defer sharedhttp.DrainAndClose(res)
+
+	if res.StatusCode != http.StatusOK {
+		return "", errors.New("could not resolve room alias %s: unexpected status: %v", room, res.StatusCode)
+	}
+
+	var alias matrixRoomAlias
+	if err := json.NewDecoder(io.LimitReader(res.Body, 4096)).Decode(&alias); err != nil {
+		return "", errors.Wrap(err, "could not decode room alias response")
+	}
+
+	if alias.RoomID == "" {
+		return "", errors.New("room alias %s did not resolve to a room", room)
+	}
+
+	return alias.RoomID, nil
+}
+
+func (s *matrixSender) do(req *http.Request) error {
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "client request error")
+	}
+
+	defer sharedhttp.DrainAndClose(res)
+
+	s.log.Trace().Msgf("matrix response status: %d", res.StatusCode)
+
+	if res.StatusCode != http.StatusOK {
+		limitedReader := io.LimitReader(res.Body, 4096)
+		body, err := io.ReadAll(limitedReader)
+		if err != nil {
+			return errors.Wrap(err, "could not read body")
+		}
+
+		return errors.New("unexpected status: %v body: %v", res.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// buildMessage returns a text message with a html formatted body. The plain
+// body is shown by clients without html support and in push notifications.
+func (s *matrixSender) buildMessage(event domain.NotificationEvent, payload domain.NotificationPayload) (matrixMessage, error) {
+	title, body, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return matrixMessage{}, err
+	}
+
+	var plain, formatted string
+	if s.Settings.BodyTemplate != "" {
+		plain = body
+		formatted = strings.ReplaceAll(html.EscapeString(body), "\n", "<br>")
+	} else {
+		plain = (&MessageBuilderPlainText{}).BuildBody(payload)
+		formatted = strings.ReplaceAll(strings.TrimSuffix(body, "\n"), "\n", "<br>")
+	}
+
+	return matrixMessage{
+		MsgType:       "m.text",
+		Body:          title + "\n" + plain,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: fmt.Sprintf(`<h4><font data-mx-color="%s">&#9679;</font> %s</h4>%s`, eventColor(event).Hex(), html.EscapeString(title), formatted),
+	}, nil
+}
+
+// matrixRooms splits the comma separated room ids and aliases
+func matrixRooms(rooms string) []string {
+	var result []string
+	for _, room := range strings.Split(rooms, ",") {
+		if room = strings.TrimSpace(room); room != "" {
+			result = append(result, room)
+		}
+	}
+	return result
+}
+
+func (s *matrixSender) CanSend(event domain.NotificationEvent) bool {
+	if s.IsEnabled() && s.isEnabledEvent(event) {
+		return true
+	}
+	return false
+}
+
+func (s *matrixSender) CanSendPayload(event domain.NotificationEvent, payload domain.NotificationPayload) bool {
+	if !s.IsEnabled() {
+		return false
+	}
+
+	if payload.FilterID > 0 {
+		if s.Settings.FilterMuted(payload.FilterID) {
+			s.log.Trace().Str("event", string(event)).Int("filter_id", payload.FilterID).Str("filter", payload.Filter).Msg("notification muted by filter")
+			return false
+		}
+
+		// Check if the filter has custom notifications configured
+		if s.Settings.FilterEventEnabled(payload.FilterID, event) {
+			return true
+		}
+
+		// If the filter has custom notifications but the event is not enabled, don't fall back to global
+		if s.Settings.HasFilterNotifications(payload.FilterID) {
+			return false
+		}
+	}
+
+	// Fall back to global events for non-filter events or filters without custom notifications
+	if s.isEnabledEvent(event) {
+		return true
+	}
+
+	return false
+}
+
+func (s *matrixSender) HasFilterEvents(filterID int) bool {
+	if s.Settings.HasFilterNotifications(filterID) {
+		return true
+	}
+	return false
+}
+
+// IsEnabled requires a homeserver url, an access token and at least one room.
+// Rooms are room ids like !abc:example.org or aliases like #room:example.org.
+func (s *matrixSender) IsEnabled() bool {
+	if !s.Settings.IsEnabled() {
+		return false
+	}
+
+	if u, err := url.Parse(s.Settings.Host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	rooms := matrixRooms(s.Settings.Rooms)
+	if len(rooms) == 0 {
+		return false
+	}
+
+	for _, room := range rooms {
+		if !strings.HasPrefix(room, "!") && !strings.HasPrefix(room, "#") {
+			return false
+		}
+		if !strings.Contains(room, ":") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *matrixSender) isEnabledEvent(event domain.NotificationEvent) bool {
+	return s.Settings.EventEnabled(string(event))
+}
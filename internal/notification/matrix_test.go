@@ -0,0 +1,141 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixSender_Send(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var messages []matrixMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/_matrix/client/v3/directory/room/#autobrr:example.org", r.URL.Path)
+			_ = json.NewEncoder(w).Encode(matrixRoomAlias{RoomID: "!alias:example.org"})
+			return
+		}
+
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var m matrixMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		messages = append(messages, m)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("AUTOBRR_SECRET_TEST_MATRIX_TOKEN", "secret-token")
+
+	settings := &domain.Notification{
+		Name:    "Matrix",
+		Type:    domain.NotificationTypeMatrix,
+		Enabled: true,
+		Host:    server.URL,
+		Token:   "env:AUTOBRR_SECRET_TEST_MATRIX_TOKEN",
+		Rooms:   "!room:example.org, #autobrr:example.org",
+		Events:  []string{string(domain.NotificationEventPushApproved)},
+	}
+
+	sender := NewMatrixSender(logger.Mock().With().Logger(), settings)
+	require.True(t, sender.IsEnabled())
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushApproved,
+		ReleaseName: "Best.Show.S01E01.1080p.WEB-DL-GROUP",
+		Filter:      "<TV>",
+		Indexer:     "MockIndexer",
+		Status:      domain.ReleasePushStatusApproved,
+		Timestamp:   time.Now(),
+	}
+
+	require.NoError(t, sender.Send(payload.Event, payload))
+
+	require.Len(t, messages, 2)
+	assert.True(t, strings.HasPrefix(paths[0], "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/autobrr-"))
+	assert.True(t, strings.HasPrefix(paths[1], "/_matrix/client/v3/rooms/!alias:example.org/send/m.room.message/autobrr-"))
+	assert.NotEqual(t, paths[0][strings.LastIndex(paths[0], "/"):], paths[1][strings.LastIndex(paths[1], "/"):], "transaction ids must be unique")
+
+	m := messages[0]
+	assert.Equal(t, "m.text", m.MsgType)
+	assert.Equal(t, "org.matrix.custom.html", m.Format)
+	assert.Contains(t, m.Body, "Push Approved\n")
+	assert.Contains(t, m.Body, "Filter: <TV>")
+	assert.Contains(t, m.FormattedBody, `<font data-mx-color="#57f287">`)
+	assert.Contains(t, m.FormattedBody, "<b>Filter:</b> &lt;TV&gt;")
+	assert.NotContains(t, m.FormattedBody, "\n")
+}
+
+func TestMatrixSender_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN"}`))
+	}))
+	defer server.Close()
+
+	settings := &domain.Notification{
+		Type:    domain.NotificationTypeMatrix,
+		Enabled: true,
+		Host:    server.URL,
+		Token:   "token",
+		Rooms:   "!room:example.org",
+	}
+
+	err := NewMatrixSender(logger.Mock().With().Logger(), settings).Send(domain.NotificationEventTest, domain.NotificationPayload{Subject: "Test", Message: "test"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "M_FORBIDDEN")
+}
+
+func TestMatrixSender_IsEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		rooms    string
+		expected bool
+	}{
+		{name: "room_id", host: "https://matrix.org", rooms: "!abc:matrix.org", expected: true},
+		{name: "alias", host: "https://matrix.org", rooms: "#autobrr:matrix.org", expected: true},
+		{name: "multiple", host: "https://matrix.org", rooms: "!abc:matrix.org,#autobrr:matrix.org", expected: true},
+		{name: "missing_server", host: "https://matrix.org", rooms: "!abc", expected: false},
+		{name: "invalid_room", host: "https://matrix.org", rooms: "autobrr:matrix.org", expected: false},
+		{name: "no_rooms", host: "https://matrix.org", rooms: " , ", expected: false},
+		{name: "invalid_host", host: "matrix.org", rooms: "!abc:matrix.org", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &domain.Notification{
+				Type:    domain.NotificationTypeMatrix,
+				Enabled: true,
+				Host:    tt.host,
+				Token:   "token",
+				Rooms:   tt.rooms,
+			}
+
+			assert.Equal(t, tt.expected, NewMatrixSender(logger.Mock().With().Logger(), settings).IsEnabled())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
 
 	"github.com/dustin/go-humanize"
 )
@@ -64,6 +65,50 @@ func (b *MessageBuilderHTML) BuildBody(payload domain.NotificationPayload) strin
 	return formatMessageContent(messageParts)
 }
 
+// messageField is a named detail of a notification, shown as a table row or
+// field by senders with rich formatting.
+type messageField struct {
+	Name  string
+	Value string
+}
+
+// buildFields returns the release details of the payload in display order
+func buildFields(payload domain.NotificationPayload) []messageField {
+	fields := []struct {
+		condition bool
+		field     messageField
+	}{
+		{payload.Status != "", messageField{"Status", payload.Status.String()}},
+		{payload.Indexer != "", messageField{"Indexer", payload.Indexer}},
+		{payload.Filter != "", messageField{"Filter", payload.Filter}},
+		{payload.Action != "", messageField{"Action", payload.Action}},
+		{payload.ActionType != "", messageField{"Action type", string(payload.ActionType)}},
+		{payload.ActionClient != "", messageField{"Action client", payload.ActionClient}},
+		{payload.Size > 0, messageField{"Size", humanize.Bytes(payload.Size)}},
+		{payload.Protocol != "", messageField{"Protocol", payload.Protocol.String()}},
+		{payload.Implementation != "", messageField{"Implementation", payload.Implementation.String()}},
+		{len(payload.Rejections) > 0, messageField{"Reasons", strings.Join(payload.Rejections, ", ")}},
+	}
+
+	result := make([]messageField, 0, len(fields))
+	for _, f := range fields {
+		if f.condition {
+			result = append(result, f.field)
+		}
+	}
+
+	return result
+}
+
+// messageHeadline returns the subject and message of the payload, or the release name
+func messageHeadline(payload domain.NotificationPayload) (string, string) {
+	if payload.Subject != "" && payload.Message != "" {
+		return payload.Subject, payload.Message
+	}
+
+	return payload.ReleaseName, "New release!"
+}
+
 func formatMessageContent(messageParts []ConditionMessagePart) string {
 	var builder strings.Builder
 	for _, part := range messageParts {
@@ -74,17 +119,58 @@ func formatMessageContent(messageParts []ConditionMessagePart) string {
 	return builder.String()
 }
 
+// buildMessage returns the title and body of a notification. The title and
+// body templates of the notification replace the defaults when set.
+func buildMessage(settings *domain.Notification, builder MessageBuilder, event domain.NotificationEvent, payload domain.NotificationPayload) (string, string, error) {
+	title, body, err := renderTemplates(settings, event, payload)
+	if err != nil {
+		return "", "", err
+	}
+
+	if settings.BodyTemplate == "" {
+		body = builder.BuildBody(payload)
+	}
+
+	return title, body, nil
+}
+
+// renderTemplates renders the title and body templates of a notification. The title
+// falls back to the default title of the event and the body is empty without a template.
+func renderTemplates(settings *domain.Notification, event domain.NotificationEvent, payload domain.NotificationPayload) (string, string, error) {
+	title := BuildTitle(event)
+	data := domain.NewNotificationTemplateData(event, payload, title)
+
+	if settings.TitleTemplate != "" {
+		rendered, err := domain.RenderNotificationTemplate(settings.TitleTemplate, data)
+		if err != nil {
+			return "", "", errors.Wrap(err, "could not render title template")
+		}
+
+		title = rendered
+		data.Title = rendered
+	}
+
+	body, err := domain.RenderNotificationTemplate(settings.BodyTemplate, data)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not render body template")
+	}
+
+	return title, body, nil
+}
+
 // BuildTitle constructs the title of the notification message.
 func BuildTitle(event domain.NotificationEvent) string {
 	titles := map[domain.NotificationEvent]string{
-		domain.NotificationEventAppUpdateAvailable: "Autobrr update available",
-		domain.NotificationEventPushApproved:       "Push Approved",
-		domain.NotificationEventPushRejected:       "Push Rejected",
-		domain.NotificationEventPushError:          "Push Error",
-		domain.NotificationEventIRCDisconnected:    "IRC Disconnected",
-		domain.NotificationEventIRCReconnected:     "IRC Reconnected",
-		domain.NotificationEventReleaseNew:         "New Release",
-		domain.NotificationEventTest:               "Test",
+		domain.NotificationEventAppUpdateAvailable:   "Autobrr update available",
+		domain.NotificationEventPushApproved:         "Push Approved",
+		domain.NotificationEventPushRejected:         "Push Rejected",
+		domain.NotificationEventPushError:            "Push Error",
+		domain.NotificationEventIRCDisconnected:      "IRC Disconnected",
+		domain.NotificationEventIRCReconnected:       "IRC Reconnected",
+		domain.NotificationEventAnnounceParseFailing: "Announce Parsing Failing",
+		domain.NotificationEventReleaseNew:           "New Release",
+		domain.NotificationEventDigest:               "Notification Digest",
+		domain.NotificationEventTest:                 "Test",
 	}
 
 	if title, ok := titles[event]; ok {
@@ -73,3 +73,92 @@ func TestNotificationBuilderPlainText_BuildBody(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildMessage(t *testing.T) {
+	t.Parallel()
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushApproved,
+		ReleaseName: "That.Show.S01E01.1080p.WEB-DL-GROUP",
+		Filter:      "tv",
+		Indexer:     "mock",
+		Status:      domain.ReleasePushStatusApproved,
+		Release: &domain.Release{
+			TorrentName: "That.Show.S01E01.1080p.WEB-DL-GROUP",
+			Title:       "That Show",
+			Season:      1,
+			Episode:     1,
+			Size:        1500000000,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		settings  domain.Notification
+		payload   domain.NotificationPayload
+		wantTitle string
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "defaults",
+			settings:  domain.Notification{},
+			payload:   payload,
+			wantTitle: "Push Approved",
+			wantBody:  "New release: That.Show.S01E01.1080p.WEB-DL-GROUP\nStatus: Approved\nIndexer: mock\nFilter: tv\n",
+		},
+		{
+			name: "templates",
+			settings: domain.Notification{
+				TitleTemplate: `{{ .Title }} ({{ .Filter }})`,
+				BodyTemplate:  `{{ .Macro.Title }} S{{ printf "%02d" .Macro.Season }}E{{ printf "%02d" .Macro.Episode }} {{ .Macro.SizeString }} {{ .Title }}`,
+			},
+			payload:   payload,
+			wantTitle: "Push Approved (tv)",
+			wantBody:  "That Show S01E01 1.5 GB Push Approved (tv)",
+		},
+		{
+			name: "sprig functions",
+			settings: domain.Notification{
+				BodyTemplate: `{{ .ReleaseName | lower | trunc 8 }} {{ .EventType }}`,
+			},
+			payload:   payload,
+			wantTitle: "Push Approved",
+			wantBody:  "that.sho action.approved",
+		},
+		{
+			name: "no release",
+			settings: domain.Notification{
+				BodyTemplate: `{{ .Subject }}: {{ .Message }}{{ with .Release }} {{ .TorrentName }}{{ end }}`,
+			},
+			payload: domain.NotificationPayload{
+				Subject: "IRC Disconnected unexpectedly",
+				Message: "Network: P2P-Network",
+				Event:   domain.NotificationEventIRCDisconnected,
+			},
+			wantTitle: "Push Approved",
+			wantBody:  "IRC Disconnected unexpectedly: Network: P2P-Network",
+		},
+		{
+			name: "unknown field",
+			settings: domain.Notification{
+				BodyTemplate: `{{ .Unknown }}`,
+			},
+			payload: payload,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body, err := buildMessage(&tt.settings, &MessageBuilderPlainText{}, domain.NotificationEventPushApproved, tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, title)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
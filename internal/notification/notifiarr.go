@@ -83,7 +83,12 @@ func (s *notifiarrSender) Send(event domain.NotificationEvent, payload domain.No
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "autobrr")
-	req.Header.Set("X-API-Key", s.Settings.APIKey)
+	apiKey, err := domain.ResolveSecret(s.Settings.APIKey)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve api key")
+	}
+
+	req.Header.Set("X-API-Key", apiKey)
 
 	res, err := s.httpClient.Do(req)
 	if err != nil {
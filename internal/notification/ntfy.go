@@ -47,9 +47,14 @@ func NewNtfySender(log zerolog.Logger, settings *domain.Notification) domain.Not
 }
 
 func (s *ntfySender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	title, message, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
+
 	m := ntfyMessage{
-		Message: s.builder.BuildBody(payload),
-		Title:   BuildTitle(event),
+		Message: message,
+		Title:   title,
 	}
 
 	req, err := http.NewRequest(http.MethodPost, s.Settings.Host, strings.NewReader(m.Message))
@@ -69,10 +74,20 @@ func (s *ntfySender) Send(event domain.NotificationEvent, payload domain.Notific
 	}
 
 	// set basic auth or access token
-	if s.Settings.Username != "" && s.Settings.Password != "" {
-		req.SetBasicAuth(s.Settings.Username, s.Settings.Password)
-	} else if s.Settings.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+s.Settings.Token)
+	password, err := domain.ResolveSecret(s.Settings.Password)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve password")
+	}
+
+	token, err := domain.ResolveSecret(s.Settings.Token)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve token")
+	}
+
+	if s.Settings.Username != "" && password != "" {
+		req.SetBasicAuth(s.Settings.Username, password)
+	} else if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	res, err := s.httpClient.Do(req)
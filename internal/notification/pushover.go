@@ -57,12 +57,24 @@ func NewPushoverSender(log zerolog.Logger, settings *domain.Notification) domain
 }
 
 func (s *pushoverSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
-	title := BuildTitle(event)
-	message := s.builder.BuildBody(payload)
+	title, message, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := domain.ResolveSecret(s.Settings.APIKey)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve api key")
+	}
+
+	userKey, err := domain.ResolveSecret(s.Settings.Token)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve user key")
+	}
 
 	m := pushoverMessage{
-		Token:     s.Settings.APIKey,
-		User:      s.Settings.Token,
+		Token:     apiKey,
+		User:      userKey,
 		Priority:  s.Settings.Priority,
 		Message:   message,
 		Title:     title,
@@ -5,10 +5,13 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/pkg/errors"
@@ -23,6 +26,7 @@ type Sender interface {
 
 type Tester interface {
 	Test(ctx context.Context, notification *domain.Notification) error
+	Preview(ctx context.Context, notification *domain.Notification) ([]domain.NotificationPreview, error)
 }
 
 type Storer interface {
@@ -44,22 +48,35 @@ type FullService interface {
 	Storer
 	Sender
 	Tester
+	Digester
 }
 
 type Service struct {
-	log  zerolog.Logger
-	repo domain.NotificationRepo
+	log      zerolog.Logger
+	repo     domain.NotificationRepo
+	auditSvc audit.Service
 
 	notifications map[int]*domain.Notification
 	senders       map[int]domain.NotificationSender
+
+	// limits holds the notifications with a rate limit or digest
+	limits     map[int]*domain.Notification
+	limitsLock sync.Mutex
+}
+
+type registeredSender struct {
+	id     int
+	sender domain.NotificationSender
 }
 
-func NewService(log logger.Logger, repo domain.NotificationRepo) *Service {
+func NewService(log logger.Logger, repo domain.NotificationRepo, auditSvc audit.Service) *Service {
 	s := &Service{
 		log:           log.With().Str("module", "notification").Logger(),
 		repo:          repo,
+		auditSvc:      auditSvc,
 		notifications: make(map[int]*domain.Notification),
 		senders:       make(map[int]domain.NotificationSender),
+		limits:        make(map[int]*domain.Notification),
 	}
 
 	s.registerSenders()
@@ -97,6 +114,10 @@ func (s *Service) FindByID(ctx context.Context, notificationID int) (*domain.Not
 }
 
 func (s *Service) Store(ctx context.Context, notification *domain.Notification) error {
+	if err := notification.Validate(); err != nil {
+		return err
+	}
+
 	err := s.repo.Store(ctx, notification)
 	if err != nil {
 		s.log.Error().Err(err).Msgf("could not store notification: %+v", notification)
@@ -106,10 +127,16 @@ func (s *Service) Store(ctx context.Context, notification *domain.Notification)
 	// register sender
 	s.registerSender(notification)
 
+	notificationAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, notification)
+
 	return nil
 }
 
 func (s *Service) Update(ctx context.Context, notification *domain.Notification) error {
+	if err := notification.Validate(); err != nil {
+		return err
+	}
+
 	existing, err := s.repo.FindByID(ctx, notification.ID)
 	if err != nil {
 		s.log.Error().Err(err).Msgf("could not find notification by id: %v", notification.ID)
@@ -134,10 +161,14 @@ func (s *Service) Update(ctx context.Context, notification *domain.Notification)
 	// register sender
 	s.registerSender(notification)
 
+	notificationAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, existing, notification)
+
 	return nil
 }
 
 func (s *Service) Delete(ctx context.Context, id int) error {
+	existing, _ := s.repo.FindByID(ctx, id)
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		s.log.Error().Err(err).Msgf("could not delete notification: %v", id)
@@ -146,6 +177,9 @@ func (s *Service) Delete(ctx context.Context, id int) error {
 
 	// delete sender
 	delete(s.senders, id)
+	s.setLimits(&domain.Notification{ID: id})
+
+	notificationAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, existing, nil)
 
 	return nil
 }
@@ -241,6 +275,8 @@ func (s *Service) registerSenders() {
 
 // registerSender registers an enabled notification via it's id
 func (s *Service) registerSender(notification *domain.Notification) {
+	s.setLimits(notification)
+
 	if !notification.Enabled {
 		delete(s.senders, notification.ID)
 		return
@@ -274,6 +310,15 @@ func (s *Service) registerSender(notification *domain.Notification) {
 	case domain.NotificationTypeWebhook:
 		s.senders[notification.ID] = NewWebhookSender(s.log, notification)
 		break
+	case domain.NotificationTypeMatrix:
+		s.senders[notification.ID] = NewMatrixSender(s.log, notification)
+		break
+	case domain.NotificationTypeSlack:
+		s.senders[notification.ID] = NewSlackSender(s.log, notification)
+		break
+	case domain.NotificationTypeEmail:
+		s.senders[notification.ID] = NewEmailSender(s.log, notification)
+		break
 	default:
 		s.log.Error().Msgf("unsupported notification type: %v", notification.Type)
 		return
@@ -290,31 +335,31 @@ func (s *Service) Send(event domain.NotificationEvent, payload domain.Notificati
 	}
 
 	// Find interested senders first to avoid spawning goroutines for no reason
-	var interestedSenders []domain.NotificationSender
+	var interestedSenders []registeredSender
 
 	if payload.FilterID > 0 {
 		hasFilterSpecific := false
-		for _, sender := range s.senders {
+		for id, sender := range s.senders {
 			if sender.HasFilterEvents(payload.FilterID) {
 				hasFilterSpecific = true
 				if sender.CanSendPayload(event, payload) {
-					interestedSenders = append(interestedSenders, sender)
+					interestedSenders = append(interestedSenders, registeredSender{id: id, sender: sender})
 				}
 			}
 		}
 
 		if !hasFilterSpecific {
 			// Fall back to global if no specific filter notifications
-			for _, sender := range s.senders {
+			for id, sender := range s.senders {
 				if sender.CanSendPayload(event, payload) {
-					interestedSenders = append(interestedSenders, sender)
+					interestedSenders = append(interestedSenders, registeredSender{id: id, sender: sender})
 				}
 			}
 		}
 	} else {
-		for _, sender := range s.senders {
+		for id, sender := range s.senders {
 			if sender.CanSendPayload(event, payload) {
-				interestedSenders = append(interestedSenders, sender)
+				interestedSenders = append(interestedSenders, registeredSender{id: id, sender: sender})
 			}
 		}
 	}
@@ -324,18 +369,29 @@ func (s *Service) Send(event domain.NotificationEvent, payload domain.Notificati
 		return
 	}
 
-	go func(interested []domain.NotificationSender, event domain.NotificationEvent, payload domain.NotificationPayload) {
-		for _, sender := range interested {
-			s.log.Debug().Str("sender", sender.Name()).Str("event", string(event)).Msg("sending notification")
+	go func(interested []registeredSender, event domain.NotificationEvent, payload domain.NotificationPayload) {
+		ctx := context.Background()
 
-			if err := sender.Send(event, payload); err != nil {
-				s.log.Error().Err(err).Msgf("could not send %s notification for %v", sender.Name(), string(event))
+		for _, r := range interested {
+			// events can be collected for a digest or held back by the rate limit
+			if !s.shouldSendNow(ctx, r.id, event, payload) {
+				continue
+			}
+
+			s.log.Debug().Str("sender", r.sender.Name()).Str("event", string(event)).Msg("sending notification")
+
+			if err := r.sender.Send(event, payload); err != nil {
+				s.log.Error().Err(err).Msgf("could not send %s notification for %v", r.sender.Name(), string(event))
 			}
 		}
 	}(interestedSenders, event, payload)
 }
 
 func (s *Service) Test(ctx context.Context, notification *domain.Notification) error {
+	if err := notification.Validate(); err != nil {
+		return err
+	}
+
 	if notification.ID > 0 {
 		existing, err := s.repo.FindByID(ctx, notification.ID)
 		if err != nil {
@@ -357,7 +413,107 @@ func (s *Service) Test(ctx context.Context, notification *domain.Notification) e
 	var agent domain.NotificationSender
 
 	// send test events
-	events := []domain.NotificationPayload{
+	events := testPayloads()
+
+	switch notification.Type {
+	case domain.NotificationTypeDiscord:
+		agent = NewDiscordSender(s.log, notification)
+	case domain.NotificationTypeGotify:
+		agent = NewGotifySender(s.log, notification)
+	case domain.NotificationTypeLunaSea:
+		agent = NewLunaSeaSender(s.log, notification)
+	case domain.NotificationTypeNotifiarr:
+		agent = NewNotifiarrSender(s.log, notification)
+	case domain.NotificationTypeNtfy:
+		agent = NewNtfySender(s.log, notification)
+	case domain.NotificationTypePushover:
+		agent = NewPushoverSender(s.log, notification)
+	case domain.NotificationTypeShoutrrr:
+		agent = NewShoutrrrSender(s.log, notification)
+	case domain.NotificationTypeTelegram:
+		agent = NewTelegramSender(s.log, notification)
+	case domain.NotificationTypeWebhook:
+		agent = NewWebhookSender(s.log, notification)
+	case domain.NotificationTypeMatrix:
+		agent = NewMatrixSender(s.log, notification)
+	case domain.NotificationTypeSlack:
+		agent = NewSlackSender(s.log, notification)
+	case domain.NotificationTypeEmail:
+		agent = NewEmailSender(s.log, notification)
+	default:
+		s.log.Error().Msgf("unsupported notification type: %v", notification.Type)
+		return errors.New("unsupported notification type")
+	}
+
+	g, _ := errgroup.WithContext(ctx)
+
+	for _, event := range events {
+		if !enabledEvent(notification.Events, event.Event) {
+			continue
+		}
+
+		if err := agent.Send(event.Event, event); err != nil {
+			s.log.Error().Err(err).Msgf("error sending test notification: %#v", notification)
+			return err
+		}
+
+		time.Sleep(1 * time.Second)
+	}
+
+	if err := g.Wait(); err != nil {
+		s.log.Error().Err(err).Msgf("Something went wrong sending test notifications to %v", notification.Type)
+		return err
+	}
+
+	return nil
+}
+
+// Preview renders the test events enabled for the notification without sending them
+func (s *Service) Preview(ctx context.Context, notification *domain.Notification) ([]domain.NotificationPreview, error) {
+	if err := notification.Validate(); err != nil {
+		return nil, err
+	}
+
+	var builder MessageBuilder = &MessageBuilderPlainText{}
+	switch notification.Type {
+	case domain.NotificationTypePushover, domain.NotificationTypeTelegram:
+		builder = &MessageBuilderHTML{}
+	}
+
+	previews := make([]domain.NotificationPreview, 0)
+
+	for _, payload := range testPayloads() {
+		if !enabledEvent(notification.Events, payload.Event) {
+			continue
+		}
+
+		title, body, err := buildMessage(notification, builder, payload.Event, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		// webhooks send the event as json without a body template
+		if notification.Type == domain.NotificationTypeWebhook && notification.BodyTemplate == "" {
+			data, err := json.MarshalIndent(domain.NewWebhookEvent(payload.Event, payload, "preview"), "", "  ")
+			if err != nil {
+				return nil, errors.Wrap(err, "could not marshal webhook event")
+			}
+			body = string(data)
+		}
+
+		previews = append(previews, domain.NotificationPreview{
+			Event: payload.Event,
+			Title: title,
+			Body:  body,
+		})
+	}
+
+	return previews, nil
+}
+
+// testPayloads returns a sample payload for every notification event
+func testPayloads() []domain.NotificationPayload {
+	return []domain.NotificationPayload{
 		{
 			Subject:   "Test Notification",
 			Message:   "autobrr goes brr!!",
@@ -467,6 +623,13 @@ func (s *Service) Test(ctx context.Context, notification *domain.Notification) e
 			Event:     domain.NotificationEventIRCReconnected,
 			Timestamp: time.Now(),
 		},
+		{
+			Subject:   "Announce parsing failing",
+			Message:   "Network: P2P-Network\nIndexer: MockIndexer\nFailed announces in a row: 10",
+			Event:     domain.NotificationEventAnnounceParseFailing,
+			Indexer:   "MockIndexer",
+			Timestamp: time.Now(),
+		},
 		{
 			Subject:   "New update available!",
 			Message:   "v1.6.0",
@@ -499,52 +662,6 @@ func (s *Service) Test(ctx context.Context, notification *domain.Notification) e
 			},
 		},
 	}
-
-	switch notification.Type {
-	case domain.NotificationTypeDiscord:
-		agent = NewDiscordSender(s.log, notification)
-	case domain.NotificationTypeGotify:
-		agent = NewGotifySender(s.log, notification)
-	case domain.NotificationTypeLunaSea:
-		agent = NewLunaSeaSender(s.log, notification)
-	case domain.NotificationTypeNotifiarr:
-		agent = NewNotifiarrSender(s.log, notification)
-	case domain.NotificationTypeNtfy:
-		agent = NewNtfySender(s.log, notification)
-	case domain.NotificationTypePushover:
-		agent = NewPushoverSender(s.log, notification)
-	case domain.NotificationTypeShoutrrr:
-		agent = NewShoutrrrSender(s.log, notification)
-	case domain.NotificationTypeTelegram:
-		agent = NewTelegramSender(s.log, notification)
-	case domain.NotificationTypeWebhook:
-		agent = NewWebhookSender(s.log, notification)
-	default:
-		s.log.Error().Msgf("unsupported notification type: %v", notification.Type)
-		return errors.New("unsupported notification type")
-	}
-
-	g, _ := errgroup.WithContext(ctx)
-
-	for _, event := range events {
-		if !enabledEvent(notification.Events, event.Event) {
-			continue
-		}
-
-		if err := agent.Send(event.Event, event); err != nil {
-			s.log.Error().Err(err).Msgf("error sending test notification: %#v", notification)
-			return err
-		}
-
-		time.Sleep(1 * time.Second)
-	}
-
-	if err := g.Wait(); err != nil {
-		s.log.Error().Err(err).Msgf("Something went wrong sending test notifications to %v", notification.Type)
-		return err
-	}
-
-	return nil
 }
 
 func enabledEvent(events []string, e domain.NotificationEvent) bool {
@@ -1,12 +1,15 @@
 package notification
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockSender struct {
@@ -92,3 +95,44 @@ func TestService_Send_Optimization(t *testing.T) {
 		sender.AssertExpectations(t)
 	})
 }
+
+func TestService_Preview(t *testing.T) {
+	svc := &Service{log: zerolog.Nop()}
+
+	t.Run("renders templates of enabled events", func(t *testing.T) {
+		previews, err := svc.Preview(context.Background(), &domain.Notification{
+			Type:          domain.NotificationTypeTelegram,
+			Events:        []string{string(domain.NotificationEventPushApproved), string(domain.NotificationEventIRCDisconnected)},
+			TitleTemplate: "{{ .Title }}",
+			BodyTemplate:  "{{ .Subject }}{{ with .Release }} {{ .TorrentName }}{{ end }}",
+		})
+		require.NoError(t, err)
+		require.Len(t, previews, 2)
+
+		assert.Equal(t, domain.NotificationEventPushApproved, previews[0].Event)
+		assert.Equal(t, "Push Approved", previews[0].Title)
+		assert.Equal(t, "New release! Best.Show.Ever.S18E21.1080p.AMZN.WEB-DL.DDP2.0.H.264-GROUP", previews[0].Body)
+
+		assert.Equal(t, domain.NotificationEventIRCDisconnected, previews[1].Event)
+		assert.Equal(t, "IRC Disconnected unexpectedly", previews[1].Body)
+	})
+
+	t.Run("webhook without template previews json", func(t *testing.T) {
+		previews, err := svc.Preview(context.Background(), &domain.Notification{
+			Type:   domain.NotificationTypeWebhook,
+			Events: []string{string(domain.NotificationEventTest)},
+		})
+		require.NoError(t, err)
+		require.Len(t, previews, 1)
+		assert.Contains(t, previews[0].Body, `"event": "test"`)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := svc.Preview(context.Background(), &domain.Notification{
+			Type:         domain.NotificationTypeWebhook,
+			Events:       []string{string(domain.NotificationEventTest)},
+			BodyTemplate: "{{ .Missing }}",
+		})
+		assert.Error(t, err)
+	})
+}
@@ -29,7 +29,10 @@ func NewShoutrrrSender(log zerolog.Logger, settings *domain.Notification) domain
 }
 
 func (s *shoutrrrSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
-	message := s.builder.BuildBody(payload)
+	_, message, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
 
 	if err := shoutrrr.Send(s.Settings.Host, message); err != nil {
 		return err
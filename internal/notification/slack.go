@@ -0,0 +1,250 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/sharedhttp"
+
+	"github.com/rs/zerolog"
+)
+
+// slackMaxSectionFields is the number of fields Slack allows in a section block
+const slackMaxSectionFields = 10
+
+type SlackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []SlackAttachment `json:"attachments,omitempty"`
+}
+
+// SlackAttachment wraps the blocks to show the color bar of the event
+type SlackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+type SlackBlock struct {
+	Type     string      `json:"type"`
+	Text     *SlackText  `json:"text,omitempty"`
+	Fields   []SlackText `json:"fields,omitempty"`
+	Elements []SlackText `json:"elements,omitempty"`
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackSender struct {
+	log      zerolog.Logger
+	Settings *domain.Notification
+
+	httpClient *http.Client
+}
+
+func (s *slackSender) Name() string {
+	return "slack"
+}
+
+func NewSlackSender(log zerolog.Logger, settings *domain.Notification) domain.NotificationSender {
+	return &slackSender{
+		log:      log.With().Str("sender", "slack").Str("name", settings.Name).Logger(),
+		Settings: settings,
+		httpClient: &http.Client{
+			Timeout:   time.Second * 30,
+			Transport: sharedhttp.Transport,
+		},
+	}
+}
+
+func (s *slackSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
+	m, err := s.buildMessage(event, payload)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal json request for event: %v payload: %v", event, payload)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.Settings.Webhook, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return errors.Wrap(err, "could not create request for event: %v payload: %v", event, payload)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autobrr")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "client request error for event: %v payload: %v", event, payload)
+	}
+
+	defer sharedhttp.DrainAndClose(res)
+
+	s.log.Trace().Msgf("slack response status: %d", res.StatusCode)
+
+	if res.StatusCode != http.StatusOK {
+		limitedReader := io.LimitReader(res.Body, 4096)
+		body, err := io.ReadAll(limitedReader)
+		if err != nil {
+			return errors.Wrap(err, "could not read body for event: %v payload: %v", event, payload)
+		}
+
+		return errors.New("unexpected status: %v body: %v", res.StatusCode, string(body))
+	}
+
+	s.log.Debug().Str("event", string(event)).Msg("notification successfully sent to slack")
+
+	return nil
+}
+
+// buildMessage returns a Block Kit message with a header, the release or
+// message, the release details as fields and a timestamp. Templates replace
+// the header and the message, the fields are kept.
+func (s *slackSender) buildMessage(event domain.NotificationEvent, payload domain.NotificationPayload) (SlackMessage, error) {
+	title, body, err := renderTemplates(s.Settings, event, payload)
+	if err != nil {
+		return SlackMessage{}, err
+	}
+
+	headline, description := messageHeadline(payload)
+
+	text := fmt.Sprintf("*%s*\n%s", slackEscape(headline), slackEscape(description))
+	if s.Settings.BodyTemplate != "" {
+		text = body
+	}
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: truncate(title, 150)}},
+	}
+
+	if strings.TrimSpace(text) != "" {
+		blocks = append(blocks, SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: truncate(text, 3000)}})
+	}
+
+	var fields []SlackText
+	for _, f := range buildFields(payload) {
+		value := slackEscape(f.Value)
+		if f.Name == "Reasons" {
+			value = fmt.Sprintf("```%s```", value)
+		}
+		fields = append(fields, SlackText{Type: "mrkdwn", Text: truncate(fmt.Sprintf("*%s*\n%s", f.Name, value), 2000)})
+	}
+
+	for start := 0; start < len(fields); start += slackMaxSectionFields {
+		end := min(start+slackMaxSectionFields, len(fields))
+		blocks = append(blocks, SlackBlock{Type: "section", Fields: fields[start:end]})
+	}
+
+	timestamp := payload.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	blocks = append(blocks, SlackBlock{
+		Type: "context",
+		Elements: []SlackText{
+			{Type: "mrkdwn", Text: fmt.Sprintf("autobrr • <!date^%d^{date_short_pretty} {time}|%s>", timestamp.Unix(), timestamp.UTC().Format(time.RFC1123))},
+		},
+	})
+
+	return SlackMessage{
+		// text is used for push notifications and clients without block support
+		Text: fmt.Sprintf("%s: %s", title, headline),
+		Attachments: []SlackAttachment{
+			{
+				Color:  eventColor(event).Hex(),
+				Blocks: blocks,
+			},
+		},
+	}, nil
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// truncate shortens text to the limit of a Slack text object
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+func (s *slackSender) CanSend(event domain.NotificationEvent) bool {
+	if s.IsEnabled() && s.isEnabledEvent(event) {
+		return true
+	}
+	return false
+}
+
+func (s *slackSender) CanSendPayload(event domain.NotificationEvent, payload domain.NotificationPayload) bool {
+	if !s.IsEnabled() {
+		return false
+	}
+
+	if payload.FilterID > 0 {
+		if s.Settings.FilterMuted(payload.FilterID) {
+			s.log.Trace().Str("event", string(event)).Int("filter_id", payload.FilterID).Str("filter", payload.Filter).Msg("notification muted by filter")
+			return false
+		}
+
+		// Check if the filter has custom notifications configured
+		if s.Settings.FilterEventEnabled(payload.FilterID, event) {
+			return true
+		}
+
+		// If the filter has custom notifications but the event is not enabled, don't fall back to global
+		if s.Settings.HasFilterNotifications(payload.FilterID) {
+			return false
+		}
+	}
+
+	// Fall back to global events for non-filter events or filters without custom notifications
+	if s.isEnabledEvent(event) {
+		return true
+	}
+
+	return false
+}
+
+func (s *slackSender) HasFilterEvents(filterID int) bool {
+	if s.Settings.HasFilterNotifications(filterID) {
+		return true
+	}
+	return false
+}
+
+// IsEnabled requires an incoming webhook url
+func (s *slackSender) IsEnabled() bool {
+	if !s.Settings.IsEnabled() {
+		return false
+	}
+
+	u, err := url.Parse(s.Settings.Webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	return true
+}
+
+func (s *slackSender) isEnabledEvent(event domain.NotificationEvent) bool {
+	return s.Settings.EventEnabled(string(event))
+}
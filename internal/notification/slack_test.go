@@ -0,0 +1,133 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackSender_Send(t *testing.T) {
+	var received SlackMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	settings := &domain.Notification{
+		Name:    "Slack",
+		Type:    domain.NotificationTypeSlack,
+		Enabled: true,
+		Webhook: server.URL,
+		Events:  []string{string(domain.NotificationEventPushRejected)},
+	}
+
+	sender := NewSlackSender(logger.Mock().With().Logger(), settings)
+	require.True(t, sender.IsEnabled())
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushRejected,
+		ReleaseName: "Best.Show.S01E01.1080p.WEB-DL-GROUP",
+		Filter:      "TV & Movies",
+		Indexer:     "MockIndexer",
+		Status:      domain.ReleasePushStatusRejected,
+		Rejections:  []string{"Unknown Series"},
+		Timestamp:   time.Unix(1700000000, 0),
+	}
+
+	require.NoError(t, sender.Send(payload.Event, payload))
+
+	assert.Equal(t, "Push Rejected: Best.Show.S01E01.1080p.WEB-DL-GROUP", received.Text)
+	require.Len(t, received.Attachments, 1)
+
+	attachment := received.Attachments[0]
+	assert.Equal(t, "#99aab5", attachment.Color)
+	require.Len(t, attachment.Blocks, 4)
+
+	assert.Equal(t, "header", attachment.Blocks[0].Type)
+	assert.Equal(t, "Push Rejected", attachment.Blocks[0].Text.Text)
+
+	assert.Equal(t, "section", attachment.Blocks[1].Type)
+	assert.Equal(t, "*Best.Show.S01E01.1080p.WEB-DL-GROUP*\nNew release!", attachment.Blocks[1].Text.Text)
+
+	fields := attachment.Blocks[2].Fields
+	require.Len(t, fields, 4)
+	assert.Equal(t, "*Status*\nRejected", fields[0].Text)
+	assert.Equal(t, "*Filter*\nTV &amp; Movies", fields[2].Text)
+	assert.Equal(t, "*Reasons*\n```Unknown Series```", fields[3].Text)
+
+	assert.Equal(t, "context", attachment.Blocks[3].Type)
+	assert.Contains(t, attachment.Blocks[3].Elements[0].Text, "<!date^1700000000^")
+}
+
+func TestSlackSender_Send_Templates(t *testing.T) {
+	var received SlackMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	settings := &domain.Notification{
+		Type:          domain.NotificationTypeSlack,
+		Enabled:       true,
+		Webhook:       server.URL,
+		TitleTemplate: "{{ .Indexer }} grabbed",
+		BodyTemplate:  "*{{ .ReleaseName }}*",
+	}
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventPushApproved,
+		ReleaseName: "Best.Show.S01E01.1080p.WEB-DL-GROUP",
+		Indexer:     "MockIndexer",
+	}
+
+	require.NoError(t, NewSlackSender(logger.Mock().With().Logger(), settings).Send(payload.Event, payload))
+
+	blocks := received.Attachments[0].Blocks
+	assert.Equal(t, "MockIndexer grabbed", blocks[0].Text.Text)
+	assert.Equal(t, "*Best.Show.S01E01.1080p.WEB-DL-GROUP*", blocks[1].Text.Text)
+	assert.Equal(t, "*Indexer*\nMockIndexer", blocks[2].Fields[0].Text)
+}
+
+func TestSlackSender_Send_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	settings := &domain.Notification{
+		Type:    domain.NotificationTypeSlack,
+		Enabled: true,
+		Webhook: server.URL,
+	}
+
+	err := NewSlackSender(logger.Mock().With().Logger(), settings).Send(domain.NotificationEventTest, domain.NotificationPayload{Subject: "Test", Message: "test"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no_service")
+}
+
+func TestSlackSender_IsEnabled(t *testing.T) {
+	log := logger.Mock().With().Logger()
+
+	assert.True(t, NewSlackSender(log, &domain.Notification{Type: domain.NotificationTypeSlack, Enabled: true, Webhook: "https://hooks.slack.com/services/T000/B000/XXX"}).IsEnabled())
+	assert.False(t, NewSlackSender(log, &domain.Notification{Type: domain.NotificationTypeSlack, Enabled: true, Webhook: "hooks.slack.com/services"}).IsEnabled())
+	assert.False(t, NewSlackSender(log, &domain.Notification{Type: domain.NotificationTypeSlack, Enabled: false, Webhook: "https://hooks.slack.com/services/T000/B000/XXX"}).IsEnabled())
+}
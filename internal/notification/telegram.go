@@ -64,7 +64,11 @@ func NewTelegramSender(log zerolog.Logger, settings *domain.Notification) domain
 func (s *telegramSender) Send(event domain.NotificationEvent, payload domain.NotificationPayload) error {
 	payload.Sender = s.Settings.Username
 
-	message := s.builder.BuildBody(payload)
+	_, message, err := buildMessage(s.Settings, &s.builder, event, payload)
+	if err != nil {
+		return err
+	}
+
 	m := TelegramMessage{
 		ChatID:          s.Settings.Channel,
 		Text:            message,
@@ -86,7 +90,12 @@ func (s *telegramSender) Send(event domain.NotificationEvent, payload domain.Not
 		host = s.Settings.Host
 	}
 
-	url := fmt.Sprintf("%v/bot%v/sendMessage", host, s.Settings.Token)
+	token, err := domain.ResolveSecret(s.Settings.Token)
+	if err != nil {
+		return errors.Wrap(err, "could not resolve token")
+	}
+
+	url := fmt.Sprintf("%v/bot%v/sendMessage", host, token)
 
 	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -48,9 +48,9 @@ func (s *webhookSender) Send(event domain.NotificationEvent, payload domain.Noti
 	// Build the full payload with new structured schema
 	webhookPayload := domain.NewWebhookEvent(event, payload, eventID)
 
-	jsonData, err := json.Marshal(webhookPayload)
+	body, err := s.buildBody(event, webhookPayload, payload)
 	if err != nil {
-		return errors.Wrap(err, "could not marshal json request for event: %v", event)
+		return err
 	}
 
 	// Use configured method or default to POST
@@ -59,7 +59,7 @@ func (s *webhookSender) Send(event domain.NotificationEvent, payload domain.Noti
 		method = http.MethodPost
 	}
 
-	req, err := http.NewRequest(method, s.Settings.Webhook, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest(method, s.Settings.Webhook, bytes.NewBuffer(body))
 	if err != nil {
 		return errors.Wrap(err, "could not create request for event: %v", event)
 	}
@@ -104,6 +104,25 @@ func (s *webhookSender) Send(event domain.NotificationEvent, payload domain.Noti
 	return nil
 }
 
+// buildBody returns the rendered body template, or the webhook event as json without a template
+func (s *webhookSender) buildBody(event domain.NotificationEvent, webhookPayload *domain.WebhookEvent, payload domain.NotificationPayload) ([]byte, error) {
+	if s.Settings.BodyTemplate != "" {
+		_, body, err := renderTemplates(s.Settings, event, payload)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(body), nil
+	}
+
+	jsonData, err := json.Marshal(webhookPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal json request for event: %v", event)
+	}
+
+	return jsonData, nil
+}
+
 func (s *webhookSender) CanSend(event domain.NotificationEvent) bool {
 	if s.IsEnabled() && s.isEnabledEvent(event) {
 		return true
@@ -174,3 +174,46 @@ func TestGenericWebhookSender_Send_CustomHeaders(t *testing.T) {
 	err := sender.Send(domain.NotificationEventTest, domain.NotificationPayload{Event: domain.NotificationEventTest})
 	assert.NoError(t, err)
 }
+
+func TestGenericWebhookSender_Send_BodyTemplate(t *testing.T) {
+	// Test that the body template replaces the json event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		assert.Equal(t, string(domain.WebhookEventReleaseNew), r.Header.Get("X-Autobrr-Event"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "New Release: Test.Release-Group [1080p] from MockIndexer", string(body))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	settings := &domain.Notification{
+		Name:         "Test Webhook with template",
+		Type:         domain.NotificationTypeWebhook,
+		Webhook:      server.URL,
+		Enabled:      true,
+		Events:       []string{"RELEASE_NEW"},
+		Headers:      "Content-Type=text/plain",
+		BodyTemplate: `{{ .Title }}: {{ .ReleaseName }} [{{ .Macro.Resolution }}] from {{ .Indexer }}`,
+	}
+
+	log := logger.Mock().With().Logger()
+	sender := NewWebhookSender(log, settings)
+
+	payload := domain.NotificationPayload{
+		Event:       domain.NotificationEventReleaseNew,
+		Timestamp:   time.Now(),
+		ReleaseName: "Test.Release-Group",
+		Indexer:     "MockIndexer",
+		Release: &domain.Release{
+			TorrentName: "Test.Release-Group",
+			Resolution:  "1080p",
+		},
+	}
+
+	err := sender.Send(domain.NotificationEventReleaseNew, payload)
+	assert.NoError(t, err)
+}
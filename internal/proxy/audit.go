@@ -0,0 +1,19 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package proxy
+
+import (
+	"strconv"
+
+	"github.com/autobrr/autobrr/internal/audit"
+	"github.com/autobrr/autobrr/internal/domain"
+)
+
+// proxyAudit records the changes of proxies
+var proxyAudit = audit.Resource[domain.Proxy]{
+	Type: domain.AuditResourceProxy,
+	Key: func(p *domain.Proxy) (string, string) {
+		return strconv.FormatInt(p.ID, 10), p.Name
+	},
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/pkg/errors"
@@ -30,15 +31,17 @@ type Service interface {
 type service struct {
 	log zerolog.Logger
 
-	repo  domain.ProxyRepo
-	cache map[int64]*domain.Proxy
+	repo     domain.ProxyRepo
+	auditSvc audit.Service
+	cache    map[int64]*domain.Proxy
 }
 
-func NewService(log logger.Logger, repo domain.ProxyRepo) Service {
+func NewService(log logger.Logger, repo domain.ProxyRepo, auditSvc audit.Service) Service {
 	return &service{
-		log:   log.With().Str("module", "proxy").Logger(),
-		repo:  repo,
-		cache: make(map[int64]*domain.Proxy),
+		log:      log.With().Str("module", "proxy").Logger(),
+		repo:     repo,
+		auditSvc: auditSvc,
+		cache:    make(map[int64]*domain.Proxy),
 	}
 }
 
@@ -54,6 +57,8 @@ func (s *service) Store(ctx context.Context, proxy *domain.Proxy) error {
 
 	s.cache[proxy.ID] = proxy
 
+	proxyAudit.Record(ctx, s.auditSvc, domain.AuditActionCreate, nil, proxy)
+
 	return nil
 }
 
@@ -77,6 +82,8 @@ func (s *service) Update(ctx context.Context, proxy *domain.Proxy) error {
 
 	s.cache[proxy.ID] = proxy
 
+	proxyAudit.Record(ctx, s.auditSvc, domain.AuditActionUpdate, existingProxy, proxy)
+
 	// TODO update IRC handlers
 
 	return nil
@@ -95,11 +102,19 @@ func (s *service) List(ctx context.Context) ([]domain.Proxy, error) {
 }
 
 func (s *service) ToggleEnabled(ctx context.Context, id int64, enabled bool) error {
+	existingProxy, _ := s.repo.FindByID(ctx, id)
+
 	err := s.repo.ToggleEnabled(ctx, id, enabled)
 	if err != nil {
 		return err
 	}
 
+	if existingProxy != nil {
+		toggled := *existingProxy
+		toggled.Enabled = enabled
+		proxyAudit.Record(ctx, s.auditSvc, domain.AuditActionToggle, existingProxy, &toggled)
+	}
+
 	v, ok := s.cache[id]
 	if !ok {
 		v.Enabled = !enabled
@@ -112,6 +127,8 @@ func (s *service) ToggleEnabled(ctx context.Context, id int64, enabled bool) err
 }
 
 func (s *service) Delete(ctx context.Context, id int64) error {
+	existingProxy, _ := s.repo.FindByID(ctx, id)
+
 	err := s.repo.Delete(ctx, id)
 	if err != nil {
 		return err
@@ -119,6 +136,10 @@ func (s *service) Delete(ctx context.Context, id int64) error {
 
 	delete(s.cache, id)
 
+	if existingProxy != nil {
+		proxyAudit.Record(ctx, s.auditSvc, domain.AuditActionDelete, existingProxy, nil)
+	}
+
 	// TODO update IRC handlers
 
 	return nil
@@ -176,9 +197,14 @@ func GetProxiedHTTPClient(p *domain.Proxy) (*http.Client, error) {
 		return nil, errors.Wrap(err, "could not parse proxy url: %s", p.Addr)
 	}
 
+	pass, err := domain.ResolveSecret(p.Pass)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not resolve proxy password")
+	}
+
 	// set user and pass if not empty
-	if p.User != "" && p.Pass != "" {
-		proxyUrl.User = url.UserPassword(p.User, p.Pass)
+	if p.User != "" && pass != "" {
+		proxyUrl.User = url.UserPassword(p.User, pass)
 	}
 
 	transport := sharedhttp.ProxyTransport.Clone()
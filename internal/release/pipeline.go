@@ -0,0 +1,249 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultPipelineWorkers = 10
+	pipelineQueueSize      = 1000
+)
+
+// rejections recorded for the actions of delayed jobs that never run
+const (
+	rejectionDelayedCancelled = "delayed job was cancelled"
+	rejectionDelayedStopped   = "delayed job was dropped on shutdown"
+)
+
+// delayedJob holds a matched release until the filter delay has passed.
+// The remaining filters are kept so the release can move on if the actions are rejected.
+type delayedJob struct {
+	domain.ReleaseDelayedJob
+
+	release *domain.Release
+	// filterIDs are the filters to try next if the actions are rejected.
+	// They are loaded again when the job runs since filters are shared with other releases.
+	filterIDs []int
+	timer     *time.Timer
+}
+
+func newDelayedJob(release *domain.Release, filter *domain.Filter, remaining []*domain.Filter) *delayedJob {
+	now := time.Now()
+
+	filterIDs := make([]int, 0, len(remaining))
+	for _, f := range remaining {
+		filterIDs = append(filterIDs, f.ID)
+	}
+
+	return &delayedJob{
+		ReleaseDelayedJob: domain.ReleaseDelayedJob{
+			ID:          fmt.Sprintf("%d-%d", release.ID, filter.ID),
+			ReleaseID:   release.ID,
+			ReleaseName: release.TorrentName,
+			Indexer:     release.Indexer.Identifier,
+			FilterID:    filter.ID,
+			FilterName:  filter.Name,
+			Delay:       filter.Delay,
+			QueuedAt:    now,
+			RunAt:       now.Add(time.Duration(filter.Delay) * time.Second),
+		},
+		release:   release,
+		filterIDs: filterIDs,
+	}
+}
+
+// pipeline runs release jobs on a fixed number of workers.
+// Delayed jobs wait on a timer and are put on the queue when due, so they don't hold a worker.
+type pipeline struct {
+	log     zerolog.Logger
+	workers int
+
+	queue     chan func(ctx context.Context)
+	inFlight  atomic.Int64
+	processed atomic.Uint64
+
+	m       sync.Mutex
+	delayed map[string]*delayedJob
+	started bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPipeline(log zerolog.Logger, workers int) *pipeline {
+	if workers <= 0 {
+		workers = defaultPipelineWorkers
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &pipeline{
+		log:     log,
+		workers: workers,
+		queue:   make(chan func(ctx context.Context), pipelineQueueSize),
+		delayed: map[string]*delayedJob{},
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (p *pipeline) start() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.started {
+		return
+	}
+	p.started = true
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	p.log.Debug().Msgf("started release pipeline with %d workers", p.workers)
+}
+
+// stop cancels pending delayed jobs and waits for running jobs to finish.
+// Jobs still on the queue are dropped, the cancelled delayed jobs are returned to be cleaned up.
+func (p *pipeline) stop() []*delayedJob {
+	p.m.Lock()
+	cancelled := make([]*delayedJob, 0, len(p.delayed))
+	for id, job := range p.delayed {
+		job.timer.Stop()
+		delete(p.delayed, id)
+		cancelled = append(cancelled, job)
+	}
+	p.m.Unlock()
+
+	p.cancel()
+	p.wg.Wait()
+
+	return cancelled
+}
+
+func (p *pipeline) worker() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case job := <-p.queue:
+			p.run(job)
+		}
+	}
+}
+
+func (p *pipeline) run(job func(ctx context.Context)) {
+	p.inFlight.Add(1)
+	defer func() {
+		p.inFlight.Add(-1)
+		p.processed.Add(1)
+
+		if r := recover(); r != nil {
+			p.log.Error().Msgf("recovering from panic in release pipeline job error: %v", r)
+		}
+	}()
+
+	job(p.ctx)
+}
+
+// enqueue adds a job to the queue. It blocks while the queue is full.
+func (p *pipeline) enqueue(job func(ctx context.Context)) {
+	select {
+	case <-p.ctx.Done():
+	case p.queue <- job:
+	}
+}
+
+// delay schedules a job to be queued when it is due.
+func (p *pipeline) delay(job *delayedJob, run func(ctx context.Context, job *delayedJob)) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if existing, ok := p.delayed[job.ID]; ok {
+		existing.timer.Stop()
+	}
+
+	job.timer = time.AfterFunc(time.Until(job.RunAt), func() {
+		p.m.Lock()
+		current, ok := p.delayed[job.ID]
+		if !ok || current != job {
+			// cancelled or replaced
+			p.m.Unlock()
+			return
+		}
+		delete(p.delayed, job.ID)
+		p.m.Unlock()
+
+		p.enqueue(func(ctx context.Context) {
+			run(ctx, job)
+		})
+	})
+
+	p.delayed[job.ID] = job
+}
+
+// cancelDelayed removes the delayed job and returns it to be cleaned up
+func (p *pipeline) cancelDelayed(id string) (*delayedJob, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	job, ok := p.delayed[id]
+	if !ok {
+		return nil, errors.Wrap(domain.ErrRecordNotFound, "delayed job %s not found", id)
+	}
+
+	job.timer.Stop()
+	delete(p.delayed, id)
+
+	p.log.Info().Msgf("cancelled delayed job for release %s filter %s", job.ReleaseName, job.FilterName)
+
+	return job, nil
+}
+
+func (p *pipeline) delayedJobs() []domain.ReleaseDelayedJob {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	jobs := make([]domain.ReleaseDelayedJob, 0, len(p.delayed))
+	for _, job := range p.delayed {
+		jobs = append(jobs, job.ReleaseDelayedJob)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].RunAt.Before(jobs[j].RunAt)
+	})
+
+	return jobs
+}
+
+func (p *pipeline) stats() domain.ReleaseQueueStats {
+	p.m.Lock()
+	delayed := len(p.delayed)
+	p.m.Unlock()
+
+	return domain.ReleaseQueueStats{
+		Workers:       p.workers,
+		QueueDepth:    len(p.queue),
+		QueueCapacity: cap(p.queue),
+		InFlight:      p.inFlight.Load(),
+		Delayed:       delayed,
+		Processed:     p.processed.Load(),
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package release
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_BoundedWorkers(t *testing.T) {
+	p := newPipeline(logger.Mock().With().Logger(), 2)
+	p.start()
+	defer p.stop()
+
+	var (
+		running atomic.Int64
+		maxSeen atomic.Int64
+		wg      sync.WaitGroup
+	)
+
+	release := make(chan struct{})
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		p.enqueue(func(ctx context.Context) {
+			defer wg.Done()
+
+			n := running.Add(1)
+			for {
+				current := maxSeen.Load()
+				if n <= current || maxSeen.CompareAndSwap(current, n) {
+					break
+				}
+			}
+
+			<-release
+			running.Add(-1)
+		})
+	}
+
+	assert.Eventually(t, func() bool {
+		return p.stats().InFlight == 2
+	}, time.Second, 5*time.Millisecond)
+
+	stats := p.stats()
+	assert.Equal(t, 2, stats.Workers)
+	assert.Equal(t, 4, stats.QueueDepth)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(2), maxSeen.Load())
+	assert.Eventually(t, func() bool {
+		return p.stats().Processed == 6
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestPipeline_Delay(t *testing.T) {
+	p := newPipeline(logger.Mock().With().Logger(), 1)
+	p.start()
+	defer p.stop()
+
+	rls := &domain.Release{ID: 1, TorrentName: "Test.Release-GROUP"}
+
+	done := make(chan string, 2)
+	run := func(ctx context.Context, job *delayedJob) {
+		done <- job.ID
+	}
+
+	due := newDelayedJob(rls, &domain.Filter{ID: 1, Name: "due"}, nil)
+	due.RunAt = time.Now().Add(20 * time.Millisecond)
+
+	cancelled := newDelayedJob(rls, &domain.Filter{ID: 2, Name: "cancelled", Delay: 60}, nil)
+
+	p.delay(due, run)
+	p.delay(cancelled, run)
+
+	jobs := p.delayedJobs()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "1-1", jobs[0].ID)
+	assert.Equal(t, "1-2", jobs[1].ID)
+
+	job, err := p.cancelDelayed("1-2")
+	assert.NoError(t, err)
+	assert.Equal(t, cancelled, job)
+
+	_, err = p.cancelDelayed("1-2")
+	assert.ErrorIs(t, err, domain.ErrRecordNotFound)
+
+	select {
+	case id := <-done:
+		assert.Equal(t, "1-1", id)
+	case <-time.After(time.Second):
+		t.Fatal("delayed job did not run")
+	}
+
+	assert.Empty(t, p.delayedJobs())
+	assert.Equal(t, 0, p.stats().Delayed)
+}
+
+func TestService_ProcessFilters_Delay(t *testing.T) {
+	log := logger.Mock()
+
+	delayFilter := &domain.Filter{ID: 1, Name: "Delay Filter", Enabled: true, Delay: 60, RejectReasons: domain.NewRejectionReasons()}
+
+	filterSvc := &mockFilterService{}
+	filterSvc.On("CheckFilter", mock.Anything, delayFilter, mock.Anything).Return(true, nil)
+	filterSvc.On("FindByID", mock.Anything, 1).Return(delayFilter, nil)
+
+	act := &domain.Action{ID: 1, Name: "Test Action", Type: domain.ActionTypeTest, Enabled: true}
+	actionSvc := &mockActionService{}
+	actionSvc.On("FindByFilterID", mock.Anything, 1, mock.Anything, false).Return([]*domain.Action{act}, nil)
+	actionSvc.On("RunAction", mock.Anything, act, mock.Anything).Return(nil, nil)
+
+	repo := &mockReleaseRepo{}
+	repo.On("Store", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Release).ID = 10
+	}).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Return(nil)
+
+	s := &service{
+		log:       log.With().Logger(),
+		bus:       EventBus.New(),
+		filterSvc: filterSvc,
+		actionSvc: actionSvc,
+		repo:      repo,
+		pipeline:  newPipeline(log.With().Logger(), 1),
+	}
+
+	tmpFile := filepath.Join(t.TempDir(), "release.torrent")
+	assert.NoError(t, os.WriteFile(tmpFile, []byte("torrent"), 0644))
+
+	rls := &domain.Release{
+		TorrentName:    "Test.Release-GROUP",
+		TorrentTmpFile: tmpFile,
+		Indexer:        domain.IndexerMinimal{Name: "MockIndexer", Identifier: "mock"},
+	}
+
+	result := &domain.ReleasePushResult{}
+
+	err := s.processRelease(context.Background(), rls, []*domain.Filter{delayFilter}, result)
+	assert.NoError(t, err)
+
+	// release is stored as approved but actions wait for the delay
+	assert.NotNil(t, result.DelayedUntil)
+	assert.Empty(t, result.Actions)
+	actionSvc.AssertNotCalled(t, "RunAction", mock.Anything, mock.Anything, mock.Anything)
+
+	jobs := s.ListDelayedJobs(context.Background())
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "10-1", jobs[0].ID)
+	assert.Equal(t, 60, jobs[0].Delay)
+
+	// the torrent file is kept for the delayed actions
+	assert.Equal(t, tmpFile, rls.TorrentTmpFile)
+	assert.FileExists(t, tmpFile)
+
+	// run the job directly instead of waiting for the timer
+	s.runDelayedJob(context.Background(), s.pipeline.delayed["10-1"])
+
+	actionSvc.AssertCalled(t, "RunAction", mock.Anything, act, rls)
+	assert.Empty(t, rls.TorrentTmpFile)
+	assert.NoFileExists(t, tmpFile)
+	assert.NoError(t, s.CancelDelayedJob(context.Background(), "10-1"))
+}
+
+func TestService_RunDelayedJob_RemainingFilters(t *testing.T) {
+	log := logger.Mock()
+
+	delayFilter := &domain.Filter{ID: 1, Name: "Delay Filter", Enabled: true, Delay: 60, RejectReasons: domain.NewRejectionReasons()}
+	nextFilter := &domain.Filter{ID: 2, Name: "Next Filter", Enabled: true, RejectReasons: domain.NewRejectionReasons()}
+	otherFilter := &domain.Filter{ID: 3, Name: "Other Filter", Enabled: true, RejectReasons: domain.NewRejectionReasons()}
+
+	// the remaining filters are loaded again instead of reusing the ones the job was created with
+	freshNextFilter := &domain.Filter{ID: 2, Name: "Next Filter Edited", Enabled: true, RejectReasons: domain.NewRejectionReasons()}
+
+	filterSvc := &mockFilterService{}
+	filterSvc.On("FindByID", mock.Anything, 1).Return(delayFilter, nil)
+	filterSvc.On("FindByIndexerIdentifier", mock.Anything, "mock").Return([]*domain.Filter{delayFilter, freshNextFilter, otherFilter}, nil)
+	filterSvc.On("CheckFilter", mock.Anything, freshNextFilter, mock.Anything).Return(true, nil)
+
+	rejectAction := &domain.Action{ID: 1, Name: "Reject Action", Type: domain.ActionTypeTest, Enabled: true}
+	nextAction := &domain.Action{ID: 2, Name: "Next Action", Type: domain.ActionTypeTest, ClientID: 2, Enabled: true}
+
+	actionSvc := &mockActionService{}
+	actionSvc.On("FindByFilterID", mock.Anything, 1, mock.Anything, false).Return([]*domain.Action{rejectAction}, nil)
+	actionSvc.On("FindByFilterID", mock.Anything, 2, mock.Anything, false).Return([]*domain.Action{nextAction}, nil)
+	actionSvc.On("RunAction", mock.Anything, rejectAction, mock.Anything).Return([]string{"rejected"}, nil)
+	actionSvc.On("RunAction", mock.Anything, nextAction, mock.Anything).Return(nil, nil)
+
+	repo := &mockReleaseRepo{}
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Return(nil)
+
+	s := &service{
+		log:       log.With().Logger(),
+		bus:       EventBus.New(),
+		filterSvc: filterSvc,
+		actionSvc: actionSvc,
+		repo:      repo,
+		pipeline:  newPipeline(log.With().Logger(), 1),
+	}
+
+	rls := &domain.Release{
+		ID:          10,
+		TorrentName: "Test.Release-GROUP",
+		Indexer:     domain.IndexerMinimal{Name: "MockIndexer", Identifier: "mock"},
+	}
+
+	job := newDelayedJob(rls, delayFilter, []*domain.Filter{nextFilter})
+	assert.Equal(t, []int{2}, job.filterIDs)
+
+	s.runDelayedJob(context.Background(), job)
+
+	filterSvc.AssertCalled(t, "CheckFilter", mock.Anything, freshNextFilter, rls)
+	filterSvc.AssertNotCalled(t, "CheckFilter", mock.Anything, otherFilter, mock.Anything)
+	actionSvc.AssertCalled(t, "RunAction", mock.Anything, nextAction, rls)
+	assert.Equal(t, 2, rls.FilterID)
+}
+
+func TestService_DropDelayedJobs(t *testing.T) {
+	log := logger.Mock()
+
+	act := &domain.Action{ID: 1, Name: "Test Action", Type: domain.ActionTypeTest, Enabled: true}
+	actionSvc := &mockActionService{}
+	actionSvc.On("FindByFilterID", mock.Anything, 1, mock.Anything, false).Return([]*domain.Action{act}, nil)
+
+	var statuses []*domain.ReleaseActionStatus
+	repo := &mockReleaseRepo{}
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		statuses = append(statuses, args.Get(1).(*domain.ReleaseActionStatus))
+	}).Return(nil)
+
+	s := &service{
+		log:       log.With().Logger(),
+		bus:       EventBus.New(),
+		actionSvc: actionSvc,
+		repo:      repo,
+		pipeline:  newPipeline(log.With().Logger(), 1),
+	}
+
+	filter := &domain.Filter{ID: 1, Name: "Delay Filter", Enabled: true, Delay: 60}
+
+	newRelease := func(id int64) *domain.Release {
+		tmpFile := filepath.Join(t.TempDir(), "release.torrent")
+		assert.NoError(t, os.WriteFile(tmpFile, []byte("torrent"), 0644))
+
+		return &domain.Release{ID: id, TorrentName: "Test.Release-GROUP", TorrentTmpFile: tmpFile, Indexer: domain.IndexerMinimal{Identifier: "mock"}}
+	}
+
+	run := func(ctx context.Context, job *delayedJob) {
+		t.Fatal("dropped delayed job must not run")
+	}
+
+	cancelled := newRelease(10)
+	cancelledFile := cancelled.TorrentTmpFile
+	s.pipeline.delay(newDelayedJob(cancelled, filter, nil), run)
+
+	stopped := newRelease(11)
+	stoppedFile := stopped.TorrentTmpFile
+	s.pipeline.delay(newDelayedJob(stopped, filter, nil), run)
+
+	// cancelled jobs remove the torrent file and record the actions as rejected
+	assert.NoError(t, s.CancelDelayedJob(context.Background(), "10-1"))
+	assert.NoFileExists(t, cancelledFile)
+	assert.FileExists(t, stoppedFile)
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, int64(10), statuses[0].ReleaseID)
+	assert.Equal(t, "Delay Filter", statuses[0].Filter)
+	assert.Equal(t, domain.ReleasePushStatusRejected, statuses[0].Status)
+	assert.Equal(t, []string{rejectionDelayedCancelled}, statuses[0].Rejections)
+
+	// pending jobs are dropped the same way on shutdown
+	s.StopPipeline()
+	assert.NoFileExists(t, stoppedFile)
+
+	require.Len(t, statuses, 2)
+	assert.Equal(t, int64(11), statuses[1].ReleaseID)
+	assert.Equal(t, []string{rejectionDelayedStopped}, statuses[1].Rejections)
+	actionSvc.AssertNotCalled(t, "RunAction", mock.Anything, mock.Anything, mock.Anything)
+}
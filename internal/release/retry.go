@@ -0,0 +1,168 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package release
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	actionRetryJobIdentifier = "release-action-retry"
+	actionRetryJobInterval   = 1 * time.Minute
+	actionRetryBatchSize     = 50
+)
+
+// ActionRetryJob re-drives failed action pushes that are due for another attempt
+type ActionRetryJob struct {
+	log     zerolog.Logger
+	service *service
+
+	// skip a run if the previous one is still going
+	running sync.Mutex
+}
+
+func (j *ActionRetryJob) Run() {
+	if !j.running.TryLock() {
+		j.log.Debug().Msg("previous action retry run still in progress, skipping")
+		return
+	}
+	defer j.running.Unlock()
+
+	ctx := context.Background()
+
+	retries, err := j.service.repo.FindDueActionRetries(ctx, time.Now(), actionRetryBatchSize)
+	if err != nil {
+		j.log.Error().Err(err).Msg("could not find action retries")
+		return
+	}
+
+	if len(retries) == 0 {
+		return
+	}
+
+	j.log.Debug().Msgf("found %d action retries due", len(retries))
+
+	for _, retry := range retries {
+		if err := j.service.processActionRetry(ctx, retry); err != nil {
+			j.log.Error().Err(err).Msgf("error processing action retry %d for release %d", retry.ID, retry.ReleaseID)
+		}
+	}
+}
+
+func (s *service) StartActionRetryJob() error {
+	job := &ActionRetryJob{
+		log:     s.log.With().Str("job", actionRetryJobIdentifier).Logger(),
+		service: s,
+	}
+
+	if _, err := s.scheduler.ScheduleJob(job, actionRetryJobInterval, actionRetryJobIdentifier); err != nil {
+		return errors.Wrap(err, "could not schedule action retry job")
+	}
+
+	s.log.Debug().Msg("successfully started action retry job")
+
+	return nil
+}
+
+// scheduleActionRetry stores a pending retry for a failed action push
+func (s *service) scheduleActionRetry(ctx context.Context, action *domain.Action, release *domain.Release, status *domain.ReleaseActionStatus, cause error) {
+	retry := &domain.ReleaseActionRetry{
+		ReleaseID:      release.ID,
+		ActionID:       action.ID,
+		ActionStatusID: status.ID,
+		Attempts:       0,
+		MaxAttempts:    action.RetryMaxAttempts,
+		NextAttemptAt:  time.Now().Add(action.RetryBackoffDuration(1)),
+		LastError:      cause.Error(),
+	}
+
+	if err := s.repo.StoreActionRetry(ctx, retry); err != nil {
+		s.log.Error().Err(err).Msgf("release.scheduleActionRetry: could not store retry for action %s release %s", action.Name, release.TorrentName)
+		return
+	}
+
+	s.log.Info().Msgf("push of %s to action %s failed, retry 1/%d scheduled at %s", release.TorrentName, action.Name, retry.MaxAttempts, retry.NextAttemptAt.Format(time.DateTime))
+}
+
+// processActionRetry runs a due retry through retryAction.
+// The retry is removed when the push succeeds, fails with a non retryable error or runs out of attempts,
+// otherwise the next attempt is scheduled with backoff.
+func (s *service) processActionRetry(ctx context.Context, retry *domain.ReleaseActionRetry) error {
+	release, err := s.Get(ctx, &domain.GetReleaseRequest{Id: int(retry.ReleaseID)})
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			return s.repo.DeleteActionRetry(ctx, retry.ID)
+		}
+		return errors.Wrap(err, "could not find release by id: %d", retry.ReleaseID)
+	}
+
+	action, err := s.actionSvc.Get(ctx, &domain.GetActionRequest{Id: retry.ActionID})
+	if err != nil {
+		if errors.Is(err, domain.ErrRecordNotFound) {
+			return s.repo.DeleteActionRetry(ctx, retry.ID)
+		}
+		return errors.Wrap(err, "could not find action by id: %d", retry.ActionID)
+	}
+
+	if !action.Enabled {
+		s.log.Info().Msgf("action %s was disabled, dropping retry for release %s", action.Name, release.TorrentName)
+		return s.repo.DeleteActionRetry(ctx, retry.ID)
+	}
+
+	indexerInfo, err := s.indexerSvc.GetBy(ctx, domain.GetIndexerRequest{Identifier: release.Indexer.Identifier})
+	if err != nil {
+		return errors.Wrap(err, "could not get indexer by identifier: %s", release.Indexer.Identifier)
+	}
+
+	release.Indexer = domain.IndexerMinimal{
+		ID:                 int(indexerInfo.ID),
+		Name:               indexerInfo.Name,
+		Identifier:         indexerInfo.Identifier,
+		IdentifierExternal: indexerInfo.IdentifierExternal,
+	}
+
+	// update the status of the failed push instead of adding a new one for every attempt
+	var status *domain.ReleaseActionStatus
+	if retry.ActionStatusID > 0 {
+		status, err = s.GetActionStatus(ctx, &domain.GetReleaseActionStatusRequest{Id: int(retry.ActionStatusID)})
+		if err != nil && !errors.Is(err, domain.ErrRecordNotFound) {
+			return errors.Wrap(err, "could not get action status by id: %d", retry.ActionStatusID)
+		}
+	}
+
+	retry.Attempts++
+
+	s.log.Debug().Msgf("retrying push of %s to action %s, attempt %d/%d", release.TorrentName, action.Name, retry.Attempts, retry.MaxAttempts)
+
+	// notifications for each attempt are sent by the action service
+	runErr := s.retryAction(ctx, action, release, status)
+	if runErr == nil {
+		s.log.Info().Msgf("retry %d/%d of %s to action %s succeeded", retry.Attempts, retry.MaxAttempts, release.TorrentName, action.Name)
+		return s.repo.DeleteActionRetry(ctx, retry.ID)
+	}
+
+	if !action.IsRetryableError(runErr) {
+		s.log.Warn().Err(runErr).Msgf("retry %d/%d of %s to action %s failed with non retryable error, giving up", retry.Attempts, retry.MaxAttempts, release.TorrentName, action.Name)
+		return s.repo.DeleteActionRetry(ctx, retry.ID)
+	}
+
+	if retry.Attempts >= retry.MaxAttempts {
+		s.log.Warn().Err(runErr).Msgf("retry %d/%d of %s to action %s failed, no attempts left", retry.Attempts, retry.MaxAttempts, release.TorrentName, action.Name)
+		return s.repo.DeleteActionRetry(ctx, retry.ID)
+	}
+
+	retry.LastError = runErr.Error()
+	retry.NextAttemptAt = time.Now().Add(action.RetryBackoffDuration(retry.Attempts + 1))
+
+	s.log.Info().Msgf("retry %d/%d of %s to action %s failed, next attempt at %s", retry.Attempts, retry.MaxAttempts, release.TorrentName, action.Name, retry.NextAttemptAt.Format(time.DateTime))
+
+	return s.repo.StoreActionRetry(ctx, retry)
+}
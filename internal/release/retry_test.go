@@ -0,0 +1,183 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package release
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *mockActionService) Get(ctx context.Context, req *domain.GetActionRequest) (*domain.Action, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Action), args.Error(1)
+}
+
+func (m *mockReleaseRepo) Get(ctx context.Context, req *domain.GetReleaseRequest) (*domain.Release, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Release), args.Error(1)
+}
+
+func (m *mockReleaseRepo) GetActionStatus(ctx context.Context, req *domain.GetReleaseActionStatusRequest) (*domain.ReleaseActionStatus, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReleaseActionStatus), args.Error(1)
+}
+
+func (m *mockReleaseRepo) StoreActionRetry(ctx context.Context, retry *domain.ReleaseActionRetry) error {
+	args := m.Called(ctx, retry)
+	return args.Error(0)
+}
+
+func (m *mockReleaseRepo) DeleteActionRetry(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestService_RunFilterActions_SchedulesRetry(t *testing.T) {
+	act := &domain.Action{ID: 1, Name: "qbit", Type: domain.ActionTypeQbittorrent, Enabled: true, RetryMaxAttempts: 3, RetryBackoff: 30}
+
+	actionSvc := &mockActionService{}
+	actionSvc.On("RunAction", mock.Anything, act, mock.Anything).Return(nil, errors.New("dial tcp 127.0.0.1:8080: connect: connection refused"))
+
+	repo := &mockReleaseRepo{}
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ReleaseActionStatus).ID = 5
+	}).Return(nil)
+	repo.On("StoreActionRetry", mock.Anything, mock.Anything).Return(nil)
+
+	s := &service{
+		log:       logger.Mock().With().Logger(),
+		bus:       EventBus.New(),
+		actionSvc: actionSvc,
+		repo:      repo,
+	}
+
+	rls := &domain.Release{ID: 10, TorrentName: "Test.Release-GROUP", Indexer: domain.IndexerMinimal{Identifier: "mock"}}
+
+	before := time.Now()
+	rejections := s.runFilterActions(context.Background(), s.log, rls, []*domain.Action{act}, map[actionClientTypeKey]struct{}{}, nil)
+	assert.Len(t, rejections, 1)
+
+	repo.AssertCalled(t, "StoreActionRetry", mock.Anything, mock.MatchedBy(func(retry *domain.ReleaseActionRetry) bool {
+		return retry.ReleaseID == 10 &&
+			retry.ActionID == 1 &&
+			retry.ActionStatusID == 5 &&
+			retry.Attempts == 0 &&
+			retry.MaxAttempts == 3 &&
+			!retry.NextAttemptAt.Before(before.Add(30*time.Second))
+	}))
+}
+
+func TestService_RunFilterActions_NoRetryForOtherErrors(t *testing.T) {
+	act := &domain.Action{ID: 1, Name: "qbit", Type: domain.ActionTypeQbittorrent, Enabled: true, RetryMaxAttempts: 3}
+
+	actionSvc := &mockActionService{}
+	actionSvc.On("RunAction", mock.Anything, act, mock.Anything).Return(nil, errors.New("invalid category"))
+
+	repo := &mockReleaseRepo{}
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Return(nil)
+
+	s := &service{
+		log:       logger.Mock().With().Logger(),
+		bus:       EventBus.New(),
+		actionSvc: actionSvc,
+		repo:      repo,
+	}
+
+	rls := &domain.Release{ID: 10, TorrentName: "Test.Release-GROUP", Indexer: domain.IndexerMinimal{Identifier: "mock"}}
+
+	s.runFilterActions(context.Background(), s.log, rls, []*domain.Action{act}, map[actionClientTypeKey]struct{}{}, nil)
+
+	repo.AssertNotCalled(t, "StoreActionRetry", mock.Anything, mock.Anything)
+}
+
+func TestService_ProcessActionRetry(t *testing.T) {
+	connErr := errors.New("dial tcp 127.0.0.1:8080: connect: connection refused")
+
+	tests := []struct {
+		name        string
+		attempts    int
+		runErr      error
+		wantDeleted bool
+		wantStored  bool
+	}{
+		{name: "success", attempts: 0, runErr: nil, wantDeleted: true},
+		{name: "retryable_failure", attempts: 0, runErr: connErr, wantStored: true},
+		{name: "no_attempts_left", attempts: 2, runErr: connErr, wantDeleted: true},
+		{name: "non_retryable_failure", attempts: 0, runErr: errors.New("invalid category"), wantDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			act := &domain.Action{ID: 1, Name: "qbit", Type: domain.ActionTypeQbittorrent, Enabled: true, RetryMaxAttempts: 3, RetryBackoff: 30}
+			status := &domain.ReleaseActionStatus{ID: 5, ReleaseID: 10, ActionID: 1, Status: domain.ReleasePushStatusErr}
+
+			actionSvc := &mockActionService{}
+			actionSvc.On("Get", mock.Anything, &domain.GetActionRequest{Id: 1}).Return(act, nil)
+			actionSvc.On("RunAction", mock.Anything, act, mock.Anything).Return(nil, tt.runErr)
+
+			indexerSvc := &mockIndexerService{}
+			indexerSvc.On("GetBy", mock.Anything, domain.GetIndexerRequest{Identifier: "mock"}).Return(&domain.Indexer{ID: 1, Name: "Mock", Identifier: "mock", Enabled: true}, nil)
+
+			repo := &mockReleaseRepo{}
+			repo.On("Get", mock.Anything, &domain.GetReleaseRequest{Id: 10}).Return(&domain.Release{ID: 10, TorrentName: "Test.Release-GROUP", Indexer: domain.IndexerMinimal{Identifier: "mock"}}, nil)
+			repo.On("GetActionStatus", mock.Anything, &domain.GetReleaseActionStatusRequest{Id: 5}).Return(status, nil)
+			repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Return(nil)
+			repo.On("StoreActionRetry", mock.Anything, mock.Anything).Return(nil)
+			repo.On("DeleteActionRetry", mock.Anything, int64(7)).Return(nil)
+
+			s := &service{
+				log:        logger.Mock().With().Logger(),
+				bus:        EventBus.New(),
+				actionSvc:  actionSvc,
+				indexerSvc: indexerSvc,
+				repo:       repo,
+			}
+
+			retry := &domain.ReleaseActionRetry{ID: 7, ReleaseID: 10, ActionID: 1, ActionStatusID: 5, Attempts: tt.attempts, MaxAttempts: 3}
+
+			err := s.processActionRetry(context.Background(), retry)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.attempts+1, retry.Attempts)
+
+			// the existing status is updated instead of adding a new one
+			repo.AssertCalled(t, "StoreReleaseActionStatus", mock.Anything, status)
+			repo.AssertNotCalled(t, "StoreReleaseActionStatus", mock.Anything, mock.MatchedBy(func(s *domain.ReleaseActionStatus) bool {
+				return s.ID == 0
+			}))
+
+			if tt.wantDeleted {
+				repo.AssertCalled(t, "DeleteActionRetry", mock.Anything, int64(7))
+			} else {
+				repo.AssertNotCalled(t, "DeleteActionRetry", mock.Anything, mock.Anything)
+			}
+
+			if tt.wantStored {
+				repo.AssertCalled(t, "StoreActionRetry", mock.Anything, retry)
+				assert.Equal(t, connErr.Error(), retry.LastError)
+				assert.True(t, retry.NextAttemptAt.After(time.Now().Add(59*time.Second)))
+			} else {
+				repo.AssertNotCalled(t, "StoreActionRetry", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package release
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -28,19 +29,25 @@ type Service interface {
 	GetActionStatus(ctx context.Context, req *domain.GetReleaseActionStatusRequest) (*domain.ReleaseActionStatus, error)
 	GetIndexerOptions(ctx context.Context) ([]string, error)
 	Stats(ctx context.Context) (*domain.ReleaseStats, error)
+	StatsTimeseries(ctx context.Context, params *domain.ReleaseStatsTimeseriesParams) (*domain.ReleaseStatsTimeseries, error)
+	StatsBreakdown(ctx context.Context) (*domain.ReleaseStatsBreakdown, error)
 	Store(ctx context.Context, release *domain.Release) error
 	Update(ctx context.Context, release *domain.Release) error
 	StoreReleaseActionStatus(ctx context.Context, actionStatus *domain.ReleaseActionStatus) error
 	Delete(ctx context.Context, req *domain.DeleteReleaseRequest) error
 	Process(release *domain.Release)
+	Enqueue(release *domain.Release)
 	ProcessMultiple(releases []*domain.Release)
 	ProcessMultipleFromIndexer(releases []*domain.Release, indexer domain.IndexerMinimal) error
 	ProcessManual(ctx context.Context, req *domain.ReleaseProcessReq) error
+	Push(ctx context.Context, reqs []*domain.ReleasePushRequest) []*domain.ReleasePushResult
+	DryRunFilters(ctx context.Context, indexer string, releases []*domain.Release) ([]*domain.FilterTestResult, error)
 	Retry(ctx context.Context, req *domain.ReleaseActionRetryReq) error
 
 	StoreReleaseProfileDuplicate(ctx context.Context, profile *domain.DuplicateReleaseProfile) error
 	FindDuplicateReleaseProfiles(ctx context.Context) ([]*domain.DuplicateReleaseProfile, error)
 	DeleteReleaseProfileDuplicate(ctx context.Context, id int64) error
+	ExistsByNormalizedHash(ctx context.Context, indexer string, hash string, since time.Time) (bool, error)
 
 	ListCleanupJobs(ctx context.Context) ([]*domain.ReleaseCleanupJob, error)
 	GetCleanupJob(ctx context.Context, id int) (*domain.ReleaseCleanupJob, error)
@@ -51,6 +58,13 @@ type Service interface {
 	ForceRunCleanupJob(ctx context.Context, id int) error
 
 	StartCleanupJobs() error
+	StartActionRetryJob() error
+
+	StartPipeline()
+	StopPipeline()
+	QueueStats() domain.ReleaseQueueStats
+	ListDelayedJobs(ctx context.Context) []domain.ReleaseDelayedJob
+	CancelDelayedJob(ctx context.Context, id string) error
 }
 
 type actionClientTypeKey struct {
@@ -79,12 +93,17 @@ type service struct {
 	filterSvc  filter.Service
 	indexerSvc indexer.Service
 	scheduler  scheduler.Service
+
+	pipeline *pipeline
 }
 
-func NewService(log logger.Logger, repo domain.ReleaseRepo, actionSvc action.Service, filterSvc filter.Service, indexerSvc indexer.Service, scheduler scheduler.Service, bus EventBus.Bus) Service {
+func NewService(log logger.Logger, config *domain.Config, repo domain.ReleaseRepo, actionSvc action.Service, filterSvc filter.Service, indexerSvc indexer.Service, scheduler scheduler.Service, bus EventBus.Bus) Service {
+	l := log.With().Str("module", "release").Logger()
+
 	return &service{
-		log:         log.With().Str("module", "release").Logger(),
+		log:         l,
 		cleanupJobs: map[string]int{},
+		pipeline:    newPipeline(l, config.ReleaseWorkers),
 		bus:         bus,
 		repo:        repo,
 		actionSvc:   actionSvc,
@@ -114,6 +133,18 @@ func (s *service) Stats(ctx context.Context) (*domain.ReleaseStats, error) {
 	return s.repo.Stats(ctx)
 }
 
+func (s *service) StatsTimeseries(ctx context.Context, params *domain.ReleaseStatsTimeseriesParams) (*domain.ReleaseStatsTimeseries, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.StatsTimeseries(ctx, params)
+}
+
+func (s *service) StatsBreakdown(ctx context.Context) (*domain.ReleaseStatsBreakdown, error) {
+	return s.repo.StatsBreakdown(ctx)
+}
+
 func (s *service) Store(ctx context.Context, release *domain.Release) error {
 	return s.repo.Store(ctx, release)
 }
@@ -142,6 +173,10 @@ func (s *service) DeleteReleaseProfileDuplicate(ctx context.Context, id int64) e
 	return s.repo.DeleteReleaseProfileDuplicate(ctx, id)
 }
 
+func (s *service) ExistsByNormalizedHash(ctx context.Context, indexer string, hash string, since time.Time) (bool, error) {
+	return s.repo.ExistsByNormalizedHash(ctx, indexer, hash, since)
+}
+
 func (s *service) ListCleanupJobs(ctx context.Context) ([]*domain.ReleaseCleanupJob, error) {
 	jobs, err := s.repo.ListCleanupJobs(ctx)
 	if err != nil {
@@ -301,6 +336,22 @@ func (s *service) ForceRunCleanupJob(ctx context.Context, id int) error {
 	return nil
 }
 
+// DryRunFilters checks the releases against the enabled filters of the indexer.
+// Like the filter test it never runs actions, external filters or touches the max downloads counters.
+func (s *service) DryRunFilters(ctx context.Context, indexer string, releases []*domain.Release) ([]*domain.FilterTestResult, error) {
+	filters, err := s.filterSvc.FindByIndexerIdentifier(ctx, indexer)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find filters for indexer: %s", indexer)
+	}
+
+	results := make([]*domain.FilterTestResult, 0, len(filters))
+	for _, f := range filters {
+		results = append(results, f.DryRun(releases))
+	}
+
+	return results, nil
+}
+
 func (s *service) ProcessManual(ctx context.Context, req *domain.ReleaseProcessReq) error {
 	// get indexer definition with data
 	def, err := s.indexerSvc.GetMappedDefinitionByName(req.IndexerIdentifier)
@@ -348,7 +399,7 @@ func (s *service) ProcessManual(ctx context.Context, req *domain.ReleaseProcessR
 	}
 
 	// process
-	go s.Process(rls)
+	s.Enqueue(rls)
 
 	return nil
 }
@@ -384,34 +435,76 @@ func (s *service) Process(release *domain.Release) {
 		return
 	}
 
-	if err := s.processRelease(ctx, release, filters); err != nil {
+	if err := s.processRelease(ctx, release, filters, nil); err != nil {
 		s.log.Error().Err(err).Msgf("release.Process: error processing filters for indexer: %s", release.Indexer.Name)
 		return
 	}
 }
 
-func (s *service) processRelease(ctx context.Context, release *domain.Release, filters []*domain.Filter) error {
-	defer func(release *domain.Release) {
-		err := release.CleanupTemporaryFiles()
-		if err != nil {
-			s.log.Error().Err(err).Msgf("release.Process: error cleaning up temporary files for indexer: %s", release.Indexer.Name)
-		}
-	}(release)
+// Enqueue puts the release on the processing queue. It blocks while the queue is full.
+func (s *service) Enqueue(release *domain.Release) {
+	if release == nil {
+		return
+	}
 
-	if err := s.processFilters(ctx, filters, release); err != nil {
+	s.pipeline.enqueue(func(ctx context.Context) {
+		s.Process(release)
+	})
+}
+
+func (s *service) StartPipeline() {
+	s.pipeline.start()
+}
+
+func (s *service) StopPipeline() {
+	for _, job := range s.pipeline.stop() {
+		s.dropDelayedJob(context.Background(), job, rejectionDelayedStopped)
+	}
+}
+
+func (s *service) QueueStats() domain.ReleaseQueueStats {
+	return s.pipeline.stats()
+}
+
+func (s *service) ListDelayedJobs(ctx context.Context) []domain.ReleaseDelayedJob {
+	return s.pipeline.delayedJobs()
+}
+
+func (s *service) CancelDelayedJob(ctx context.Context, id string) error {
+	job, err := s.pipeline.cancelDelayed(id)
+	if err != nil {
 		return err
 	}
 
+	s.dropDelayedJob(ctx, job, rejectionDelayedCancelled)
+
 	return nil
 }
 
-func (s *service) processFilters(ctx context.Context, filters []*domain.Filter, release *domain.Release) error {
+// processRelease checks the release against filters and runs actions.
+// If result is not nil the filter and action outcomes are recorded on it.
+func (s *service) processRelease(ctx context.Context, release *domain.Release, filters []*domain.Filter, result *domain.ReleasePushResult) error {
+	delayed, err := s.processFilters(ctx, filters, release, result)
+
+	// a delayed job still needs the downloaded torrent file and cleans up once it has run
+	if !delayed {
+		if err := release.CleanupTemporaryFiles(); err != nil {
+			s.log.Error().Err(err).Msgf("release.Process: error cleaning up temporary files for indexer: %s", release.Indexer.Name)
+		}
+	}
+
+	return err
+}
+
+// processFilters checks the filters in order and runs the actions of the first match.
+// It returns true if the release was handed over to the delay queue.
+func (s *service) processFilters(ctx context.Context, filters []*domain.Filter, release *domain.Release, result *domain.ReleasePushResult) (bool, error) {
 	// keep track of action clients to avoid sending the same thing all over again
 	// save both client type and client id to potentially try another client of same type
 	triedActionClients := map[actionClientTypeKey]struct{}{}
 
 	// loop over and check filters
-	for _, f := range filters {
+	for idx, f := range filters {
 		l := s.log.With().Str("indexer", release.Indexer.Identifier).Str("filter", f.Name).Str("release", release.TorrentName).Logger()
 
 		// save filter on release
@@ -428,7 +521,16 @@ func (s *service) processFilters(ctx context.Context, filters []*domain.Filter,
 		match, err := s.filterSvc.CheckFilter(ctx, f, release)
 		if err != nil {
 			l.Error().Err(err).Msg("release.Process: error checking filter")
-			return err
+			return false, err
+		}
+
+		if result != nil {
+			result.Filters = append(result.Filters, domain.ReleaseFilterResult{
+				FilterID:   f.ID,
+				FilterName: f.Name,
+				Match:      match && f.RejectReasons.Len() == 0,
+				Rejections: f.RejectReasons.Strings(),
+			})
 		}
 
 		if !match || f.RejectReasons.Len() > 0 {
@@ -445,7 +547,7 @@ func (s *service) processFilters(ctx context.Context, filters []*domain.Filter,
 		actions, err := s.actionSvc.FindByFilterID(ctx, f.ID, &active, false)
 		if err != nil {
 			s.log.Error().Err(err).Msgf("release.Process: error finding actions for filter: %s", f.Name)
-			return err
+			return false, err
 		}
 
 		// if no actions, continue to next filter
@@ -460,84 +562,223 @@ func (s *service) processFilters(ctx context.Context, filters []*domain.Filter,
 
 			if err = s.Store(ctx, release); err != nil {
 				l.Error().Err(err).Msgf("release.Process: error writing release to database: %+v", release)
-				return err
+				return false, err
 			}
 		}
 
-		var rejections []string
+		// hand the release over to the delay queue instead of holding up the remaining releases
+		if f.Delay > 0 {
+			job := newDelayedJob(release, f, filters[idx+1:])
 
-		// run actions (watchFolder, test, exec, qBittorrent, Deluge, arr etc.)
-		for idx, act := range actions {
-			// only run enabled actions
-			if !act.Enabled {
-				l.Trace().Msgf("release.Process: indexer: %s, filter: %s release: %s action '%s' not enabled, skip", release.Indexer.Name, release.FilterName, release.TorrentName, act.Name)
-				continue
-			}
+			l.Debug().Msgf("release.Process: delaying processing of '%s' (%s) for %s by %d seconds as specified in the filter", release.TorrentName, release.FilterName, release.Indexer.Name, f.Delay)
 
-			// add action status as pending
-			actionStatus := domain.NewReleaseActionStatus(act, release)
+			s.pipeline.delay(job, s.runDelayedJob)
 
-			if err := s.StoreReleaseActionStatus(ctx, actionStatus); err != nil {
-				s.log.Error().Err(err).Msgf("release.runAction: error storing action for filter: %s", release.FilterName)
+			if result != nil {
+				result.DelayedUntil = &job.RunAt
 			}
 
-			if idx == 0 {
-				// sleep for the delay period specified in the filter before running actions
-				delay := release.Filter.Delay
-				if delay > 0 {
-					l.Debug().Msgf("release.Process: delaying processing of '%s' (%s) for %s by %d seconds as specified in the filter", release.TorrentName, release.FilterName, release.Indexer.Name, delay)
-					time.Sleep(time.Duration(delay) * time.Second)
-				}
-			}
+			return true, nil
+		}
 
-			l.Trace().Msgf("release.Process: indexer: %s, filter: %s release: %s , run action: %s", release.Indexer.Name, release.FilterName, release.TorrentName, act.Name)
+		rejections := s.runFilterActions(ctx, l, release, actions, triedActionClients, result)
 
-			// keep track of action clients to avoid sending the same thing all over again
-			_, tried := triedActionClients[actionClientTypeKey{Type: act.Type, ClientID: act.ClientID}]
-			if tried {
-				l.Debug().Msgf("release.Process: indexer: %s, filter: %s release: %s action client already tried, skip", release.Indexer.Name, release.FilterName, release.TorrentName)
-				continue
-			}
+		if err = s.Update(ctx, release); err != nil {
+			l.Error().Err(err).Msgf("release.Process: error updating release: %v", release.TorrentName)
+		}
 
-			// run action
-			status, err := s.runAction(ctx, act, release, actionStatus)
-			if err != nil {
-				l.Error().Err(err).Msgf("release.Process: error running actions for filter: %s", release.FilterName)
-				//continue
-			}
+		// if we have rejections from arr, continue to next filter
+		if len(rejections) > 0 {
+			continue
+		}
 
-			rejections = status.Rejections
+		// all actions run, decide to stop or continue here
+		break
+	}
 
-			if err := s.StoreReleaseActionStatus(ctx, status); err != nil {
-				s.log.Error().Err(err).Msgf("release.Process: error storing action status for filter: %s", release.FilterName)
-			}
+	return false, nil
+}
 
-			if len(rejections) > 0 {
-				// if we get action rejection, remember which action client it was from
-				triedActionClients[actionClientTypeKey{Type: act.Type, ClientID: act.ClientID}] = struct{}{}
+// runFilterActions runs the actions for the matched filter and returns the rejections of the last action run.
+func (s *service) runFilterActions(ctx context.Context, l zerolog.Logger, release *domain.Release, actions []*domain.Action, triedActionClients map[actionClientTypeKey]struct{}, result *domain.ReleasePushResult) []string {
+	var rejections []string
 
-				// log something and fire events
-				l.Debug().Str("action", act.Name).Str("action_type", string(act.Type)).Msgf("release rejected: %s", strings.Join(rejections, ", "))
-			}
+	// run actions (watchFolder, test, exec, qBittorrent, Deluge, arr etc.)
+	for _, act := range actions {
+		// only run enabled actions
+		if !act.Enabled {
+			l.Trace().Msgf("release.Process: indexer: %s, filter: %s release: %s action '%s' not enabled, skip", release.Indexer.Name, release.FilterName, release.TorrentName, act.Name)
+			continue
+		}
+
+		// add action status as pending
+		actionStatus := domain.NewReleaseActionStatus(act, release)
+
+		if err := s.StoreReleaseActionStatus(ctx, actionStatus); err != nil {
+			s.log.Error().Err(err).Msgf("release.runAction: error storing action for filter: %s", release.FilterName)
+		}
 
-			// if no rejections consider action approved, run next
+		l.Trace().Msgf("release.Process: indexer: %s, filter: %s release: %s , run action: %s", release.Indexer.Name, release.FilterName, release.TorrentName, act.Name)
+
+		// keep track of action clients to avoid sending the same thing all over again
+		_, tried := triedActionClients[actionClientTypeKey{Type: act.Type, ClientID: act.ClientID}]
+		if tried {
+			l.Debug().Msgf("release.Process: indexer: %s, filter: %s release: %s action client already tried, skip", release.Indexer.Name, release.FilterName, release.TorrentName)
 			continue
 		}
 
-		if err = s.Update(ctx, release); err != nil {
-			l.Error().Err(err).Msgf("release.Process: error updating release: %v", release.TorrentName)
+		// run action
+		status, err := s.runAction(ctx, act, release, actionStatus)
+		if err != nil {
+			l.Error().Err(err).Msgf("release.Process: error running actions for filter: %s", release.FilterName)
+			//continue
+		}
+
+		rejections = status.Rejections
+
+		if err := s.StoreReleaseActionStatus(ctx, status); err != nil {
+			s.log.Error().Err(err).Msgf("release.Process: error storing action status for filter: %s", release.FilterName)
+		}
+
+		if err != nil && act.RetryEnabled() && act.IsRetryableError(err) {
+			s.scheduleActionRetry(ctx, act, release, status, err)
+		}
+
+		if result != nil {
+			result.Actions = append(result.Actions, *status)
 		}
 
-		// if we have rejections from arr, continue to next filter
 		if len(rejections) > 0 {
+			// if we get action rejection, remember which action client it was from
+			triedActionClients[actionClientTypeKey{Type: act.Type, ClientID: act.ClientID}] = struct{}{}
+
+			// log something and fire events
+			l.Debug().Str("action", act.Name).Str("action_type", string(act.Type)).Msgf("release rejected: %s", strings.Join(rejections, ", "))
+		}
+
+		// if no rejections consider action approved, run next
+		continue
+	}
+
+	return rejections
+}
+
+// runDelayedJob runs the actions of a delayed release once the filter delay has passed.
+// The filter and its actions are loaded again so edits made while waiting are used,
+// and the job is dropped if the filter was disabled or deleted in the meantime.
+func (s *service) runDelayedJob(ctx context.Context, job *delayedJob) {
+	release := job.release
+
+	// the remaining filters can delay the release again
+	delayed := false
+
+	defer func() {
+		if delayed {
+			return
+		}
+
+		if err := release.CleanupTemporaryFiles(); err != nil {
+			s.log.Error().Err(err).Msgf("release.Process: error cleaning up temporary files for indexer: %s", release.Indexer.Name)
+		}
+	}()
+
+	l := s.log.With().Str("indexer", release.Indexer.Identifier).Str("filter", job.FilterName).Str("release", release.TorrentName).Logger()
+
+	f, err := s.filterSvc.FindByID(ctx, job.FilterID)
+	if err != nil {
+		l.Error().Err(err).Msgf("release.Process: could not find filter for delayed release, skipping")
+		return
+	}
+
+	if !f.Enabled {
+		l.Info().Msgf("release.Process: filter %s was disabled while release %s was delayed, skipping", f.Name, release.TorrentName)
+		return
+	}
+
+	release.Filter = f
+	release.FilterName = f.Name
+	release.FilterID = f.ID
+
+	active := true
+	actions, err := s.actionSvc.FindByFilterID(ctx, f.ID, &active, false)
+	if err != nil {
+		l.Error().Err(err).Msgf("release.Process: error finding actions for filter: %s", f.Name)
+		return
+	}
+
+	triedActionClients := map[actionClientTypeKey]struct{}{}
+
+	rejections := s.runFilterActions(ctx, l, release, actions, triedActionClients, nil)
+
+	if err := s.Update(ctx, release); err != nil {
+		l.Error().Err(err).Msgf("release.Process: error updating release: %v", release.TorrentName)
+	}
+
+	// try the next filters like a regular release would
+	if len(rejections) > 0 && len(job.filterIDs) > 0 {
+		filters, err := s.remainingFilters(ctx, release, job.filterIDs)
+		if err != nil {
+			l.Error().Err(err).Msgf("release.Process: could not find remaining filters for release: %s", release.TorrentName)
+			return
+		}
+
+		delayed, err = s.processFilters(ctx, filters, release, nil)
+		if err != nil {
+			l.Error().Err(err).Msgf("release.Process: error processing remaining filters for release: %s", release.TorrentName)
+		}
+	}
+}
+
+// dropDelayedJob records the actions of a delayed job that will not run as rejected and removes its temporary files
+func (s *service) dropDelayedJob(ctx context.Context, job *delayedJob, reason string) {
+	release := job.release
+
+	defer func() {
+		if err := release.CleanupTemporaryFiles(); err != nil {
+			s.log.Error().Err(err).Msgf("release.Process: error cleaning up temporary files for indexer: %s", release.Indexer.Name)
+		}
+	}()
+
+	active := true
+	actions, err := s.actionSvc.FindByFilterID(ctx, job.FilterID, &active, false)
+	if err != nil {
+		s.log.Error().Err(err).Msgf("release.Process: error finding actions for filter: %s", job.FilterName)
+		return
+	}
+
+	for _, act := range actions {
+		if !act.Enabled {
 			continue
 		}
 
-		// all actions run, decide to stop or continue here
-		break
+		status := domain.NewReleaseActionStatus(act, release)
+		status.Filter = job.FilterName
+		status.FilterID = int64(job.FilterID)
+		status.Status = domain.ReleasePushStatusRejected
+		status.Rejections = []string{reason}
+
+		if err := s.StoreReleaseActionStatus(ctx, status); err != nil {
+			s.log.Error().Err(err).Msgf("release.Process: error storing action status for filter: %s", job.FilterName)
+		}
 	}
+}
 
-	return nil
+// remainingFilters loads the filters of the indexer again and keeps those of a delayed job, in priority order.
+// Fresh filters are used so the checks don't share rejection reasons with releases processed in the meantime.
+func (s *service) remainingFilters(ctx context.Context, release *domain.Release, filterIDs []int) ([]*domain.Filter, error) {
+	filters, err := s.filterSvc.FindByIndexerIdentifier(ctx, release.Indexer.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]*domain.Filter, 0, len(filterIDs))
+	for _, f := range filters {
+		if slices.Contains(filterIDs, f.ID) {
+			remaining = append(remaining, f)
+		}
+	}
+
+	return remaining, nil
 }
 
 func (s *service) ProcessMultiple(releases []*domain.Release) {
@@ -593,7 +834,7 @@ func (s *service) ProcessMultipleFromIndexer(releases []*domain.Release, indexer
 
 		s.publishEventReleaseNew(release)
 
-		if err := s.processRelease(ctx, release, filters); err != nil {
+		if err := s.processRelease(ctx, release, filters, nil); err != nil {
 			s.log.Error().Err(err).Msgf("release.ProcessMultipleFromIndexer: error processing filters for indexer: %s", indexer.Name)
 			return nil
 		}
@@ -602,6 +843,83 @@ func (s *service) ProcessMultipleFromIndexer(releases []*domain.Release, indexer
 	return nil
 }
 
+// Push processes releases sent by external sources like an announce and returns the outcome for each release.
+// Releases are processed synchronously so the result includes the filter checks and action statuses.
+func (s *service) Push(ctx context.Context, reqs []*domain.ReleasePushRequest) []*domain.ReleasePushResult {
+	// keep processing even if the client goes away, same as announces
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]*domain.ReleasePushResult, 0, len(reqs))
+
+	for _, req := range reqs {
+		result := &domain.ReleasePushResult{
+			Name:         req.Name,
+			Indexer:      req.IndexerIdentifier,
+			FilterStatus: domain.ReleaseStatusFilterPending,
+			Filters:      []domain.ReleaseFilterResult{},
+			Actions:      []domain.ReleaseActionStatus{},
+		}
+		results = append(results, result)
+
+		if err := s.push(ctx, req, result); err != nil {
+			s.log.Error().Err(err).Msgf("release.Push: error processing release: %s", req.Name)
+			result.Error = err.Error()
+		}
+	}
+
+	return results
+}
+
+func (s *service) push(ctx context.Context, req *domain.ReleasePushRequest, result *domain.ReleasePushResult) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error().Msgf("recovering from panic in release push %s error: %v", req.Name, r)
+			err = errors.New("panic in release push: %s", req.Name)
+		}
+	}()
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	indexer, err := s.indexerSvc.GetBy(ctx, domain.GetIndexerRequest{Identifier: req.IndexerIdentifier})
+	if err != nil {
+		return errors.Wrap(err, "could not find indexer: %s", req.IndexerIdentifier)
+	}
+
+	if !indexer.Enabled {
+		return errors.New("indexer %s is not enabled", req.IndexerIdentifier)
+	}
+
+	release := req.ToRelease(domain.IndexerMinimal{
+		ID:                 int(indexer.ID),
+		Name:               indexer.Name,
+		Identifier:         indexer.Identifier,
+		IdentifierExternal: indexer.IdentifierExternal,
+	})
+
+	s.publishEventReleaseNew(release)
+
+	filters, err := s.filterSvc.FindByIndexerIdentifier(ctx, release.Indexer.Identifier)
+	if err != nil {
+		return errors.Wrap(err, "could not find filters for indexer: %s", release.Indexer.Name)
+	}
+
+	if len(filters) == 0 {
+		s.log.Debug().Msgf("no active filters found for indexer: %s", release.Indexer.Name)
+		return nil
+	}
+
+	if err := s.processRelease(ctx, release, filters, result); err != nil {
+		return err
+	}
+
+	result.ReleaseID = release.ID
+	result.FilterStatus = release.FilterStatus
+
+	return nil
+}
+
 func (s *service) runAction(ctx context.Context, action *domain.Action, release *domain.Release, status *domain.ReleaseActionStatus) (*domain.ReleaseActionStatus, error) {
 	// add action status as pending
 	//status := domain.NewReleaseActionStatus(action, release)
@@ -611,6 +929,12 @@ func (s *service) runAction(ctx context.Context, action *domain.Action, release
 	//}
 
 	rejections, err := s.actionSvc.RunAction(ctx, action, release)
+
+	// with failover clients this is the client that accepted the release, or the last one tried
+	if action.Client != nil {
+		status.Client = action.Client.Name
+	}
+
 	if err != nil {
 		s.log.Error().Err(err).Msgf("release.runAction: error running actions for filter: %s", release.FilterName)
 
@@ -629,12 +953,25 @@ func (s *service) runAction(ctx context.Context, action *domain.Action, release
 
 	status.Status = domain.ReleasePushStatusApproved
 
+	// keep track of upload and ratio of torrents pushed to clients that can be polled
+	if stats, ok := domain.NewReleaseTorrentStats(action, release); ok {
+		s.bus.Publish(domain.EventReleaseTrackTorrent, stats)
+	}
+
 	return status, nil
 }
 
-func (s *service) retryAction(ctx context.Context, action *domain.Action, release *domain.Release) error {
-	// add action status as pending
-	status := domain.NewReleaseActionStatus(action, release)
+// retryAction runs the action again. If status is nil a new action status is created,
+// otherwise the existing status is reset to pending and updated with the outcome.
+func (s *service) retryAction(ctx context.Context, action *domain.Action, release *domain.Release, status *domain.ReleaseActionStatus) error {
+	if status == nil {
+		// add action status as pending
+		status = domain.NewReleaseActionStatus(action, release)
+	} else {
+		status.Status = domain.ReleasePushStatusPending
+		status.Rejections = []string{}
+		status.Timestamp = time.Now()
+	}
 
 	if err := s.StoreReleaseActionStatus(ctx, status); err != nil {
 		s.log.Error().Err(err).Msgf("release.runAction: error storing action for filter: %s", release.FilterName)
@@ -692,7 +1029,7 @@ func (s *service) Retry(ctx context.Context, req *domain.ReleaseActionRetryReq)
 	}
 
 	// run filterAction
-	if err := s.retryAction(ctx, filterAction, release); err != nil {
+	if err := s.retryAction(ctx, filterAction, release, nil); err != nil {
 		s.log.Error().Err(err).Msgf("release.Retry: error re-running action: %s", filterAction.Name)
 		return err
 	}
@@ -10,11 +10,13 @@ import (
 	"github.com/autobrr/autobrr/internal/action"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/filter"
+	"github.com/autobrr/autobrr/internal/indexer"
 	"github.com/autobrr/autobrr/internal/logger"
 
 	"github.com/asaskevich/EventBus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock objects
@@ -28,6 +30,14 @@ func (m *mockFilterService) CheckFilter(ctx context.Context, f *domain.Filter, r
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockFilterService) FindByID(ctx context.Context, filterID int) (*domain.Filter, error) {
+	args := m.Called(ctx, filterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Filter), args.Error(1)
+}
+
 func (m *mockFilterService) FindByIndexerIdentifier(ctx context.Context, indexer string) ([]*domain.Filter, error) {
 	args := m.Called(ctx, indexer)
 	return args.Get(0).([]*domain.Filter), args.Error(1)
@@ -43,6 +53,27 @@ func (m *mockActionService) FindByFilterID(ctx context.Context, filterID int, ac
 	return args.Get(0).([]*domain.Action), args.Error(1)
 }
 
+func (m *mockActionService) RunAction(ctx context.Context, action *domain.Action, release *domain.Release) ([]string, error) {
+	args := m.Called(ctx, action, release)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type mockIndexerService struct {
+	indexer.Service
+	mock.Mock
+}
+
+func (m *mockIndexerService) GetBy(ctx context.Context, req domain.GetIndexerRequest) (*domain.Indexer, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Indexer), args.Error(1)
+}
+
 type mockReleaseRepo struct {
 	domain.ReleaseRepo
 	mock.Mock
@@ -105,6 +136,69 @@ func TestService_Process_PublishesEvent(t *testing.T) {
 	assert.True(t, published, "RELEASE_NEW event should have been published")
 }
 
+func (m *mockReleaseRepo) StoreReleaseActionStatus(ctx context.Context, status *domain.ReleaseActionStatus) error {
+	args := m.Called(ctx, status)
+	return args.Error(0)
+}
+
+func TestService_Push(t *testing.T) {
+	log := logger.Mock()
+
+	rejectFilter := &domain.Filter{ID: 1, Name: "Rejecting Filter", RejectReasons: domain.NewRejectionReasons()}
+	rejectFilter.RejectReasons.Add("resolution", "1080p", []string{"2160p"})
+	matchFilter := &domain.Filter{ID: 2, Name: "Matching Filter", RejectReasons: domain.NewRejectionReasons()}
+
+	filterSvc := &mockFilterService{}
+	filterSvc.On("FindByIndexerIdentifier", mock.Anything, "mock").Return([]*domain.Filter{rejectFilter, matchFilter}, nil)
+	filterSvc.On("CheckFilter", mock.Anything, rejectFilter, mock.Anything).Return(false, nil)
+	filterSvc.On("CheckFilter", mock.Anything, matchFilter, mock.Anything).Return(true, nil)
+
+	act := &domain.Action{ID: 1, Name: "Test Action", Type: domain.ActionTypeTest, Enabled: true}
+	actionSvc := &mockActionService{}
+	actionSvc.On("FindByFilterID", mock.Anything, 2, mock.Anything, false).Return([]*domain.Action{act}, nil)
+	actionSvc.On("RunAction", mock.Anything, act, mock.Anything).Return(nil, nil)
+
+	indexerSvc := &mockIndexerService{}
+	indexerSvc.On("GetBy", mock.Anything, domain.GetIndexerRequest{Identifier: "mock"}).Return(&domain.Indexer{ID: 1, Name: "Mock", Identifier: "mock", Enabled: true}, nil)
+	indexerSvc.On("GetBy", mock.Anything, domain.GetIndexerRequest{Identifier: "missing"}).Return(nil, domain.ErrRecordNotFound)
+
+	repo := &mockReleaseRepo{}
+	repo.On("Store", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Release).ID = 10
+	}).Return(nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	repo.On("StoreReleaseActionStatus", mock.Anything, mock.Anything).Return(nil)
+
+	s := &service{
+		log:        log.With().Logger(),
+		bus:        EventBus.New(),
+		filterSvc:  filterSvc,
+		actionSvc:  actionSvc,
+		indexerSvc: indexerSvc,
+		repo:       repo,
+	}
+
+	results := s.Push(context.Background(), []*domain.ReleasePushRequest{
+		{Name: "That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", IndexerIdentifier: "mock", DownloadURL: "https://example.com/dl/1"},
+		{Name: "That.Show.S01E02.1080p.WEB-DL.H.264-GROUP", IndexerIdentifier: "missing", DownloadURL: "https://example.com/dl/2"},
+	})
+
+	assert.Len(t, results, 2)
+
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, int64(10), results[0].ReleaseID)
+	assert.Equal(t, domain.ReleaseStatusFilterApproved, results[0].FilterStatus)
+	assert.Len(t, results[0].Filters, 2)
+	assert.False(t, results[0].Filters[0].Match)
+	assert.Equal(t, []string{"[resolution] not matching: got 1080p want: [2160p]"}, results[0].Filters[0].Rejections)
+	assert.True(t, results[0].Filters[1].Match)
+	assert.Len(t, results[0].Actions, 1)
+	assert.Equal(t, domain.ReleasePushStatusApproved, results[0].Actions[0].Status)
+
+	assert.NotEmpty(t, results[1].Error)
+	assert.Equal(t, domain.ReleaseStatusFilterPending, results[1].FilterStatus)
+}
+
 func TestCleanupJobKey_ToString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -135,3 +229,79 @@ func TestCleanupJobKey_ToString(t *testing.T) {
 		})
 	}
 }
+
+func TestService_runAction_TrackTorrent(t *testing.T) {
+	bus := EventBus.New()
+
+	var tracked []*domain.ReleaseTorrentStats
+	bus.Subscribe(domain.EventReleaseTrackTorrent, func(stats *domain.ReleaseTorrentStats) {
+		tracked = append(tracked, stats)
+	})
+
+	client := &domain.DownloadClient{ID: 5, Name: "qbit", Type: domain.DownloadClientTypeQbittorrent}
+	qbitAction := &domain.Action{ID: 1, Name: "qbit", Type: domain.ActionTypeQbittorrent, Client: client}
+	execAction := &domain.Action{ID: 2, Name: "exec", Type: domain.ActionTypeExec}
+
+	actionSvc := &mockActionService{}
+	actionSvc.On("RunAction", mock.Anything, qbitAction, mock.Anything).Return(nil, nil)
+	actionSvc.On("RunAction", mock.Anything, execAction, mock.Anything).Return(nil, nil)
+
+	s := &service{
+		log:       logger.Mock().With().Logger(),
+		bus:       bus,
+		actionSvc: actionSvc,
+	}
+
+	release := &domain.Release{ID: 10, FilterID: 2, TorrentName: "That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", TorrentHash: "0123456789abcdef0123456789abcdef01234567", Indexer: domain.IndexerMinimal{Identifier: "mock"}}
+
+	_, err := s.runAction(context.Background(), qbitAction, release, domain.NewReleaseActionStatus(qbitAction, release))
+	assert.NoError(t, err)
+
+	_, err = s.runAction(context.Background(), execAction, release, domain.NewReleaseActionStatus(execAction, release))
+	assert.NoError(t, err)
+
+	// only torrent clients that can be polled are tracked
+	assert.Len(t, tracked, 1)
+	assert.Equal(t, int32(5), tracked[0].ClientID)
+	assert.Equal(t, int64(10), tracked[0].ReleaseID)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", tracked[0].TorrentHash)
+}
+
+func TestService_DryRunFilters(t *testing.T) {
+	tvFilter := &domain.Filter{ID: 1, Name: "TV", Enabled: true, Resolutions: []string{"1080p"}}
+	allFilter := &domain.Filter{ID: 2, Name: "All", Enabled: true}
+
+	filterSvc := &mockFilterService{}
+	filterSvc.On("FindByIndexerIdentifier", mock.Anything, "mock").Return([]*domain.Filter{tvFilter, allFilter}, nil)
+
+	// no actions are looked up or run
+	actionSvc := &mockActionService{}
+
+	s := &service{
+		log:       logger.Mock().With().Logger(),
+		filterSvc: filterSvc,
+		actionSvc: actionSvc,
+	}
+
+	indexer := domain.IndexerMinimal{Name: "Mock", Identifier: "mock"}
+
+	var releases []*domain.Release
+	for _, name := range []string{"That.Show.S01E01.1080p.WEB-DL.H.264-GROUP", "That.Show.S01E01.2160p.WEB-DL.H.265-GROUP"} {
+		rls := domain.NewRelease(indexer)
+		rls.ParseString(name)
+		releases = append(releases, rls)
+	}
+
+	results, err := s.DryRunFilters(context.Background(), "mock", releases)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	assert.Equal(t, "TV", results[0].FilterName)
+	assert.Equal(t, 1, results[0].Matched)
+	assert.Equal(t, 1, results[0].Rejected)
+	assert.Equal(t, "All", results[1].FilterName)
+	assert.Equal(t, 2, results[1].Matched)
+
+	actionSvc.AssertNotCalled(t, "FindByFilterID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	actionSvc.AssertNotCalled(t, "RunAction", mock.Anything, mock.Anything, mock.Anything)
+}
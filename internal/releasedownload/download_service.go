@@ -22,6 +22,7 @@ import (
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/proxy"
 	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/magnet"
 	"github.com/autobrr/autobrr/pkg/sharedhttp"
 
 	"github.com/anacrolix/torrent/bencode"
@@ -51,20 +52,25 @@ type DownloadService struct {
 	indexerRepo domain.IndexerRepo
 
 	proxySvc proxy.Service
+
+	magnetFetcher  *magnet.Fetcher
+	magnetFetchURL string
 }
 
-func NewDownloadService(log logger.Logger, repo domain.ReleaseRepo, indexerRepo domain.IndexerRepo, proxySvc proxy.Service) *DownloadService {
+func NewDownloadService(log logger.Logger, config *domain.Config, repo domain.ReleaseRepo, indexerRepo domain.IndexerRepo, proxySvc proxy.Service) *DownloadService {
 	return &DownloadService{
-		log:         log.With().Str("module", "release-download").Logger(),
-		repo:        repo,
-		indexerRepo: indexerRepo,
-		proxySvc:    proxySvc,
+		log:            log.With().Str("module", "release-download").Logger(),
+		repo:           repo,
+		indexerRepo:    indexerRepo,
+		proxySvc:       proxySvc,
+		magnetFetcher:  magnet.NewFetcher(time.Duration(config.MagnetResolveTimeout) * time.Second),
+		magnetFetchURL: config.MagnetFetchURL,
 	}
 }
 
 func (s *DownloadService) DownloadRelease(ctx context.Context, rls *domain.Release) error {
 	if rls.HasMagnetUri() {
-		return errors.New("downloading magnet links is not supported: %s", rls.MagnetURI)
+		return s.downloadMagnet(ctx, rls)
 	} else if rls.Protocol != domain.ReleaseProtocolTorrent {
 		return errors.New("could not download file: protocol %s is not supported", rls.Protocol)
 	}
@@ -144,25 +150,11 @@ func (s *DownloadService) downloadTorrentFile(ctx context.Context, indexer *doma
 		req.Header.Set("Cookie", r.RawCookie)
 	}
 
-	tmpFilePattern := "autobrr-"
-	tmpDir := os.TempDir()
-
 	// Create tmp file
 	// TODO check if tmp file is wanted
-	tmpFile, err := os.CreateTemp(tmpDir, tmpFilePattern)
+	tmpFile, err := createTmpFile()
 	if err != nil {
-		if os.IsNotExist(err) {
-			if mkdirErr := os.MkdirAll(tmpDir, os.ModePerm); mkdirErr != nil {
-				return errors.Wrap(mkdirErr, "could not create TMP dir: %s", tmpDir)
-			}
-
-			tmpFile, err = os.CreateTemp(tmpDir, tmpFilePattern)
-			if err != nil {
-				return errors.Wrap(err, "error creating tmp file in: %s", tmpDir)
-			}
-		} else {
-			return errors.Wrap(err, "error creating tmp file")
-		}
+		return err
 	}
 	defer tmpFile.Close()
 
@@ -188,6 +180,29 @@ func (s *DownloadService) downloadTorrentFile(ctx context.Context, indexer *doma
 	return errFunc
 }
 
+func createTmpFile() (*os.File, error) {
+	tmpFilePattern := "autobrr-"
+	tmpDir := os.TempDir()
+
+	tmpFile, err := os.CreateTemp(tmpDir, tmpFilePattern)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if mkdirErr := os.MkdirAll(tmpDir, os.ModePerm); mkdirErr != nil {
+				return nil, errors.Wrap(mkdirErr, "could not create TMP dir: %s", tmpDir)
+			}
+
+			tmpFile, err = os.CreateTemp(tmpDir, tmpFilePattern)
+			if err != nil {
+				return nil, errors.Wrap(err, "error creating tmp file in: %s", tmpDir)
+			}
+		} else {
+			return nil, errors.Wrap(err, "error creating tmp file")
+		}
+	}
+
+	return tmpFile, nil
+}
+
 func retryableRequest(httpClient *http.Client, req *http.Request, r *domain.Release, tmpFile *os.File) func() error {
 	return func() error {
 		// Get the data
@@ -0,0 +1,130 @@
+// Copyright (c) 2021-2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package releasedownload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/sharedhttp"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// downloadMagnet resolves the magnet link of the release into a torrent file,
+// so magnet releases can be used with torrent file macros, external filters and watch folders.
+// The fetch helper is tried first if set, then peers from the magnet link and its trackers.
+func (s *DownloadService) downloadMagnet(ctx context.Context, rls *domain.Release) error {
+	if rls.TorrentTmpFile != "" {
+		// already downloaded
+		return nil
+	}
+
+	m, err := metainfo.ParseMagnetUri(rls.MagnetURI)
+	if err != nil {
+		return errors.Wrap(err, "could not parse magnet uri: %s", rls.MagnetURI)
+	}
+
+	var meta *metainfo.MetaInfo
+
+	if s.magnetFetchURL != "" {
+		meta, err = s.fetchMagnetHelper(ctx, m, rls.MagnetURI)
+		if err != nil {
+			s.log.Warn().Err(err).Msgf("could not fetch torrent for magnet from helper, trying peers: %s", rls.TorrentName)
+		}
+	}
+
+	if meta == nil {
+		start := time.Now()
+
+		meta, err = s.magnetFetcher.Fetch(ctx, rls.MagnetURI)
+		if err != nil {
+			return errors.Wrap(err, "could not resolve metadata for magnet: %s", rls.TorrentName)
+		}
+
+		s.log.Debug().Msgf("resolved metadata for magnet %s in %s", rls.TorrentName, time.Since(start))
+	}
+
+	info, err := meta.UnmarshalInfo()
+	if err != nil {
+		return errors.Wrap(err, "metainfo could not unmarshal info from magnet: %s", rls.TorrentName)
+	}
+
+	tmpFile, err := createTmpFile()
+	if err != nil {
+		return err
+	}
+	defer tmpFile.Close()
+
+	if err := meta.Write(tmpFile); err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "error writing torrent file: %s", tmpFile.Name())
+	}
+
+	rls.TorrentTmpFile = tmpFile.Name()
+	rls.TorrentHash = meta.HashInfoBytes().String()
+	rls.Size = uint64(info.TotalLength())
+
+	return nil
+}
+
+// fetchMagnetHelper downloads the torrent from the configured fetch helper and checks it matches the magnet
+func (s *DownloadService) fetchMagnetHelper(ctx context.Context, m metainfo.Magnet, magnetURI string) (*metainfo.MetaInfo, error) {
+	replacer := strings.NewReplacer(
+		"{infohash}", m.InfoHash.HexString(),
+		"{magnet}", url.QueryEscape(magnetURI),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, replacer.Replace(s.magnetFetchURL), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build request")
+	}
+
+	req.Header.Set("User-Agent", "autobrr")
+
+	httpClient := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: sharedhttp.Transport,
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request")
+	}
+	defer sharedhttp.DrainAndClose(res)
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("unexpected status code: %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+
+	meta, err := metainfo.Load(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode torrent")
+	}
+
+	if meta.HashInfoBytes() != m.InfoHash {
+		return nil, errors.New("torrent info hash %s does not match magnet %s", meta.HashInfoBytes().HexString(), m.InfoHash.HexString())
+	}
+
+	// keep the trackers of the magnet if the helper strips them
+	if meta.Announce == "" && len(meta.AnnounceList) == 0 && len(m.Trackers) > 0 {
+		meta.Announce = m.Trackers[0]
+		meta.AnnounceList = metainfo.AnnounceList{m.Trackers}
+	}
+
+	return meta, nil
+}
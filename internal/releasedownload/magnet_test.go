@@ -0,0 +1,88 @@
+// Copyright (c) 2021-2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package releasedownload
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/logger"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTorrent(t *testing.T) *metainfo.MetaInfo {
+	t.Helper()
+
+	info, err := bencode.Marshal(metainfo.Info{
+		Name:        "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP.mkv",
+		PieceLength: 1 << 18,
+		Length:      10 << 18,
+		Pieces:      bytes.Repeat([]byte{0xab}, 10*20),
+	})
+	require.NoError(t, err)
+
+	return &metainfo.MetaInfo{InfoBytes: info}
+}
+
+func TestDownloadService_DownloadRelease_Magnet(t *testing.T) {
+	t.Parallel()
+
+	torrent := newTestTorrent(t)
+	infoHash := torrent.HashInfoBytes()
+
+	helper := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/torrent/"+infoHash.HexString() {
+			http.NotFound(w, r)
+			return
+		}
+		_ = torrent.Write(w)
+	}))
+	t.Cleanup(helper.Close)
+
+	magnetURI := metainfo.Magnet{InfoHash: infoHash, Trackers: []string{"udp://tracker.example.com:1337/announce"}}.String()
+
+	t.Run("fetch_helper", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewDownloadService(logger.New(&domain.Config{LogLevel: "ERROR"}), &domain.Config{MagnetResolveTimeout: 1, MagnetFetchURL: helper.URL + "/torrent/{infohash}"}, nil, nil, nil)
+
+		rls := &domain.Release{TorrentName: "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP", MagnetURI: magnetURI, Protocol: domain.ReleaseProtocolTorrent}
+
+		err := s.DownloadRelease(context.Background(), rls)
+		require.NoError(t, err)
+		defer os.Remove(rls.TorrentTmpFile)
+
+		assert.NotEmpty(t, rls.TorrentTmpFile)
+		assert.Equal(t, infoHash.HexString(), rls.TorrentHash)
+		assert.Equal(t, uint64(10<<18), rls.Size)
+
+		// torrent file is usable like a downloaded one
+		meta, err := metainfo.LoadFromFile(rls.TorrentTmpFile)
+		require.NoError(t, err)
+		assert.Equal(t, infoHash, meta.HashInfoBytes())
+		assert.Equal(t, "udp://tracker.example.com:1337/announce", meta.Announce)
+	})
+
+	t.Run("fetch_helper_not_found_no_peers", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewDownloadService(logger.New(&domain.Config{LogLevel: "ERROR"}), &domain.Config{MagnetResolveTimeout: 1, MagnetFetchURL: helper.URL + "/missing/{infohash}"}, nil, nil, nil)
+
+		// no trackers or peers to fall back to
+		rls := &domain.Release{MagnetURI: metainfo.Magnet{InfoHash: infoHash}.String(), Protocol: domain.ReleaseProtocolTorrent}
+
+		err := s.DownloadRelease(context.Background(), rls)
+		assert.Error(t, err)
+		assert.Empty(t, rls.TorrentTmpFile)
+	})
+}
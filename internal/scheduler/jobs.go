@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/notification"
 	"github.com/autobrr/autobrr/internal/update"
@@ -54,6 +55,50 @@ func (j *CheckUpdatesJob) Run() {
 	}
 }
 
+// NotificationDigestJob sends the digests of notifications that are due
+type NotificationDigestJob struct {
+	Name string
+	log  zerolog.Logger
+
+	notificationSvc notification.Digester
+}
+
+func NewNotificationDigestJob(log zerolog.Logger, notificationSvc notification.Digester) *NotificationDigestJob {
+	return &NotificationDigestJob{
+		Name:            "notification-digests",
+		log:             log,
+		notificationSvc: notificationSvc,
+	}
+}
+
+func (j *NotificationDigestJob) Run() {
+	if err := j.notificationSvc.FlushDigests(context.Background()); err != nil {
+		j.log.Error().Err(err).Msg("could not send notification digests")
+	}
+}
+
+// AuditCleanupJob removes audit log entries older than the configured retention
+type AuditCleanupJob struct {
+	Name string
+	log  zerolog.Logger
+
+	auditSvc audit.Service
+}
+
+func NewAuditCleanupJob(log zerolog.Logger, auditSvc audit.Service) *AuditCleanupJob {
+	return &AuditCleanupJob{
+		Name:     "audit-log-cleanup",
+		log:      log,
+		auditSvc: auditSvc,
+	}
+}
+
+func (j *AuditCleanupJob) Run() {
+	if err := j.auditSvc.DeleteExpired(context.Background()); err != nil {
+		j.log.Error().Err(err).Msg("could not delete expired audit log entries")
+	}
+}
+
 type TempDirCleanupJob struct {
 	Name string
 	log  zerolog.Logger
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/autobrr/autobrr/internal/audit"
 	"github.com/autobrr/autobrr/internal/domain"
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/notification"
@@ -26,24 +27,31 @@ type Service interface {
 	GetNextRun(id string) (time.Time, error)
 }
 
+type notificationService interface {
+	notification.Sender
+	notification.Digester
+}
+
 type service struct {
 	log             zerolog.Logger
 	config          *domain.Config
 	version         string
-	notificationSvc notification.Sender
+	notificationSvc notificationService
 	updateSvc       *update.Service
+	auditSvc        audit.Service
 
 	cron *cron.Cron
 	jobs map[string]cron.EntryID
 	m    sync.RWMutex
 }
 
-func NewService(log logger.Logger, config *domain.Config, notificationSvc notification.Sender, updateSvc *update.Service) Service {
+func NewService(log logger.Logger, config *domain.Config, notificationSvc notificationService, updateSvc *update.Service, auditSvc audit.Service) Service {
 	return &service{
 		log:             log.With().Str("module", "scheduler").Logger(),
 		config:          config,
 		notificationSvc: notificationSvc,
 		updateSvc:       updateSvc,
+		auditSvc:        auditSvc,
 		cron: cron.New(cron.WithChain(
 			cron.Recover(cron.DefaultLogger),
 		)),
@@ -81,6 +89,18 @@ func (s *service) addAppJobs() {
 		}
 	}
 
+	notificationDigests := NewNotificationDigestJob(s.log.With().Str("job", "notification-digests").Logger(), s.notificationSvc)
+
+	if id, err := s.ScheduleJob(notificationDigests, time.Minute, "notification-digests"); err != nil {
+		s.log.Error().Err(err).Msgf("scheduler.addAppJobs: error adding notification digest job: %v", id)
+	}
+
+	auditCleanup := NewAuditCleanupJob(s.log.With().Str("job", "audit-log-cleanup").Logger(), s.auditSvc)
+
+	if id, err := s.AddJob(auditCleanup, "30 4 * * *", "audit-log-cleanup"); err != nil {
+		s.log.Error().Err(err).Msgf("scheduler.addAppJobs: error adding audit log cleanup job: %v", id)
+	}
+
 	tempDirCleanup := NewTempDirCleanupJob(s.log.With().Str("job", "temp-dir-cleanup").Logger())
 
 	if id, err := s.AddJob(tempDirCleanup, "0 4 * * *", "temp-dir-cleanup"); err != nil {
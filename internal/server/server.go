@@ -16,6 +16,7 @@ import (
 	"github.com/autobrr/autobrr/internal/logger"
 	"github.com/autobrr/autobrr/internal/release"
 	"github.com/autobrr/autobrr/internal/scheduler"
+	"github.com/autobrr/autobrr/internal/tracking"
 	"github.com/autobrr/autobrr/internal/update"
 
 	"github.com/rs/zerolog"
@@ -25,29 +26,31 @@ type Server struct {
 	log    zerolog.Logger
 	config *domain.Config
 
-	indexerService indexer.Service
-	ircService     irc.Service
-	feedService    feed.Service
-	releaseService release.Service
-	scheduler      scheduler.Service
-	listService    list.Service
-	updateService  *update.Service
+	indexerService  indexer.Service
+	ircService      irc.Service
+	feedService     feed.Service
+	releaseService  release.Service
+	scheduler       scheduler.Service
+	listService     list.Service
+	trackingService tracking.Service
+	updateService   *update.Service
 
 	stopWG sync.WaitGroup
 	lock   sync.Mutex
 }
 
-func NewServer(log logger.Logger, config *domain.Config, ircSvc irc.Service, indexerSvc indexer.Service, feedSvc feed.Service, releaseSvc release.Service, listSvc list.Service, scheduler scheduler.Service, updateSvc *update.Service) *Server {
+func NewServer(log logger.Logger, config *domain.Config, ircSvc irc.Service, indexerSvc indexer.Service, feedSvc feed.Service, releaseSvc release.Service, listSvc list.Service, trackingSvc tracking.Service, scheduler scheduler.Service, updateSvc *update.Service) *Server {
 	return &Server{
-		log:            log.With().Str("module", "server").Logger(),
-		config:         config,
-		indexerService: indexerSvc,
-		ircService:     ircSvc,
-		feedService:    feedSvc,
-		releaseService: releaseSvc,
-		listService:    listSvc,
-		scheduler:      scheduler,
-		updateService:  updateSvc,
+		log:             log.With().Str("module", "server").Logger(),
+		config:          config,
+		indexerService:  indexerSvc,
+		ircService:      ircSvc,
+		feedService:     feedSvc,
+		releaseService:  releaseSvc,
+		listService:     listSvc,
+		trackingService: trackingSvc,
+		scheduler:       scheduler,
+		updateService:   updateSvc,
 	}
 }
 
@@ -57,6 +60,9 @@ func (s *Server) Start() error {
 	// start cron scheduler
 	s.scheduler.Start()
 
+	// start release processing workers before any announces come in
+	s.releaseService.StartPipeline()
+
 	// instantiate indexers
 	if err := s.indexerService.Start(); err != nil {
 		s.log.Error().Err(err).Msg("Could not start indexer service")
@@ -76,9 +82,17 @@ func (s *Server) Start() error {
 		s.log.Error().Err(err).Msg("Could not start release cleanup scheduler")
 	}
 
+	// start retrying failed action pushes
+	if err := s.releaseService.StartActionRetryJob(); err != nil {
+		s.log.Error().Err(err).Msg("Could not start action retry job")
+	}
+
 	// start lists background updater
 	go s.listService.Start()
 
+	// start polling download clients for torrent stats
+	s.trackingService.Start()
+
 	return nil
 }
 
@@ -90,6 +104,9 @@ func (s *Server) Shutdown() {
 
 	// stop cron scheduler
 	s.scheduler.Stop()
+
+	// stop release processing workers and drop delayed jobs
+	s.releaseService.StopPipeline()
 }
 
 func (s *Server) checkUpdates() {
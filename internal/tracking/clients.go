@@ -0,0 +1,249 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package tracking
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/porla"
+
+	"github.com/autobrr/go-deluge"
+	"github.com/autobrr/go-qbittorrent"
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// porlaPageSize is the number of torrents per page when listing porla torrents
+const porlaPageSize = 500
+
+func (s *service) getClientStats(ctx context.Context, client *domain.DownloadClient, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+	switch client.Type {
+	case domain.DownloadClientTypeQbittorrent:
+		qbtClient, ok := client.Client.(*qbittorrent.Client)
+		if !ok {
+			return nil, errors.New("unexpected client type for qBittorrent: %T", client.Client)
+		}
+		return qbittorrentStats(ctx, qbtClient, hashes)
+
+	case domain.DownloadClientTypeDelugeV1, domain.DownloadClientTypeDelugeV2:
+		return s.delugeStats(ctx, client, hashes)
+
+	case domain.DownloadClientTypeTransmission:
+		tbt, ok := client.Client.(*transmissionrpc.Client)
+		if !ok {
+			return nil, errors.New("unexpected client type for Transmission: %T", client.Client)
+		}
+		return transmissionStats(ctx, tbt, hashes)
+
+	case domain.DownloadClientTypePorla:
+		porlaClient, ok := client.Client.(*porla.Client)
+		if !ok {
+			return nil, errors.New("unexpected client type for Porla: %T", client.Client)
+		}
+		return porlaStats(ctx, porlaClient, hashes)
+
+	default:
+		return nil, errors.New("torrent stats not supported for client type: %s", client.Type)
+	}
+}
+
+func qbittorrentStats(ctx context.Context, client *qbittorrent.Client, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+	torrents, err := client.GetTorrentsCtx(ctx, qbittorrent.TorrentFilterOptions{Hashes: hashes})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get torrents")
+	}
+
+	states := make(map[string]*domain.TorrentClientStats, len(torrents))
+	for _, torrent := range torrents {
+		state := &domain.TorrentClientStats{
+			Hash:       strings.ToLower(torrent.Hash),
+			Size:       torrent.Size,
+			Uploaded:   torrent.Uploaded,
+			Downloaded: torrent.Downloaded,
+			Ratio:      torrent.Ratio,
+			SeedTime:   torrent.SeedingTime,
+			Progress:   torrent.Progress,
+			State:      string(torrent.State),
+		}
+
+		if torrent.CompletionOn > 0 {
+			completed := time.Unix(torrent.CompletionOn, 0)
+			state.CompletedAt = &completed
+		}
+
+		states[state.Hash] = state
+	}
+
+	return states, nil
+}
+
+func (s *service) delugeStats(ctx context.Context, client *domain.DownloadClient, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+	password, err := domain.ResolveSecret(client.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not resolve password of client %s", client.Name)
+	}
+
+	settings := deluge.Settings{
+		Hostname:         client.Host,
+		Port:             uint(client.Port),
+		Login:            client.Username,
+		Password:         password,
+		ReadWriteTimeout: time.Second * 60,
+	}
+
+	var delugeClient deluge.DelugeClient
+	if client.Type == domain.DownloadClientTypeDelugeV1 {
+		delugeClient = deluge.NewV1(settings)
+	} else {
+		delugeClient = deluge.NewV2(settings)
+	}
+
+	if err := delugeClient.Connect(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not connect to client %s at %s", client.Name, client.Host)
+	}
+
+	defer delugeClient.Close()
+
+	torrents, err := delugeClient.TorrentsStatus(ctx, deluge.StateUnspecified, hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get torrents")
+	}
+
+	states := make(map[string]*domain.TorrentClientStats, len(torrents))
+	for hash, torrent := range torrents {
+		if torrent == nil {
+			continue
+		}
+
+		state := &domain.TorrentClientStats{
+			Hash:       strings.ToLower(hash),
+			Size:       torrent.TotalSize,
+			Uploaded:   torrent.TotalUploaded,
+			Downloaded: torrent.AllTimeDownload,
+			Ratio:      float64(torrent.Ratio),
+			SeedTime:   torrent.SeedingTime,
+			Progress:   float64(torrent.Progress) / 100,
+			State:      torrent.State,
+		}
+
+		// completed time is only reported by deluge v2
+		if torrent.CompletedTime > 0 {
+			completed := time.Unix(torrent.CompletedTime, 0)
+			state.CompletedAt = &completed
+		} else if torrent.IsFinished {
+			state.Progress = 1
+		}
+
+		states[state.Hash] = state
+	}
+
+	return states, nil
+}
+
+var transmissionStatsFields = []string{
+	"hashString",
+	"sizeWhenDone",
+	"uploadedEver",
+	"downloadedEver",
+	"uploadRatio",
+	"secondsSeeding",
+	"percentDone",
+	"doneDate",
+	"status",
+}
+
+func transmissionStats(ctx context.Context, client *transmissionrpc.Client, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+	torrents, err := client.TorrentGetHashes(ctx, transmissionStatsFields, hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get torrents")
+	}
+
+	states := make(map[string]*domain.TorrentClientStats, len(torrents))
+	for _, torrent := range torrents {
+		if torrent.HashString == nil {
+			continue
+		}
+
+		state := &domain.TorrentClientStats{
+			Hash: strings.ToLower(*torrent.HashString),
+		}
+
+		if torrent.SizeWhenDone != nil {
+			state.Size = int64(torrent.SizeWhenDone.Byte())
+		}
+		if torrent.UploadedEver != nil {
+			state.Uploaded = *torrent.UploadedEver
+		}
+		if torrent.DownloadedEver != nil {
+			state.Downloaded = *torrent.DownloadedEver
+		}
+		// transmission reports -1 when nothing is downloaded and -2 for infinite
+		if torrent.UploadRatio != nil && *torrent.UploadRatio > 0 {
+			state.Ratio = *torrent.UploadRatio
+		}
+		if torrent.TimeSeeding != nil {
+			state.SeedTime = int64(torrent.TimeSeeding.Seconds())
+		}
+		if torrent.PercentDone != nil {
+			state.Progress = *torrent.PercentDone
+		}
+		if torrent.DoneDate != nil && torrent.DoneDate.Unix() > 0 {
+			completed := *torrent.DoneDate
+			state.CompletedAt = &completed
+		}
+		if torrent.Status != nil {
+			state.State = torrent.Status.String()
+		}
+
+		states[state.Hash] = state
+	}
+
+	return states, nil
+}
+
+// porlaStats lists all torrents since porla can not filter by hash. Porla does not report seed time.
+func porlaStats(ctx context.Context, client *porla.Client, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+	wanted := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		wanted[hash] = struct{}{}
+	}
+
+	states := make(map[string]*domain.TorrentClientStats, len(hashes))
+
+	for page := 0; ; page++ {
+		res, err := client.TorrentsListPage(ctx, nil, page, porlaPageSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list torrents")
+		}
+
+		for _, torrent := range res.Torrents {
+			if len(torrent.InfoHash) == 0 {
+				continue
+			}
+
+			hash := strings.ToLower(torrent.InfoHash[0])
+			if _, ok := wanted[hash]; !ok {
+				continue
+			}
+
+			states[hash] = &domain.TorrentClientStats{
+				Hash:       hash,
+				Size:       int64(torrent.Size),
+				Uploaded:   torrent.AllTimeUpload,
+				Downloaded: torrent.AllTimeDownload,
+				Ratio:      torrent.Ratio,
+				Progress:   torrent.Progress,
+			}
+		}
+
+		if len(res.Torrents) < porlaPageSize || (page+1)*porlaPageSize >= res.TorrentsTotal {
+			break
+		}
+	}
+
+	return states, nil
+}
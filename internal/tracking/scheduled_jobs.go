@@ -0,0 +1,48 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package tracking
+
+import (
+	"context"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog"
+)
+
+type Job interface {
+	cron.Job
+	RunE(ctx context.Context) error
+}
+
+type RefreshStatsSvc interface {
+	Refresh(ctx context.Context) error
+}
+
+type RefreshStatsJob struct {
+	log         zerolog.Logger
+	trackingSvc RefreshStatsSvc
+}
+
+func NewRefreshStatsJob(log zerolog.Logger, trackingSvc RefreshStatsSvc) Job {
+	return &RefreshStatsJob{log: log, trackingSvc: trackingSvc}
+}
+
+func (job *RefreshStatsJob) Run() {
+	ctx := context.Background()
+	if err := job.RunE(ctx); err != nil {
+		job.log.Error().Err(err).Msg("error refreshing torrent stats")
+	}
+}
+
+func (job *RefreshStatsJob) RunE(ctx context.Context) error {
+	job.log.Debug().Msg("running refresh torrent stats job")
+
+	if err := job.trackingSvc.Refresh(ctx); err != nil {
+		return err
+	}
+
+	job.log.Debug().Msg("finished refresh torrent stats job")
+
+	return nil
+}
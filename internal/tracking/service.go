@@ -0,0 +1,175 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package tracking
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/download_client"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/internal/scheduler"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/rs/zerolog"
+)
+
+type Service interface {
+	Start()
+	Track(ctx context.Context, stats *domain.ReleaseTorrentStats) error
+	Refresh(ctx context.Context) error
+	FindByReleaseID(ctx context.Context, releaseID int64) ([]*domain.ReleaseTorrentStats, error)
+	StatsByFilter(ctx context.Context) ([]*domain.TorrentStatsSummary, error)
+	StatsByIndexer(ctx context.Context) ([]*domain.TorrentStatsSummary, error)
+}
+
+// clientStatsFunc returns the state of the torrents in the client by lowercase info hash
+type clientStatsFunc func(ctx context.Context, client *domain.DownloadClient, hashes []string) (map[string]*domain.TorrentClientStats, error)
+
+type service struct {
+	log       zerolog.Logger
+	config    *domain.Config
+	repo      domain.TorrentStatsRepo
+	clientSvc download_client.Service
+	scheduler scheduler.Service
+
+	clientStats clientStatsFunc
+
+	// refresh runs from the scheduler and the api so make sure they don't overlap
+	m sync.Mutex
+}
+
+func NewService(log logger.Logger, config *domain.Config, repo domain.TorrentStatsRepo, clientSvc download_client.Service, scheduler scheduler.Service) Service {
+	s := &service{
+		log:       log.With().Str("module", "tracking").Logger(),
+		config:    config,
+		repo:      repo,
+		clientSvc: clientSvc,
+		scheduler: scheduler,
+	}
+
+	s.clientStats = s.getClientStats
+
+	return s
+}
+
+func (s *service) Start() {
+	if s.config.TorrentTrackingInterval <= 0 {
+		s.log.Debug().Msg("torrent tracking disabled")
+		return
+	}
+
+	if err := s.scheduleJob(); err != nil {
+		s.log.Error().Err(err).Msg("error while scheduling job")
+	}
+}
+
+func (s *service) scheduleJob() error {
+	identifierKey := "torrent-stats-updater"
+
+	job := NewRefreshStatsJob(s.log.With().Str("job", identifierKey).Logger(), s)
+
+	id, err := s.scheduler.ScheduleJob(job, time.Duration(s.config.TorrentTrackingInterval)*time.Minute, identifierKey)
+	if err != nil {
+		return err
+	}
+
+	s.log.Debug().Msgf("scheduled job with id %d", id)
+
+	return nil
+}
+
+func (s *service) Track(ctx context.Context, stats *domain.ReleaseTorrentStats) error {
+	if err := s.repo.Store(ctx, stats); err != nil {
+		return errors.Wrap(err, "could not store torrent stats for: %s", stats.TorrentHash)
+	}
+
+	s.log.Debug().Msgf("tracking torrent %s with hash %s in client %d", stats.TorrentName, stats.TorrentHash, stats.ClientID)
+
+	return nil
+}
+
+// Refresh polls the download clients for the state of all tracked torrents that are still in a client
+func (s *service) Refresh(ctx context.Context) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	active, err := s.repo.FindActive(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not find tracked torrents")
+	}
+
+	if len(active) == 0 {
+		return nil
+	}
+
+	byClient := make(map[int32][]*domain.ReleaseTorrentStats)
+	for _, stats := range active {
+		byClient[stats.ClientID] = append(byClient[stats.ClientID], stats)
+	}
+
+	for clientID, torrents := range byClient {
+		if err := s.refreshClient(ctx, clientID, torrents); err != nil {
+			// an unreachable client should not mark its torrents as removed, try again next run
+			s.log.Error().Err(err).Msgf("could not refresh torrent stats from client %d", clientID)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) refreshClient(ctx context.Context, clientID int32, torrents []*domain.ReleaseTorrentStats) error {
+	client, err := s.clientSvc.GetClient(ctx, clientID)
+	if err != nil {
+		return errors.Wrap(err, "could not get client")
+	}
+
+	if !client.Enabled {
+		s.log.Trace().Msgf("client %s not enabled, skip refreshing torrent stats", client.Name)
+		return nil
+	}
+
+	hashes := make([]string, 0, len(torrents))
+	for _, stats := range torrents {
+		hashes = append(hashes, stats.TorrentHash)
+	}
+
+	states, err := s.clientStats(ctx, client, hashes)
+	if err != nil {
+		return errors.Wrap(err, "could not get torrents from client %s", client.Name)
+	}
+
+	now := time.Now()
+
+	for _, stats := range torrents {
+		state := states[stats.TorrentHash]
+		if state == nil {
+			s.log.Debug().Msgf("torrent %s with hash %s no longer in client %s", stats.TorrentName, stats.TorrentHash, client.Name)
+		}
+
+		stats.Apply(state, now)
+
+		if err := s.repo.Update(ctx, stats); err != nil {
+			s.log.Error().Err(err).Msgf("could not update torrent stats for: %s", stats.TorrentHash)
+		}
+	}
+
+	s.log.Debug().Msgf("refreshed (%d) torrents from client %s", len(torrents), client.Name)
+
+	return nil
+}
+
+func (s *service) FindByReleaseID(ctx context.Context, releaseID int64) ([]*domain.ReleaseTorrentStats, error) {
+	return s.repo.FindByReleaseID(ctx, releaseID)
+}
+
+func (s *service) StatsByFilter(ctx context.Context) ([]*domain.TorrentStatsSummary, error) {
+	return s.repo.StatsByFilter(ctx)
+}
+
+func (s *service) StatsByIndexer(ctx context.Context) ([]*domain.TorrentStatsSummary, error) {
+	return s.repo.StatsByIndexer(ctx)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package tracking
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/autobrr/autobrr/internal/domain"
+	"github.com/autobrr/autobrr/internal/download_client"
+	"github.com/autobrr/autobrr/internal/logger"
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/autobrr/go-qbittorrent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTorrentStatsRepo struct {
+	domain.TorrentStatsRepo
+	active  []*domain.ReleaseTorrentStats
+	updated []*domain.ReleaseTorrentStats
+}
+
+func (m *mockTorrentStatsRepo) FindActive(_ context.Context) ([]*domain.ReleaseTorrentStats, error) {
+	return m.active, nil
+}
+
+func (m *mockTorrentStatsRepo) Update(_ context.Context, stats *domain.ReleaseTorrentStats) error {
+	m.updated = append(m.updated, stats)
+	return nil
+}
+
+type mockClientSvc struct {
+	download_client.Service
+	clients map[int32]*domain.DownloadClient
+}
+
+func (m *mockClientSvc) GetClient(_ context.Context, id int32) (*domain.DownloadClient, error) {
+	client, ok := m.clients[id]
+	if !ok {
+		return nil, domain.ErrRecordNotFound
+	}
+	return client, nil
+}
+
+func TestService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	seeding := &domain.ReleaseTorrentStats{ID: 1, ClientID: 1, TorrentHash: "aaaa"}
+	removed := &domain.ReleaseTorrentStats{ID: 2, ClientID: 1, TorrentHash: "bbbb"}
+	unreachable := &domain.ReleaseTorrentStats{ID: 3, ClientID: 2, TorrentHash: "cccc"}
+
+	repo := &mockTorrentStatsRepo{active: []*domain.ReleaseTorrentStats{seeding, removed, unreachable}}
+
+	s := NewService(logger.New(&domain.Config{LogLevel: "ERROR"}), &domain.Config{}, repo, &mockClientSvc{clients: map[int32]*domain.DownloadClient{
+		1: {ID: 1, Name: "qbit", Enabled: true},
+		2: {ID: 2, Name: "down", Enabled: true},
+	}}, nil).(*service)
+
+	s.clientStats = func(_ context.Context, client *domain.DownloadClient, hashes []string) (map[string]*domain.TorrentClientStats, error) {
+		if client.ID == 2 {
+			return nil, errors.New("connection refused")
+		}
+
+		assert.ElementsMatch(t, []string{"aaaa", "bbbb"}, hashes)
+
+		return map[string]*domain.TorrentClientStats{
+			"aaaa": {Hash: "aaaa", Uploaded: 2000, Downloaded: 1000, Ratio: 2, SeedTime: 3600, Progress: 1, State: "uploading"},
+		}, nil
+	}
+
+	err := s.Refresh(context.Background())
+	require.NoError(t, err)
+
+	// torrents of an unreachable client are left alone
+	assert.ElementsMatch(t, []*domain.ReleaseTorrentStats{seeding, removed}, repo.updated)
+
+	assert.Equal(t, int64(2000), seeding.Uploaded)
+	assert.Equal(t, 2.0, seeding.Ratio)
+	assert.NotNil(t, seeding.CompletedAt)
+	assert.False(t, seeding.Removed)
+
+	assert.True(t, removed.Removed)
+
+	assert.False(t, unreachable.Removed)
+	assert.Nil(t, unreachable.LastCheckedAt)
+}
+
+func Test_qbittorrentStats(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/torrents/info" || r.URL.Query().Get("hashes") != "abcd|ef01" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"hash":"ABCD","size":1000,"uploaded":3000,"downloaded":1000,"ratio":3,"seeding_time":7200,"progress":1,"state":"stalledUP","completion_on":1700000000}]`))
+	}))
+	defer srv.Close()
+
+	client := qbittorrent.NewClient(qbittorrent.Config{Host: srv.URL, APIKey: "key"})
+
+	states, err := qbittorrentStats(context.Background(), client, []string{"abcd", "ef01"})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+
+	state := states["abcd"]
+	require.NotNil(t, state)
+	assert.Equal(t, int64(3000), state.Uploaded)
+	assert.Equal(t, 3.0, state.Ratio)
+	assert.Equal(t, int64(7200), state.SeedTime)
+	assert.Equal(t, "stalledUP", state.State)
+	require.NotNil(t, state.CompletedAt)
+	assert.Equal(t, int64(1700000000), state.CompletedAt.Unix())
+}
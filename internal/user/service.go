@@ -12,9 +12,11 @@ import (
 
 type Service interface {
 	GetUserCount(ctx context.Context) (int, error)
+	List(ctx context.Context) ([]domain.User, error)
 	FindByUsername(ctx context.Context, username string) (*domain.User, error)
 	CreateUser(ctx context.Context, req domain.CreateUserRequest) error
 	Update(ctx context.Context, req domain.UpdateUserRequest) error
+	Delete(ctx context.Context, username string) error
 }
 
 type service struct {
@@ -31,6 +33,10 @@ func (s *service) GetUserCount(ctx context.Context) (int, error) {
 	return s.repo.GetUserCount(ctx)
 }
 
+func (s *service) List(ctx context.Context) ([]domain.User, error) {
+	return s.repo.List(ctx)
+}
+
 func (s *service) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
 	user, err := s.repo.FindByUsername(ctx, username)
 	if err != nil {
@@ -41,18 +47,77 @@ func (s *service) FindByUsername(ctx context.Context, username string) (*domain.
 }
 
 func (s *service) CreateUser(ctx context.Context, req domain.CreateUserRequest) error {
-	userCount, err := s.repo.GetUserCount(ctx)
-	if err != nil {
+	if req.Role == "" {
+		req.Role = domain.UserRoleViewer
+	}
+
+	if !req.Role.IsValid() {
+		return errors.New("validation error: invalid role: %s", req.Role)
+	}
+
+	existing, err := s.repo.FindByUsername(ctx, req.Username)
+	if err != nil && !errors.Is(err, domain.ErrRecordNotFound) {
 		return err
 	}
 
-	if userCount > 0 {
-		return errors.New("only 1 user account is supported at the moment")
+	if existing != nil {
+		return errors.Wrap(domain.ErrUserExists, "%s", req.Username)
 	}
 
 	return s.repo.Store(ctx, req)
 }
 
 func (s *service) Update(ctx context.Context, req domain.UpdateUserRequest) error {
+	if req.Role != "" {
+		if !req.Role.IsValid() {
+			return errors.New("validation error: invalid role: %s", req.Role)
+		}
+
+		if req.Role != domain.UserRoleAdmin {
+			if err := s.checkLastAdmin(ctx, req.UsernameCurrent); err != nil {
+				return err
+			}
+		}
+	}
+
 	return s.repo.Update(ctx, req)
 }
+
+func (s *service) Delete(ctx context.Context, username string) error {
+	if _, err := s.repo.FindByUsername(ctx, username); err != nil {
+		return err
+	}
+
+	if err := s.checkLastAdmin(ctx, username); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, username)
+}
+
+// checkLastAdmin returns an error if username is the only admin, so there is always someone left to manage users
+func (s *service) checkLastAdmin(ctx context.Context, username string) error {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	isAdmin := false
+	admins := 0
+	for _, u := range users {
+		if u.Role != domain.UserRoleAdmin {
+			continue
+		}
+
+		admins++
+		if u.Username == username {
+			isAdmin = true
+		}
+	}
+
+	if isAdmin && admins == 1 {
+		return errors.Wrap(domain.ErrLastAdmin, "%s is the last admin", username)
+	}
+
+	return nil
+}
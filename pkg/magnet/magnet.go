@@ -0,0 +1,255 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+// Package magnet resolves magnet links into torrent files by fetching the info dictionary
+// from peers with the metadata extension (BEP 9). Peers are taken from the magnet link (x.pe)
+// and from announcing to its trackers.
+package magnet
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+const (
+	DefaultTimeout     = 60 * time.Second
+	DefaultPeerTimeout = 15 * time.Second
+	DefaultMaxConns    = 8
+
+	// maxMetadataSize guards against peers announcing a huge info dictionary
+	maxMetadataSize = 16 << 20
+)
+
+var (
+	ErrNoPeers         = errors.New("no peers found for magnet")
+	ErrMetadataTimeout = errors.New("timed out fetching metadata")
+)
+
+type Fetcher struct {
+	// Timeout for resolving a magnet link, DefaultTimeout if zero
+	Timeout time.Duration
+
+	// PeerTimeout for fetching the metadata from a single peer, DefaultPeerTimeout if zero
+	PeerTimeout time.Duration
+
+	// MaxConns is the number of peers tried at the same time, DefaultMaxConns if zero
+	MaxConns int
+
+	// Port announced to trackers, nothing is listening on it
+	Port int
+
+	peerID [20]byte
+	once   sync.Once
+}
+
+func NewFetcher(timeout time.Duration) *Fetcher {
+	return &Fetcher{
+		Timeout: timeout,
+	}
+}
+
+func (f *Fetcher) timeout() time.Duration {
+	if f.Timeout > 0 {
+		return f.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (f *Fetcher) peerTimeout() time.Duration {
+	if f.PeerTimeout > 0 {
+		return f.PeerTimeout
+	}
+	return DefaultPeerTimeout
+}
+
+func (f *Fetcher) maxConns() int {
+	if f.MaxConns > 0 {
+		return f.MaxConns
+	}
+	return DefaultMaxConns
+}
+
+func (f *Fetcher) port() int {
+	if f.Port > 0 {
+		return f.Port
+	}
+	return 6881
+}
+
+func (f *Fetcher) id() [20]byte {
+	f.once.Do(func() {
+		copy(f.peerID[:], "-AB0001-")
+		_, _ = rand.Read(f.peerID[8:])
+	})
+	return f.peerID
+}
+
+// Fetch resolves the magnet uri into a torrent with the trackers of the magnet as announce list.
+func (f *Fetcher) Fetch(ctx context.Context, uri string) (*metainfo.MetaInfo, error) {
+	m, err := metainfo.ParseMagnetUri(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse magnet uri")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout())
+	defer cancel()
+
+	peers := make(chan string)
+	go f.collectPeers(ctx, m, peers)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		lastErr error
+		tried   int
+	)
+
+	results := make(chan []byte, 1)
+
+	for i := 0; i < f.maxConns(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for addr := range peers {
+				info, err := f.fetchFromPeer(ctx, addr, m.InfoHash)
+
+				mu.Lock()
+				tried++
+				if err != nil {
+					lastErr = errors.Wrap(err, "peer %s", addr)
+				}
+				mu.Unlock()
+
+				if err != nil {
+					continue
+				}
+
+				select {
+				case results <- info:
+					cancel()
+				default:
+				}
+				return
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	info, ok := <-results
+	if ok {
+		return newMetaInfo(info, m.Trackers), nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if tried == 0 {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errors.Wrap(ErrMetadataTimeout, "no peers found within %s", f.timeout())
+		}
+		return nil, ErrNoPeers
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, errors.Wrap(ErrMetadataTimeout, "tried %d peers, last error: %v", tried, lastErr)
+	}
+
+	return nil, errors.Wrap(lastErr, "could not fetch metadata from %d peers", tried)
+}
+
+// collectPeers sends the unique peers of the magnet to out and closes it when done.
+// Peers from the magnet are sent first, then peers from the trackers as they answer.
+func (f *Fetcher) collectPeers(ctx context.Context, m metainfo.Magnet, out chan<- string) {
+	defer close(out)
+
+	seen := map[string]struct{}{}
+	send := func(addr string) bool {
+		if _, ok := seen[addr]; ok {
+			return true
+		}
+		seen[addr] = struct{}{}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case out <- addr:
+			return true
+		}
+	}
+
+	for _, addr := range m.Params["x.pe"] {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			continue
+		}
+		if !send(addr) {
+			return
+		}
+	}
+
+	if len(m.Trackers) == 0 {
+		return
+	}
+
+	announced := make(chan []string)
+
+	var wg sync.WaitGroup
+	for _, tracker := range m.Trackers {
+		wg.Add(1)
+		go func(tracker string) {
+			defer wg.Done()
+
+			peers, err := f.announce(ctx, tracker, m.InfoHash)
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+			case announced <- peers:
+			}
+		}(tracker)
+	}
+
+	go func() {
+		wg.Wait()
+		close(announced)
+	}()
+
+	for peers := range announced {
+		for _, addr := range peers {
+			if !send(addr) {
+				// drain so the announce goroutines can exit
+				for range announced {
+				}
+				return
+			}
+		}
+	}
+}
+
+func newMetaInfo(info []byte, trackers []string) *metainfo.MetaInfo {
+	mi := &metainfo.MetaInfo{
+		InfoBytes:    info,
+		CreatedBy:    "autobrr",
+		CreationDate: time.Now().Unix(),
+	}
+
+	if len(trackers) > 0 {
+		mi.Announce = trackers[0]
+		mi.AnnounceList = metainfo.AnnounceList{trackers}
+	}
+
+	return mi
+}
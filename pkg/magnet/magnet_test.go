@@ -0,0 +1,350 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package magnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPeer is a minimal in-process peer that only serves the metadata of a torrent
+type testPeer struct {
+	listener net.Listener
+	infoHash metainfo.Hash
+	metadata []byte
+}
+
+func newTestInfo(t *testing.T, pieces int) []byte {
+	t.Helper()
+
+	info := metainfo.Info{
+		Name:        "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP.mkv",
+		PieceLength: 1 << 18,
+		Length:      int64(pieces) << 18,
+		Pieces:      bytes.Repeat([]byte{0xab}, pieces*20),
+	}
+
+	b, err := bencode.Marshal(info)
+	require.NoError(t, err)
+
+	return b
+}
+
+func newTestPeer(t *testing.T, metadata []byte, infoHash metainfo.Hash) *testPeer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	p := &testPeer{listener: l, infoHash: infoHash, metadata: metadata}
+	t.Cleanup(func() { l.Close() })
+
+	go p.serve()
+
+	return p
+}
+
+func (p *testPeer) addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *testPeer) port() int {
+	return p.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (p *testPeer) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *testPeer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	hs := make([]byte, 68)
+	if _, err := io.ReadFull(conn, hs); err != nil {
+		return
+	}
+
+	if !bytes.Equal(hs[28:48], p.infoHash[:]) {
+		return
+	}
+
+	var resp bytes.Buffer
+	resp.WriteByte(19)
+	resp.WriteString(protocolName)
+	resp.Write([]byte{0, 0, 0, 0, 0, 0x10, 0, 0})
+	resp.Write(p.infoHash[:])
+	resp.WriteString("-TP0001-000000000000")
+	if _, err := conn.Write(resp.Bytes()); err != nil {
+		return
+	}
+
+	// bitfield before the extended handshake like most clients do
+	_, _ = conn.Write([]byte{0, 0, 0, 2, 5, 0xff})
+
+	ext, _ := bencode.Marshal(extendedHandshake{M: map[string]int{"ut_metadata": 3}, MetadataSize: len(p.metadata)})
+	if err := writeExtended(conn, extHandshake, ext); err != nil {
+		return
+	}
+
+	var clientMetadataID byte
+
+	for {
+		id, payload, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+
+		if id != msgExtended || len(payload) == 0 {
+			continue
+		}
+
+		switch payload[0] {
+		case extHandshake:
+			var hs extendedHandshake
+			if err := bencode.Unmarshal(payload[1:], &hs); err != nil {
+				return
+			}
+			clientMetadataID = byte(hs.M["ut_metadata"])
+
+		case 3:
+			msg, _, err := decodeMetadataMessage(payload[1:])
+			if err != nil {
+				return
+			}
+
+			offset := msg.Piece * metadataPieceSize
+			end := min(offset+metadataPieceSize, len(p.metadata))
+
+			header, _ := bencode.Marshal(metadataMessage{MsgType: metadataData, Piece: msg.Piece, TotalSize: len(p.metadata)})
+			if err := writeExtended(conn, clientMetadataID, append(header, p.metadata[offset:end]...)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func magnetURI(infoHash metainfo.Hash, trackers []string, peers []string) string {
+	m := metainfo.Magnet{InfoHash: infoHash, Trackers: trackers, Params: map[string][]string{}}
+	for _, peer := range peers {
+		m.Params.Add("x.pe", peer)
+	}
+	return m.String()
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	// more than one metadata piece
+	info := newTestInfo(t, 1000)
+	require.Greater(t, len(info), metadataPieceSize)
+
+	infoHash := metainfo.HashBytes(info)
+
+	t.Run("peer_from_magnet", func(t *testing.T) {
+		t.Parallel()
+
+		peer := newTestPeer(t, info, infoHash)
+
+		f := NewFetcher(5 * time.Second)
+		mi, err := f.Fetch(context.Background(), magnetURI(infoHash, []string{"http://tracker.example.com/announce"}, []string{peer.addr()}))
+		require.NoError(t, err)
+
+		assert.Equal(t, infoHash, mi.HashInfoBytes())
+		assert.Equal(t, "http://tracker.example.com/announce", mi.Announce)
+
+		parsed, err := mi.UnmarshalInfo()
+		require.NoError(t, err)
+		assert.Equal(t, "That.Show.S01E01.1080p.WEB-DL.DDP5.1.H.264-GROUP.mkv", parsed.Name)
+
+		// written torrent can be loaded again
+		var buf bytes.Buffer
+		require.NoError(t, mi.Write(&buf))
+
+		loaded, err := metainfo.Load(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, infoHash, loaded.HashInfoBytes())
+	})
+
+	t.Run("peer_from_http_tracker", func(t *testing.T) {
+		t.Parallel()
+
+		peer := newTestPeer(t, info, infoHash)
+
+		tracker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, string(infoHash[:]), r.URL.Query().Get("info_hash"))
+			assert.Equal(t, "secret", r.URL.Query().Get("passkey"))
+
+			compact := make([]byte, 6)
+			copy(compact, net.ParseIP("127.0.0.1").To4())
+			binary.BigEndian.PutUint16(compact[4:], uint16(peer.port()))
+
+			b, _ := bencode.Marshal(map[string]any{"interval": 1800, "peers": compact})
+			_, _ = w.Write(b)
+		}))
+		defer tracker.Close()
+
+		f := NewFetcher(5 * time.Second)
+		mi, err := f.Fetch(context.Background(), magnetURI(infoHash, []string{tracker.URL + "/announce?passkey=secret"}, nil))
+		require.NoError(t, err)
+		assert.Equal(t, infoHash, mi.HashInfoBytes())
+	})
+
+	t.Run("peer_from_udp_tracker", func(t *testing.T) {
+		t.Parallel()
+
+		peer := newTestPeer(t, info, infoHash)
+		tracker := newTestUDPTracker(t, peer.port())
+
+		f := NewFetcher(5 * time.Second)
+		mi, err := f.Fetch(context.Background(), magnetURI(infoHash, []string{"udp://" + tracker + "/announce"}, nil))
+		require.NoError(t, err)
+		assert.Equal(t, infoHash, mi.HashInfoBytes())
+	})
+
+	t.Run("skips_bad_peer", func(t *testing.T) {
+		t.Parallel()
+
+		// serves metadata that doesn't match the info hash
+		bad := newTestPeer(t, newTestInfo(t, 10), infoHash)
+		good := newTestPeer(t, info, infoHash)
+
+		f := NewFetcher(5 * time.Second)
+		f.MaxConns = 1
+
+		mi, err := f.Fetch(context.Background(), magnetURI(infoHash, nil, []string{bad.addr(), good.addr()}))
+		require.NoError(t, err)
+		assert.Equal(t, infoHash, mi.HashInfoBytes())
+	})
+
+	t.Run("hash_mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		bad := newTestPeer(t, newTestInfo(t, 10), infoHash)
+
+		f := NewFetcher(5 * time.Second)
+		_, err := f.Fetch(context.Background(), magnetURI(infoHash, nil, []string{bad.addr()}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "metadata does not match info hash")
+	})
+
+	t.Run("no_peers", func(t *testing.T) {
+		t.Parallel()
+
+		f := NewFetcher(5 * time.Second)
+		_, err := f.Fetch(context.Background(), magnetURI(infoHash, nil, nil))
+		assert.ErrorIs(t, err, ErrNoPeers)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// accepts the connection and never answers
+		silent, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer silent.Close()
+
+		go func() {
+			for {
+				conn, err := silent.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					_, _ = io.Copy(io.Discard, conn)
+				}()
+			}
+		}()
+
+		f := NewFetcher(200 * time.Millisecond)
+		_, err = f.Fetch(context.Background(), magnetURI(infoHash, nil, []string{silent.Addr().String()}))
+		assert.ErrorIs(t, err, ErrMetadataTimeout)
+	})
+
+	t.Run("invalid_uri", func(t *testing.T) {
+		t.Parallel()
+
+		f := NewFetcher(time.Second)
+		_, err := f.Fetch(context.Background(), "https://example.com/file.torrent")
+		assert.Error(t, err)
+	})
+}
+
+// newTestUDPTracker answers connect and announce requests with a single peer on localhost
+func newTestUDPTracker(t *testing.T, peerPort int) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 2048)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < 16 {
+				continue
+			}
+
+			action := binary.BigEndian.Uint32(buf[8:])
+			txID := binary.BigEndian.Uint32(buf[12:])
+
+			var resp bytes.Buffer
+			switch action {
+			case udpActionConnect:
+				_ = binary.Write(&resp, binary.BigEndian, uint32(udpActionConnect))
+				_ = binary.Write(&resp, binary.BigEndian, txID)
+				_ = binary.Write(&resp, binary.BigEndian, uint64(1234))
+			case udpActionAnnounce:
+				_ = binary.Write(&resp, binary.BigEndian, uint32(udpActionAnnounce))
+				_ = binary.Write(&resp, binary.BigEndian, txID)
+				_ = binary.Write(&resp, binary.BigEndian, uint32(1800))
+				_ = binary.Write(&resp, binary.BigEndian, uint32(0))
+				_ = binary.Write(&resp, binary.BigEndian, uint32(1))
+				resp.Write(net.ParseIP("127.0.0.1").To4())
+				_ = binary.Write(&resp, binary.BigEndian, uint16(peerPort))
+			default:
+				continue
+			}
+
+			_, _ = conn.WriteTo(resp.Bytes(), addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCompactPeers(t *testing.T) {
+	t.Parallel()
+
+	b := []byte{127, 0, 0, 1, 0x1a, 0xe1, 10, 0, 0, 2, 0, 0, 192, 168, 1, 10, 0x1a, 0xe2}
+	assert.Equal(t, []string{"127.0.0.1:6881", "192.168.1.10:6882"}, compactPeers(b, net.IPv4len))
+
+	peers, err := decodePeers(bencode.Bytes(fmt.Sprintf("ld2:ip9:127.0.0.14:porti%seee", strconv.Itoa(6881))))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:6881"}, peers)
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package magnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+const (
+	protocolName = "BitTorrent protocol"
+
+	msgExtended byte = 20
+
+	extHandshake byte = 0
+
+	// utMetadataID is the id peers use for ut_metadata messages sent to us
+	utMetadataID byte = 1
+
+	metadataPieceSize = 16 * 1024
+
+	metadataRequest = 0
+	metadataData    = 1
+	metadataReject  = 2
+
+	// maxMessageSize is a metadata piece plus room for the bencoded header
+	maxMessageSize = metadataPieceSize + 1024
+)
+
+type extendedHandshake struct {
+	M            map[string]int `bencode:"m"`
+	MetadataSize int            `bencode:"metadata_size,omitempty"`
+	V            string         `bencode:"v,omitempty"`
+}
+
+type metadataMessage struct {
+	MsgType   int `bencode:"msg_type"`
+	Piece     int `bencode:"piece"`
+	TotalSize int `bencode:"total_size,omitempty"`
+}
+
+// fetchFromPeer downloads the info dictionary from a single peer and verifies it against the info hash
+func (f *Fetcher) fetchFromPeer(ctx context.Context, addr string, infoHash metainfo.Hash) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.peerTimeout())
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect")
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// unblock reads and writes if the fetch is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := handshake(conn, infoHash, f.id()); err != nil {
+		return nil, err
+	}
+
+	ext, err := bencode.Marshal(extendedHandshake{M: map[string]int{"ut_metadata": int(utMetadataID)}, V: "autobrr"})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode extended handshake")
+	}
+
+	if err := writeExtended(conn, extHandshake, ext); err != nil {
+		return nil, errors.Wrap(err, "could not send extended handshake")
+	}
+
+	var (
+		peerMetadataID byte
+		metadata       []byte
+		received       int
+		pieces         int
+	)
+
+	for {
+		id, payload, err := readMessage(conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if id != msgExtended || len(payload) == 0 {
+			// keep-alive, bitfield, have etc.
+			continue
+		}
+
+		switch payload[0] {
+		case extHandshake:
+			var hs extendedHandshake
+			if err := bencode.Unmarshal(payload[1:], &hs); err != nil {
+				return nil, errors.Wrap(err, "could not decode extended handshake")
+			}
+
+			metadataID, ok := hs.M["ut_metadata"]
+			if !ok || metadataID <= 0 || metadataID > 255 {
+				return nil, errors.New("peer does not support metadata exchange")
+			}
+
+			if hs.MetadataSize <= 0 || hs.MetadataSize > maxMetadataSize {
+				return nil, errors.New("invalid metadata size: %d", hs.MetadataSize)
+			}
+
+			// a second handshake is allowed but the metadata size can't change mid transfer
+			if metadata != nil {
+				continue
+			}
+
+			peerMetadataID = byte(metadataID)
+			metadata = make([]byte, hs.MetadataSize)
+			pieces = (hs.MetadataSize + metadataPieceSize - 1) / metadataPieceSize
+
+			for i := 0; i < pieces; i++ {
+				req, err := bencode.Marshal(metadataMessage{MsgType: metadataRequest, Piece: i})
+				if err != nil {
+					return nil, errors.Wrap(err, "could not encode metadata request")
+				}
+
+				if err := writeExtended(conn, peerMetadataID, req); err != nil {
+					return nil, errors.Wrap(err, "could not request metadata piece %d", i)
+				}
+			}
+
+		case utMetadataID:
+			if metadata == nil {
+				return nil, errors.New("got metadata before extended handshake")
+			}
+
+			msg, data, err := decodeMetadataMessage(payload[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			switch msg.MsgType {
+			case metadataData:
+			case metadataRequest:
+				// we have nothing to share
+				continue
+			case metadataReject:
+				return nil, errors.New("peer rejected metadata piece %d", msg.Piece)
+			default:
+				return nil, errors.New("unexpected metadata message type: %d", msg.MsgType)
+			}
+
+			piece := msg.Piece
+
+			if piece < 0 || piece >= pieces {
+				return nil, errors.New("got invalid metadata piece: %d", piece)
+			}
+
+			offset := piece * metadataPieceSize
+			want := min(metadataPieceSize, len(metadata)-offset)
+			if len(data) != want {
+				return nil, errors.New("metadata piece %d has size %d, want %d", piece, len(data), want)
+			}
+
+			copy(metadata[offset:], data)
+			received++
+
+			if received < pieces {
+				continue
+			}
+
+			if metainfo.HashBytes(metadata) != infoHash {
+				return nil, errors.New("metadata does not match info hash")
+			}
+
+			return metadata, nil
+		}
+	}
+}
+
+func handshake(conn net.Conn, infoHash metainfo.Hash, peerID [20]byte) error {
+	var buf bytes.Buffer
+	buf.WriteByte(byte(len(protocolName)))
+	buf.WriteString(protocolName)
+
+	// extension protocol bit (BEP 10)
+	reserved := [8]byte{}
+	reserved[5] |= 0x10
+	buf.Write(reserved[:])
+
+	buf.Write(infoHash[:])
+	buf.Write(peerID[:])
+
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, "could not send handshake")
+	}
+
+	resp := make([]byte, 68)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return errors.Wrap(err, "could not read handshake")
+	}
+
+	if resp[0] != byte(len(protocolName)) || string(resp[1:20]) != protocolName {
+		return errors.New("invalid handshake")
+	}
+
+	if resp[25]&0x10 == 0 {
+		return errors.New("peer does not support the extension protocol")
+	}
+
+	if !bytes.Equal(resp[28:48], infoHash[:]) {
+		return errors.New("peer answered with another info hash")
+	}
+
+	return nil
+}
+
+// readMessage reads a length prefixed message. Keep-alives are returned with id 0 and no payload.
+func readMessage(r io.Reader) (byte, []byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return 0, nil, errors.Wrap(err, "could not read message")
+	}
+
+	if length == 0 {
+		return 0, nil, nil
+	}
+
+	if length > maxMessageSize {
+		// skip big messages like pieces, we never ask for them
+		if _, err := io.CopyN(io.Discard, r, int64(length)); err != nil {
+			return 0, nil, errors.Wrap(err, "could not read message")
+		}
+		return 0, nil, nil
+	}
+
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return 0, nil, errors.Wrap(err, "could not read message")
+	}
+
+	return msg[0], msg[1:], nil
+}
+
+func writeExtended(w io.Writer, extID byte, payload []byte) error {
+	buf := make([]byte, 4, 6+len(payload))
+	binary.BigEndian.PutUint32(buf, uint32(2+len(payload)))
+	buf = append(buf, msgExtended, extID)
+	buf = append(buf, payload...)
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// decodeMetadataMessage splits a ut_metadata message into the bencoded header and the piece data following it
+func decodeMetadataMessage(payload []byte) (metadataMessage, []byte, error) {
+	var msg metadataMessage
+
+	d := bencode.NewDecoder(bytes.NewReader(payload))
+	if err := d.Decode(&msg); err != nil {
+		return msg, nil, errors.Wrap(err, "could not decode metadata message")
+	}
+
+	return msg, payload[d.Offset:], nil
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+// SPDX-License-Identifier: GPL-2.0-or-later
+
+package magnet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/autobrr/autobrr/pkg/errors"
+	"github.com/autobrr/autobrr/pkg/sharedhttp"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+const (
+	udpProtocolID     = 0x41727101980
+	udpActionConnect  = 0
+	udpActionAnnounce = 1
+
+	announceNumWant = 50
+)
+
+type httpAnnounceResponse struct {
+	FailureReason string        `bencode:"failure reason"`
+	Peers         bencode.Bytes `bencode:"peers"`
+	Peers6        []byte        `bencode:"peers6"`
+}
+
+type httpPeer struct {
+	IP   string `bencode:"ip"`
+	Port int    `bencode:"port"`
+}
+
+// announce asks the tracker for peers of the torrent. HTTP(S) and UDP (BEP 15) trackers are supported.
+func (f *Fetcher) announce(ctx context.Context, tracker string, infoHash metainfo.Hash) ([]string, error) {
+	u, err := url.Parse(tracker)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse tracker url")
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return f.announceHTTP(ctx, u, infoHash)
+	case "udp":
+		return f.announceUDP(ctx, u, infoHash)
+	default:
+		return nil, errors.New("unsupported tracker scheme: %s", u.Scheme)
+	}
+}
+
+func (f *Fetcher) announceHTTP(ctx context.Context, u *url.URL, infoHash metainfo.Hash) ([]string, error) {
+	peerID := f.id()
+
+	// info_hash and peer_id are raw bytes so the query is built by hand to keep existing params like passkeys
+	params := []string{
+		"info_hash=" + url.QueryEscape(string(infoHash[:])),
+		"peer_id=" + url.QueryEscape(string(peerID[:])),
+		"port=" + strconv.Itoa(f.port()),
+		"uploaded=0",
+		"downloaded=0",
+		"left=0",
+		"compact=1",
+		"event=started",
+		"numwant=" + strconv.Itoa(announceNumWant),
+	}
+
+	announceURL := *u
+	if announceURL.RawQuery != "" {
+		announceURL.RawQuery += "&"
+	}
+	announceURL.RawQuery += strings.Join(params, "&")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, announceURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build announce request")
+	}
+
+	req.Header.Set("User-Agent", "autobrr")
+
+	client := &http.Client{
+		Timeout:   f.peerTimeout(),
+		Transport: sharedhttp.Transport,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not announce")
+	}
+	defer sharedhttp.DrainAndClose(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read announce response")
+	}
+
+	var res httpAnnounceResponse
+	if err := bencode.Unmarshal(body, &res); err != nil {
+		return nil, errors.Wrap(err, "could not decode announce response")
+	}
+
+	if res.FailureReason != "" {
+		return nil, errors.New("tracker failure: %s", res.FailureReason)
+	}
+
+	var peers []string
+	if len(res.Peers) > 0 {
+		peers, err = decodePeers(res.Peers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	peers = append(peers, compactPeers(res.Peers6, net.IPv6len)...)
+
+	return peers, nil
+}
+
+// decodePeers decodes the peers of an http announce which are either a compact string or a list of dicts
+func decodePeers(b bencode.Bytes) ([]string, error) {
+	var compact []byte
+	if err := bencode.Unmarshal(b, &compact); err == nil {
+		return compactPeers(compact, net.IPv4len), nil
+	}
+
+	var list []httpPeer
+	if err := bencode.Unmarshal(b, &list); err != nil {
+		return nil, errors.Wrap(err, "could not decode peers")
+	}
+
+	peers := make([]string, 0, len(list))
+	for _, p := range list {
+		peers = append(peers, net.JoinHostPort(p.IP, strconv.Itoa(p.Port)))
+	}
+
+	return peers, nil
+}
+
+func (f *Fetcher) announceUDP(ctx context.Context, u *url.URL, infoHash metainfo.Hash) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.peerTimeout())
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", u.Host)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect")
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// connect
+	txID := randUint32()
+
+	req := make([]byte, 16)
+	binary.BigEndian.PutUint64(req[0:], udpProtocolID)
+	binary.BigEndian.PutUint32(req[8:], udpActionConnect)
+	binary.BigEndian.PutUint32(req[12:], txID)
+
+	resp, err := udpRoundTrip(conn, req, udpActionConnect, txID, 16)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to tracker")
+	}
+
+	connectionID := binary.BigEndian.Uint64(resp[8:])
+
+	// announce
+	txID = randUint32()
+	peerID := f.id()
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, connectionID)
+	_ = binary.Write(&buf, binary.BigEndian, uint32(udpActionAnnounce))
+	_ = binary.Write(&buf, binary.BigEndian, txID)
+	buf.Write(infoHash[:])
+	buf.Write(peerID[:])
+	_ = binary.Write(&buf, binary.BigEndian, uint64(0)) // downloaded
+	_ = binary.Write(&buf, binary.BigEndian, uint64(0)) // left
+	_ = binary.Write(&buf, binary.BigEndian, uint64(0)) // uploaded
+	_ = binary.Write(&buf, binary.BigEndian, uint32(2)) // event started
+	_ = binary.Write(&buf, binary.BigEndian, uint32(0)) // ip
+	_ = binary.Write(&buf, binary.BigEndian, randUint32())
+	_ = binary.Write(&buf, binary.BigEndian, int32(announceNumWant))
+	_ = binary.Write(&buf, binary.BigEndian, uint16(f.port()))
+
+	resp, err = udpRoundTrip(conn, buf.Bytes(), udpActionAnnounce, txID, 20)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not announce")
+	}
+
+	return compactPeers(resp[20:], net.IPv4len), nil
+}
+
+// udpRoundTrip sends req and reads the response for the transaction
+func udpRoundTrip(conn net.Conn, req []byte, action uint32, txID uint32, minSize int) ([]byte, error) {
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, 2048)
+	for {
+		n, err := conn.Read(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		if n < 8 || binary.BigEndian.Uint32(resp[4:]) != txID {
+			continue
+		}
+
+		// action 3 is an error with a message
+		if got := binary.BigEndian.Uint32(resp[0:]); got != action {
+			if got == 3 {
+				return nil, errors.New("tracker error: %s", string(resp[8:n]))
+			}
+			return nil, errors.New("unexpected action: %d", got)
+		}
+
+		if n < minSize {
+			return nil, errors.New("short response: %d bytes", n)
+		}
+
+		return resp[:n], nil
+	}
+}
+
+func compactPeers(b []byte, ipLen int) []string {
+	size := ipLen + 2
+
+	peers := make([]string, 0, len(b)/size)
+	for i := 0; i+size <= len(b); i += size {
+		ip := net.IP(b[i : i+ipLen])
+		port := binary.BigEndian.Uint16(b[i+ipLen:])
+		if port == 0 {
+			continue
+		}
+
+		peers = append(peers, net.JoinHostPort(ip.String(), strconv.Itoa(int(port))))
+	}
+
+	return peers
+}
+
+func randUint32() uint32 {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint32(b[:])
+}
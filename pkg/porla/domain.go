@@ -25,7 +25,9 @@ type TorrentsAddRes struct {
 }
 
 type TorrentsListReq struct {
-	Filters *TorrentsListFilters `json:"filters"`
+	Filters  *TorrentsListFilters `json:"filters"`
+	Page     int                  `json:"page,omitempty"`
+	PageSize int                  `json:"page_size,omitempty"`
 }
 
 type TorrentsListFilters struct {
@@ -40,18 +42,21 @@ type TorrentsListRes struct {
 }
 
 type Torrent struct {
-	DownloadRate  int      `json:"download_rate"`
-	UploadRate    int      `json:"upload_rate"`
-	InfoHash      []string `json:"info_hash"`
-	ListPeers     int      `json:"list_peers"`
-	ListSeeds     int      `json:"list_seeds"`
-	Name          string   `json:"name"`
-	NumPeers      int      `json:"num_peers"`
-	NumSeeds      int      `json:"num_seeds"`
-	Progress      float64  `json:"progress"`
-	QueuePosition int      `json:"queue_position"`
-	SavePath      string   `json:"save_path"`
-	Size          int      `json:"size"`
-	Total         int      `json:"total"`
-	TotalDone     int      `json:"total_done"`
+	AllTimeDownload int64    `json:"all_time_download"`
+	AllTimeUpload   int64    `json:"all_time_upload"`
+	DownloadRate    int      `json:"download_rate"`
+	UploadRate      int      `json:"upload_rate"`
+	InfoHash        []string `json:"info_hash"`
+	ListPeers       int      `json:"list_peers"`
+	ListSeeds       int      `json:"list_seeds"`
+	Name            string   `json:"name"`
+	NumPeers        int      `json:"num_peers"`
+	NumSeeds        int      `json:"num_seeds"`
+	Progress        float64  `json:"progress"`
+	QueuePosition   int      `json:"queue_position"`
+	Ratio           float64  `json:"ratio"`
+	SavePath        string   `json:"save_path"`
+	Size            int      `json:"size"`
+	Total           int      `json:"total"`
+	TotalDone       int      `json:"total_done"`
 }
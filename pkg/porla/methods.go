@@ -42,7 +42,12 @@ func (c *Client) TorrentsAdd(ctx context.Context, req *TorrentsAddReq) error {
 }
 
 func (c *Client) TorrentsList(ctx context.Context, filters *TorrentsListFilters) (*TorrentsListRes, error) {
-	response, err := c.rpcClient.CallCtx(ctx, "torrents.list", TorrentsListReq{Filters: filters})
+	return c.TorrentsListPage(ctx, filters, 0, 0)
+}
+
+// TorrentsListPage lists a page of torrents. A page size of 0 uses the default of porla.
+func (c *Client) TorrentsListPage(ctx context.Context, filters *TorrentsListFilters, page int, pageSize int) (*TorrentsListRes, error) {
+	response, err := c.rpcClient.CallCtx(ctx, "torrents.list", TorrentsListReq{Filters: filters, Page: page, PageSize: pageSize})
 	if err != nil {
 		return nil, err
 	}
@@ -13,6 +13,7 @@ type Primitive = string | number | boolean | symbol | undefined;
 type ValidateResponse = {
   username?: AuthInfo['username'];
   auth_method?: AuthInfo['authMethod'];
+  role?: AuthInfo['role'];
   profile_picture?: AuthInfo['profilePicture'];
 }
 
@@ -288,6 +289,16 @@ export const APIClient = {
     }),
     delete: (key: string) => appClient.Delete(`api/keys/${key}`)
   },
+  users: {
+    getAll: () => appClient.Get<User[]>("api/users"),
+    create: (user: UserCreate) => appClient.Post("api/users", {
+      body: user
+    }),
+    update: (username: string, user: UserEdit) => appClient.Put(`api/users/${encodeURIComponent(username)}`, {
+      body: user
+    }),
+    delete: (username: string) => appClient.Delete(`api/users/${encodeURIComponent(username)}`)
+  },
   config: {
     get: () => appClient.Get<Config>("api/config"),
     update: (config: ConfigUpdate) => appClient.Patch("api/config", {
@@ -345,6 +356,10 @@ export const APIClient = {
       body: feed
     }),
     fetchCaps: (id: number) => appClient.Get<FeedCaps>(`api/feeds/${id}/caps`),
+    backfill: (req: FeedBackfillRequest, dryRun?: boolean) => appClient.Post<FeedBackfillPlan[]>("api/feeds/backfill", {
+      body: req,
+      queryString: dryRun ? { dry_run: true } : undefined
+    }),
     toggleEnable: (id: number, enabled: boolean) => appClient.Patch(`api/feeds/${id}/enabled`, {
       body: { enabled }
     }),
@@ -420,6 +435,10 @@ export const APIClient = {
     test: (notification: ServiceNotification) => appClient.Post("api/notification/test", {
       body: notification
     }),
+    preview: (notification: ServiceNotification) => appClient.Post<NotificationPreview[]>("api/notification/test", {
+      queryString: { preview: true },
+      body: notification
+    }),
     getPushoverSounds: (apiToken: string) => {
       // Don't make request if token is redacted or empty
       if (!apiToken || apiToken === "<redacted>" || apiToken === "") {
@@ -505,6 +524,9 @@ export const APIClient = {
     },
     indexerOptions: () => appClient.Get<string[]>("api/release/indexers"),
     stats: () => appClient.Get<ReleaseStats>("api/release/stats"),
+    statsTimeseries: (params: ReleaseStatsTimeseriesParams) => appClient.Get<ReleaseStatsTimeseries>("api/release/stats/timeseries", {
+      queryString: { ...params }
+    }),
     delete: (params: DeleteParams) => {
       return appClient.Delete("api/release", {
         queryString: {
@@ -543,6 +565,12 @@ export const APIClient = {
       }
     }
   },
+  tracking: {
+    filters: () => appClient.Get<TorrentStatsSummary[]>("api/tracking/filters"),
+    indexers: () => appClient.Get<TorrentStatsSummary[]>("api/tracking/indexers"),
+    release: (releaseId: number) => appClient.Get<ReleaseTorrentStats[]>(`api/tracking/releases/${releaseId}`),
+    refresh: () => appClient.Post("api/tracking/refresh")
+  },
   updates: {
     check: () => appClient.Get("api/updates/check"),
     getLatestRelease: () => appClient.Get<GithubRelease>("api/updates/latest")
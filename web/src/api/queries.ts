@@ -13,7 +13,8 @@ import {
   IndexerKeys,
   IrcKeys, ListKeys, NotificationKeys, ProxyKeys,
   ReleaseKeys, ReleaseProfileDuplicateKeys,
-  SettingsKeys
+  SettingsKeys,
+  UserKeys
 } from "@api/query_keys";
 import { ColumnFilter } from "@tanstack/react-table";
 
@@ -136,6 +137,13 @@ export const ApikeysQueryOptions = () =>
     refetchOnWindowFocus: false,
   });
 
+export const UsersQueryOptions = () =>
+  queryOptions({
+    queryKey: UserKeys.lists(),
+    queryFn: () => APIClient.users.getAll(),
+    refetchOnWindowFocus: false,
+  });
+
 export const ReleasesListQueryOptions = (offset: number, limit: number, filters: ColumnFilter[]) =>
   queryOptions({
     queryKey: ReleaseKeys.list(offset, limit, filters),
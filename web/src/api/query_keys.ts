@@ -54,6 +54,11 @@ export const ApiKeys = {
   detail: (id: string) => [...ApiKeys.details(), id] as const
 };
 
+export const UserKeys = {
+  all: ["users"] as const,
+  lists: () => [...UserKeys.all, "list"] as const
+};
+
 export const DownloadClientKeys = {
   all: ["download_clients"] as const,
   lists: () => [...DownloadClientKeys.all, "list"] as const,
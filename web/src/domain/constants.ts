@@ -655,12 +655,15 @@ export const NotificationTypeOptions: OptionBasicTyped<NotificationType>[] = [
 
 export const getNotificationTypeOptions = (t: TFunction): OptionBasicTyped<NotificationType>[] => [
   { label: t("options:notificationType.DISCORD"), value: "DISCORD" },
+  { label: t("options:notificationType.EMAIL"), value: "EMAIL" },
   { label: t("options:notificationType.GOTIFY"), value: "GOTIFY" },
   { label: t("options:notificationType.LUNASEA"), value: "LUNASEA" },
+  { label: t("options:notificationType.MATRIX"), value: "MATRIX" },
   { label: t("options:notificationType.NOTIFIARR"), value: "NOTIFIARR" },
   { label: t("options:notificationType.NTFY"), value: "NTFY" },
   { label: t("options:notificationType.PUSHOVER"), value: "PUSHOVER" },
   { label: t("options:notificationType.SHOUTRRR"), value: "SHOUTRRR" },
+  { label: t("options:notificationType.SLACK"), value: "SLACK" },
   { label: t("options:notificationType.TELEGRAM"), value: "TELEGRAM" },
   { label: t("options:notificationType.WEBHOOK"), value: "WEBHOOK" }
 ];
@@ -775,6 +778,11 @@ export const EventOptions: SelectOption[] = [
     value: "IRC_RECONNECTED",
     description: "Reconnected to irc network after error"
   },
+  {
+    label: "Announce Parsing Failing",
+    value: "ANNOUNCE_PARSE_FAILING",
+    description: "Announces of an indexer stopped matching its definition"
+  },
   {
     label: "New update",
     value: "APP_UPDATE_AVAILABLE",
@@ -813,6 +821,11 @@ export const getEventOptions = (t: TFunction): SelectOption[] => [
     value: "IRC_RECONNECTED",
     description: t("options:event.IRC_RECONNECTED.description")
   },
+  {
+    label: t("options:event.ANNOUNCE_PARSE_FAILING.label"),
+    value: "ANNOUNCE_PARSE_FAILING",
+    description: t("options:event.ANNOUNCE_PARSE_FAILING.description")
+  },
   {
     label: t("options:event.APP_UPDATE_AVAILABLE.label"),
     value: "APP_UPDATE_AVAILABLE",
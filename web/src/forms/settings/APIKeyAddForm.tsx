@@ -18,6 +18,20 @@ import { toast } from "@components/hot-toast";
 import Toast from "@components/notifications/Toast";
 import { AddFormProps } from "@forms/_shared";
 
+const APIKeyScopes = [
+  "admin",
+  "filters:read",
+  "filters:write",
+  "releases:read",
+  "releases:write",
+  "releases:push",
+  "irc:read",
+  "irc:admin",
+  "config:read",
+  "config:write",
+  "lists:refresh"
+];
+
 export function APIKeyAddForm({ isOpen, toggle }: AddFormProps) {
   const { t } = useTranslation("settings");
   const queryClient = useQueryClient();
@@ -120,6 +134,31 @@ export function APIKeyAddForm({ isOpen, toggle }: AddFormProps) {
                               )}
                             </Field>
                           </div>
+
+                          <div
+                            className="space-y-1 px-4 sm:space-y-0 sm:grid sm:grid-cols-3 sm:gap-4 sm:py-4">
+                            <div>
+                              <span className="block text-sm font-medium text-gray-900 dark:text-white sm:mt-px sm:pt-2">
+                                {t("forms.apiKey.scopes")}
+                              </span>
+                            </div>
+                            <div className="sm:col-span-2">
+                              <div className="grid grid-cols-2 gap-2">
+                                {APIKeyScopes.map((scope) => (
+                                  <label key={scope} className="flex items-center space-x-2 text-sm text-gray-700 dark:text-gray-300">
+                                    <Field
+                                      type="checkbox"
+                                      name="scopes"
+                                      value={scope}
+                                      className="rounded-sm border-gray-300 dark:border-gray-700 text-blue-600 focus:ring-blue-500 dark:bg-gray-815"
+                                    />
+                                    <span>{scope}</span>
+                                  </label>
+                                ))}
+                              </div>
+                              <p className="mt-2 text-sm text-gray-500 dark:text-gray-400">{t("forms.apiKey.scopesHelp")}</p>
+                            </div>
+                          </div>
                         </div>
                       </div>
 
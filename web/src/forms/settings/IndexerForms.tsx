@@ -546,6 +546,28 @@ export function IndexerAddForm({ isOpen, toggle }: AddFormProps) {
       });
       return;
 
+    } else if (formData.implementation === "api") {
+      const createFeed: FeedCreate = {
+        name: formData.name,
+        enabled: false,
+        type: "API",
+        url: formData.base_url || ind.urls[0],
+        interval: ind.api?.minInterval || 30,
+        timeout: 60,
+        indexer_id: 0,
+        settings: formData.feed.settings
+      };
+
+      mutation.mutate(formData as Indexer, {
+        onSuccess: (indexer) => {
+          // @eslint-ignore
+          createFeed.indexer_id = indexer.id;
+
+          feedMutation.mutate(createFeed);
+        }
+      });
+      return;
+
     } else if (formData.implementation === "irc") {
       const channels: IrcChannel[] = [];
       if (ind.irc?.channels.length) {
@@ -734,7 +756,7 @@ export function IndexerAddForm({ isOpen, toggle }: AddFormProps) {
 
                           <SwitchGroupWide name="enabled" label={t("forms.indexer.enabled")} />
 
-                          {indexer.implementation == "irc" && (
+                          {(indexer.implementation == "irc" || indexer.implementation == "api") && (
                             <SelectFieldCreatable
                               name="base_url"
                               label={t("forms.indexer.baseUrl")}
@@ -384,7 +384,7 @@ export function IrcNetworkUpdateForm({
             required={true}
           />
 
-          <SwitchGroupWide name="use_bouncer" label={t("forms.irc.bouncer")}/>
+          <SwitchGroupWide name="use_bouncer" label={t("forms.irc.bouncer")} description={t("forms.irc.bouncerDesc")}/>
           {values.use_bouncer && (
             <TextFieldWide
               name="bouncer_addr"
@@ -23,7 +23,7 @@ import { ExternalLink } from "@components/ExternalLink";
 import { toast } from "@components/hot-toast";
 import Toast from "@components/notifications/Toast";
 import * as common from "@components/inputs/common";
-import { NumberFieldWide, PasswordFieldWide, SelectFieldWide, SwitchGroupWide, TextFieldWide } from "@components/inputs";
+import { NumberFieldWide, PasswordFieldWide, SelectFieldWide, SwitchGroupWide, TextAreaAutoResize, TextFieldWide } from "@components/inputs";
 import { Checkbox } from "@components/Checkbox";
 import { EmptySimple } from "@components/emptystates";
 
@@ -384,6 +384,198 @@ function FormFieldsGenericWebhook() {
   );
 }
 
+function FormFieldsMatrix() {
+  const { t } = useTranslation("settings");
+  return (
+    <div className="border-t border-gray-200 dark:border-gray-700 py-4">
+      <div className="px-4">
+        <DialogTitle className="text-lg font-medium text-gray-900 dark:text-white">
+          {t("forms.notification.settings")}
+        </DialogTitle>
+        <p className="text-sm text-gray-500 dark:text-gray-400">
+          {t("forms.notification.settingsDescMatrix")}
+        </p>
+      </div>
+
+      <TextFieldWide
+        name="host"
+        label={t("forms.notification.matrixHomeserver")}
+        help={t("forms.notification.matrixHomeserverHelp")}
+        placeholder={t("forms.notification.matrixHomeserverPlaceholder")}
+        required={true}
+      />
+      <PasswordFieldWide
+        name="token"
+        label={t("forms.notification.accessToken")}
+        help={t("forms.notification.matrixAccessTokenHelp")}
+        required={true}
+      />
+      <TextFieldWide
+        name="rooms"
+        label={t("forms.notification.matrixRooms")}
+        help={t("forms.notification.matrixRoomsHelp")}
+        placeholder={t("forms.notification.matrixRoomsPlaceholder")}
+        required={true}
+      />
+    </div>
+  );
+}
+
+function FormFieldsSlack() {
+  const { t } = useTranslation("settings");
+  return (
+    <div className="border-t border-gray-200 dark:border-gray-700 py-4">
+      <div className="px-4">
+        <DialogTitle className="text-lg font-medium text-gray-900 dark:text-white">
+          {t("forms.notification.settings")}
+        </DialogTitle>
+        <p className="text-sm text-gray-500 dark:text-gray-400">
+          {t("forms.notification.settingsDescSlackPrefix")}
+          <ExternalLink
+            href="https://api.slack.com/messaging/webhooks"
+            className="font-medium text-blue-500 underline underline-offset-1 hover:text-blue-400"
+          >
+            {t("forms.notification.slackIncomingWebhooks")}
+          </ExternalLink>
+          {t("forms.notification.settingsDescSlackSuffix")}
+        </p>
+      </div>
+
+      <PasswordFieldWide
+        name="webhook"
+        label={t("forms.notification.webhookUrl")}
+        help={t("forms.notification.slackWebhookHelp")}
+        placeholder={t("forms.notification.slackWebhookPlaceholder")}
+        required={true}
+      />
+    </div>
+  );
+}
+
+function FormFieldsEmail() {
+  const { t } = useTranslation("settings");
+  return (
+    <div className="border-t border-gray-200 dark:border-gray-700 py-4">
+      <div className="px-4">
+        <DialogTitle className="text-lg font-medium text-gray-900 dark:text-white">
+          {t("forms.notification.settings")}
+        </DialogTitle>
+        <p className="text-sm text-gray-500 dark:text-gray-400">
+          {t("forms.notification.settingsDescEmail")}
+        </p>
+      </div>
+
+      <TextFieldWide
+        name="host"
+        label={t("forms.notification.smtpServer")}
+        help={t("forms.notification.smtpServerHelp")}
+        placeholder={t("forms.notification.smtpServerPlaceholder")}
+        required={true}
+      />
+      <TextFieldWide
+        name="username"
+        label={t("forms.notification.username")}
+        help={t("forms.notification.smtpUsernameHelp")}
+      />
+      <PasswordFieldWide
+        name="password"
+        label={t("forms.notification.password")}
+        help={t("forms.notification.smtpPasswordHelp")}
+      />
+      <TextFieldWide
+        name="from_address"
+        label={t("forms.notification.fromAddress")}
+        help={t("forms.notification.fromAddressHelp")}
+        placeholder={t("forms.notification.fromAddressPlaceholder")}
+        required={true}
+      />
+      <TextFieldWide
+        name="targets"
+        label={t("forms.notification.recipients")}
+        help={t("forms.notification.recipientsHelp")}
+        placeholder={t("forms.notification.recipientsPlaceholder")}
+        required={true}
+      />
+    </div>
+  );
+}
+
+// template examples are not translated, they are go template syntax
+const titleTemplatePlaceholder = "{{ .Title }}: {{ .ReleaseName }}";
+const bodyTemplatePlaceholder = "{\"text\": {{ printf \"%s (%s)\" .ReleaseName .Macro.SizeString | toJson }}}";
+
+function FormFieldsTemplates() {
+  const { t } = useTranslation("settings");
+  const { values } = useFormikContext<ServiceNotification>();
+
+  const previewMutation = useMutation({
+    mutationFn: (n: ServiceNotification) => APIClient.notifications.preview(n),
+    onError: (err) => {
+      toast.custom((toastInstance) => <Toast type="error" body={t("forms.notification.previewFailed", { error: err.message })} t={toastInstance} />);
+    }
+  });
+
+  // notifiarr messages are structured and can not be templated
+  if (values.type === "NOTIFIARR") {
+    return null;
+  }
+
+  return (
+    <div className="border-t border-gray-200 dark:border-gray-700 py-4">
+      <div className="px-4">
+        <DialogTitle className="text-lg font-medium text-gray-900 dark:text-white">
+          {t("forms.notification.templates")}
+        </DialogTitle>
+        <p className="text-sm text-gray-500 dark:text-gray-400">
+          {t("forms.notification.templatesDesc")}
+        </p>
+      </div>
+
+      <TextFieldWide
+        name="title_template"
+        label={t("forms.notification.titleTemplate")}
+        placeholder={titleTemplatePlaceholder}
+      />
+      <div className="space-y-1 p-4 sm:space-y-0 sm:grid sm:grid-cols-3 sm:gap-4">
+        <label htmlFor="body_template" className="flex ml-px text-sm font-medium text-gray-900 dark:text-white sm:mt-px sm:pt-2">
+          {t("forms.notification.bodyTemplate")}
+        </label>
+        <div className="sm:col-span-2">
+          <TextAreaAutoResize name="body_template" placeholder={bodyTemplatePlaceholder} rows={4} />
+          {values.type === "WEBHOOK" && (
+            <p className="mt-2 text-sm text-gray-500">{t("forms.notification.bodyTemplateWebhookHelp")}</p>
+          )}
+        </div>
+      </div>
+
+      <div className="px-4 flex justify-end">
+        <button
+          type="button"
+          className="bg-white dark:bg-gray-700 py-2 px-4 border border-gray-300 dark:border-gray-600 rounded-md shadow-xs text-sm font-medium text-gray-700 dark:text-gray-200 hover:bg-gray-50 dark:hover:bg-gray-600 focus:outline-hidden focus:ring-2 focus:ring-offset-2 focus:ring-blue-500 dark:focus:ring-blue-500"
+          onClick={() => previewMutation.mutate(values)}
+        >
+          {t("forms.notification.preview")}
+        </button>
+      </div>
+
+      {previewMutation.data && (
+        <div className="px-4 pt-4 space-y-3">
+          {previewMutation.data.length === 0 && (
+            <p className="text-sm text-gray-500 dark:text-gray-400">{t("forms.notification.previewEmpty")}</p>
+          )}
+          {previewMutation.data.map((preview) => (
+            <div key={preview.event} className="rounded-md border border-gray-200 dark:border-gray-700 p-3">
+              <p className="text-xs font-medium text-gray-500 dark:text-gray-400">{preview.event}</p>
+              <p className="text-sm font-medium text-gray-900 dark:text-white">{preview.title}</p>
+              <pre className="mt-1 text-sm text-gray-700 dark:text-gray-300 break-all whitespace-pre-wrap">{preview.body}</pre>
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  );
+}
+
 const componentMap: componentMapType = {
   DISCORD: <FormFieldsDiscord />,
   NOTIFIARR: <FormFieldsNotifiarr />,
@@ -393,7 +585,10 @@ const componentMap: componentMapType = {
   NTFY: <FormFieldsNtfy />,
   SHOUTRRR: <FormFieldsShoutrrr />,
   LUNASEA: <FormFieldsLunaSea />,
-  WEBHOOK: <FormFieldsGenericWebhook />
+  WEBHOOK: <FormFieldsGenericWebhook />,
+  MATRIX: <FormFieldsMatrix />,
+  SLACK: <FormFieldsSlack />,
+  EMAIL: <FormFieldsEmail />
 };
 
 interface NotificationAddFormValues {
@@ -469,7 +664,10 @@ export function NotificationAddForm({ isOpen, toggle }: AddFormProps) {
                     events: [],
                     username: "",
                     sound: "",
-                    event_sounds: {}
+                    event_sounds: {},
+                    rate_limit: 0,
+                    digest_interval: 0,
+                    digest_events: []
                   }}
                   onSubmit={onSubmit}
                   validate={validate}
@@ -585,6 +783,8 @@ export function NotificationAddForm({ isOpen, toggle }: AddFormProps) {
                           </div>
                         </div>
                         {componentMap[values.type]}
+                        {values.type && <FormFieldsTemplates />}
+                        {values.type && <FormFieldsLimits />}
                       </div>
 
                       <div className="shrink-0 px-4 border-t border-gray-200 dark:border-gray-700 py-4 sm:px-6">
@@ -625,6 +825,69 @@ export function NotificationAddForm({ isOpen, toggle }: AddFormProps) {
   );
 }
 
+// events with a release can be collected into digests
+const digestEvents = ["PUSH_REJECTED", "PUSH_APPROVED", "PUSH_ERROR", "RELEASE_NEW"];
+
+function FormFieldsLimits() {
+  const { t } = useTranslation(["options", "settings"]);
+  const eventOptions = getEventOptions(t).filter((event) => digestEvents.includes(event.value));
+
+  return (
+    <div className="border-t border-gray-200 dark:border-gray-700 py-4">
+      <div className="px-4">
+        <DialogTitle className="text-lg font-medium text-gray-900 dark:text-white">
+          {t("settings:forms.notification.limits")}
+        </DialogTitle>
+        <p className="text-sm text-gray-500 dark:text-gray-400">
+          {t("settings:forms.notification.limitsDesc")}
+        </p>
+      </div>
+
+      <NumberFieldWide
+        name="rate_limit"
+        label={t("settings:forms.notification.rateLimit")}
+        help={t("settings:forms.notification.rateLimitHelp")}
+      />
+      <NumberFieldWide
+        name="digest_interval"
+        label={t("settings:forms.notification.digestInterval")}
+        help={t("settings:forms.notification.digestIntervalHelp")}
+      />
+
+      <div className="px-4 space-y-1 sm:space-y-0 sm:grid sm:grid-cols-3 sm:gap-4 sm:py-4">
+        <span className="block ml-px text-sm font-medium text-gray-900 dark:text-white sm:mt-px sm:pt-2">
+          {t("settings:forms.notification.digestEvents")}
+        </span>
+        <fieldset className="sm:col-span-2 space-y-3">
+          <legend className="sr-only">{t("settings:forms.notification.digestEvents")}</legend>
+          {eventOptions.map((event) => (
+            <Field name="digest_events" key={event.value}>
+              {({ field, form }: FieldProps<string[] | undefined>) => {
+                const selected = field.value ?? [];
+                return (
+                  <div className="flex items-center justify-between">
+                    <span className="text-sm font-medium text-gray-900 dark:text-gray-100">{event.label}</span>
+                    <Checkbox
+                      value={selected.includes(event.value)}
+                      setValue={(checked) =>
+                        form.setFieldValue("digest_events",
+                          checked
+                            ? [...selected, event.value]
+                            : selected.filter(e => e !== event.value)
+                        )
+                      }
+                    />
+                  </div>
+                );
+              }}
+            </Field>
+          ))}
+        </fieldset>
+      </div>
+    </div>
+  );
+}
+
 const EventCheckBox = ({ event }: { event: NotificationEventOption; }) => (
   <Field name="events">
     {({ field, form }: FieldProps<string[]>) => (
@@ -793,6 +1056,14 @@ interface InitialValues {
   events: NotificationEvent[];
   username?: string;
   password?: string;
+  rooms?: string;
+  targets?: string;
+  from_address?: string;
+  title_template?: string;
+  body_template?: string;
+  rate_limit?: number;
+  digest_interval?: number;
+  digest_events?: string[];
   used_by_filters?: NotificationFilter[];
 }
 
@@ -856,6 +1127,14 @@ export function NotificationUpdateForm({ isOpen, toggle, data: notification }: U
     events: notification.events || [],
     username: notification.username,
     password: notification.password,
+    rooms: notification.rooms,
+    targets: notification.targets,
+    from_address: notification.from_address,
+    title_template: notification.title_template,
+    body_template: notification.body_template,
+    rate_limit: notification.rate_limit ?? 0,
+    digest_interval: notification.digest_interval ?? 0,
+    digest_events: notification.digest_events || [],
     used_by_filters: notification.used_by_filters || [],
   };
 
@@ -978,6 +1257,8 @@ export function NotificationUpdateForm({ isOpen, toggle, data: notification }: U
           </div>
 
           {componentMap[values.type]}
+          {values.type && <FormFieldsTemplates />}
+          {values.type && <FormFieldsLimits />}
 
         </div>
       )}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+ * SPDX-License-Identifier: GPL-2.0-or-later
+ */
+
+import { useMutation, useQueryClient } from "@tanstack/react-query";
+import { useTranslation } from "react-i18next";
+
+import { APIClient } from "@api/APIClient";
+import { UserKeys } from "@api/query_keys";
+import { toast } from "@components/hot-toast";
+import Toast from "@components/notifications/Toast";
+import { SlideOver } from "@components/panels";
+import { PasswordFieldWide, SelectFieldWide, TextFieldWide } from "@components/inputs";
+import { AddFormProps } from "@forms/_shared";
+
+export const userRoles: UserRole[] = ["admin", "editor", "viewer"];
+
+export function UserAddForm({ isOpen, toggle }: AddFormProps) {
+  const { t } = useTranslation("settings");
+  const queryClient = useQueryClient();
+
+  const mutation = useMutation({
+    mutationFn: (user: UserCreate) => APIClient.users.create(user),
+    onSuccess: (_, user) => {
+      queryClient.invalidateQueries({ queryKey: UserKeys.lists() });
+
+      toast.custom((toastInstance) => <Toast type="success" body={t("forms.user.created", { name: user.username })} t={toastInstance} />);
+      toggle();
+    },
+    onError: (error) => {
+      toast.custom((toastInstance) => <Toast type="error" body={error.message} t={toastInstance} />);
+    }
+  });
+
+  const onSubmit = (data: unknown) => mutation.mutate(data as UserCreate);
+
+  const initialValues: UserCreate = {
+    username: "",
+    password: "",
+    role: "viewer"
+  };
+
+  const roleOptions = userRoles.map((role) => ({
+    label: t(`forms.user.roles.${role}`),
+    value: role
+  }));
+
+  return (
+    <SlideOver
+      type="CREATE"
+      title={t("forms.user.title")}
+      isOpen={isOpen}
+      toggle={toggle}
+      onSubmit={onSubmit}
+      initialValues={initialValues}
+      validate={(values) => {
+        const errors: Record<string, string> = {};
+        if (!values.username) {
+          errors.username = t("forms.user.required");
+        }
+        if (!values.password) {
+          errors.password = t("forms.user.required");
+        }
+        return errors;
+      }}
+    >
+      {() => (
+        <div className="flex flex-col space-y-4 px-1 py-6 sm:py-0 sm:space-y-0">
+          <TextFieldWide
+            name="username"
+            label={t("forms.user.username")}
+            required={true}
+          />
+          <PasswordFieldWide
+            name="password"
+            label={t("forms.user.password")}
+            required={true}
+          />
+          <SelectFieldWide
+            name="role"
+            label={t("forms.user.role")}
+            optionDefaultText={t("forms.user.role")}
+            options={roleOptions}
+            tooltip={<p>{t("forms.user.roleHelp")}</p>}
+          />
+        </div>
+      )}
+    </SlideOver>
+  );
+}
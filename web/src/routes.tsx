@@ -31,7 +31,8 @@ import {
   IndexersQueryOptions,
   IrcQueryOptions, ListsQueryOptions,
   NotificationsQueryOptions,
-  ProxiesQueryOptions
+  ProxiesQueryOptions,
+  UsersQueryOptions
 } from "@api/queries";
 import LogSettings from "@screens/settings/Logs";
 import NotificationSettings from "@screens/settings/Notifications";
@@ -47,6 +48,7 @@ import DownloadClientSettings from "@screens/settings/DownloadClient";
 import FeedSettings from "@screens/settings/Feed";
 import { Dashboard } from "@screens/Dashboard";
 import AccountSettings from "@screens/settings/Account";
+import UserSettings from "@screens/settings/Users";
 import { AuthContext, SettingsContext } from "@utils/Context";
 import { TanStackRouterDevtools } from "@tanstack/react-router-devtools";
 import { ReactQueryDevtools } from "@tanstack/react-query-devtools";
@@ -226,6 +228,13 @@ export const SettingsApiRoute = createRoute({
   component: APISettings
 });
 
+export const SettingsUsersRoute = createRoute({
+  getParentRoute: () => SettingsRoute,
+  path: 'users',
+  loader: (opts) => opts.context.queryClient.ensureQueryData(UsersQueryOptions()),
+  component: UserSettings
+});
+
 export const SettingsProxiesRoute = createRoute({
   getParentRoute: () => SettingsRoute,
   path: 'proxies',
@@ -319,6 +328,7 @@ export const AuthRoute = createRoute({
           isLoggedIn: true,
           username: response.username || 'unknown',
           authMethod: response.auth_method,
+          role: response.role,
           profilePicture: response.profile_picture,
           issuerUrl: issuerUrl
         });
@@ -385,7 +395,7 @@ export const RootRoute = createRootRouteWithContext<{
 });
 
 const filterRouteTree = FiltersRoute.addChildren([FilterIndexRoute, FilterGetByIdRoute.addChildren([FilterGeneralRoute, FilterMoviesTvRoute, FilterMusicRoute, FilterAdvancedRoute, FilterExternalRoute, FilterActionsRoute, FilterNotificationsRoute])])
-const settingsRouteTree = SettingsRoute.addChildren([SettingsIndexRoute, SettingsLogRoute, SettingsIndexersRoute, SettingsIrcRoute, SettingsListsRoute, SettingsFeedsRoute, SettingsClientsRoute, SettingsNotificationsRoute, SettingsApiRoute, SettingsProxiesRoute, SettingsReleasesRoute, SettingsAccountRoute])
+const settingsRouteTree = SettingsRoute.addChildren([SettingsIndexRoute, SettingsLogRoute, SettingsIndexersRoute, SettingsIrcRoute, SettingsListsRoute, SettingsFeedsRoute, SettingsClientsRoute, SettingsNotificationsRoute, SettingsApiRoute, SettingsUsersRoute, SettingsProxiesRoute, SettingsReleasesRoute, SettingsAccountRoute])
 const authenticatedTree = AuthRoute.addChildren([AuthIndexRoute.addChildren([DashboardRoute, filterRouteTree, ReleasesRoute, settingsRouteTree, LogsRoute])])
 const routeTree = RootRoute.addChildren([
   authenticatedTree,
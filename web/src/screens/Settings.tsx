@@ -14,18 +14,21 @@ import {
   RectangleStackIcon,
   RssIcon,
   Square3Stack3DIcon,
-  UserCircleIcon
+  UserCircleIcon,
+  UsersIcon
 } from "@heroicons/react/24/outline";
 import { Link, Outlet } from "@tanstack/react-router";
 import { useTranslation } from "react-i18next";
 
 import { classNames } from "@utils";
+import { AuthContext } from "@utils/Context";
 
 interface NavTabType {
   labelKey: string;
   href: string;
   icon: typeof CogIcon;
   exact?: boolean;
+  adminOnly?: boolean;
 }
 
 const subNavigation: NavTabType[] = [
@@ -37,7 +40,8 @@ const subNavigation: NavTabType[] = [
   { labelKey: "nav.lists", href: "/settings/lists", icon: BarsArrowDownIcon },
   { labelKey: "nav.clients", href: "/settings/clients", icon: FolderArrowDownIcon },
   { labelKey: "nav.notifications", href: "/settings/notifications", icon: BellIcon },
-  { labelKey: "nav.apiKeys", href: "/settings/api", icon: KeyIcon },
+  { labelKey: "nav.apiKeys", href: "/settings/api", icon: KeyIcon, adminOnly: true },
+  { labelKey: "nav.users", href: "/settings/users", icon: UsersIcon, adminOnly: true },
   { labelKey: "nav.proxies", href: "/settings/proxies", icon: GlobeAltIcon },
   { labelKey: "nav.releases", href: "/settings/releases", icon: RectangleStackIcon },
   { labelKey: "nav.account", href: "/settings/account", icon: UserCircleIcon }
@@ -91,10 +95,15 @@ interface SidebarNavProps {
 }
 
 function SidebarNav({ subNavigation }: SidebarNavProps) {
+  const role = AuthContext.useSelector((s) => s.role);
+
+  // sessions from before roles have no role and are admins
+  const isAdmin = !role || role === "admin";
+
   return (
     <aside className="py-2 lg:col-span-3 border-b lg:border-b-0 lg:border-r border-gray-150 dark:border-gray-725">
       <nav className="space-y-1">
-        {subNavigation.map((item) => (
+        {subNavigation.filter((item) => isAdmin || !item.adminOnly).map((item) => (
           <SubNavLink key={item.href} item={item} />
         ))}
       </nav>
@@ -97,6 +97,7 @@ export const Login = () => {
                     isLoggedIn: true,
                     username: response.username || 'unknown',
                     authMethod: response.auth_method || (oidcConfig?.enabled ? 'oidc' : 'password'),
+                    role: response.role,
                     profilePicture: response.profile_picture,
                 });
                 router.invalidate();
@@ -111,12 +112,15 @@ export const Login = () => {
 
     const loginMutation = useMutation({
         mutationFn: (data: LoginFormFields) => APIClient.auth.login(data.username, data.password, data.remember_me),
-        onSuccess: (_, variables: LoginFormFields) => {
+        onSuccess: async (_, variables: LoginFormFields) => {
             queryErrorResetBoundary.reset()
+            // the role decides which settings are shown
+            const response = await APIClient.auth.validate().catch(() => undefined);
             setAuth({
                 isLoggedIn: true,
                 username: variables.username,
-                authMethod: 'password'
+                authMethod: 'password',
+                role: response?.role
             });
             router.invalidate()
         },
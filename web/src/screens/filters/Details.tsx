@@ -434,6 +434,7 @@ export const FilterDetails = () => {
               seasons: filter.seasons,
               episodes: filter.episodes,
               smart_episode: filter.smart_episode,
+              backfill: filter.backfill,
               match_releases: filter.match_releases,
               except_releases: filter.except_releases,
               match_release_groups: filter.match_release_groups,
@@ -474,6 +475,7 @@ export const FilterDetails = () => {
               max_seeders: filter.max_seeders,
               min_leechers: filter.min_leechers,
               max_leechers: filter.max_leechers,
+              expression: filter.expression,
               indexers: filter.indexers || [],
               actions: filter.actions || [],
               external: filter.external || [],
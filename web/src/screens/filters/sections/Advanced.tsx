@@ -585,6 +585,32 @@ const RawReleaseTags = () => {
   );
 }
 
+const Expression = () => {
+  const { t } = useTranslation("filters");
+  const { values } = useFormikContext<Filter>();
+
+  return (
+    <CollapsibleSection
+      defaultOpen={values.expression !== undefined && values.expression !== ""}
+      title={t("advanced.expression.title")}
+      subtitle={t("advanced.expression.subtitle")}
+    >
+      <TextAreaAutoResize
+        name="expression"
+        label={t("advanced.expression.label")}
+        columns={12}
+        placeholder={t("advanced.expression.placeholder")}
+        tooltip={
+          <div>
+            <p>{t("advanced.expression.tooltip")}</p>
+            <DocsLink href="https://autobrr.com/filters/macros" />
+          </div>
+        }
+      />
+    </CollapsibleSection>
+  );
+}
+
 export const Advanced = () => {
   return (
     <div className="flex flex-col w-full gap-y-4 py-2 sm:-mx-1">
@@ -598,6 +624,7 @@ export const Advanced = () => {
       <Origins />
       <FeedSpecific />
       <RawReleaseTags />
+      <Expression />
     </div>
   );
 }
@@ -94,6 +94,13 @@ const SeasonsAndEpisodes = () => {
           description={t("moviesTv.seasonEpisode.smartEpisodeDescription")}
         />
       </div>
+      <div className="col-span-12 sm:col-span-6">
+        <SwitchGroup
+          name="backfill"
+          label={t("moviesTv.seasonEpisode.backfill")}
+          description={t("moviesTv.seasonEpisode.backfillDescription")}
+        />
+      </div>
     </FilterLayout>
   </FilterSection>
   );
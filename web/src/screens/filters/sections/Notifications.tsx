@@ -36,7 +36,10 @@ const NOTIFICATION_TYPE_MAP: Record<string, string> = {
   "GOTIFY": "Gotify",
   "NTFY": "Ntfy",
   "SHOUTRRR": "Shoutrrr",
-  "WEBHOOK": "Webhook"
+  "WEBHOOK": "Webhook",
+  "MATRIX": "Matrix",
+  "SLACK": "Slack",
+  "EMAIL": "Email"
 };
 
 export function Notifications() {
@@ -18,12 +18,15 @@ import toast from "@components/hot-toast";
 import Toast from "@components/notifications/Toast";
 import {
   DiscordIcon,
+  EmailIcon,
   GotifyIcon,
   LunaSeaIcon,
+  MatrixIcon,
   NotifiarrIcon,
   NtfyIcon,
   PushoverIcon,
   Section,
+  SlackIcon,
   TelegramIcon,
   WebhookIcon
 } from "./_components";
@@ -103,7 +106,10 @@ const iconComponentMap: componentMapType = {
   NTFY: <span className={iconStyle}><NtfyIcon /> ntfy</span>,
   SHOUTRRR: <span className={iconStyle}><NtfyIcon /> Shoutrrr</span>,
   LUNASEA: <span className={iconStyle}><LunaSeaIcon /> LunaSea</span>,
-  WEBHOOK: <span className={iconStyle}><WebhookIcon /> Webhook</span>
+  WEBHOOK: <span className={iconStyle}><WebhookIcon /> Webhook</span>,
+  MATRIX: <span className={iconStyle}><MatrixIcon /> Matrix</span>,
+  SLACK: <span className={iconStyle}><SlackIcon /> Slack</span>,
+  EMAIL: <span className={iconStyle}><EmailIcon /> Email</span>
 };
 
 interface ListItemProps {
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2021 - 2025, Ludvig Lundgren and the autobrr contributors.
+ * SPDX-License-Identifier: GPL-2.0-or-later
+ */
+
+import { useRef } from "react";
+import { useMutation, useQueryClient, useSuspenseQuery } from "@tanstack/react-query";
+import { TrashIcon } from "@heroicons/react/24/outline";
+import { PlusIcon } from "@heroicons/react/24/solid";
+import { useTranslation } from "react-i18next";
+
+import { APIClient } from "@api/APIClient";
+import { UsersQueryOptions } from "@api/queries";
+import { UserKeys } from "@api/query_keys";
+import { DeleteModal } from "@components/modals";
+import { EmptySimple } from "@components/emptystates";
+import { toast } from "@components/hot-toast";
+import Toast from "@components/notifications/Toast";
+import { UserAddForm, userRoles } from "@forms/settings/UserForms";
+import { useToggle } from "@hooks/hooks";
+import { classNames } from "@utils";
+import { AuthContext } from "@utils/Context";
+import { Section } from "./_components";
+
+function UserSettings() {
+  const { t } = useTranslation("settings");
+  const [addFormIsOpen, toggleAddForm] = useToggle(false);
+
+  const usersQuery = useSuspenseQuery(UsersQueryOptions());
+
+  return (
+    <Section
+      title={t("forms.user.listTitle")}
+      description={t("forms.user.listDescription")}
+      rightSide={
+        <button
+          type="button"
+          className="relative inline-flex items-center px-4 py-2 border border-transparent shadow-xs text-sm font-medium rounded-md text-white bg-blue-600 dark:bg-blue-600 hover:bg-blue-700 dark:hover:bg-blue-700 focus:outline-hidden focus:ring-2 focus:ring-offset-2 focus:ring-blue-500"
+          onClick={toggleAddForm}
+        >
+          <PlusIcon className="h-5 w-5 mr-1" />
+          {t("forms.user.addNew")}
+        </button>
+      }
+    >
+      <UserAddForm isOpen={addFormIsOpen} toggle={toggleAddForm} />
+
+      {usersQuery.data && usersQuery.data.length > 0 ? (
+        <ul className="min-w-full relative">
+          <li className="hidden sm:grid grid-cols-12 gap-4 mb-2 border-b border-gray-200 dark:border-gray-700">
+            <div className="col-span-5 px-6 py-3 text-left text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              {t("forms.user.username")}
+            </div>
+            <div className="col-span-6 py-3 text-left text-xs font-medium text-gray-500 dark:text-gray-400 uppercase tracking-wider">
+              {t("forms.user.role")}
+            </div>
+          </li>
+
+          {usersQuery.data.map((user) => <UserListItem key={user.id} user={user} />)}
+        </ul>
+      ) : (
+        <EmptySimple
+          title={t("forms.user.noItems")}
+          subtitle=""
+          buttonAction={toggleAddForm}
+          buttonText={t("forms.user.addNew")}
+        />
+      )}
+    </Section>
+  );
+}
+
+interface UserListItemProps {
+  user: User;
+}
+
+function UserListItem({ user }: UserListItemProps) {
+  const { t } = useTranslation("settings");
+  const cancelModalButtonRef = useRef(null);
+  const [deleteModalIsOpen, toggleDeleteModal] = useToggle(false);
+  const currentUsername = AuthContext.useSelector((s) => s.username);
+
+  const queryClient = useQueryClient();
+
+  const onError = (error: Error) => {
+    toast.custom((tst) => <Toast type="error" body={error.message} t={tst} />);
+  };
+
+  const roleMutation = useMutation({
+    mutationFn: (role: UserRole) => APIClient.users.update(user.username, { role }),
+    onSuccess: (_, role) => {
+      queryClient.invalidateQueries({ queryKey: UserKeys.lists() });
+
+      toast.custom((tst) => (
+        <Toast type="success" body={t("forms.user.roleUpdated", { name: user.username, role: t(`forms.user.roles.${role}`) })} t={tst} />
+      ));
+    },
+    onError
+  });
+
+  const deleteMutation = useMutation({
+    mutationFn: (username: string) => APIClient.users.delete(username),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: UserKeys.lists() });
+
+      toast.custom((tst) => <Toast type="success" body={t("forms.user.deleted", { name: user.username })} t={tst} />);
+    },
+    onError
+  });
+
+  return (
+    <li className="text-gray-500 dark:text-gray-400">
+      <DeleteModal
+        isOpen={deleteModalIsOpen}
+        isLoading={deleteMutation.isPending}
+        toggle={toggleDeleteModal}
+        buttonRef={cancelModalButtonRef}
+        deleteAction={() => {
+          deleteMutation.mutate(user.username);
+          toggleDeleteModal();
+        }}
+        title={t("forms.user.removeTitle", { name: user.username })}
+        text={t("forms.user.removeText")}
+      />
+
+      <div className="sm:grid grid-cols-12 gap-4 items-center py-2">
+        <div className="col-span-5 px-2 sm:px-6 py-2 sm:py-0 truncate block sm:text-sm text-md font-medium text-gray-900 dark:text-white">
+          {user.username}
+          {user.username === currentUsername && (
+            <span className="ml-2 text-xs text-gray-500 dark:text-gray-400">{t("forms.user.you")}</span>
+          )}
+        </div>
+        <div className="col-span-6 px-2 sm:px-0">
+          <select
+            value={user.role}
+            disabled={roleMutation.isPending}
+            onChange={(e) => roleMutation.mutate(e.target.value as UserRole)}
+            className="block w-full sm:w-48 shadow-xs sm:text-sm focus:ring-blue-500 dark:focus:ring-blue-500 focus:border-blue-500 dark:focus:border-blue-500 rounded-md border-gray-300 dark:border-gray-700 bg-gray-100 dark:bg-gray-815 dark:text-gray-100"
+          >
+            {userRoles.map((role) => (
+              <option key={role} value={role}>{t(`forms.user.roles.${role}`)}</option>
+            ))}
+          </select>
+        </div>
+        <div className="col-span-1 flex items-center px-2 sm:px-0">
+          <button
+            className={classNames(
+              "text-gray-900 dark:text-gray-300",
+              "font-medium group flex rounded-md items-center px-2 py-2 text-sm"
+            )}
+            onClick={toggleDeleteModal}
+            title={t("forms.user.deleteUser")}
+          >
+            <TrashIcon className="text-red-500 w-5 h-5" aria-hidden="true" />
+          </button>
+        </div>
+      </div>
+    </li>
+  );
+}
+
+export default UserSettings;
@@ -160,4 +160,50 @@ export const WebhookIcon = () => (
   </svg>
 )
 
+export const MatrixIcon = () => (
+  <svg
+    {...commonSVGProps}
+    viewBox="0 0 24 24"
+    fill="none"
+    stroke="currentColor"
+    strokeWidth="2"
+    strokeLinecap="round"
+    strokeLinejoin="round"
+  >
+    <path d="M6 3H3v18h3" />
+    <path d="M18 3h3v18h-3" />
+    <path d="M8 16v-6m0 1.5a2 2 0 0 1 4 0V16m0-4.5a2 2 0 0 1 4 0V16" />
+  </svg>
+);
+
+export const SlackIcon = () => (
+  <svg
+    {...commonSVGProps}
+    viewBox="0 0 24 24"
+    fill="none"
+    stroke="currentColor"
+    strokeWidth="2"
+    strokeLinecap="round"
+    strokeLinejoin="round"
+  >
+    <path d="M9 3 7 21" />
+    <path d="M17 3l-2 18" />
+    <path d="M4 9h17" />
+    <path d="M3 15h17" />
+  </svg>
+);
 
+export const EmailIcon = () => (
+  <svg
+    {...commonSVGProps}
+    viewBox="0 0 24 24"
+    fill="none"
+    stroke="currentColor"
+    strokeWidth="2"
+    strokeLinecap="round"
+    strokeLinejoin="round"
+  >
+    <rect x="2" y="4" width="20" height="16" rx="2" />
+    <path d="m22 7-10 6L2 7" />
+  </svg>
+);
@@ -16,3 +16,4 @@ export { default as Proxy } from "./Proxy";
 export { default as Release } from "./Releases";
 export { default as RegexPlayground } from "./RegexPlayground";
 export { default as Account } from "./Account";
+export { default as Users } from "./Users";
@@ -10,6 +10,26 @@ interface APIKey {
   created_at: Date;
 }
 
+type UserRole = "admin" | "editor" | "viewer";
+
+interface User {
+  id: number;
+  username: string;
+  role: UserRole;
+  created_at: Date;
+}
+
+interface UserCreate {
+  username: string;
+  password: string;
+  role: UserRole;
+}
+
+interface UserEdit {
+  role?: UserRole;
+  password?: string;
+}
+
 interface UserUpdate {
   username_current: string;
   username_new?: string;
@@ -33,7 +33,7 @@ interface FeedSettings {
 
 type FeedDownloadType = "MAGNET" | "TORRENT" | "NZB";
 
-type FeedType = "TORZNAB" | "NEWZNAB" | "RSS";
+type FeedType = "TORZNAB" | "NEWZNAB" | "RSS" | "API";
 
 interface FeedCreate {
   name: string;
@@ -66,6 +66,25 @@ interface FeedCaps {
   categories: FeedCapsCategory[];
 }
 
+interface FeedBackfillRequest {
+  filter_id?: number;
+  list_id?: number;
+  feed_ids?: number[];
+}
+
+interface FeedBackfillFeed {
+  id: number;
+  name: string;
+  indexer: IndexerMinimal;
+}
+
+interface FeedBackfillPlan {
+  filter_id: number;
+  filter_name: string;
+  queries: string[];
+  feeds: FeedBackfillFeed[];
+}
+
 interface FeedCapsRequest {
   type: FeedType;
   url: string;
@@ -36,6 +36,7 @@ interface Filter {
   seasons: string;
   episodes: string;
   smart_episode: boolean;
+  backfill: boolean;
   resolutions: string[];
   codecs: string[];
   sources: string[];
@@ -76,6 +77,7 @@ interface Filter {
   max_seeders: number;
   min_leechers: number;
   max_leechers: number;
+  expression?: string;
   is_auto_updated: boolean;
   actions_count: number;
   actions_enabled_count: number;
@@ -122,6 +124,7 @@ interface Action {
   external_download_client_id?: number;
   external_download_client?: string;
   client_id?: number;
+  failover_client_ids?: number[];
   filter_id?: number;
 }
 
@@ -44,6 +44,7 @@ interface IndexerDefinition {
   torznab: IndexerTorznab;
   newznab?: IndexerTorznab;
   rss: IndexerFeed;
+  api?: IndexerAPI;
   parse: IndexerParse;
 }
 
@@ -80,6 +81,10 @@ interface IndexerFeed {
   settings: IndexerSetting[];
 }
 
+interface IndexerAPI {
+  minInterval: number;
+}
+
 interface IndexerParse {
   type: string;
   lines: IndexerParseLines[];
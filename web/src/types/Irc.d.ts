@@ -61,6 +61,18 @@ interface IrcNetworkWithHealth extends IrcNetwork {
   channels: IrcChannelWithHealth[];
   connection_errors: string[];
   healthy: boolean;
+  parsers: IrcAnnounceParserHealth[];
+}
+
+interface IrcAnnounceParserHealth {
+  indexer: string;
+  indexer_name: string;
+  successes: number;
+  failures: number;
+  consecutive_failures: number;
+  last_success: string;
+  last_failure: string;
+  failing: boolean;
 }
 
 type IrcAuthMechanism = "NONE" | "SASL_PLAIN" | "NICKSERV";
@@ -3,13 +3,14 @@
  * SPDX-License-Identifier: GPL-2.0-or-later
  */
 
-type NotificationType = "DISCORD" | "NOTIFIARR" | "TELEGRAM" | "PUSHOVER" | "GOTIFY" | "NTFY" | "LUNASEA" | "SHOUTRRR" | "WEBHOOK";
+type NotificationType = "DISCORD" | "NOTIFIARR" | "TELEGRAM" | "PUSHOVER" | "GOTIFY" | "NTFY" | "LUNASEA" | "SHOUTRRR" | "WEBHOOK" | "MATRIX" | "SLACK" | "EMAIL";
 type NotificationEvent =
   "PUSH_APPROVED"
   | "PUSH_REJECTED"
   | "PUSH_ERROR"
   | "IRC_DISCONNECTED"
   | "IRC_RECONNECTED"
+  | "ANNOUNCE_PARSE_FAILING"
   | "APP_UPDATE_AVAILABLE"
   | "RELEASE_NEW";
 
@@ -32,9 +33,23 @@ interface ServiceNotification {
   password?: string;
   method?: string;
   headers?: string;
+  rooms?: string;
+  targets?: string;
+  from_address?: string;
+  title_template?: string;
+  body_template?: string;
+  rate_limit?: number;
+  digest_interval?: number;
+  digest_events?: string[];
   used_by_filters?: NotificationFilter[];
 }
 
+interface NotificationPreview {
+  event: NotificationEvent;
+  title: string;
+  body: string;
+}
+
 interface NotificationFilter {
   filter_name: string;
   filter_id: number;
@@ -65,6 +65,67 @@ interface ReleaseStats {
   push_error_count: number;
 }
 
+type ReleaseStatsBucket = "hour" | "day" | "week";
+
+type ReleaseStatsGroupBy = "" | "indexer" | "filter" | "action" | "client";
+
+interface ReleaseStatsTimeseriesParams {
+  from?: string;
+  to?: string;
+  bucket?: ReleaseStatsBucket;
+  group_by?: ReleaseStatsGroupBy;
+}
+
+interface ReleaseStatsTimeseriesPoint extends ReleaseStats {
+  time: string;
+  group?: string;
+}
+
+interface ReleaseStatsTimeseries {
+  from: string;
+  to: string;
+  bucket: ReleaseStatsBucket;
+  group_by: ReleaseStatsGroupBy;
+  points: ReleaseStatsTimeseriesPoint[];
+}
+
+interface ReleaseTorrentStats {
+  id: number;
+  release_id: number;
+  filter_id: number;
+  indexer: string;
+  client_id: number;
+  torrent_name: string;
+  torrent_hash: string;
+  size: number;
+  uploaded: number;
+  downloaded: number;
+  ratio: number;
+  seed_time: number;
+  progress: number;
+  state: string;
+  completed_at?: string;
+  removed: boolean;
+  last_checked_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+interface TorrentStatsSummary {
+  id?: number;
+  name: string;
+  identifier?: string;
+  torrents: number;
+  completed: number;
+  removed: number;
+  size: number;
+  uploaded: number;
+  downloaded: number;
+  ratio: number;
+  seed_time: number;
+  avg_seed_time: number;
+}
+
 interface ReleaseFilter {
   id: string;
   value: string;
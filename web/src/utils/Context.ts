@@ -37,6 +37,7 @@ export interface AuthInfo {
   username: string;
   isLoggedIn: boolean;
   authMethod?: 'password' | 'oidc';
+  role?: UserRole;
   profilePicture?: string;
   issuerUrl?: string;
 }
@@ -46,6 +47,7 @@ const AuthContextDefaults: AuthInfo = {
   username: "",
   isLoggedIn: false,
   authMethod: undefined,
+  role: undefined,
   profilePicture: undefined,
   issuerUrl: undefined
 };